@@ -5,6 +5,7 @@ import (
 	"os"
 	"runtime"
 
+	"github.com/nspcc-dev/neo-go/cli/dap"
 	"github.com/nspcc-dev/neo-go/cli/query"
 	"github.com/nspcc-dev/neo-go/cli/server"
 	"github.com/nspcc-dev/neo-go/cli/smartcontract"
@@ -32,7 +33,13 @@ func New() *cli.App {
 	ctl.ErrWriter = os.Stdout
 
 	ctl.Commands = append(ctl.Commands, server.NewCommands()...)
-	ctl.Commands = append(ctl.Commands, smartcontract.NewCommands()...)
+	scCommands := smartcontract.NewCommands()
+	for i := range scCommands {
+		if scCommands[i].Name == "contract" {
+			scCommands[i].Subcommands = append(scCommands[i].Subcommands, dap.NewCommand())
+		}
+	}
+	ctl.Commands = append(ctl.Commands, scCommands...)
 	ctl.Commands = append(ctl.Commands, wallet.NewCommands()...)
 	ctl.Commands = append(ctl.Commands, vm.NewCommands()...)
 	ctl.Commands = append(ctl.Commands, util.NewCommands()...)
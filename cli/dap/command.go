@@ -0,0 +1,119 @@
+package dap
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"os"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/urfave/cli"
+)
+
+// NewCommand returns 'debug' subcommand of 'contract' command. It's not a
+// part of the smartcontract package because of its dependency on the core
+// package.
+func NewCommand() cli.Command {
+	return cli.Command{
+		Name:      "debug",
+		Usage:     "start Debug Adapter Protocol server for Go contracts",
+		UsageText: "neo-go contract debug [--config-path path] [-p/-m/-t] [--listen address]",
+		Description: `Starts Debug Adapter Protocol (DAP) server communicating over stdin/stdout
+   (or over a TCP connection if --listen address is given, only one client
+   connection is accepted then), it's supposed to be started by an IDE (like
+   VS Code) rather than manually. When stdin/stdout are used, stdout is
+   reserved for the protocol, everything else is written to stderr.
+   The contract to debug along with the method to invoke are specified via
+   'launch' request arguments:
+
+     program      path to the contract source file or package (mandatory)
+     method       contract method to invoke (mandatory)
+     args         array of method parameters in testinvokefunction format
+     signers      array of signers in testinvokefunction format
+     gas          GAS limit for the invocation (integer number, fractional
+                  units), MaxGasInvoke from the RPC configuration is used by
+                  default
+     stopOnEntry  stop at the first method instruction
+
+   Contract is compiled and executed the same way 'neo-go vm' does it with
+   'loadgo' command, by default (if no flags are given) a clean in-memory
+   chain is used, but if the node configuration is provided, the invocation
+   uses the state of the chain from the configured DB.
+`,
+		Action: contractDebug,
+		Flags: append([]cli.Flag{
+			options.Config,
+			cli.StringFlag{
+				Name:  "listen",
+				Usage: "TCP address to accept DAP client connection at instead of using stdin/stdout",
+			},
+		}, options.Network...),
+	}
+}
+
+func contractDebug(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	var (
+		r io.Reader = os.Stdin
+		w io.Writer = os.Stdout
+	)
+	if addr := ctx.String("listen"); addr != "" {
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to listen: %w", err), 1)
+		}
+		fmt.Fprintf(ctx.App.Writer, "Listening for DAP client at %s\n", ln.Addr())
+		conn, err := ln.Accept()
+		_ = ln.Close()
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to accept connection: %w", err), 1)
+		}
+		defer conn.Close()
+		r, w = conn, conn
+	} else {
+		// Stdout is reserved for the protocol, anything else written
+		// there would corrupt the session.
+		stdout := os.Stdout
+		os.Stdout = os.Stderr
+		ctx.App.Writer = os.Stderr
+		defer func() { os.Stdout = stdout }()
+	}
+
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	chainFlags := ctx.NumFlags()
+	if ctx.IsSet("listen") {
+		chainFlags--
+	}
+	if chainFlags == 0 {
+		cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.InMemoryDB
+	}
+	if cfg.ApplicationConfiguration.DBConfiguration.Type != dbconfig.InMemoryDB {
+		cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.ReadOnly = true
+		cfg.ApplicationConfiguration.DBConfiguration.BoltDBOptions.ReadOnly = true
+	}
+	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to open DB: %w", err), 1)
+	}
+	defer store.Close()
+
+	srv := NewServer(r, w, int64(cfg.ApplicationConfiguration.RPC.MaxGasInvoke))
+	// Do not run chain, we need only state-related functionality from it.
+	chain, err := core.NewBlockchain(store, cfg.Blockchain(), srv.Logger())
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("could not initialize blockchain: %w", err), 1)
+	}
+	if err := srv.Run(chain); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package dap
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/runtime"
+	"go.uber.org/zap/zapcore"
+)
+
+// logCore is a zap core that sends runtime.Log messages to the client as
+// output events.
+type logCore struct {
+	s *Server
+}
+
+// Enabled implements zapcore.LevelEnabler interface.
+func (c *logCore) Enabled(l zapcore.Level) bool {
+	return l == zapcore.InfoLevel
+}
+
+// With implements zapcore.Core interface.
+func (c *logCore) With([]zapcore.Field) zapcore.Core {
+	return c
+}
+
+// Check implements zapcore.Core interface.
+func (c *logCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) && ent.Message == runtime.SystemRuntimeLogMessage {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write implements zapcore.Core interface.
+func (c *logCore) Write(_ zapcore.Entry, fields []zapcore.Field) error {
+	for _, f := range fields {
+		if f.Key == "msg" {
+			c.s.output("console", f.String+"\n")
+		}
+	}
+	return nil
+}
+
+// Sync implements zapcore.Core interface.
+func (c *logCore) Sync() error {
+	return nil
+}
@@ -0,0 +1,228 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Message types.
+const (
+	typeRequest  = "request"
+	typeResponse = "response"
+	typeEvent    = "event"
+)
+
+// contentLengthHeader is the only header used by the protocol.
+const contentLengthHeader = "Content-Length"
+
+// message is the base protocol message.
+type message struct {
+	Seq  int    `json:"seq"`
+	Type string `json:"type"`
+}
+
+// request is a client request.
+type request struct {
+	message
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+// response is a response to the client request.
+type response struct {
+	message
+	RequestSeq int         `json:"request_seq"`
+	Success    bool        `json:"success"`
+	Command    string      `json:"command"`
+	Message    string      `json:"message,omitempty"`
+	Body       interface{} `json:"body,omitempty"`
+}
+
+// event is a server-initiated event.
+type event struct {
+	message
+	Event string      `json:"event"`
+	Body  interface{} `json:"body,omitempty"`
+}
+
+// capabilities is the body of the initialize response.
+type capabilities struct {
+	SupportsConfigurationDoneRequest bool `json:"supportsConfigurationDoneRequest"`
+	SupportsTerminateRequest         bool `json:"supportsTerminateRequest"`
+}
+
+// LaunchArguments are the arguments of the launch request.
+type LaunchArguments struct {
+	// Program is the path to the Go contract (a file or a package directory).
+	Program string `json:"program"`
+	// Method is the name of the contract method to invoke.
+	Method string `json:"method"`
+	// Args are the method parameters in the same format as used by
+	// 'contract testinvokefunction' command.
+	Args []string `json:"args,omitempty"`
+	// Signers are the transaction signers in the same format as used by
+	// 'contract testinvokefunction' command.
+	Signers []string `json:"signers,omitempty"`
+	// Gas is the GAS limit for the invocation (in fractional units), the
+	// server's default limit is used if it's not specified.
+	Gas int64 `json:"gas,omitempty"`
+	// StopOnEntry makes the debugger stop at the first method instruction.
+	StopOnEntry bool `json:"stopOnEntry,omitempty"`
+}
+
+type source struct {
+	Name string `json:"name,omitempty"`
+	Path string `json:"path,omitempty"`
+}
+
+type sourceBreakpoint struct {
+	Line int `json:"line"`
+}
+
+type setBreakpointsArguments struct {
+	Source      source             `json:"source"`
+	Breakpoints []sourceBreakpoint `json:"breakpoints"`
+}
+
+type breakpoint struct {
+	Verified bool    `json:"verified"`
+	Message  string  `json:"message,omitempty"`
+	Source   *source `json:"source,omitempty"`
+	Line     int     `json:"line,omitempty"`
+}
+
+type setBreakpointsResponse struct {
+	Breakpoints []breakpoint `json:"breakpoints"`
+}
+
+type thread struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type threadsResponse struct {
+	Threads []thread `json:"threads"`
+}
+
+type stackTraceArguments struct {
+	ThreadID   int `json:"threadId"`
+	StartFrame int `json:"startFrame,omitempty"`
+	Levels     int `json:"levels,omitempty"`
+}
+
+type stackFrame struct {
+	ID     int     `json:"id"`
+	Name   string  `json:"name"`
+	Source *source `json:"source,omitempty"`
+	Line   int     `json:"line"`
+	Column int     `json:"column"`
+	// InstructionPointerReference is the offset of the instruction.
+	InstructionPointerReference string `json:"instructionPointerReference,omitempty"`
+}
+
+type stackTraceResponse struct {
+	StackFrames []stackFrame `json:"stackFrames"`
+	TotalFrames int          `json:"totalFrames"`
+}
+
+type scopesArguments struct {
+	FrameID int `json:"frameId"`
+}
+
+type scope struct {
+	Name               string `json:"name"`
+	VariablesReference int    `json:"variablesReference"`
+	Expensive          bool   `json:"expensive"`
+}
+
+type scopesResponse struct {
+	Scopes []scope `json:"scopes"`
+}
+
+type variablesArguments struct {
+	VariablesReference int `json:"variablesReference"`
+}
+
+type variable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+type variablesResponse struct {
+	Variables []variable `json:"variables"`
+}
+
+type continueResponse struct {
+	AllThreadsContinued bool `json:"allThreadsContinued"`
+}
+
+type stoppedEvent struct {
+	Reason            string `json:"reason"`
+	Description       string `json:"description,omitempty"`
+	ThreadID          int    `json:"threadId"`
+	AllThreadsStopped bool   `json:"allThreadsStopped"`
+	Text              string `json:"text,omitempty"`
+}
+
+type outputEvent struct {
+	Category string `json:"category"`
+	Output   string `json:"output"`
+}
+
+type exitedEvent struct {
+	ExitCode int `json:"exitCode"`
+}
+
+// readMessage reads a single protocol message (headers and JSON content)
+// from r and unmarshals it into v.
+func readMessage(r *bufio.Reader, v interface{}) error {
+	var length = -1
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			if errors.Is(err, io.EOF) && line == "" && length == -1 {
+				return io.EOF
+			}
+			return fmt.Errorf("failed to read header: %w", err)
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return fmt.Errorf("invalid header: %q", line)
+		}
+		if strings.TrimSpace(name) == contentLengthHeader {
+			length, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || length < 0 {
+				return fmt.Errorf("invalid %s: %q", contentLengthHeader, value)
+			}
+		}
+	}
+	if length == -1 {
+		return fmt.Errorf("no %s header", contentLengthHeader)
+	}
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return fmt.Errorf("failed to read message: %w", err)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// writeMessage writes v as a protocol message to w.
+func writeMessage(w io.Writer, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "%s: %d\r\n\r\n%s", contentLengthHeader, len(data), data)
+	return err
+}
@@ -0,0 +1,793 @@
+/*
+Package dap implements Debug Adapter Protocol server for Go contracts.
+
+Server compiles the contract with debug information and executes the
+requested method in NeoVM backed by the given blockchain (so that all the
+interops work the same way they do in 'neo-go vm'). It supports breakpoints
+by source line, stepping (over, into and out of functions), call stack
+inspection and variables (arguments, locals, static variables and evaluation
+stack items) inspection. Only one session is served per connection.
+*/
+package dap
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"go.uber.org/zap"
+)
+
+// threadID is the ID of the only thread reported to the client.
+const threadID = 1
+
+// DefaultGasLimit is the GAS limit (in fractional units) used for invocations
+// if it's not specified in the launch request and there is no other limit
+// given to NewServer.
+const DefaultGasLimit = 100_0000_0000
+
+// Stop reasons.
+const (
+	reasonEntry      = "entry"
+	reasonStep       = "step"
+	reasonBreakpoint = "breakpoint"
+)
+
+// stepMode defines when the execution should be stopped.
+type stepMode int
+
+const (
+	stepContinue stepMode = iota
+	stepOver
+	stepIn
+	stepOut
+)
+
+// Server is a Debug Adapter Protocol server. It processes requests
+// sequentially, so the execution can't be paused, but it's stopped at
+// breakpoints and on every step.
+type Server struct {
+	chain  *core.Blockchain
+	r      *bufio.Reader
+	maxGas int64
+
+	wLock sync.Mutex
+	w     io.Writer
+	seq   int
+
+	ic          *interop.Context
+	di          *compiler.DebugInfo
+	name        string
+	seqPoints   map[int]*compiler.DebugSeqPoint
+	breakpoints map[int]bool
+	stopOnEntry bool
+	handles     []varContainer
+}
+
+// NewServer returns a new Server reading requests from r and writing
+// responses and events to w. maxGas is the default GAS limit for invocations
+// (DefaultGasLimit is used if it's not positive), it can be overridden by the
+// launch request.
+func NewServer(r io.Reader, w io.Writer, maxGas int64) *Server {
+	if maxGas <= 0 {
+		maxGas = DefaultGasLimit
+	}
+	return &Server{
+		r:      bufio.NewReader(r),
+		w:      w,
+		maxGas: maxGas,
+	}
+}
+
+// Logger returns a logger that forwards runtime.Log messages to the client
+// (ignoring everything else), it's supposed to be used by the chain passed
+// to Run.
+func (s *Server) Logger() *zap.Logger {
+	return zap.New(&logCore{s: s})
+}
+
+// Run processes requests until the client disconnects executing contracts
+// using the state of the given chain. It returns nil on disconnect request or
+// EOF.
+func (s *Server) Run(chain *core.Blockchain) error {
+	s.chain = chain
+	defer s.finalize()
+	for {
+		var req request
+		err := readMessage(s.r, &req)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if req.Type != typeRequest {
+			continue
+		}
+		if s.handle(&req) {
+			return nil
+		}
+	}
+}
+
+// handle processes a single request and returns true if the server should
+// stop.
+func (s *Server) handle(req *request) bool {
+	var (
+		body interface{}
+		err  error
+		// after is executed after the response is sent.
+		after func()
+	)
+	switch req.Command {
+	case "initialize":
+		body = capabilities{
+			SupportsConfigurationDoneRequest: true,
+			SupportsTerminateRequest:         true,
+		}
+	case "launch":
+		var args LaunchArguments
+		if err = s.unmarshalArgs(req, &args); err == nil {
+			err = s.launch(&args)
+		}
+		if err == nil {
+			after = func() { s.sendEvent("initialized", nil) }
+		}
+	case "setBreakpoints":
+		var args setBreakpointsArguments
+		if err = s.unmarshalArgs(req, &args); err == nil {
+			body, err = s.setBreakpoints(&args)
+		}
+	case "setExceptionBreakpoints":
+		body = struct{}{}
+	case "configurationDone":
+		if err = s.checkLaunched(); err == nil {
+			after = func() {
+				if s.stopOnEntry {
+					s.stop(reasonEntry)
+					return
+				}
+				s.resume(stepContinue)
+			}
+		}
+	case "threads":
+		body = threadsResponse{Threads: []thread{{ID: threadID, Name: "NeoVM"}}}
+	case "stackTrace":
+		var args stackTraceArguments
+		if err = s.unmarshalArgs(req, &args); err == nil {
+			body, err = s.stackTrace(&args)
+		}
+	case "scopes":
+		var args scopesArguments
+		if err = s.unmarshalArgs(req, &args); err == nil {
+			body, err = s.scopes(&args)
+		}
+	case "variables":
+		var args variablesArguments
+		if err = s.unmarshalArgs(req, &args); err == nil {
+			body, err = s.variables(&args)
+		}
+	case "continue", "next", "stepIn", "stepOut":
+		var mode = map[string]stepMode{
+			"continue": stepContinue,
+			"next":     stepOver,
+			"stepIn":   stepIn,
+			"stepOut":  stepOut,
+		}[req.Command]
+		if err = s.checkRunning(); err == nil {
+			if mode == stepContinue {
+				body = continueResponse{AllThreadsContinued: true}
+			}
+			after = func() { s.resume(mode) }
+		}
+	case "terminate":
+		after = func() {
+			if s.ic != nil {
+				s.finalize()
+				s.sendEvent("terminated", nil)
+			}
+		}
+	case "disconnect":
+		s.respond(req, nil, nil)
+		return true
+	default:
+		err = fmt.Errorf("unsupported command: %s", req.Command)
+	}
+	s.respond(req, body, err)
+	if after != nil {
+		after()
+	}
+	return false
+}
+
+func (s *Server) unmarshalArgs(req *request, v interface{}) error {
+	if len(req.Arguments) == 0 {
+		return errors.New("no arguments")
+	}
+	if err := json.Unmarshal(req.Arguments, v); err != nil {
+		return fmt.Errorf("invalid arguments: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) checkLaunched() error {
+	if s.di == nil {
+		return errors.New("no program launched")
+	}
+	return nil
+}
+
+func (s *Server) checkRunning() error {
+	if err := s.checkLaunched(); err != nil {
+		return err
+	}
+	if s.ic == nil || !s.ic.VM.Ready() {
+		return errors.New("program is not running")
+	}
+	return nil
+}
+
+func (s *Server) respond(req *request, body interface{}, err error) {
+	resp := response{
+		RequestSeq: req.Seq,
+		Success:    err == nil,
+		Command:    req.Command,
+		Body:       body,
+	}
+	if err != nil {
+		resp.Message = err.Error()
+		resp.Body = nil
+	}
+	s.send(typeResponse, &resp, &resp.message)
+}
+
+func (s *Server) sendEvent(name string, body interface{}) {
+	ev := event{Event: name, Body: body}
+	s.send(typeEvent, &ev, &ev.message)
+}
+
+// send writes the given message to the client setting its type and sequence
+// number. Write errors are ignored, they're detected on the next read.
+func (s *Server) send(typ string, v interface{}, m *message) {
+	s.wLock.Lock()
+	defer s.wLock.Unlock()
+	s.seq++
+	m.Seq = s.seq
+	m.Type = typ
+	_ = writeMessage(s.w, v)
+}
+
+func (s *Server) output(category string, text string) {
+	s.sendEvent("output", outputEvent{Category: category, Output: text})
+}
+
+// launch compiles the program and prepares VM for the method invocation.
+func (s *Server) launch(args *LaunchArguments) error {
+	if s.di != nil {
+		return errors.New("program is already launched")
+	}
+	if args.Program == "" {
+		return errors.New("program is not specified")
+	}
+	if args.Method == "" {
+		return errors.New("method is not specified")
+	}
+	name := strings.TrimSuffix(filepath.Base(args.Program), ".go")
+	nf, di, err := compiler.CompileWithOptions(args.Program, nil, &compiler.Options{Name: name})
+	if err != nil {
+		return fmt.Errorf("failed to compile: %w", err)
+	}
+	// Don't perform checks, just load.
+	m, err := di.ConvertToManifest(&compiler.Options{Name: name})
+	if err != nil {
+		return fmt.Errorf("can't create manifest: %w", err)
+	}
+	_, scParams, err := cmdargs.ParseParams(args.Args, true)
+	if err != nil {
+		return fmt.Errorf("invalid method arguments: %w", err)
+	}
+	params := make([]stackitem.Item, len(scParams))
+	for i := range scParams {
+		params[i], err = scParams[i].ToStackItem()
+		if err != nil {
+			return fmt.Errorf("failed to convert parameter #%d to stackitem: %w", i, err)
+		}
+	}
+	signers, err := cmdargs.ParseSigners(args.Signers)
+	if err != nil {
+		return fmt.Errorf("invalid signers: %w", err)
+	}
+	md := m.ABI.GetMethod(args.Method, len(params))
+	if md == nil {
+		return fmt.Errorf("method %s with %d parameters not found", args.Method, len(params))
+	}
+
+	tx := &transaction.Transaction{
+		Script:          nf.Script,
+		Signers:         signers,
+		ValidUntilBlock: s.chain.BlockHeight() + 1,
+	}
+	ic, err := s.chain.GetTestVM(trigger.Application, tx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create VM: %w", err)
+	}
+	ic.VM.GasLimit = s.maxGas
+	if args.Gas > 0 {
+		ic.VM.GasLimit = args.Gas
+	}
+	ic.VM.LoadWithFlags(nf.Script, callflag.All)
+	for i := len(params) - 1; i >= 0; i-- {
+		ic.VM.Estack().PushVal(params[i])
+	}
+	ic.VM.Context().Jump(md.Offset)
+	if initMD := m.ABI.GetMethod(manifest.MethodInit, 0); initMD != nil {
+		ic.VM.Call(initMD.Offset)
+	}
+
+	s.ic = ic
+	s.di = di
+	s.name = m.Name
+	s.stopOnEntry = args.StopOnEntry
+	s.breakpoints = make(map[int]bool)
+	s.seqPoints = make(map[int]*compiler.DebugSeqPoint)
+	for i := range di.Methods {
+		for j := range di.Methods[i].SeqPoints {
+			sp := &di.Methods[i].SeqPoints[j]
+			// Inlined code shares the offset with the call site, the
+			// latter is the one to show.
+			if _, ok := s.seqPoints[sp.Opcode]; !ok {
+				s.seqPoints[sp.Opcode] = sp
+			}
+		}
+	}
+	return nil
+}
+
+// finalize releases resources of the current session.
+func (s *Server) finalize() {
+	if s.ic != nil {
+		s.ic.Finalize()
+		s.ic = nil
+	}
+}
+
+// findDocument returns the index of the contract document with the given
+// path or -1 if there is none.
+func (s *Server) findDocument(path string) int {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	for i, doc := range s.di.Documents {
+		if doc == path {
+			return i
+		}
+		if d, err := filepath.Abs(doc); err == nil && d == abs {
+			return i
+		}
+	}
+	return -1
+}
+
+// setBreakpoints replaces all breakpoints of the given source file. Every
+// breakpoint is moved to the nearest following line with code.
+func (s *Server) setBreakpoints(args *setBreakpointsArguments) (interface{}, error) {
+	if err := s.checkLaunched(); err != nil {
+		return nil, err
+	}
+	var (
+		doc = s.findDocument(args.Source.Path)
+		res = setBreakpointsResponse{Breakpoints: make([]breakpoint, 0, len(args.Breakpoints))}
+	)
+	for ip, bp := range s.breakpoints {
+		if bp && doc >= 0 && s.seqPoints[ip].Document == doc {
+			delete(s.breakpoints, ip)
+		}
+	}
+	for _, sbp := range args.Breakpoints {
+		if doc < 0 {
+			res.Breakpoints = append(res.Breakpoints, breakpoint{
+				Message: "file is not a part of the contract",
+				Line:    sbp.Line,
+			})
+			continue
+		}
+		var line = -1
+		for _, sp := range s.seqPoints {
+			if sp.Document == doc && sp.StartLine >= sbp.Line && (line == -1 || sp.StartLine < line) {
+				line = sp.StartLine
+			}
+		}
+		if line == -1 {
+			res.Breakpoints = append(res.Breakpoints, breakpoint{
+				Message: "no code at or after this line",
+				Line:    sbp.Line,
+			})
+			continue
+		}
+		for ip, sp := range s.seqPoints {
+			if sp.Document == doc && sp.StartLine == line {
+				s.breakpoints[ip] = true
+			}
+		}
+		res.Breakpoints = append(res.Breakpoints, breakpoint{
+			Verified: true,
+			Source:   &source{Name: filepath.Base(args.Source.Path), Path: args.Source.Path},
+			Line:     line,
+		})
+	}
+	return res, nil
+}
+
+// inContract checks whether the given context belongs to the debugged
+// contract.
+func (s *Server) inContract(ctx *vm.Context) bool {
+	return ctx != nil && ctx.ScriptHash().Equals(s.di.Hash)
+}
+
+// resume continues the execution until it's stopped at a breakpoint or
+// according to the step mode. Events are sent to the client when the
+// execution is stopped or finished.
+func (s *Server) resume(mode stepMode) {
+	var (
+		v          = s.ic.VM
+		startDepth = len(v.Istack())
+	)
+	s.handles = nil
+	for {
+		err := v.Step()
+		if err != nil {
+			s.finish(err)
+			return
+		}
+		if v.HasHalted() || !v.Ready() {
+			s.finish(nil)
+			return
+		}
+		var (
+			ctx      = v.Context()
+			depth    = len(v.Istack())
+			atSeq    = s.inContract(ctx) && s.seqPoints[ctx.NextIP()] != nil
+			reason   string
+			isBreakp = s.inContract(ctx) && s.breakpoints[ctx.NextIP()]
+		)
+		switch {
+		case isBreakp:
+			reason = reasonBreakpoint
+		case mode == stepIn && atSeq,
+			mode == stepOver && atSeq && depth <= startDepth,
+			mode == stepOut && atSeq && depth < startDepth:
+			reason = reasonStep
+		}
+		if reason != "" {
+			s.stop(reason)
+			return
+		}
+	}
+}
+
+func (s *Server) stop(reason string) {
+	s.sendEvent("stopped", stoppedEvent{
+		Reason:            reason,
+		ThreadID:          threadID,
+		AllThreadsStopped: true,
+	})
+}
+
+// finish reports the execution result to the client and ends the session.
+func (s *Server) finish(err error) {
+	var exitCode int
+	if err != nil {
+		exitCode = 1
+		s.output("stderr", fmt.Sprintf("Execution failed: %s\n", err))
+	} else {
+		s.output("console", fmt.Sprintf("Execution finished, result stack:\n%s\n", s.ic.VM.DumpEStack()))
+	}
+	if len(s.ic.Notifications) != 0 {
+		b, _ := json.MarshalIndent(s.ic.Notifications, "", "    ")
+		s.output("console", fmt.Sprintf("Events:\n%s\n", b))
+	}
+	s.finalize()
+	s.sendEvent("exited", exitedEvent{ExitCode: exitCode})
+	s.sendEvent("terminated", nil)
+}
+
+// sequencePoint returns the sequence point of the given contract instruction.
+func (s *Server) sequencePoint(ip int) *compiler.DebugSeqPoint {
+	var res *compiler.DebugSeqPoint
+	m := s.method(ip)
+	if m == nil {
+		return nil
+	}
+	for i := range m.SeqPoints {
+		if m.SeqPoints[i].Opcode <= ip && (res == nil || res.Opcode < m.SeqPoints[i].Opcode) {
+			res = &m.SeqPoints[i]
+		}
+	}
+	return res
+}
+
+// method returns the debug info of the contract method containing the given
+// instruction.
+func (s *Server) method(ip int) *compiler.MethodDebugInfo {
+	for i := range s.di.Methods {
+		if int(s.di.Methods[i].Range.Start) <= ip && ip <= int(s.di.Methods[i].Range.End) {
+			return &s.di.Methods[i]
+		}
+	}
+	return nil
+}
+
+// frame returns the invocation stack context with the given frame ID.
+func (s *Server) frame(id int) (*vm.Context, error) {
+	if err := s.checkRunning(); err != nil {
+		return nil, err
+	}
+	istack := s.ic.VM.Istack()
+	if id < 0 || id >= len(istack) {
+		return nil, fmt.Errorf("invalid frame %d", id)
+	}
+	// Frame 0 is the top one.
+	return istack[len(istack)-1-id], nil
+}
+
+// frameIP returns the instruction offset to show for the given frame, it's the
+// next instruction for the top frame and the current one (the call) for the
+// others. Frames that haven't executed anything yet (like the one of the
+// invoked method when _initialize is being run) have no current instruction,
+// so the next one is used for them as well.
+func (s *Server) frameIP(id int, ctx *vm.Context) int {
+	if id == 0 || s.method(ctx.IP()) != s.method(ctx.NextIP()) {
+		return ctx.NextIP()
+	}
+	return ctx.IP()
+}
+
+func (s *Server) stackTrace(args *stackTraceArguments) (interface{}, error) {
+	if err := s.checkRunning(); err != nil {
+		return nil, err
+	}
+	var (
+		total = len(s.ic.VM.Istack())
+		res   = stackTraceResponse{StackFrames: []stackFrame{}, TotalFrames: total}
+		last  = total
+	)
+	if args.Levels > 0 && args.StartFrame+args.Levels < last {
+		last = args.StartFrame + args.Levels
+	}
+	for id := args.StartFrame; id < last; id++ {
+		ctx, err := s.frame(id)
+		if err != nil {
+			return nil, err
+		}
+		var (
+			ip = s.frameIP(id, ctx)
+			f  = stackFrame{
+				ID:                          id,
+				InstructionPointerReference: strconv.Itoa(ip),
+			}
+		)
+		if !s.inContract(ctx) {
+			f.Name = s.contractName(ctx) + " @ " + strconv.Itoa(ip)
+			res.StackFrames = append(res.StackFrames, f)
+			continue
+		}
+		f.Name = s.name
+		if m := s.method(ip); m != nil {
+			f.Name += "." + m.ID
+		}
+		if sp := s.sequencePoint(ip); sp != nil && sp.Document >= 0 && sp.Document < len(s.di.Documents) {
+			doc := s.di.Documents[sp.Document]
+			f.Source = &source{Name: filepath.Base(doc), Path: doc}
+			f.Line = sp.StartLine
+			f.Column = sp.StartCol
+		}
+		res.StackFrames = append(res.StackFrames, f)
+	}
+	return res, nil
+}
+
+// contractName returns the name of the contract the given context belongs to.
+func (s *Server) contractName(ctx *vm.Context) string {
+	h := ctx.ScriptHash()
+	if cs, err := s.ic.GetContract(h); err == nil {
+		return cs.Manifest.Name
+	}
+	return h.StringLE()
+}
+
+// scopeKind is a kind of variables scope.
+type scopeKind int
+
+const (
+	scopeArguments scopeKind = iota
+	scopeLocals
+	scopeStatic
+	scopeEstack
+)
+
+// varContainer is something that has variables, either a scope of some frame
+// or a compound stack item.
+type varContainer struct {
+	frame int
+	kind  scopeKind
+	item  stackitem.Item
+}
+
+// newHandle registers a new variables container and returns its reference.
+func (s *Server) newHandle(c varContainer) int {
+	s.handles = append(s.handles, c)
+	return len(s.handles)
+}
+
+func (s *Server) scopes(args *scopesArguments) (interface{}, error) {
+	if _, err := s.frame(args.FrameID); err != nil {
+		return nil, err
+	}
+	var res = scopesResponse{Scopes: []scope{}}
+	add := func(name string, kind scopeKind) {
+		res.Scopes = append(res.Scopes, scope{
+			Name:               name,
+			VariablesReference: s.newHandle(varContainer{frame: args.FrameID, kind: kind}),
+		})
+	}
+	add("Arguments", scopeArguments)
+	add("Locals", scopeLocals)
+	add("Static variables", scopeStatic)
+	add("Evaluation stack", scopeEstack)
+	return res, nil
+}
+
+func (s *Server) variables(args *variablesArguments) (interface{}, error) {
+	if err := s.checkRunning(); err != nil {
+		return nil, err
+	}
+	if args.VariablesReference <= 0 || args.VariablesReference > len(s.handles) {
+		return nil, fmt.Errorf("invalid variables reference %d", args.VariablesReference)
+	}
+	var (
+		c   = s.handles[args.VariablesReference-1]
+		res = variablesResponse{Variables: []variable{}}
+	)
+	if c.item != nil {
+		res.Variables = s.itemChildren(c.item)
+		return res, nil
+	}
+	ctx, err := s.frame(c.frame)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		items []stackitem.Item
+		vars  []compiler.DebugVariable
+	)
+	switch c.kind {
+	case scopeArguments:
+		items = ctx.ArgumentsSlot()
+		if s.inContract(ctx) {
+			if m := s.method(s.frameIP(c.frame, ctx)); m != nil {
+				vars = m.ArgumentVariables()
+			}
+		}
+	case scopeLocals:
+		items = ctx.LocalSlot()
+		if s.inContract(ctx) {
+			if m := s.method(s.frameIP(c.frame, ctx)); m != nil {
+				vars = compiler.ParseDebugVariables(m.Variables)
+			}
+		}
+	case scopeStatic:
+		items = ctx.StaticSlot()
+		if s.inContract(ctx) {
+			vars = compiler.ParseDebugVariables(s.di.StaticVariables)
+		}
+	case scopeEstack:
+		ctx.Estack().Iter(func(e vm.Element) {
+			items = append(items, e.Item())
+		})
+	}
+	var named = make(map[int]bool)
+	for _, dv := range vars {
+		if dv.Index >= len(items) {
+			continue
+		}
+		named[dv.Index] = true
+		v := s.itemVariable(dv.Name, items[dv.Index])
+		v.Type = dv.Type
+		res.Variables = append(res.Variables, v)
+	}
+	// Show unnamed slot items (like auxiliary ones or those of other
+	// contracts) by their indexes.
+	for i := range items {
+		if !named[i] {
+			res.Variables = append(res.Variables, s.itemVariable("#"+strconv.Itoa(i), items[i]))
+		}
+	}
+	return res, nil
+}
+
+// itemVariable creates a variable for the given stack item registering
+// a handle for its children if needed.
+func (s *Server) itemVariable(name string, item stackitem.Item) variable {
+	var v = variable{
+		Name:  name,
+		Value: itemValue(item),
+		Type:  item.Type().String(),
+	}
+	switch item.Type() {
+	case stackitem.ArrayT, stackitem.StructT, stackitem.MapT:
+		v.VariablesReference = s.newHandle(varContainer{item: item})
+	}
+	return v
+}
+
+// itemChildren returns the elements of the compound item as variables.
+func (s *Server) itemChildren(item stackitem.Item) []variable {
+	var res []variable
+	switch item.Type() {
+	case stackitem.ArrayT, stackitem.StructT:
+		for i, elem := range item.Value().([]stackitem.Item) {
+			res = append(res, s.itemVariable("["+strconv.Itoa(i)+"]", elem))
+		}
+	case stackitem.MapT:
+		for _, e := range item.Value().([]stackitem.MapElement) {
+			res = append(res, s.itemVariable("["+itemValue(e.Key)+"]", e.Value))
+		}
+	}
+	return res
+}
+
+// itemValue returns a human-readable representation of the stack item.
+func itemValue(item stackitem.Item) string {
+	switch t := item.Type(); t {
+	case stackitem.AnyT:
+		return "null"
+	case stackitem.BooleanT, stackitem.IntegerT:
+		return fmt.Sprint(item.Value())
+	case stackitem.ByteArrayT, stackitem.BufferT:
+		b, _ := item.TryBytes()
+		if isPrintable(b) {
+			return strconv.Quote(string(b))
+		}
+		return "0x" + hex.EncodeToString(b)
+	case stackitem.ArrayT, stackitem.StructT:
+		return fmt.Sprintf("%s[%d]", t, len(item.Value().([]stackitem.Item)))
+	case stackitem.MapT:
+		return fmt.Sprintf("%s[%d]", t, item.(*stackitem.Map).Len())
+	case stackitem.PointerT:
+		return fmt.Sprintf("Pointer(%d)", item.(*stackitem.Pointer).Position())
+	default:
+		return t.String()
+	}
+}
+
+// isPrintable checks whether the byte slice is a non-empty printable ASCII
+// string.
+func isPrintable(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
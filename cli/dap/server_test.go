@@ -0,0 +1,397 @@
+package dap
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+const testContract = `package kek
+
+import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+
+var counter int
+
+func Main(a int, s string) int {
+	counter = a
+	b := add(a, 1)
+	runtime.Log(s)
+	arr := []int{a, b}
+	return arr[1]
+}
+
+func add(x, y int) int {
+	res := x + y
+	return res
+}
+`
+
+type testMessage struct {
+	Seq     int             `json:"seq"`
+	Type    string          `json:"type"`
+	Command string          `json:"command"`
+	Event   string          `json:"event"`
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Body    json.RawMessage `json:"body"`
+}
+
+type testClient struct {
+	t   *testing.T
+	r   *bufio.Reader
+	w   io.Writer
+	seq int
+}
+
+func (c *testClient) request(cmd string, args interface{}) {
+	c.seq++
+	req := request{
+		message: message{Seq: c.seq, Type: typeRequest},
+		Command: cmd,
+	}
+	if args != nil {
+		data, err := json.Marshal(args)
+		require.NoError(c.t, err)
+		req.Arguments = data
+	}
+	require.NoError(c.t, writeMessage(c.w, req))
+}
+
+func (c *testClient) read() testMessage {
+	var (
+		m  testMessage
+		ch = make(chan error, 1)
+	)
+	go func() { ch <- readMessage(c.r, &m) }()
+	select {
+	case err := <-ch:
+		require.NoError(c.t, err)
+	case <-time.After(10 * time.Second):
+		require.FailNow(c.t, "no message from server")
+	}
+	return m
+}
+
+// call sends the request and checks the response, its body is unmarshalled
+// into res (if not nil).
+func (c *testClient) call(cmd string, args interface{}, res interface{}) {
+	c.request(cmd, args)
+	m := c.read()
+	require.Equal(c.t, typeResponse, m.Type)
+	require.Equal(c.t, cmd, m.Command)
+	require.True(c.t, m.Success, m.Message)
+	if res != nil {
+		require.NoError(c.t, json.Unmarshal(m.Body, res))
+	}
+}
+
+func (c *testClient) callFail(cmd string, args interface{}) string {
+	c.request(cmd, args)
+	m := c.read()
+	require.Equal(c.t, typeResponse, m.Type)
+	require.Equal(c.t, cmd, m.Command)
+	require.False(c.t, m.Success)
+	return m.Message
+}
+
+func (c *testClient) expectEvent(name string, res interface{}) {
+	m := c.read()
+	require.Equal(c.t, typeEvent, m.Type)
+	require.Equal(c.t, name, m.Event)
+	if res != nil {
+		require.NoError(c.t, json.Unmarshal(m.Body, res))
+	}
+}
+
+func (c *testClient) expectStopped(reason string) {
+	var ev stoppedEvent
+	c.expectEvent("stopped", &ev)
+	require.Equal(c.t, reason, ev.Reason)
+	require.Equal(c.t, threadID, ev.ThreadID)
+}
+
+func (c *testClient) stackTrace() []stackFrame {
+	var res stackTraceResponse
+	c.call("stackTrace", stackTraceArguments{ThreadID: threadID}, &res)
+	require.Equal(c.t, len(res.StackFrames), res.TotalFrames)
+	return res.StackFrames
+}
+
+// variables returns the variables of the given frame scope.
+func (c *testClient) variables(frame int, scopeName string) []variable {
+	var scopes scopesResponse
+	c.call("scopes", scopesArguments{FrameID: frame}, &scopes)
+	for _, sc := range scopes.Scopes {
+		if sc.Name == scopeName {
+			return c.children(sc.VariablesReference)
+		}
+	}
+	require.FailNow(c.t, "no such scope", scopeName)
+	return nil
+}
+
+func (c *testClient) children(ref int) []variable {
+	var res variablesResponse
+	c.call("variables", variablesArguments{VariablesReference: ref}, &res)
+	return res.Variables
+}
+
+func newTestServer(t *testing.T) *testClient {
+	return newTestServerWithGas(t, 0)
+}
+
+func newTestServerWithGas(t *testing.T, maxGas int64) *testClient {
+	cfg, err := config.LoadFile("../../config/protocol.unit_testnet.single.yml")
+	require.NoError(t, err)
+
+	var (
+		inR, inW   = io.Pipe()
+		outR, outW = io.Pipe()
+		srv        = NewServer(inR, outW, maxGas)
+		errCh      = make(chan error, 1)
+	)
+	chain, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.Blockchain(), srv.Logger())
+	require.NoError(t, err)
+	go func() {
+		errCh <- srv.Run(chain)
+		_ = outW.Close()
+	}()
+	t.Cleanup(func() {
+		_ = inW.Close()
+		_, _ = io.Copy(io.Discard, outR)
+		require.NoError(t, <-errCh)
+	})
+	return &testClient{t: t, r: bufio.NewReader(outR), w: inW}
+}
+
+func writeTestContract(t *testing.T) string {
+	return writeContract(t, testContract)
+}
+
+func writeContract(t *testing.T, src string) string {
+	tmpDir := t.TempDir()
+	filename := filepath.Join(tmpDir, "contract.go")
+	require.NoError(t, os.WriteFile(filename, []byte(src), os.ModePerm))
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	goMod := []byte(`module test.example/dap
+require (
+	github.com/nspcc-dev/neo-go/pkg/interop v0.0.0
+)
+replace github.com/nspcc-dev/neo-go/pkg/interop => ` + filepath.Join(wd, "../../pkg/interop") + `
+go 1.17`)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "go.mod"), goMod, os.ModePerm))
+	return filename
+}
+
+func TestServer(t *testing.T) {
+	filename := writeTestContract(t)
+	c := newTestServer(t)
+
+	var caps capabilities
+	c.call("initialize", map[string]string{"adapterID": "neo-go"}, &caps)
+	require.True(t, caps.SupportsConfigurationDoneRequest)
+
+	require.Contains(t, c.callFail("setBreakpoints", setBreakpointsArguments{}), "no program launched")
+	require.Contains(t, c.callFail("launch", LaunchArguments{Program: filename}), "method is not specified")
+	require.Contains(t, c.callFail("launch", LaunchArguments{Program: filename, Method: "main"}), "not found")
+	c.call("launch", LaunchArguments{Program: filename, Method: "main", Args: []string{"3", "hello"}}, nil)
+	c.expectEvent("initialized", nil)
+
+	var bps setBreakpointsResponse
+	c.call("setBreakpoints", setBreakpointsArguments{
+		Source:      source{Path: filename},
+		Breakpoints: []sourceBreakpoint{{Line: 14}, {Line: 100}},
+	}, &bps)
+	require.Equal(t, 2, len(bps.Breakpoints))
+	require.True(t, bps.Breakpoints[0].Verified)
+	require.Equal(t, 16, bps.Breakpoints[0].Line) // Moved to the next line with code.
+	require.False(t, bps.Breakpoints[1].Verified)
+
+	c.call("setBreakpoints", setBreakpointsArguments{
+		Source:      source{Path: "unknown.go"},
+		Breakpoints: []sourceBreakpoint{{Line: 1}},
+	}, &bps)
+	require.Equal(t, 1, len(bps.Breakpoints))
+	require.False(t, bps.Breakpoints[0].Verified)
+
+	// Replaces the previous breakpoint.
+	c.call("setBreakpoints", setBreakpointsArguments{
+		Source:      source{Path: filename},
+		Breakpoints: []sourceBreakpoint{{Line: 9}},
+	}, &bps)
+	require.Equal(t, 1, len(bps.Breakpoints))
+	require.True(t, bps.Breakpoints[0].Verified)
+	require.Equal(t, 9, bps.Breakpoints[0].Line)
+
+	c.call("setExceptionBreakpoints", map[string]interface{}{"filters": []string{}}, nil)
+	c.call("configurationDone", nil, nil)
+	c.expectStopped(reasonBreakpoint)
+
+	var threads threadsResponse
+	c.call("threads", nil, &threads)
+	require.Equal(t, []thread{{ID: threadID, Name: "NeoVM"}}, threads.Threads)
+
+	frames := c.stackTrace()
+	require.Equal(t, 1, len(frames))
+	require.Equal(t, "contract.Main", frames[0].Name)
+	require.Equal(t, filename, frames[0].Source.Path)
+	require.Equal(t, 9, frames[0].Line)
+
+	require.Equal(t, []variable{
+		{Name: "a", Value: "3", Type: "Integer"},
+		{Name: "s", Value: `"hello"`, Type: "ByteString"},
+	}, c.variables(0, "Arguments"))
+	require.Equal(t, []variable{
+		{Name: "b", Value: "null", Type: "Integer"},
+		{Name: "arr", Value: "null", Type: "Array"},
+	}, c.variables(0, "Locals"))
+	require.Equal(t, []variable{
+		{Name: "counter", Value: "3", Type: "Integer"},
+	}, c.variables(0, "Static variables"))
+
+	c.call("stepIn", nil, nil)
+	c.expectStopped(reasonStep)
+	frames = c.stackTrace()
+	require.Equal(t, 2, len(frames))
+	require.Equal(t, "contract.add", frames[0].Name)
+	require.Equal(t, 16, frames[0].Line)
+	require.Equal(t, "contract.Main", frames[1].Name)
+	require.Equal(t, 9, frames[1].Line)
+	require.Equal(t, []variable{
+		{Name: "x", Value: "3", Type: "Integer"},
+		{Name: "y", Value: "1", Type: "Integer"},
+	}, c.variables(0, "Arguments"))
+	require.Equal(t, []variable{
+		{Name: "a", Value: "3", Type: "Integer"},
+		{Name: "s", Value: `"hello"`, Type: "ByteString"},
+	}, c.variables(1, "Arguments"))
+
+	c.call("stepOut", nil, nil)
+	c.expectStopped(reasonStep)
+	frames = c.stackTrace()
+	require.Equal(t, 1, len(frames))
+	require.Equal(t, 10, frames[0].Line)
+
+	c.call("next", nil, nil)
+	var out outputEvent
+	c.expectEvent("output", &out)
+	require.Equal(t, outputEvent{Category: "console", Output: "hello\n"}, out)
+	c.expectStopped(reasonStep)
+	require.Equal(t, 11, c.stackTrace()[0].Line)
+
+	c.call("next", nil, nil)
+	c.expectStopped(reasonStep)
+	require.Equal(t, 12, c.stackTrace()[0].Line)
+	locals := c.variables(0, "Locals")
+	require.Equal(t, 2, len(locals))
+	require.Equal(t, variable{Name: "b", Value: "4", Type: "Integer"}, locals[0])
+	require.Equal(t, "arr", locals[1].Name)
+	require.Equal(t, "Array[2]", locals[1].Value)
+	require.NotZero(t, locals[1].VariablesReference)
+	require.Equal(t, []variable{
+		{Name: "[0]", Value: "3", Type: "Integer"},
+		{Name: "[1]", Value: "4", Type: "Integer"},
+	}, c.children(locals[1].VariablesReference))
+
+	c.call("continue", nil, nil)
+	c.expectEvent("output", &out)
+	require.Contains(t, out.Output, "Execution finished")
+	var exited exitedEvent
+	c.expectEvent("exited", &exited)
+	require.Equal(t, 0, exited.ExitCode)
+	c.expectEvent("terminated", nil)
+
+	require.Contains(t, c.callFail("next", nil), "not running")
+	c.call("disconnect", nil, nil)
+}
+
+func TestServerStopOnEntry(t *testing.T) {
+	filename := writeTestContract(t)
+	c := newTestServer(t)
+
+	c.call("initialize", nil, nil)
+	c.call("launch", LaunchArguments{
+		Program:     filename,
+		Method:      "main",
+		Args:        []string{"3", "hello"},
+		StopOnEntry: true,
+	}, nil)
+	c.expectEvent("initialized", nil)
+	c.call("configurationDone", nil, nil)
+	c.expectStopped(reasonEntry)
+	frames := c.stackTrace()
+	require.Equal(t, 2, len(frames))
+	require.Equal(t, "contract._initialize", frames[0].Name)
+	require.Equal(t, "contract.Main", frames[1].Name)
+
+	c.call("terminate", nil, nil)
+	c.expectEvent("terminated", nil)
+	require.Contains(t, c.callFail("unknown", nil), "unsupported command")
+	c.call("disconnect", nil, nil)
+}
+
+func TestServerGasLimit(t *testing.T) {
+	filename := writeContract(t, `package kek
+
+func Main() int {
+	var i int
+	for {
+		i++
+	}
+}
+`)
+	c := newTestServerWithGas(t, 1_0000_0000)
+
+	c.call("initialize", nil, nil)
+	c.call("launch", LaunchArguments{Program: filename, Method: "main"}, nil)
+	c.expectEvent("initialized", nil)
+	c.call("configurationDone", nil, nil)
+	var out outputEvent
+	c.expectEvent("output", &out)
+	require.Equal(t, "stderr", out.Category)
+	require.Contains(t, out.Output, "gas limit")
+	var exited exitedEvent
+	c.expectEvent("exited", &exited)
+	require.Equal(t, 1, exited.ExitCode)
+	c.expectEvent("terminated", nil)
+	c.call("disconnect", nil, nil)
+}
+
+func TestCommandListen(t *testing.T) {
+	var (
+		outR, outW = io.Pipe()
+		errCh      = make(chan error, 1)
+		app        = cli.NewApp()
+	)
+	app.Writer = outW
+	app.Commands = []cli.Command{NewCommand()}
+	go func() {
+		errCh <- app.Run([]string{"neo-go", "debug", "--config-path", "../../config", "--unittest", "--listen", "127.0.0.1:0"})
+	}()
+	line, err := bufio.NewReader(outR).ReadString('\n')
+	require.NoError(t, err)
+	addr := strings.TrimSpace(strings.TrimPrefix(line, "Listening for DAP client at "))
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+	c := &testClient{t: t, r: bufio.NewReader(conn), w: conn}
+	var caps capabilities
+	c.call("initialize", nil, &caps)
+	require.True(t, caps.SupportsConfigurationDoneRequest)
+	c.call("disconnect", nil, nil)
+	require.NoError(t, <-errCh)
+}
@@ -17,7 +17,10 @@ import (
 func InitAndSave(net netmode.Magic, tx *transaction.Transaction, acc *wallet.Account, filename string) error {
 	scCtx := context.NewParameterContext(context.TransactionType, net, tx)
 	if acc != nil && acc.CanSign() {
-		sign := acc.SignHashable(net, tx)
+		sign, err := acc.SignHashableErr(net, tx)
+		if err != nil {
+			return fmt.Errorf("can't sign transaction: %w", err)
+		}
 		if err := scCtx.AddSignature(acc.ScriptHash(), acc.Contract, acc.PublicKey(), sign); err != nil {
 			return fmt.Errorf("can't add signature: %w", err)
 		}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testcli"
@@ -110,4 +111,46 @@ func TestDBRestoreDump(t *testing.T) {
 	d2, err := os.ReadFile(dumpPath)
 	require.NoError(t, err)
 	require.Equal(t, d1, d2, "dumps differ")
+
+	t.Run("export", func(t *testing.T) {
+		exportCmd := []string{"neo-go", "db", "export", "--unittest", "--config-path", tmpDir}
+
+		t.Run("bad format", func(t *testing.T) {
+			e.RunWithError(t, append(exportCmd, "--format", "xml")...)
+		})
+		t.Run("csv without output", func(t *testing.T) {
+			e.RunWithError(t, append(exportCmd, "--format", "csv")...)
+		})
+		t.Run("invalid start/count", func(t *testing.T) {
+			e.RunWithError(t, append(exportCmd, "--start", "5", "--count", "100")...)
+		})
+		t.Run("sql", func(t *testing.T) {
+			sqlPath := filepath.Join(t.TempDir(), "export.sql")
+			e.Run(t, append(exportCmd, "--out", sqlPath, "--start", "1", "--count", "10")...)
+			data, err := os.ReadFile(sqlPath)
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			require.Equal(t, "BEGIN;", lines[0])
+			require.Equal(t, "COMMIT;", lines[len(lines)-1])
+			require.Contains(t, string(data), "CREATE TABLE IF NOT EXISTS nep17_transfers (")
+			require.Equal(t, 10, strings.Count(string(data), "INSERT INTO blocks "))
+			require.Contains(t, string(data), `INSERT INTO blocks ("index", "hash",`)
+			require.Contains(t, string(data), "INSERT INTO transactions ")
+			require.Contains(t, string(data), "INSERT INTO nep17_transfers ")
+		})
+		t.Run("csv", func(t *testing.T) {
+			csvDir := filepath.Join(t.TempDir(), "csv")
+			e.Run(t, append(exportCmd, "--format", "csv", "--out", csvDir)...)
+			for _, name := range []string{"blocks", "transactions", "signers", "executions",
+				"notifications", "nep17_transfers", "nep11_transfers"} {
+				require.FileExists(t, filepath.Join(csvDir, name+".csv"))
+			}
+			data, err := os.ReadFile(filepath.Join(csvDir, "blocks.csv"))
+			require.NoError(t, err)
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			require.Equal(t, 1+51, len(lines))
+			require.True(t, strings.HasPrefix(lines[0], "index,hash,version,"))
+			require.True(t, strings.HasPrefix(lines[1], "0,0x"))
+		})
+	})
 }
@@ -58,6 +58,19 @@ func NewCommands() []cli.Command {
 			Usage: "Output file (stdout if not given)",
 		},
 	)
+	var cfgExportFlags = make([]cli.Flag, len(cfgCountOutFlags))
+	copy(cfgExportFlags, cfgCountOutFlags)
+	cfgExportFlags[len(cfgExportFlags)-1] = cli.StringFlag{
+		Name:  "out, o",
+		Usage: "Output file for SQL (stdout if not given) or directory for CSV files (mandatory)",
+	}
+	cfgExportFlags = append(cfgExportFlags,
+		cli.StringFlag{
+			Name:  "format, f",
+			Usage: "Output format (sql or csv)",
+			Value: exportFormatSQL,
+		},
+	)
 	var cfgCountInFlags = make([]cli.Flag, len(cfgWithCountFlags))
 	copy(cfgCountInFlags, cfgWithCountFlags)
 	cfgCountInFlags = append(cfgCountInFlags,
@@ -107,6 +120,20 @@ func NewCommands() []cli.Command {
 					Action:    restoreDB,
 					Flags:     cfgCountInFlags,
 				},
+				{
+					Name:      "export",
+					Usage:     "export blocks, transactions, application logs and token transfers in relational form",
+					UsageText: "neo-go db export [-f sql|csv] [-o path] [-s start] [-c count] [--config-path path] [-p/-m/-t]",
+					Description: `Exports chain data as a set of relational tables: blocks, transactions,
+   signers, executions and notifications (from application logs), nep17_transfers
+   and nep11_transfers (from token transfer logs, every transfer is stored for
+   both of its sides). SQL format is a PostgreSQL-compatible script with table
+   definitions and INSERT statements, CSV format is a set of files (one per
+   table, with a header line) written into the directory specified with -o.
+`,
+					Action: exportDB,
+					Flags:  cfgExportFlags,
+				},
 				{
 					Name:      "reset",
 					Usage:     "reset database to the previous state",
@@ -206,6 +233,94 @@ func dumpDB(ctx *cli.Context) error {
 	return nil
 }
 
+// Supported export formats.
+const (
+	exportFormatSQL = "sql"
+	exportFormatCSV = "csv"
+)
+
+func exportDB(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+	count := uint32(ctx.Uint("count"))
+	start := uint32(ctx.Uint("start"))
+	out := ctx.String("out")
+
+	var (
+		w      chaindump.RowWriter
+		closer func() error
+	)
+	switch format := ctx.String("format"); format {
+	case exportFormatSQL:
+		var outStream = os.Stdout
+		if out != "" {
+			outStream, err = os.Create(out)
+			if err != nil {
+				return cli.NewExitError(err, 1)
+			}
+		}
+		defer outStream.Close()
+		sw := chaindump.NewSQLWriter(outStream)
+		if err = sw.WriteSchema(); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		w, closer = sw, sw.Close
+	case exportFormatCSV:
+		if out == "" {
+			return cli.NewExitError("output directory is mandatory for CSV format", 1)
+		}
+		if err = os.MkdirAll(out, os.ModePerm); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		cw, err := chaindump.NewCSVWriter(out)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer cw.Close()
+		w, closer = cw, cw.Close
+	default:
+		return cli.NewExitError(fmt.Errorf("unsupported format: %s", format), 1)
+	}
+
+	chain, prometheus, pprof, err := initBCWithMetrics(cfg, log)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pprof.ShutDown()
+		prometheus.ShutDown()
+		chain.Close()
+	}()
+
+	chainCount := chain.BlockHeight() + 1
+	if start+count > chainCount {
+		return cli.NewExitError(fmt.Errorf("chain is not that high (%d) to export %d blocks starting from %d", chainCount-1, count, start), 1)
+	}
+	if count == 0 {
+		count = chainCount - start
+	}
+	err = chaindump.Export(chain, w, start, count)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if err = closer(); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	return nil
+}
+
 func restoreDB(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
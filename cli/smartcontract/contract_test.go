@@ -701,6 +701,9 @@ func TestComlileAndInvokeFunction(t *testing.T) {
 
 	// deploy verification contract
 	hVerify := deployVerifyContract(t, e)
+	// deploy contract with conventional update and destroy methods
+	hUpdatable := testcli.DeployContract(t, e, "testdata/updatable/main.go", "testdata/updatable/neo-go.yml",
+		testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
 
 	t.Run("real invoke", func(t *testing.T) {
 		cmd := []string{"neo-go", "contract", "invokefunction",
@@ -917,6 +920,95 @@ func TestComlileAndInvokeFunction(t *testing.T) {
 			h.StringLE(), "getValue")
 		checkGetValueOut("on update|sub update")
 	})
+	t.Run("Update command", func(t *testing.T) {
+		updatedNef := filepath.Join(tmpDir, "updatable.updated.nef")
+		updatedManifest := filepath.Join(tmpDir, "updatable.updated.manifest.json")
+		e.Run(t, "neo-go", "contract", "compile",
+			"--in", "testdata/updatable/", // compile all files in dir
+			"--config", "testdata/updatable/neo-go.yml",
+			"--out", updatedNef, "--manifest", updatedManifest)
+
+		cmd := []string{"neo-go", "contract", "update",
+			"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+			"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+			"--in", updatedNef, "--manifest", updatedManifest,
+			"--force"}
+		t.Run("missing hash", func(t *testing.T) {
+			e.RunWithError(t, cmd...)
+		})
+		t.Run("invalid hash", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, "notahash")...)
+		})
+		t.Run("unknown contract", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, util.Uint160{1, 2, 3}.StringLE())...)
+		})
+		t.Run("missing nef", func(t *testing.T) {
+			e.RunWithError(t, "neo-go", "contract", "update",
+				"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+				"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+				"--manifest", updatedManifest, hUpdatable.StringLE())
+		})
+		t.Run("excessive data", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, hUpdatable.StringLE(), "42", "43")...)
+		})
+		t.Run("no update method", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, e.Chain.GoverningTokenHash().StringLE())...)
+		})
+		t.Run("update method without data", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, h.StringLE())...)
+		})
+		t.Run("name change", func(t *testing.T) {
+			rawManifest, err := os.ReadFile(updatedManifest)
+			require.NoError(t, err)
+			m := new(manifest.Manifest)
+			require.NoError(t, json.Unmarshal(rawManifest, m))
+			m.Name = "Renamed"
+			rawManifest, err = json.Marshal(m)
+			require.NoError(t, err)
+			renamedManifest := filepath.Join(tmpDir, "renamed.manifest.json")
+			require.NoError(t, os.WriteFile(renamedManifest, rawManifest, os.ModePerm))
+			e.RunWithError(t, "neo-go", "contract", "update",
+				"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+				"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+				"--in", updatedNef, "--manifest", renamedManifest,
+				"--force", hUpdatable.StringLE())
+		})
+
+		e.In.WriteString("one\r")
+		e.Run(t, append(cmd, hUpdatable.StringLE(), "string:updated")...)
+		e.CheckNextLine(t, "^ABI changes:$")
+		e.CheckNextLine(t, `^\+ method getData\(\) String$`)
+		e.CheckTxPersisted(t, "Sent invocation transaction ")
+
+		e.Run(t, "neo-go", "contract", "testinvokefunction",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			hUpdatable.StringLE(), "getData")
+		checkGetValueOut("updated")
+	})
+	t.Run("Destroy command", func(t *testing.T) {
+		cmd := []string{"neo-go", "contract", "destroy",
+			"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+			"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+			"--force"}
+		t.Run("missing hash", func(t *testing.T) {
+			e.RunWithError(t, cmd...)
+		})
+		t.Run("unknown contract", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, util.Uint160{1, 2, 3}.StringLE())...)
+		})
+		t.Run("excessive parameters", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, hUpdatable.StringLE(), "something")...)
+		})
+		t.Run("no destroy method", func(t *testing.T) {
+			e.RunWithError(t, append(cmd, h.StringLE())...)
+		})
+
+		e.In.WriteString("one\r")
+		e.Run(t, append(cmd, hUpdatable.StringLE(), "--", testcli.ValidatorAddr+":CalledByEntry")...)
+		e.CheckNextLine(t, "^Contract Test updatable \\("+hUpdatable.StringLE()+"\\) is to be destroyed.$")
+		e.CheckTxPersisted(t, "Sent invocation transaction ")
+		require.Nil(t, e.Chain.GetContractState(hUpdatable))
+	})
 	t.Run("historic", func(t *testing.T) {
 		t.Run("bad ref", func(t *testing.T) {
 			e.RunWithError(t, "neo-go", "contract", "testinvokefunction",
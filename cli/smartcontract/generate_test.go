@@ -2,15 +2,28 @@ package smartcontract
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
 
+	nextoken "github.com/nspcc-dev/neo-go/cli/smartcontract/testdata/nex"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
 )
@@ -365,6 +378,161 @@ func TestGenerateRPCBindings(t *testing.T) {
 		filepath.Join("testdata", "nonepiter", "iter.go"))
 }
 
+func TestGeneratedRPCBindingsEvents(t *testing.T) {
+	var (
+		acc      = util.Uint160{1, 2, 3}
+		transfer = func(h util.Uint160, from stackitem.Item) state.NotificationEvent {
+			return state.NotificationEvent{
+				ScriptHash: h,
+				Name:       "Transfer",
+				Item: stackitem.NewArray([]stackitem.Item{
+					from,
+					stackitem.NewByteArray(acc.BytesBE()),
+					stackitem.NewBigInteger(big.NewInt(42)),
+				}),
+			}
+		}
+		log = &result.ApplicationLog{Executions: []state.Execution{{
+			Events: []state.NotificationEvent{
+				transfer(nextoken.Hash, stackitem.Null{}),
+				transfer(util.Uint160{}, stackitem.Null{}), // Other contract.
+				{ScriptHash: nextoken.Hash, Name: "OnMint", Item: stackitem.NewArray(nil)},
+				transfer(nextoken.Hash, stackitem.NewByteArray(acc.BytesBE())),
+			},
+		}}}
+	)
+
+	events, err := nextoken.ParseTransferEventsFromApplicationLog(log)
+	require.NoError(t, err)
+	require.Equal(t, []*nextoken.TransferEvent{
+		{To: acc, Amount: big.NewInt(42)},
+		{From: acc, To: acc, Amount: big.NewInt(42)},
+	}, events)
+
+	_, err = nextoken.ParseOnMintEventsFromApplicationLog(log)
+	require.Error(t, err)
+	_, err = nextoken.ParseTransferEventsFromApplicationLog(nil)
+	require.Error(t, err)
+
+	flt := nextoken.TransferEventFilter()
+	require.Equal(t, nextoken.Hash, *flt.Contract)
+	require.Equal(t, "Transfer", *flt.Name)
+
+	ev, err := nextoken.TransferEventFromNotification(&log.Executions[0].Events[3])
+	require.NoError(t, err)
+	require.Equal(t, events[1], ev)
+	_, err = nextoken.TransferEventFromNotification(&log.Executions[0].Events[1])
+	require.Error(t, err)
+	_, err = nextoken.OnMintEventFromNotification(&log.Executions[0].Events[0])
+	require.Error(t, err)
+
+	bad := transfer(nextoken.Hash, stackitem.NewBool(true))
+	_, err = nextoken.TransferEventFromNotification(&bad)
+	require.Error(t, err)
+}
+
+func TestGeneratedRPCBindingsReceiveEvents(t *testing.T) {
+	var (
+		acc   = util.Uint160{1, 2, 3}
+		event = func(h util.Uint160, name string, to stackitem.Item) []byte {
+			b, err := json.Marshal(neorpc.Notification{
+				JSONRPC: neorpc.JSONRPCVersion,
+				Event:   neorpc.NotificationEventID,
+				Payload: []interface{}{&state.ContainedNotificationEvent{
+					NotificationEvent: state.NotificationEvent{
+						ScriptHash: h,
+						Name:       name,
+						Item: stackitem.NewArray([]stackitem.Item{
+							stackitem.Null{},
+							to,
+							stackitem.NewBigInteger(big.NewInt(42)),
+						}),
+					},
+				}},
+			})
+			require.NoError(t, err)
+			return b
+		}
+		unsubscribed = make(chan struct{})
+		events       = make(chan []byte)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var upgrader = websocket.Upgrader{}
+		ws, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			return
+		}
+		defer ws.Close()
+		var (
+			reqs = make(chan []byte)
+			done = make(chan struct{})
+		)
+		go func() {
+			defer close(done)
+			for {
+				var r struct {
+					ID     json.RawMessage `json:"id"`
+					Method string          `json:"method"`
+				}
+				if err := ws.ReadJSON(&r); err != nil {
+					return
+				}
+				var res = `"0"`
+				if r.Method == "unsubscribe" {
+					res = "true"
+					close(unsubscribed)
+				}
+				reqs <- []byte(`{"jsonrpc":"2.0","id":` + string(r.ID) + `,"result":` + res + `}`)
+			}
+		}()
+		// All writes are made from this goroutine.
+		for {
+			var msg []byte
+			select {
+			case msg = <-reqs:
+			case msg = <-events:
+			case <-done:
+				return
+			}
+			if err := ws.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	c, err := rpcclient.NewWS(context.Background(), "ws"+strings.TrimPrefix(srv.URL, "http"), rpcclient.Options{})
+	require.NoError(t, err)
+	defer c.Close()
+
+	var (
+		ctx, cancel = context.WithCancel(context.Background())
+		ch          = make(chan *nextoken.TransferEvent)
+	)
+	defer cancel()
+	require.NoError(t, nextoken.ReceiveTransferEvents(ctx, c, ch))
+	events <- event(util.Uint160{}, "Transfer", stackitem.NewByteArray(acc.BytesBE())) // Other contract.
+	events <- event(nextoken.Hash, "Transfer", stackitem.NewBool(true))                // Can't be converted.
+	events <- event(nextoken.Hash, "Transfer", stackitem.NewByteArray(acc.BytesBE()))
+	select {
+	case ev := <-ch:
+		require.Equal(t, &nextoken.TransferEvent{To: acc, Amount: big.NewInt(42)}, ev)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no event received")
+	}
+
+	cancel()
+	select {
+	case <-unsubscribed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no unsubscription")
+	}
+	require.Eventually(t, func() bool {
+		_, ok := <-ch
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func TestAssistedRPCBindings(t *testing.T) {
 	tmpDir := t.TempDir()
 	app := cli.NewApp()
@@ -16,6 +16,7 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/txctx"
 	cliwallet "github.com/nspcc-dev/neo-go/cli/wallet"
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -118,6 +119,9 @@ func NewCommands() []cli.Command {
 			Usage: "Manifest input file (*.manifest.json)",
 		},
 	}...)
+	// Commands can't share flag slices since urfave/cli appends to them.
+	updateFlags := append([]cli.Flag{}, deployFlags...)
+	destroyFlags := append([]cli.Flag{}, invokeFunctionFlags...)
 	return []cli.Command{{
 		Name:  "contract",
 		Usage: "compile - debug - deploy smart contracts",
@@ -181,6 +185,36 @@ func NewCommands() []cli.Command {
 				Action: contractDeploy,
 				Flags:  deployFlags,
 			},
+			{
+				Name:      "update",
+				Usage:     "update a deployed smart contract (.nef with description)",
+				UsageText: "neo-go contract update -r endpoint -w wallet [-a address] [-g gas] [-e sysgas] --in contract.nef --manifest contract.manifest.json [--out file] [--force] scripthash [data] [-- <signer>...]",
+				Description: `Updates the contract with the given script hash using the new NEF and manifest.
+   It invokes 'update' method of the contract itself which is expected to
+   accept NEF, manifest and data and to call ContractManagement's 'update'
+   with them. The data parameter is passed to '_deploy' method then (null is
+   passed if it's not specified). Before sending the transaction, changes of the contract
+   ABI (added, removed and changed methods and events) are printed compared
+   to the currently deployed contract state. Sender is included in the list
+   of signers with CalledByEntry scope by default, if you'd like to change it,
+   specify signers explicitly (see invokefunction for the syntax).
+`,
+				Action: contractUpdate,
+				Flags:  updateFlags,
+			},
+			{
+				Name:      "destroy",
+				Usage:     "destroy a deployed smart contract",
+				UsageText: "neo-go contract destroy -r endpoint -w wallet [-a address] [-g gas] [-e sysgas] [--out file] [--force] scripthash [-- <signer>...]",
+				Description: `Destroys the contract with the given script hash. It invokes 'destroy'
+   method of the contract itself which is expected to have no parameters and
+   to call ContractManagement's 'destroy'. Sender is included in the list of
+   signers with CalledByEntry scope by default, if you'd like to change it,
+   specify signers explicitly (see invokefunction for the syntax).
+`,
+				Action: contractDestroy,
+				Flags:  destroyFlags,
+			},
 			generateWrapperCmd,
 			generateRPCWrapperCmd,
 			{
@@ -724,9 +758,13 @@ func getAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 	if len(wPath) == 0 && len(walletConfigPath) == 0 {
 		return nil, nil, errNoWallet
 	}
-	var pass *string
+	var (
+		pass *string
+		cfg  *config.Wallet
+	)
 	if len(walletConfigPath) != 0 {
-		cfg, err := cliwallet.ReadWalletConfig(walletConfigPath)
+		var err error
+		cfg, err = cliwallet.ReadWalletConfig(walletConfigPath)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -738,6 +776,11 @@ func getAccFromContext(ctx *cli.Context) (*wallet.Account, *wallet.Wallet, error
 	if err != nil {
 		return nil, nil, err
 	}
+	if cfg != nil {
+		if err := cliwallet.AttachRemoteSigner(wall, cfg); err != nil {
+			return nil, nil, err
+		}
+	}
 	addrFlag := ctx.Generic("address").(*flags.Address)
 	if addrFlag.IsSet {
 		addr = addrFlag.Uint160()
@@ -8,6 +8,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
@@ -137,3 +138,42 @@ func TestPermissionUnmarshalInvalid(t *testing.T) {
 		})
 	}
 }
+
+func TestABIDiff(t *testing.T) {
+	oldABI := &manifest.ABI{
+		Methods: []manifest.Method{
+			{Name: "same", Offset: 0, ReturnType: smartcontract.VoidType},
+			{Name: "removed", Parameters: []manifest.Parameter{manifest.NewParameter("a", smartcontract.IntegerType)}, ReturnType: smartcontract.BoolType},
+			{Name: "changed", Parameters: []manifest.Parameter{manifest.NewParameter("a", smartcontract.IntegerType)}, ReturnType: smartcontract.IntegerType},
+			{Name: "safe", ReturnType: smartcontract.StringType},
+		},
+		Events: []manifest.Event{
+			{Name: "Same"},
+			{Name: "Removed"},
+			{Name: "Changed", Parameters: []manifest.Parameter{manifest.NewParameter("x", smartcontract.Hash160Type)}},
+		},
+	}
+	newABI := &manifest.ABI{
+		Methods: []manifest.Method{
+			{Name: "same", Offset: 10, ReturnType: smartcontract.VoidType},
+			{Name: "removed", Parameters: []manifest.Parameter{}, ReturnType: smartcontract.BoolType},
+			{Name: "changed", Parameters: []manifest.Parameter{manifest.NewParameter("b", smartcontract.StringType)}, ReturnType: smartcontract.IntegerType},
+			{Name: "safe", ReturnType: smartcontract.StringType, Safe: true},
+		},
+		Events: []manifest.Event{
+			{Name: "Same"},
+			{Name: "Changed", Parameters: []manifest.Parameter{manifest.NewParameter("x", smartcontract.Hash160Type), manifest.NewParameter("y", smartcontract.AnyType)}},
+			{Name: "Added"},
+		},
+	}
+	require.Equal(t, []string{
+		"- method removed(a Integer) Boolean",
+		"~ method changed(a Integer) Integer -> changed(b String) Integer",
+		"~ method safe() String -> safe() String (safe)",
+		"+ method removed() Boolean",
+		"- event Removed()",
+		"~ event Changed(x Hash160) -> Changed(x Hash160, y Any)",
+		"+ event Added()",
+	}, abiDiff(oldABI, newABI))
+	require.Nil(t, abiDiff(oldABI, oldABI))
+}
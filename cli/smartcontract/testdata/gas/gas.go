@@ -2,13 +2,28 @@
 package gastoken
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"math/big"
 )
 
 // Hash contains contract hash.
 var Hash = util.Uint160{0xcf, 0x76, 0xe2, 0x8b, 0xd0, 0x6, 0x2c, 0x4a, 0x47, 0x8e, 0xe3, 0x55, 0x61, 0x1, 0x13, 0x19, 0xf3, 0xcf, 0xa4, 0xd2}
 
+
+// TransferEvent represents "Transfer" event emitted by the contract.
+type TransferEvent struct {
+	From util.Uint160
+	To util.Uint160
+	Amount *big.Int
+}
 // Invoker is used by ContractReader to call various safe methods.
 type Invoker interface {
 	nep17.Invoker
@@ -21,6 +36,14 @@ type Actor interface {
 	nep17.Actor
 }
 
+// EventReceiver is used by Receive*Events functions to subscribe for contract
+// events, it's implemented by rpcclient.WSClient.
+type EventReceiver interface {
+	Context() context.Context
+	ReceiveExecutionNotifications(flt *neorpc.NotificationFilter, rcvr chan<- *state.ContainedNotificationEvent) (string, error)
+	Unsubscribe(id string) error
+}
+
 // ContractReader implements safe contract methods.
 type ContractReader struct {
 	nep17.TokenReader
@@ -45,3 +68,173 @@ func New(actor Actor) *Contract {
 	return &Contract{ContractReader{nep17t.TokenReader, actor}, nep17t.TokenWriter, actor}
 }
 
+
+// TransferEventFilter returns a filter for "Transfer" events of the
+// contract that can be used to subscribe for them via WSClient's
+// ReceiveExecutionNotifications.
+func TransferEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "Transfer"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
+// TransferEventFromNotification converts the given notification (like the one
+// received via WSClient subscription made with TransferEventFilter) into
+// TransferEvent. It returns an error if the notification is not a
+// "Transfer" event of the contract or if it can't be converted.
+func TransferEventFromNotification(ne *state.NotificationEvent) (*TransferEvent, error) {
+	if ne.ScriptHash != Hash || ne.Name != "Transfer" {
+		return nil, fmt.Errorf("unexpected %s event from %s", ne.Name, ne.ScriptHash.StringLE())
+	}
+	var res = new(TransferEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReceiveTransferEvents subscribes for "Transfer" events of the
+// contract using the given EventReceiver and sends them (converted into
+// TransferEvent) to the given channel until the context is done or the
+// subscription is dropped by the client (like when the connection is lost).
+// Notifications that can't be converted are skipped. The channel is closed
+// when receiving stops, subscription is removed at this point as well.
+func ReceiveTransferEvents(ctx context.Context, c EventReceiver, ch chan<- *TransferEvent) error {
+	var rcvr = make(chan *state.ContainedNotificationEvent)
+	id, err := c.ReceiveExecutionNotifications(TransferEventFilter(), rcvr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ne, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event, err := TransferEventFromNotification(&ne.NotificationEvent)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+				}
+			case <-c.Context().Done():
+				return
+			case <-ctx.Done():
+				var unsubbed = make(chan struct{})
+				go func() {
+					_ = c.Unsubscribe(id)
+					close(unsubbed)
+				}()
+				// The receiver must be drained until it's removed.
+				for {
+					select {
+					case _, ok := <-rcvr:
+						if !ok {
+							rcvr = nil
+						}
+					case <-unsubbed:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// ParseTransferEventsFromApplicationLog retrieves all "Transfer"
+// events emitted by the contract from the given application log.
+func ParseTransferEventsFromApplicationLog(log *result.ApplicationLog) ([]*TransferEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*TransferEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.ScriptHash != Hash || e.Name != "Transfer" {
+				continue
+			}
+			event := new(TransferEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("execution %d, event %d: %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts the given event state item into TransferEvent, it
+// returns an error if that's not possible. Null parameters are left as zero
+// values of the corresponding fields.
+func (e *TransferEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 3 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	var (
+		index = -1
+		err   error
+	)
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.From, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field From: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.To, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field To: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.Amount, err = arr[index].TryInteger()
+		if err != nil {
+			return fmt.Errorf("field Amount: %w", err)
+		}
+	}
+
+
+	return nil
+}
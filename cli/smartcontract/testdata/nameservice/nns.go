@@ -2,8 +2,13 @@
 package nameservice
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
@@ -11,11 +16,34 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"math/big"
+	"unicode/utf8"
 )
 
 // Hash contains contract hash.
 var Hash = util.Uint160{0xde, 0x46, 0x5f, 0x5d, 0x50, 0x57, 0xcf, 0x33, 0x28, 0x47, 0x94, 0xc5, 0xcf, 0xc2, 0xc, 0x69, 0x37, 0x1c, 0xac, 0x50}
 
+
+// TransferEvent represents "Transfer" event emitted by the contract.
+type TransferEvent struct {
+	From util.Uint160
+	To util.Uint160
+	Amount *big.Int
+	TokenId []byte
+}
+
+// SetAdminEvent represents "SetAdmin" event emitted by the contract.
+type SetAdminEvent struct {
+	Name string
+	OldAdmin util.Uint160
+	NewAdmin util.Uint160
+}
+
+// RenewEvent represents "Renew" event emitted by the contract.
+type RenewEvent struct {
+	Name string
+	OldExpiration *big.Int
+	NewExpiration *big.Int
+}
 // Invoker is used by ContractReader to call various safe methods.
 type Invoker interface {
 	nep11.Invoker
@@ -35,6 +63,14 @@ type Actor interface {
 	SendRun(script []byte) (util.Uint256, uint32, error)
 }
 
+// EventReceiver is used by Receive*Events functions to subscribe for contract
+// events, it's implemented by rpcclient.WSClient.
+type EventReceiver interface {
+	Context() context.Context
+	ReceiveExecutionNotifications(flt *neorpc.NotificationFilter, rcvr chan<- *state.ContainedNotificationEvent) (string, error)
+	Unsubscribe(id string) error
+}
+
 // ContractReader implements safe contract methods.
 type ContractReader struct {
 	nep11.NonDivisibleReader
@@ -321,3 +357,519 @@ func (c *Contract) DeleteRecordTransaction(name string, typev *big.Int) (*transa
 func (c *Contract) DeleteRecordUnsigned(name string, typev *big.Int) (*transaction.Transaction, error) {
 	return c.actor.MakeUnsignedCall(Hash, "deleteRecord", nil, name, typev)
 }
+
+// TransferEventFilter returns a filter for "Transfer" events of the
+// contract that can be used to subscribe for them via WSClient's
+// ReceiveExecutionNotifications.
+func TransferEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "Transfer"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
+// TransferEventFromNotification converts the given notification (like the one
+// received via WSClient subscription made with TransferEventFilter) into
+// TransferEvent. It returns an error if the notification is not a
+// "Transfer" event of the contract or if it can't be converted.
+func TransferEventFromNotification(ne *state.NotificationEvent) (*TransferEvent, error) {
+	if ne.ScriptHash != Hash || ne.Name != "Transfer" {
+		return nil, fmt.Errorf("unexpected %s event from %s", ne.Name, ne.ScriptHash.StringLE())
+	}
+	var res = new(TransferEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReceiveTransferEvents subscribes for "Transfer" events of the
+// contract using the given EventReceiver and sends them (converted into
+// TransferEvent) to the given channel until the context is done or the
+// subscription is dropped by the client (like when the connection is lost).
+// Notifications that can't be converted are skipped. The channel is closed
+// when receiving stops, subscription is removed at this point as well.
+func ReceiveTransferEvents(ctx context.Context, c EventReceiver, ch chan<- *TransferEvent) error {
+	var rcvr = make(chan *state.ContainedNotificationEvent)
+	id, err := c.ReceiveExecutionNotifications(TransferEventFilter(), rcvr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ne, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event, err := TransferEventFromNotification(&ne.NotificationEvent)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+				}
+			case <-c.Context().Done():
+				return
+			case <-ctx.Done():
+				var unsubbed = make(chan struct{})
+				go func() {
+					_ = c.Unsubscribe(id)
+					close(unsubbed)
+				}()
+				// The receiver must be drained until it's removed.
+				for {
+					select {
+					case _, ok := <-rcvr:
+						if !ok {
+							rcvr = nil
+						}
+					case <-unsubbed:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// ParseTransferEventsFromApplicationLog retrieves all "Transfer"
+// events emitted by the contract from the given application log.
+func ParseTransferEventsFromApplicationLog(log *result.ApplicationLog) ([]*TransferEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*TransferEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.ScriptHash != Hash || e.Name != "Transfer" {
+				continue
+			}
+			event := new(TransferEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("execution %d, event %d: %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts the given event state item into TransferEvent, it
+// returns an error if that's not possible. Null parameters are left as zero
+// values of the corresponding fields.
+func (e *TransferEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 4 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	var (
+		index = -1
+		err   error
+	)
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.From, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field From: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.To, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field To: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.Amount, err = arr[index].TryInteger()
+		if err != nil {
+			return fmt.Errorf("field Amount: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.TokenId, err = arr[index].TryBytes()
+		if err != nil {
+			return fmt.Errorf("field TokenId: %w", err)
+		}
+	}
+
+
+	return nil
+}
+
+// SetAdminEventFilter returns a filter for "SetAdmin" events of the
+// contract that can be used to subscribe for them via WSClient's
+// ReceiveExecutionNotifications.
+func SetAdminEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "SetAdmin"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
+// SetAdminEventFromNotification converts the given notification (like the one
+// received via WSClient subscription made with SetAdminEventFilter) into
+// SetAdminEvent. It returns an error if the notification is not a
+// "SetAdmin" event of the contract or if it can't be converted.
+func SetAdminEventFromNotification(ne *state.NotificationEvent) (*SetAdminEvent, error) {
+	if ne.ScriptHash != Hash || ne.Name != "SetAdmin" {
+		return nil, fmt.Errorf("unexpected %s event from %s", ne.Name, ne.ScriptHash.StringLE())
+	}
+	var res = new(SetAdminEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReceiveSetAdminEvents subscribes for "SetAdmin" events of the
+// contract using the given EventReceiver and sends them (converted into
+// SetAdminEvent) to the given channel until the context is done or the
+// subscription is dropped by the client (like when the connection is lost).
+// Notifications that can't be converted are skipped. The channel is closed
+// when receiving stops, subscription is removed at this point as well.
+func ReceiveSetAdminEvents(ctx context.Context, c EventReceiver, ch chan<- *SetAdminEvent) error {
+	var rcvr = make(chan *state.ContainedNotificationEvent)
+	id, err := c.ReceiveExecutionNotifications(SetAdminEventFilter(), rcvr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ne, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event, err := SetAdminEventFromNotification(&ne.NotificationEvent)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+				}
+			case <-c.Context().Done():
+				return
+			case <-ctx.Done():
+				var unsubbed = make(chan struct{})
+				go func() {
+					_ = c.Unsubscribe(id)
+					close(unsubbed)
+				}()
+				// The receiver must be drained until it's removed.
+				for {
+					select {
+					case _, ok := <-rcvr:
+						if !ok {
+							rcvr = nil
+						}
+					case <-unsubbed:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// ParseSetAdminEventsFromApplicationLog retrieves all "SetAdmin"
+// events emitted by the contract from the given application log.
+func ParseSetAdminEventsFromApplicationLog(log *result.ApplicationLog) ([]*SetAdminEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*SetAdminEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.ScriptHash != Hash || e.Name != "SetAdmin" {
+				continue
+			}
+			event := new(SetAdminEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("execution %d, event %d: %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts the given event state item into SetAdminEvent, it
+// returns an error if that's not possible. Null parameters are left as zero
+// values of the corresponding fields.
+func (e *SetAdminEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 3 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	var (
+		index = -1
+		err   error
+	)
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.Name, err = func (item stackitem.Item) (string, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return "", err
+			}
+			if !utf8.Valid(b) {
+				return "", errors.New("not a UTF-8 string")
+			}
+			return string(b), nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field Name: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.OldAdmin, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field OldAdmin: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.NewAdmin, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field NewAdmin: %w", err)
+		}
+	}
+
+
+	return nil
+}
+
+// RenewEventFilter returns a filter for "Renew" events of the
+// contract that can be used to subscribe for them via WSClient's
+// ReceiveExecutionNotifications.
+func RenewEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "Renew"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
+// RenewEventFromNotification converts the given notification (like the one
+// received via WSClient subscription made with RenewEventFilter) into
+// RenewEvent. It returns an error if the notification is not a
+// "Renew" event of the contract or if it can't be converted.
+func RenewEventFromNotification(ne *state.NotificationEvent) (*RenewEvent, error) {
+	if ne.ScriptHash != Hash || ne.Name != "Renew" {
+		return nil, fmt.Errorf("unexpected %s event from %s", ne.Name, ne.ScriptHash.StringLE())
+	}
+	var res = new(RenewEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReceiveRenewEvents subscribes for "Renew" events of the
+// contract using the given EventReceiver and sends them (converted into
+// RenewEvent) to the given channel until the context is done or the
+// subscription is dropped by the client (like when the connection is lost).
+// Notifications that can't be converted are skipped. The channel is closed
+// when receiving stops, subscription is removed at this point as well.
+func ReceiveRenewEvents(ctx context.Context, c EventReceiver, ch chan<- *RenewEvent) error {
+	var rcvr = make(chan *state.ContainedNotificationEvent)
+	id, err := c.ReceiveExecutionNotifications(RenewEventFilter(), rcvr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ne, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event, err := RenewEventFromNotification(&ne.NotificationEvent)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+				}
+			case <-c.Context().Done():
+				return
+			case <-ctx.Done():
+				var unsubbed = make(chan struct{})
+				go func() {
+					_ = c.Unsubscribe(id)
+					close(unsubbed)
+				}()
+				// The receiver must be drained until it's removed.
+				for {
+					select {
+					case _, ok := <-rcvr:
+						if !ok {
+							rcvr = nil
+						}
+					case <-unsubbed:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// ParseRenewEventsFromApplicationLog retrieves all "Renew"
+// events emitted by the contract from the given application log.
+func ParseRenewEventsFromApplicationLog(log *result.ApplicationLog) ([]*RenewEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*RenewEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.ScriptHash != Hash || e.Name != "Renew" {
+				continue
+			}
+			event := new(RenewEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("execution %d, event %d: %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts the given event state item into RenewEvent, it
+// returns an error if that's not possible. Null parameters are left as zero
+// values of the corresponding fields.
+func (e *RenewEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 3 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	var (
+		index = -1
+		err   error
+	)
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.Name, err = func (item stackitem.Item) (string, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return "", err
+			}
+			if !utf8.Valid(b) {
+				return "", errors.New("not a UTF-8 string")
+			}
+			return string(b), nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field Name: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.OldExpiration, err = arr[index].TryInteger()
+		if err != nil {
+			return fmt.Errorf("field OldExpiration: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.NewExpiration, err = arr[index].TryInteger()
+		if err != nil {
+			return fmt.Errorf("field NewExpiration: %w", err)
+		}
+	}
+
+
+	return nil
+}
@@ -2,17 +2,39 @@
 package nextoken
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"math/big"
 )
 
 // Hash contains contract hash.
 var Hash = util.Uint160{0xa8, 0x1a, 0xa1, 0xf0, 0x4b, 0xf, 0xdc, 0x4a, 0xa2, 0xce, 0xd5, 0xbf, 0xc6, 0x22, 0xcf, 0xe8, 0x9, 0x7f, 0xa6, 0xa2}
 
+
+// TransferEvent represents "Transfer" event emitted by the contract.
+type TransferEvent struct {
+	From util.Uint160
+	To util.Uint160
+	Amount *big.Int
+}
+
+// OnMintEvent represents "OnMint" event emitted by the contract.
+type OnMintEvent struct {
+	From util.Uint160
+	To util.Uint160
+	Amount *big.Int
+	SwapId *big.Int
+}
 // Invoker is used by ContractReader to call various safe methods.
 type Invoker interface {
 	nep17.Invoker
@@ -32,6 +54,14 @@ type Actor interface {
 	SendRun(script []byte) (util.Uint256, uint32, error)
 }
 
+// EventReceiver is used by Receive*Events functions to subscribe for contract
+// events, it's implemented by rpcclient.WSClient.
+type EventReceiver interface {
+	Context() context.Context
+	ReceiveExecutionNotifications(flt *neorpc.NotificationFilter, rcvr chan<- *state.ContainedNotificationEvent) (string, error)
+	Unsubscribe(id string) error
+}
+
 // ContractReader implements safe contract methods.
 type ContractReader struct {
 	nep17.TokenReader
@@ -230,3 +260,351 @@ func (c *Contract) UpdateCapTransaction(newCap *big.Int) (*transaction.Transacti
 func (c *Contract) UpdateCapUnsigned(newCap *big.Int) (*transaction.Transaction, error) {
 	return c.actor.MakeUnsignedCall(Hash, "updateCap", nil, newCap)
 }
+
+// TransferEventFilter returns a filter for "Transfer" events of the
+// contract that can be used to subscribe for them via WSClient's
+// ReceiveExecutionNotifications.
+func TransferEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "Transfer"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
+// TransferEventFromNotification converts the given notification (like the one
+// received via WSClient subscription made with TransferEventFilter) into
+// TransferEvent. It returns an error if the notification is not a
+// "Transfer" event of the contract or if it can't be converted.
+func TransferEventFromNotification(ne *state.NotificationEvent) (*TransferEvent, error) {
+	if ne.ScriptHash != Hash || ne.Name != "Transfer" {
+		return nil, fmt.Errorf("unexpected %s event from %s", ne.Name, ne.ScriptHash.StringLE())
+	}
+	var res = new(TransferEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReceiveTransferEvents subscribes for "Transfer" events of the
+// contract using the given EventReceiver and sends them (converted into
+// TransferEvent) to the given channel until the context is done or the
+// subscription is dropped by the client (like when the connection is lost).
+// Notifications that can't be converted are skipped. The channel is closed
+// when receiving stops, subscription is removed at this point as well.
+func ReceiveTransferEvents(ctx context.Context, c EventReceiver, ch chan<- *TransferEvent) error {
+	var rcvr = make(chan *state.ContainedNotificationEvent)
+	id, err := c.ReceiveExecutionNotifications(TransferEventFilter(), rcvr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ne, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event, err := TransferEventFromNotification(&ne.NotificationEvent)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+				}
+			case <-c.Context().Done():
+				return
+			case <-ctx.Done():
+				var unsubbed = make(chan struct{})
+				go func() {
+					_ = c.Unsubscribe(id)
+					close(unsubbed)
+				}()
+				// The receiver must be drained until it's removed.
+				for {
+					select {
+					case _, ok := <-rcvr:
+						if !ok {
+							rcvr = nil
+						}
+					case <-unsubbed:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// ParseTransferEventsFromApplicationLog retrieves all "Transfer"
+// events emitted by the contract from the given application log.
+func ParseTransferEventsFromApplicationLog(log *result.ApplicationLog) ([]*TransferEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*TransferEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.ScriptHash != Hash || e.Name != "Transfer" {
+				continue
+			}
+			event := new(TransferEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("execution %d, event %d: %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts the given event state item into TransferEvent, it
+// returns an error if that's not possible. Null parameters are left as zero
+// values of the corresponding fields.
+func (e *TransferEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 3 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	var (
+		index = -1
+		err   error
+	)
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.From, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field From: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.To, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field To: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.Amount, err = arr[index].TryInteger()
+		if err != nil {
+			return fmt.Errorf("field Amount: %w", err)
+		}
+	}
+
+
+	return nil
+}
+
+// OnMintEventFilter returns a filter for "OnMint" events of the
+// contract that can be used to subscribe for them via WSClient's
+// ReceiveExecutionNotifications.
+func OnMintEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "OnMint"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
+// OnMintEventFromNotification converts the given notification (like the one
+// received via WSClient subscription made with OnMintEventFilter) into
+// OnMintEvent. It returns an error if the notification is not a
+// "OnMint" event of the contract or if it can't be converted.
+func OnMintEventFromNotification(ne *state.NotificationEvent) (*OnMintEvent, error) {
+	if ne.ScriptHash != Hash || ne.Name != "OnMint" {
+		return nil, fmt.Errorf("unexpected %s event from %s", ne.Name, ne.ScriptHash.StringLE())
+	}
+	var res = new(OnMintEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReceiveOnMintEvents subscribes for "OnMint" events of the
+// contract using the given EventReceiver and sends them (converted into
+// OnMintEvent) to the given channel until the context is done or the
+// subscription is dropped by the client (like when the connection is lost).
+// Notifications that can't be converted are skipped. The channel is closed
+// when receiving stops, subscription is removed at this point as well.
+func ReceiveOnMintEvents(ctx context.Context, c EventReceiver, ch chan<- *OnMintEvent) error {
+	var rcvr = make(chan *state.ContainedNotificationEvent)
+	id, err := c.ReceiveExecutionNotifications(OnMintEventFilter(), rcvr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ne, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event, err := OnMintEventFromNotification(&ne.NotificationEvent)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+				}
+			case <-c.Context().Done():
+				return
+			case <-ctx.Done():
+				var unsubbed = make(chan struct{})
+				go func() {
+					_ = c.Unsubscribe(id)
+					close(unsubbed)
+				}()
+				// The receiver must be drained until it's removed.
+				for {
+					select {
+					case _, ok := <-rcvr:
+						if !ok {
+							rcvr = nil
+						}
+					case <-unsubbed:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// ParseOnMintEventsFromApplicationLog retrieves all "OnMint"
+// events emitted by the contract from the given application log.
+func ParseOnMintEventsFromApplicationLog(log *result.ApplicationLog) ([]*OnMintEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*OnMintEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.ScriptHash != Hash || e.Name != "OnMint" {
+				continue
+			}
+			event := new(OnMintEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("execution %d, event %d: %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts the given event state item into OnMintEvent, it
+// returns an error if that's not possible. Null parameters are left as zero
+// values of the corresponding fields.
+func (e *OnMintEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 4 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	var (
+		index = -1
+		err   error
+	)
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.From, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field From: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.To, err = func (item stackitem.Item) (util.Uint160, error) {
+			b, err := item.TryBytes()
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			u, err := util.Uint160DecodeBytesBE(b)
+			if err != nil {
+				return util.Uint160{}, err
+			}
+			return u, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field To: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.Amount, err = arr[index].TryInteger()
+		if err != nil {
+			return fmt.Errorf("field Amount: %w", err)
+		}
+	}
+
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.SwapId, err = arr[index].TryInteger()
+		if err != nil {
+			return fmt.Errorf("field SwapId: %w", err)
+		}
+	}
+
+
+	return nil
+}
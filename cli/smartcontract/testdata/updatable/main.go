@@ -0,0 +1,24 @@
+package updatable
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+const dataKey = "data"
+
+func _deploy(data interface{}, isUpdate bool) {
+	if isUpdate && data != nil {
+		storage.Put(storage.GetContext(), dataKey, data)
+	}
+}
+
+// Update updates the contract with a new one passing data to its _deploy.
+func Update(script, manifest []byte, data interface{}) {
+	management.UpdateWithData(script, manifest, data)
+}
+
+// Destroy destroys the contract.
+func Destroy() {
+	management.Destroy()
+}
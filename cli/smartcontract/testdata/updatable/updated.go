@@ -0,0 +1,8 @@
+package updatable
+
+import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+
+// GetData returns the data passed on update.
+func GetData() string {
+	return storage.Get(storage.GetReadOnlyContext(), dataKey).(string)
+}
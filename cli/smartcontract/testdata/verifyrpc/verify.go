@@ -2,14 +2,26 @@
 package verify
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
 // Hash contains contract hash.
 var Hash = util.Uint160{0x33, 0x22, 0x11, 0x0, 0xff, 0xee, 0xdd, 0xcc, 0xbb, 0xaa, 0x99, 0x88, 0x77, 0x66, 0x55, 0x44, 0x33, 0x22, 0x11, 0x0}
 
+
+// HelloWorldEvent represents "Hello world!" event emitted by the contract.
+type HelloWorldEvent struct {
+	Args []interface{}
+}
 // Actor is used by Contract to call state-changing methods.
 type Actor interface {
 	MakeCall(contract util.Uint160, method string, params ...interface{}) (*transaction.Transaction, error)
@@ -20,6 +32,14 @@ type Actor interface {
 	SendRun(script []byte) (util.Uint256, uint32, error)
 }
 
+// EventReceiver is used by Receive*Events functions to subscribe for contract
+// events, it's implemented by rpcclient.WSClient.
+type EventReceiver interface {
+	Context() context.Context
+	ReceiveExecutionNotifications(flt *neorpc.NotificationFilter, rcvr chan<- *state.ContainedNotificationEvent) (string, error)
+	Unsubscribe(id string) error
+}
+
 // Contract implements all contract methods.
 type Contract struct {
 	actor Actor
@@ -68,3 +88,150 @@ func (c *Contract) VerifyUnsigned() (*transaction.Transaction, error) {
 	}
 	return c.actor.MakeUnsignedRun(script, nil)
 }
+
+// HelloWorldEventFilter returns a filter for "Hello world!" events of the
+// contract that can be used to subscribe for them via WSClient's
+// ReceiveExecutionNotifications.
+func HelloWorldEventFilter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "Hello world!"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
+// HelloWorldEventFromNotification converts the given notification (like the one
+// received via WSClient subscription made with HelloWorldEventFilter) into
+// HelloWorldEvent. It returns an error if the notification is not a
+// "Hello world!" event of the contract or if it can't be converted.
+func HelloWorldEventFromNotification(ne *state.NotificationEvent) (*HelloWorldEvent, error) {
+	if ne.ScriptHash != Hash || ne.Name != "Hello world!" {
+		return nil, fmt.Errorf("unexpected %s event from %s", ne.Name, ne.ScriptHash.StringLE())
+	}
+	var res = new(HelloWorldEvent)
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// ReceiveHelloWorldEvents subscribes for "Hello world!" events of the
+// contract using the given EventReceiver and sends them (converted into
+// HelloWorldEvent) to the given channel until the context is done or the
+// subscription is dropped by the client (like when the connection is lost).
+// Notifications that can't be converted are skipped. The channel is closed
+// when receiving stops, subscription is removed at this point as well.
+func ReceiveHelloWorldEvents(ctx context.Context, c EventReceiver, ch chan<- *HelloWorldEvent) error {
+	var rcvr = make(chan *state.ContainedNotificationEvent)
+	id, err := c.ReceiveExecutionNotifications(HelloWorldEventFilter(), rcvr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ne, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event, err := HelloWorldEventFromNotification(&ne.NotificationEvent)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+				}
+			case <-c.Context().Done():
+				return
+			case <-ctx.Done():
+				var unsubbed = make(chan struct{})
+				go func() {
+					_ = c.Unsubscribe(id)
+					close(unsubbed)
+				}()
+				// The receiver must be drained until it's removed.
+				for {
+					select {
+					case _, ok := <-rcvr:
+						if !ok {
+							rcvr = nil
+						}
+					case <-unsubbed:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// ParseHelloWorldEventsFromApplicationLog retrieves all "Hello world!"
+// events emitted by the contract from the given application log.
+func ParseHelloWorldEventsFromApplicationLog(log *result.ApplicationLog) ([]*HelloWorldEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*HelloWorldEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.ScriptHash != Hash || e.Name != "Hello world!" {
+				continue
+			}
+			event := new(HelloWorldEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("execution %d, event %d: %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts the given event state item into HelloWorldEvent, it
+// returns an error if that's not possible. Null parameters are left as zero
+// values of the corresponding fields.
+func (e *HelloWorldEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 1 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	var (
+		index = -1
+		err   error
+	)
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.Args, err = func (item stackitem.Item) ([]interface{}, error) {
+			arr, ok := item.Value().([]stackitem.Item)
+			if !ok {
+				return nil, errors.New("not an array")
+			}
+			res := make([]interface{}, len(arr))
+			for i := range res {
+				res[i], err = arr[i].Value(), nil
+				if err != nil {
+					return nil, fmt.Errorf("item %d: %w", i, err)
+				}
+			}
+			return res, nil
+		} (arr[index])
+		if err != nil {
+			return fmt.Errorf("field Args: %w", err)
+		}
+	}
+
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package smartcontract
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/txctx"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/management"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/urfave/cli"
+)
+
+// contractUpdate updates deployed contract via its 'update' method.
+func contractUpdate(ctx *cli.Context) error {
+	args := ctx.Args()
+	if !args.Present() {
+		return cli.NewExitError(errNoScriptHash, 1)
+	}
+	hash, err := flags.ParseAddress(args[0])
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("incorrect script hash: %w", err), 1)
+	}
+	nefFile, _, err := readNEFFile(ctx.String("in"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	m, _, err := readManifest(ctx.String("manifest"), hash)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+	}
+
+	signOffset, params, err := cmdargs.ParseParams(args[1:], true)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("unable to parse 'data' parameter: %w", err), 1)
+	}
+	if len(params) > 1 {
+		return cli.NewExitError("'data' should be represented as a single parameter", 1)
+	}
+	var data interface{}
+	if len(params) != 0 {
+		data, err = smartcontract.ExpandParameterToEmitable(params[0])
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to convert 'data' to emitable type: %w", err), 1)
+		}
+	}
+
+	cs, err := getDeployedContract(ctx, hash)
+	if err != nil {
+		return err
+	}
+	if cs.Manifest.ABI.GetMethod("update", 3) == nil {
+		return cli.NewExitError(fmt.Errorf("contract %s doesn't have 'update' method with 3 parameters", hash.StringLE()), 1)
+	}
+	if m.Name != cs.Manifest.Name {
+		return cli.NewExitError(fmt.Errorf("contract name can't be changed (%q -> %q)", cs.Manifest.Name, m.Name), 1)
+	}
+
+	diff := abiDiff(&cs.Manifest.ABI, &m.ABI)
+	if len(diff) == 0 {
+		fmt.Fprintln(ctx.App.Writer, "No ABI changes.")
+	} else {
+		fmt.Fprintln(ctx.App.Writer, "ABI changes:")
+		for _, d := range diff {
+			fmt.Fprintln(ctx.App.Writer, d)
+		}
+	}
+
+	return signAndSendUpdater(ctx, 1+signOffset, hash, func(u *management.Updater) (*transaction.Transaction, error) {
+		return u.UpdateUnsigned(nefFile, m, data)
+	})
+}
+
+// contractDestroy destroys deployed contract via its 'destroy' method.
+func contractDestroy(ctx *cli.Context) error {
+	args := ctx.Args()
+	if !args.Present() {
+		return cli.NewExitError(errNoScriptHash, 1)
+	}
+	hash, err := flags.ParseAddress(args[0])
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("incorrect script hash: %w", err), 1)
+	}
+	if len(args) > 1 && args[1] != cmdargs.CosignersSeparator {
+		return cli.NewExitError(fmt.Errorf("unexpected argument %q, signers should be specified after '%s'", args[1], cmdargs.CosignersSeparator), 1)
+	}
+
+	cs, err := getDeployedContract(ctx, hash)
+	if err != nil {
+		return err
+	}
+	if cs.Manifest.ABI.GetMethod("destroy", 0) == nil {
+		return cli.NewExitError(fmt.Errorf("contract %s doesn't have 'destroy' method without parameters", hash.StringLE()), 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Contract %s (%s) is to be destroyed.\n", cs.Manifest.Name, hash.StringLE())
+
+	var signOffset int
+	if len(args) > 1 {
+		signOffset = 2
+	}
+	return signAndSendUpdater(ctx, signOffset, hash, func(u *management.Updater) (*transaction.Transaction, error) {
+		return u.DestroyUnsigned()
+	})
+}
+
+// signAndSendUpdater creates a transaction using the management.Updater for
+// the given contract with an actor made of the sender account and signers given after
+// signOffset arguments (sender is added with CalledByEntry scope if it's not
+// specified there), then signs and sends or saves it.
+func signAndSendUpdater(ctx *cli.Context, signOffset int, hash util.Uint160, makeTx func(*management.Updater) (*transaction.Transaction, error)) error {
+	acc, w, err := getAccFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't get sender address: %w", err), 1)
+	}
+	defer w.Close()
+
+	cosigners, sgnErr := cmdargs.GetSignersFromContext(ctx, signOffset)
+	if sgnErr != nil {
+		return sgnErr
+	}
+	signersAccounts, err := cmdargs.GetSignersAccounts(acc, w, cosigners, transaction.CalledByEntry)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid signers: %w", err), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+	act, err := actor.New(c, signersAccounts)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create RPC actor: %w", err), 1)
+	}
+	tx, err := makeTx(management.NewUpdater(act, hash))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create tx: %w", err), 1)
+	}
+	return txctx.SignAndSend(ctx, act, acc, tx)
+}
+
+// getDeployedContract retrieves the state of the deployed contract via RPC.
+func getDeployedContract(ctx *cli.Context, hash util.Uint160) (*state.Contract, error) {
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return nil, exitErr
+	}
+	cs, err := c.GetContractStateByHash(hash)
+	if err != nil {
+		return nil, cli.NewExitError(fmt.Errorf("failed to get deployed contract %s: %w", hash.StringLE(), err), 1)
+	}
+	return cs, nil
+}
+
+// abiDiff returns a human-readable list of differences between old and new
+// contract ABIs. Methods are matched by name and the number of parameters,
+// events are matched by name, method offsets are not compared.
+func abiDiff(oldABI, newABI *manifest.ABI) []string {
+	var res []string
+	for i := range oldABI.Methods {
+		om := &oldABI.Methods[i]
+		nm := newABI.GetMethod(om.Name, len(om.Parameters))
+		switch {
+		case nm == nil:
+			res = append(res, "- method "+methodSignature(om))
+		case methodSignature(om) != methodSignature(nm):
+			res = append(res, "~ method "+methodSignature(om)+" -> "+methodSignature(nm))
+		}
+	}
+	for i := range newABI.Methods {
+		nm := &newABI.Methods[i]
+		if oldABI.GetMethod(nm.Name, len(nm.Parameters)) == nil {
+			res = append(res, "+ method "+methodSignature(nm))
+		}
+	}
+	for i := range oldABI.Events {
+		oe := &oldABI.Events[i]
+		ne := newABI.GetEvent(oe.Name)
+		switch {
+		case ne == nil:
+			res = append(res, "- event "+eventSignature(oe))
+		case eventSignature(oe) != eventSignature(ne):
+			res = append(res, "~ event "+eventSignature(oe)+" -> "+eventSignature(ne))
+		}
+	}
+	for i := range newABI.Events {
+		ne := &newABI.Events[i]
+		if oldABI.GetEvent(ne.Name) == nil {
+			res = append(res, "+ event "+eventSignature(ne))
+		}
+	}
+	return res
+}
+
+func methodSignature(m *manifest.Method) string {
+	s := m.Name + parametersSignature(m.Parameters) + " " + m.ReturnType.String()
+	if m.Safe {
+		s += " (safe)"
+	}
+	return s
+}
+
+func eventSignature(e *manifest.Event) string {
+	return e.Name + parametersSignature(e.Parameters)
+}
+
+func parametersSignature(ps []manifest.Parameter) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i := range ps {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(ps[i].Name + " " + ps[i].Type.String())
+	}
+	b.WriteByte(')')
+	return b.String()
+}
@@ -12,6 +12,8 @@ import (
 	"io"
 	"math/big"
 	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
@@ -52,6 +54,7 @@ const (
 	chainCfgKey         = "chainCfg"
 	icKey               = "ic"
 	manifestKey         = "manifest"
+	debugInfoKey        = "debugInfo"
 	exitFuncKey         = "exitFunc"
 	readlineInstanceKey = "readlineKey"
 	printLogoKey        = "printLogoKey"
@@ -96,13 +99,31 @@ var commands = []cli.Command{
 	{
 		Name:      "break",
 		Usage:     "Place a breakpoint",
-		UsageText: `break <ip>`,
-		Description: `<ip> is mandatory parameter.
+		UsageText: `break <ip> | <file>:<line>`,
+		Description: `Either <ip> or <file>:<line> is mandatory parameter. Source code positions
+can only be used for contracts loaded with 'loadgo' command, breakpoints are
+placed on all instructions of the given line (or the next line with code if
+there is none on the given one). <file> can be an absolute path or a path
+suffix (like a file name) matching one of the contract source files.
 
 Example:
-> break 12`,
+> break 12
+> break contract.go:42`,
 		Action: handleBreak,
 	},
+	{
+		Name:      "list",
+		Usage:     "Show contract source code around the current (or specified) line",
+		UsageText: `list [<file>:<line>]`,
+		Description: `Show contract source code around the current line or around the specified
+<file>:<line> position. It's only available for contracts loaded with 'loadgo'
+command.
+
+Example:
+> list
+> list contract.go:42`,
+		Action: handleList,
+	},
 	{
 		Name:      "jump",
 		Usage:     "Jump to the specified instruction (absolute IP value)",
@@ -148,6 +169,20 @@ Example:
 		Description: "Show arguments slot contents.",
 		Action:      handleSlots,
 	},
+	{
+		Name:      "vars",
+		Usage:     "Show arguments, local and static variables by their names",
+		UsageText: `vars [<name>]`,
+		Description: `Show arguments and local variables of the current method along with static
+variables using their names from the contract source code. If <name> is given,
+only variables with this name are shown. It's only available for contracts
+loaded with 'loadgo' command.
+
+Example:
+> vars
+> vars amount`,
+		Action: handleVars,
+	},
 	{
 		Name:      "loadnef",
 		Usage:     "Load a NEF-consistent script into the VM optionally attaching to it provided signers with scopes",
@@ -524,6 +559,11 @@ func getManifestFromContext(app *cli.App) *manifest.Manifest {
 	return app.Metadata[manifestKey].(*manifest.Manifest)
 }
 
+func getDebugInfoFromContext(app *cli.App) *compiler.DebugInfo {
+	di, _ := app.Metadata[debugInfoKey].(*compiler.DebugInfo)
+	return di
+}
+
 func getPrintLogoFromContext(app *cli.App) bool {
 	return app.Metadata[printLogoKey].(bool)
 }
@@ -536,6 +576,10 @@ func setManifestInContext(app *cli.App, m *manifest.Manifest) {
 	app.Metadata[manifestKey] = m
 }
 
+func setDebugInfoInContext(app *cli.App, di *compiler.DebugInfo) {
+	app.Metadata[debugInfoKey] = di
+}
+
 func checkVMIsReady(app *cli.App) bool {
 	v := getVMFromContext(app)
 	if v == nil || !v.Ready() {
@@ -563,7 +607,7 @@ func handleIP(c *cli.Context) error {
 	ctx := v.Context()
 	if ctx.NextIP() < ctx.LenInstr() {
 		ip, opcode := v.Context().NextInstr()
-		fmt.Fprintf(c.App.Writer, "instruction pointer at %d (%s)\n", ip, opcode)
+		fmt.Fprintf(c.App.Writer, "instruction pointer at %d (%s)%s\n", ip, opcode, getSourcePosition(c.App))
 	} else {
 		fmt.Fprintln(c.App.Writer, "execution has finished")
 	}
@@ -574,6 +618,16 @@ func handleBreak(c *cli.Context) error {
 	if !checkVMIsReady(c.App) {
 		return nil
 	}
+	args := c.Args()
+	if len(args) == 1 {
+		file, line, ok, err := parseSourcePosition(args[0])
+		if err != nil {
+			return err
+		}
+		if ok {
+			return addSourceBreakPoints(c, file, line)
+		}
+	}
 	n, err := getInstructionParameter(c)
 	if err != nil {
 		return err
@@ -648,6 +702,270 @@ func handleSlots(c *cli.Context) error {
 	return nil
 }
 
+// listContextLines is the number of source code lines shown before and after
+// the current one by 'list' command.
+const listContextLines = 5
+
+// getCurrentDebugInfo returns debug info of the contract loaded with 'loadgo'
+// if the current VM context belongs to it.
+func getCurrentDebugInfo(app *cli.App) (*compiler.DebugInfo, error) {
+	di := getDebugInfoFromContext(app)
+	if di == nil {
+		return nil, errors.New("no debug info available, use 'loadgo' to load Go contract")
+	}
+	ctx := getVMFromContext(app).Context()
+	if ctx == nil || !ctx.ScriptHash().Equals(di.Hash) {
+		return nil, errors.New("current context doesn't belong to the contract loaded with 'loadgo'")
+	}
+	return di, nil
+}
+
+// parseSourcePosition parses <file>:<line> source code position. It returns
+// false if the argument doesn't look like a position.
+func parseSourcePosition(arg string) (string, int, bool, error) {
+	i := strings.LastIndexByte(arg, ':')
+	if i <= 0 {
+		return "", 0, false, nil
+	}
+	line, err := strconv.Atoi(arg[i+1:])
+	if err != nil || line <= 0 {
+		return "", 0, false, fmt.Errorf("%w: invalid line number %q", ErrInvalidParameter, arg[i+1:])
+	}
+	return arg[:i], line, true, nil
+}
+
+// findDocument returns the index of the contract source file matching the
+// given path (either exactly or by suffix).
+func findDocument(di *compiler.DebugInfo, file string) (int, error) {
+	var (
+		res     = -1
+		absFile string
+		suffix  = "/" + filepath.ToSlash(filepath.Clean(file))
+	)
+	if abs, err := filepath.Abs(file); err == nil {
+		absFile = abs
+	}
+	for i, doc := range di.Documents {
+		if doc == file {
+			return i, nil
+		}
+		if abs, err := filepath.Abs(doc); (err == nil && abs == absFile) ||
+			strings.HasSuffix(filepath.ToSlash(doc), suffix) {
+			if res != -1 {
+				return 0, fmt.Errorf("%w: ambiguous file %s (matches both %s and %s)",
+					ErrInvalidParameter, file, di.Documents[res], doc)
+			}
+			res = i
+		}
+	}
+	if res == -1 {
+		return 0, fmt.Errorf("%w: file %s is not a part of the contract", ErrInvalidParameter, file)
+	}
+	return res, nil
+}
+
+// findMethod returns debug info of the method containing the given
+// instruction (or nil if there is none).
+func findMethod(di *compiler.DebugInfo, ip int) *compiler.MethodDebugInfo {
+	for i := range di.Methods {
+		if int(di.Methods[i].Range.Start) <= ip && ip <= int(di.Methods[i].Range.End) {
+			return &di.Methods[i]
+		}
+	}
+	return nil
+}
+
+// findSequencePoint returns the sequence point the given instruction belongs
+// to (or nil if there is none).
+func findSequencePoint(di *compiler.DebugInfo, ip int) *compiler.DebugSeqPoint {
+	m := findMethod(di, ip)
+	if m == nil {
+		return nil
+	}
+	var res *compiler.DebugSeqPoint
+	for i := range m.SeqPoints {
+		if m.SeqPoints[i].Opcode <= ip && (res == nil || res.Opcode < m.SeqPoints[i].Opcode) {
+			res = &m.SeqPoints[i]
+		}
+	}
+	return res
+}
+
+// getSourcePosition returns a string with the source code position of the
+// next instruction to be executed suitable for appending to instruction
+// descriptions, it's empty if the position is unknown.
+func getSourcePosition(app *cli.App) string {
+	di, err := getCurrentDebugInfo(app)
+	if err != nil {
+		return ""
+	}
+	sp := findSequencePoint(di, getVMFromContext(app).Context().NextIP())
+	if sp == nil || sp.Document < 0 || sp.Document >= len(di.Documents) {
+		return ""
+	}
+	return fmt.Sprintf(" at %s:%d", di.Documents[sp.Document], sp.StartLine)
+}
+
+func addSourceBreakPoints(c *cli.Context, file string, line int) error {
+	di, err := getCurrentDebugInfo(c.App)
+	if err != nil {
+		return err
+	}
+	doc, err := findDocument(di, file)
+	if err != nil {
+		return err
+	}
+	// Pick the first line with code starting from the given one.
+	var codeLine = -1
+	for _, m := range di.Methods {
+		for _, sp := range m.SeqPoints {
+			if sp.Document == doc && sp.StartLine >= line && (codeLine == -1 || sp.StartLine < codeLine) {
+				codeLine = sp.StartLine
+			}
+		}
+	}
+	if codeLine == -1 {
+		return fmt.Errorf("%w: no code at or after line %d of %s", ErrInvalidParameter, line, di.Documents[doc])
+	}
+	var ips []int
+	for _, m := range di.Methods {
+		for _, sp := range m.SeqPoints {
+			if sp.Document == doc && sp.StartLine == codeLine {
+				ips = append(ips, sp.Opcode)
+			}
+		}
+	}
+	sort.Ints(ips)
+	v := getVMFromContext(c.App)
+	for _, ip := range ips {
+		v.AddBreakPoint(ip)
+		fmt.Fprintf(c.App.Writer, "breakpoint added at instruction %d (%s:%d)\n", ip, di.Documents[doc], codeLine)
+	}
+	return nil
+}
+
+func handleList(c *cli.Context) error {
+	if !checkVMIsReady(c.App) {
+		return nil
+	}
+	args := c.Args()
+	switch len(args) {
+	case 0:
+		di, err := getCurrentDebugInfo(c.App)
+		if err != nil {
+			return err
+		}
+		sp := findSequencePoint(di, getVMFromContext(c.App).Context().NextIP())
+		if sp == nil || sp.Document < 0 || sp.Document >= len(di.Documents) {
+			return errors.New("no source code available for the current instruction")
+		}
+		return printSourceLines(c.App.Writer, di.Documents[sp.Document], sp.StartLine, sp.StartLine)
+	case 1:
+		file, line, ok, err := parseSourcePosition(args[0])
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return fmt.Errorf("%w: <file>:<line> expected", ErrInvalidParameter)
+		}
+		di := getDebugInfoFromContext(c.App)
+		if di == nil {
+			return errors.New("no debug info available, use 'loadgo' to load Go contract")
+		}
+		doc, err := findDocument(di, file)
+		if err != nil {
+			return err
+		}
+		return printSourceLines(c.App.Writer, di.Documents[doc], line, -1)
+	default:
+		return fmt.Errorf("%w: too many arguments", ErrInvalidParameter)
+	}
+}
+
+// printSourceLines prints source code lines of the file around the given
+// line, current line (if it's positive) is marked.
+func printSourceLines(w io.Writer, file string, line int, current int) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read source file: %w", err)
+	}
+	lines := strings.Split(string(data), "\n")
+	if line > len(lines) {
+		return fmt.Errorf("%w: line %d is out of file range (%d lines)", ErrInvalidParameter, line, len(lines))
+	}
+	var (
+		first = line - listContextLines
+		last  = line + listContextLines
+	)
+	if first < 1 {
+		first = 1
+	}
+	if last > len(lines) {
+		last = len(lines)
+	}
+	width := len(strconv.Itoa(last))
+	for i := first; i <= last; i++ {
+		mark := "  "
+		if i == current {
+			mark = "=>"
+		}
+		fmt.Fprintf(w, "%s %*d\t%s\n", mark, width, i, strings.TrimRight(lines[i-1], "\r"))
+	}
+	return nil
+}
+
+func handleVars(c *cli.Context) error {
+	if !checkVMIsReady(c.App) {
+		return nil
+	}
+	di, err := getCurrentDebugInfo(c.App)
+	if err != nil {
+		return err
+	}
+	var (
+		args  = c.Args()
+		ctx   = getVMFromContext(c.App).Context()
+		name  string
+		found bool
+	)
+	if len(args) > 1 {
+		return fmt.Errorf("%w: too many arguments", ErrInvalidParameter)
+	}
+	if len(args) == 1 {
+		name = args[0]
+	}
+	printVars := func(title string, vars []compiler.DebugVariable, items []stackitem.Item) {
+		var header bool
+		for _, v := range vars {
+			if (name != "" && v.Name != name) || v.Index >= len(items) {
+				continue
+			}
+			if !header {
+				fmt.Fprintln(c.App.Writer, title+":")
+				header = true
+			}
+			found = true
+			data, err := stackitem.ToJSONWithTypes(items[v.Index])
+			if err != nil {
+				data = []byte(fmt.Sprintf("<%s>", err))
+			}
+			fmt.Fprintf(c.App.Writer, "  %s (%s): %s\n", v.Name, v.Type, data)
+		}
+	}
+	if m := findMethod(di, ctx.NextIP()); m != nil {
+		printVars("Arguments", m.ArgumentVariables(), ctx.ArgumentsSlot())
+		printVars("Locals", compiler.ParseDebugVariables(m.Variables), ctx.LocalSlot())
+	}
+	printVars("Static variables", compiler.ParseDebugVariables(di.StaticVariables), ctx.StaticSlot())
+	if !found {
+		if name != "" {
+			return fmt.Errorf("%w: variable %s is not available", ErrInvalidParameter, name)
+		}
+		fmt.Fprintln(c.App.Writer, "no variables available")
+	}
+	return nil
+}
+
 // prepareVM retrieves --historic flag from context (if set) and resets app state
 // (to the specified historic height if given).
 func prepareVM(c *cli.Context, tx *transaction.Transaction) error {
@@ -795,6 +1113,7 @@ func handleLoadGo(c *cli.Context) error {
 	}
 	v := getVMFromContext(c.App)
 	setManifestInContext(c.App, m)
+	setDebugInfoInContext(c.App, di)
 	fmt.Fprintf(c.App.Writer, "READY: loaded %d instructions\n", v.Context().LenInstr())
 	changePrompt(c.App)
 	return nil
@@ -944,14 +1263,20 @@ func resetManifest(app *cli.App) {
 	setManifestInContext(app, nil)
 }
 
-// resetState resets state of the app (clear interop context and manifest) so that it's ready
-// to load new program.
+// resetDebugInfo removes debug info from app context.
+func resetDebugInfo(app *cli.App) {
+	setDebugInfoInContext(app, nil)
+}
+
+// resetState resets state of the app (clear interop context, manifest and
+// debug info) so that it's ready to load new program.
 func resetState(app *cli.App, tx *transaction.Transaction, height ...uint32) error {
 	err := resetInteropContext(app, tx, height...)
 	if err != nil {
 		return err
 	}
 	resetManifest(app)
+	resetDebugInfo(app)
 	return nil
 }
 
@@ -1042,7 +1367,7 @@ func runVMWithHandling(c *cli.Context) {
 		ctx := v.Context()
 		if ctx.NextIP() < ctx.LenInstr() {
 			i, op := ctx.NextInstr()
-			message = fmt.Sprintf("at breakpoint %d (%s)", i, op)
+			message = fmt.Sprintf("at breakpoint %d (%s)%s", i, op, getSourcePosition(c.App))
 		} else {
 			message = "execution has finished"
 		}
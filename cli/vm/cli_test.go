@@ -1217,3 +1217,93 @@ func TestJump(t *testing.T) {
 	e.checkNextLine(t, fmt.Sprintf("jumped to instruction %d", jmpTo))
 	e.checkStack(t, 9)
 }
+
+func TestSourceDebugging(t *testing.T) {
+	src := `package kek
+
+var counter int
+
+func Main(a, b int) int {
+	c := a + b
+	counter = c
+
+	if c > 10 {
+		return c * 2
+	}
+	return c
+}
+`
+	tmpDir := t.TempDir()
+	filename := filepath.Join(tmpDir, "vmtestcontract.go")
+	require.NoError(t, os.WriteFile(filename, []byte(src), os.ModePerm))
+	goMod := []byte(`module test.example/vmcli
+go 1.17`)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "go.mod"), goMod, os.ModePerm))
+
+	e := newTestVMCLI(t)
+	e.runProgWithTimeout(t, 10*time.Second,
+		"loadhex 11",
+		"break vmtestcontract.go:7",
+		"list",
+		"vars",
+		"loadgo '"+filename+"'",
+		"break vmtestcontract.go:7",
+		"break vmtestcontract.go:8", // Empty line, the next one with code is used.
+		"break vmtestcontract.go:100",
+		"break unknown.go:7",
+		"break vmtestcontract.go:x",
+		"run main 7 5",
+		"list",
+		"vars",
+		"vars c",
+		"vars d",
+		"cont",
+		"ip",
+		"list vmtestcontract.go:1",
+		"cont",
+	)
+
+	e.checkNextLine(t, "READY: loaded 1 instructions")
+	e.checkNextLine(t, "Error: no debug info available")
+	e.checkNextLine(t, "Error: no debug info available")
+	e.checkNextLine(t, "Error: no debug info available")
+	e.checkNextLine(t, "READY: loaded \\d+ instructions")
+	e.checkNextLine(t, "breakpoint added at instruction \\d+ \\(.*vmtestcontract.go:7\\)")
+	e.checkNextLine(t, "breakpoint added at instruction \\d+ \\(.*vmtestcontract.go:10\\)")
+	e.checkError(t, fmt.Errorf("%w: no code at or after line 100", ErrInvalidParameter))
+	e.checkError(t, fmt.Errorf("%w: file unknown.go is not a part of the contract", ErrInvalidParameter))
+	e.checkError(t, fmt.Errorf("%w: invalid line number", ErrInvalidParameter))
+
+	e.checkNextLine(t, "at breakpoint \\d+ \\(.*\\) at .*vmtestcontract.go:7")
+	e.checkNextLineExact(t, "    2\t\n")
+	e.checkNextLineExact(t, "    3\tvar counter int\n")
+	e.checkNextLineExact(t, "    4\t\n")
+	e.checkNextLineExact(t, "    5\tfunc Main(a, b int) int {\n")
+	e.checkNextLineExact(t, "    6\t\tc := a + b\n")
+	e.checkNextLineExact(t, "=>  7\t\tcounter = c\n")
+	e.checkNextLineExact(t, "    8\t\n")
+	e.checkNextLineExact(t, "    9\t\tif c > 10 {\n")
+	e.checkNextLineExact(t, "   10\t\t\treturn c * 2\n")
+	e.checkNextLineExact(t, "   11\t\t}\n")
+	e.checkNextLineExact(t, "   12\t\treturn c\n")
+
+	e.checkNextLineExact(t, "Arguments:\n")
+	e.checkNextLineExact(t, `  a (Integer): {"type":"Integer","value":"7"}`+"\n")
+	e.checkNextLineExact(t, `  b (Integer): {"type":"Integer","value":"5"}`+"\n")
+	e.checkNextLineExact(t, "Locals:\n")
+	e.checkNextLineExact(t, `  c (Integer): {"type":"Integer","value":"12"}`+"\n")
+	e.checkNextLineExact(t, "Static variables:\n")
+	e.checkNextLineExact(t, `  counter (Integer): {"type":"Integer","value":"0"}`+"\n")
+
+	e.checkNextLineExact(t, "Locals:\n")
+	e.checkNextLineExact(t, `  c (Integer): {"type":"Integer","value":"12"}`+"\n")
+	e.checkError(t, fmt.Errorf("%w: variable d is not available", ErrInvalidParameter))
+
+	e.checkNextLine(t, "at breakpoint \\d+ \\(.*\\) at .*vmtestcontract.go:10")
+	e.checkNextLine(t, "instruction pointer at \\d+ \\(.*\\) at .*vmtestcontract.go:10")
+	e.checkNextLineExact(t, "   1\tpackage kek\n")
+	for i := 2; i <= 6; i++ {
+		e.checkNextLine(t, fmt.Sprintf("^   %d\t", i))
+	}
+	e.checkStack(t, 24)
+}
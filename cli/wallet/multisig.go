@@ -59,7 +59,10 @@ func signStoredTransaction(ctx *cli.Context) error {
 	}
 
 	if acc.CanSign() {
-		sign := acc.SignHashable(pc.Network, pc.Verifiable)
+		sign, err := acc.SignHashableErr(pc.Network, pc.Verifiable)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't sign transaction: %w", err), 1)
+		}
 		if err := pc.AddSignature(ch, acc.Contract, acc.PublicKey(), sign); err != nil {
 			return cli.NewExitError(fmt.Errorf("can't add signature: %w", err), 1)
 		}
@@ -5,11 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -17,8 +22,12 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/remotesigner"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 // Test signing of multisig transactions.
@@ -265,3 +274,60 @@ func TestSignMultisigTx(t *testing.T) {
 func deployVerifyContract(t *testing.T, e *testcli.Executor) util.Uint160 {
 	return testcli.DeployContract(t, e, "../smartcontract/testdata/verify.go", "../smartcontract/testdata/verify.yml", testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
 }
+
+func TestSignWithRemoteSigner(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	tmpDir := t.TempDir()
+
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	srv := httptest.NewServer(remotesigner.NewServer(priv))
+	t.Cleanup(srv.Close)
+
+	// The wallet contains encrypted key, but the password is not known.
+	k, err := keys.NewPrivateKeyFromBytes(priv.Bytes())
+	require.NoError(t, err)
+	walletPath := filepath.Join(tmpDir, "wallet.json")
+	w, err := wallet.NewWallet(walletPath)
+	require.NoError(t, err)
+	acc := wallet.NewAccountFromPrivateKey(k)
+	require.NoError(t, acc.Encrypt("secret", w.Scrypt))
+	w.AddAccount(acc)
+	require.NoError(t, w.Save())
+	w.Close()
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.Signers = []transaction.Signer{{Account: priv.GetScriptHash()}}
+	inPath := filepath.Join(tmpDir, "in.json")
+	outPath := filepath.Join(tmpDir, "out.json")
+	require.NoError(t, paramcontext.InitAndSave(netmode.UnitTestNet, tx, nil, inPath))
+
+	writeConfig := func(t *testing.T, remote string) string {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		data, err := yaml.Marshal(config.Wallet{
+			Path:         walletPath,
+			Password:     "wrong",
+			RemoteSigner: remote,
+		})
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(configPath, data, 0644))
+		return configPath
+	}
+
+	t.Run("no remote signer", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign", "--wallet-config", writeConfig(t, ""),
+			"--address", priv.Address(), "--in", inPath, "--out", outPath)
+	})
+	t.Run("bad remote signer", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign", "--wallet-config", writeConfig(t, "unix://"+filepath.Join(tmpDir, "nonexistent")),
+			"--address", priv.Address(), "--in", inPath, "--out", outPath)
+	})
+	e.Run(t, "neo-go", "wallet", "sign", "--wallet-config", writeConfig(t, srv.URL),
+		"--address", priv.Address(), "--in", inPath, "--out", outPath)
+
+	pc, err := paramcontext.Read(outPath)
+	require.NoError(t, err)
+	signed, err := pc.GetCompleteTransaction()
+	require.NoError(t, err)
+	require.True(t, priv.PublicKey().VerifyHashable(signed.Scripts[0].InvocationScript[2:], uint32(netmode.UnitTestNet), signed))
+}
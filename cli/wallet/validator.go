@@ -164,7 +164,8 @@ func getDecryptedAccount(wall *wallet.Wallet, addr util.Uint160, password *strin
 	}
 
 	// No private key available, nothing to decrypt, but it's still a useful account for many purposes.
-	if acc.EncryptedWIF == "" {
+	// Accounts with remote signer attached can be used as is too.
+	if acc.EncryptedWIF == "" || acc.CanSign() {
 		return acc, nil
 	}
 
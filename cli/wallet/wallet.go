@@ -25,6 +25,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/remotesigner"
 	"github.com/urfave/cli"
 	"gopkg.in/yaml.v3"
 )
@@ -119,8 +120,19 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "init",
 				Usage:     "create a new wallet",
-				UsageText: "neo-go wallet init -w wallet [--wallet-config path] [-a]",
-				Action:    createWallet,
+				UsageText: "neo-go wallet init -w wallet [--wallet-config path] [-a] [--mnemonic | --recover [--accounts n]]",
+				Description: `Creates a new wallet. With '--account' a new random account is also
+   created. Wallets can also be hierarchical deterministic (HD) ones, their
+   accounts are derived from a BIP-39 mnemonic along the m/44'/888'/0'/0/i
+   path. '--mnemonic' generates a new 24-word mnemonic (which is printed once,
+   write it down and keep in a safe place, it's the only way to restore
+   accounts) and creates the first account. '--recover' asks for an existing
+   mnemonic and restores the number of accounts specified with '--accounts'.
+   The seed is stored encrypted in the wallet with the password of the first
+   account, 'wallet create' derives next accounts for HD wallets and the same
+   password must be used for them.
+`,
+				Action: createWallet,
 				Flags: []cli.Flag{
 					walletPathFlag,
 					walletConfigFlag,
@@ -128,6 +140,19 @@ func NewCommands() []cli.Command {
 						Name:  "account, a",
 						Usage: "Create a new account",
 					},
+					cli.BoolFlag{
+						Name:  "mnemonic",
+						Usage: "Create an HD wallet from a newly generated mnemonic",
+					},
+					cli.BoolFlag{
+						Name:  "recover",
+						Usage: "Create an HD wallet from an existing mnemonic and recover its accounts",
+					},
+					cli.UintFlag{
+						Name:  "accounts",
+						Value: 1,
+						Usage: "Number of HD wallet accounts to derive",
+					},
 				},
 			},
 			{
@@ -820,6 +845,13 @@ func createWallet(ctx *cli.Context) error {
 	if len(path) == 0 && len(configPath) == 0 {
 		return cli.NewExitError(errNoPath, 1)
 	}
+	genMnemonic, recoverHD := ctx.Bool("mnemonic"), ctx.Bool("recover")
+	if genMnemonic && recoverHD {
+		return cli.NewExitError(errors.New("'--mnemonic' and '--recover' flags are mutually exclusive"), 1)
+	}
+	if ctx.IsSet("accounts") && !recoverHD && !genMnemonic {
+		return cli.NewExitError(errors.New("'--accounts' can only be used for HD wallets"), 1)
+	}
 	var pass *string
 	if len(configPath) != 0 {
 		cfg, err := ReadWalletConfig(configPath)
@@ -837,7 +869,21 @@ func createWallet(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	if ctx.Bool("account") {
+	var mnemonic string
+	if genMnemonic || recoverHD {
+		if genMnemonic {
+			mnemonic, err = wallet.NewMnemonic()
+		} else {
+			mnemonic, err = input.ReadPassword("Enter the mnemonic > ")
+		}
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		if err := createHDAccounts(wall, mnemonic, pass, uint32(ctx.Uint("accounts"))); err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer wall.Close()
+	} else if ctx.Bool("account") {
 		if err := createAccount(wall, pass); err != nil {
 			return cli.NewExitError(err, 1)
 		}
@@ -845,6 +891,9 @@ func createWallet(ctx *cli.Context) error {
 	}
 
 	fmtPrintWallet(ctx.App.Writer, wall)
+	if genMnemonic {
+		fmt.Fprintf(ctx.App.Writer, "mnemonic (write it down and keep in a safe place, it won't be shown again):\n%s\n\n", mnemonic)
+	}
 	fmt.Fprintf(ctx.App.Writer, "wallet successfully created, file location is %s\n", wall.Path())
 	return nil
 }
@@ -897,6 +946,34 @@ func createAccount(wall *wallet.Wallet, pass *string) error {
 	return wall.CreateAccount(name, phrase)
 }
 
+func createHDAccounts(wall *wallet.Wallet, mnemonic string, pass *string, n uint32) error {
+	var (
+		name, phrase string
+		err          error
+	)
+	if pass == nil {
+		name, phrase, err = readAccountInfo()
+		if err != nil {
+			return err
+		}
+	} else {
+		phrase = *pass
+	}
+	if n == 0 {
+		n = 1
+	}
+	if err := wall.InitHD(mnemonic, "", phrase); err != nil {
+		return err
+	}
+	if _, err := wall.DeriveAccount(name, phrase); err != nil {
+		return err
+	}
+	if err := wall.RecoverAccounts(n, phrase); err != nil {
+		return err
+	}
+	return wall.Save()
+}
+
 func openWallet(ctx *cli.Context, canUseWalletConfig bool) (*wallet.Wallet, *string, error) {
 	path, pass, err := getWalletPathAndPass(ctx, canUseWalletConfig)
 	if err != nil {
@@ -909,6 +986,9 @@ func openWallet(ctx *cli.Context, canUseWalletConfig bool) (*wallet.Wallet, *str
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := attachRemoteSigner(ctx, w); err != nil {
+		return nil, nil, err
+	}
 	return w, pass, nil
 }
 
@@ -928,9 +1008,39 @@ func readWallet(ctx *cli.Context) (*wallet.Wallet, *string, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := attachRemoteSigner(ctx, w); err != nil {
+		return nil, nil, err
+	}
 	return w, pass, nil
 }
 
+// attachRemoteSigner attaches remote signers to wallet accounts if wallet
+// configuration file is used and it specifies remote signer.
+func attachRemoteSigner(ctx *cli.Context, w *wallet.Wallet) error {
+	configPath := ctx.String("wallet-config")
+	if len(configPath) == 0 {
+		return nil
+	}
+	cfg, err := ReadWalletConfig(configPath)
+	if err != nil {
+		return err
+	}
+	return AttachRemoteSigner(w, cfg)
+}
+
+// AttachRemoteSigner attaches signers of the remote signer specified in the
+// wallet configuration (if any) to the wallet accounts, so that they don't need
+// to be decrypted.
+func AttachRemoteSigner(w *wallet.Wallet, cfg *config.Wallet) error {
+	if len(cfg.RemoteSigner) == 0 {
+		return nil
+	}
+	if _, err := remotesigner.Attach(w, cfg.RemoteSigner); err != nil {
+		return fmt.Errorf("can't use remote signer: %w", err)
+	}
+	return nil
+}
+
 // getWalletPathAndPass retrieves wallet path from context or from wallet configuration file.
 // If wallet configuration file is specified, then account password is returned.
 func getWalletPathAndPass(ctx *cli.Context, canUseWalletConfig bool) (string, *string, error) {
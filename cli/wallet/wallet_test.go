@@ -198,6 +198,70 @@ func TestWalletInit(t *testing.T) {
 		require.Equal(t, 1, len(w.Accounts))
 		require.Equal(t, "", w.Accounts[0].Label)
 	})
+	t.Run("HD", func(t *testing.T) {
+		tmp := t.TempDir()
+		walletPath := filepath.Join(tmp, "wallet.json")
+		t.Run("conflicting flags", func(t *testing.T) {
+			e.RunWithError(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--mnemonic", "--recover")
+		})
+		t.Run("accounts without HD", func(t *testing.T) {
+			e.RunWithError(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--accounts", "2")
+		})
+
+		e.In.WriteString("acc\r")
+		e.In.WriteString("pass\r")
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--mnemonic")
+		var mnemonic string
+		for {
+			line, err := e.Out.ReadString('\n')
+			require.NoError(t, err)
+			if strings.HasPrefix(line, "mnemonic") {
+				mnemonic = e.GetNextLine(t)
+				break
+			}
+		}
+		require.Equal(t, 24, len(strings.Fields(mnemonic)))
+		w, err := wallet.NewWalletFromFile(walletPath)
+		require.NoError(t, err)
+		require.True(t, w.IsHD())
+		require.Equal(t, 1, len(w.Accounts))
+		require.Equal(t, "acc", w.Accounts[0].Label)
+
+		// Next account is derived.
+		e.In.WriteString("acc2\r")
+		e.In.WriteString("pass\r")
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "create", "--wallet", walletPath)
+		w, err = wallet.NewWalletFromFile(walletPath)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(w.Accounts))
+
+		t.Run("recover", func(t *testing.T) {
+			recPath := filepath.Join(tmp, "recovered.json")
+			t.Run("bad mnemonic", func(t *testing.T) {
+				e.In.WriteString(strings.Repeat("abandon ", 12) + "\r")
+				e.In.WriteString("acc\r")
+				e.In.WriteString("newpass\r")
+				e.In.WriteString("newpass\r")
+				e.RunWithError(t, "neo-go", "wallet", "init", "--wallet", recPath, "--recover")
+			})
+			e.In.WriteString(mnemonic + "\r")
+			e.In.WriteString("acc\r")
+			e.In.WriteString("newpass\r")
+			e.In.WriteString("newpass\r")
+			e.Run(t, "neo-go", "wallet", "init", "--wallet", recPath, "--recover", "--accounts", "3")
+			rec, err := wallet.NewWalletFromFile(recPath)
+			require.NoError(t, err)
+			require.True(t, rec.IsHD())
+			require.Equal(t, 3, len(rec.Accounts))
+			require.Equal(t, "acc", rec.Accounts[0].Label)
+			for i := range w.Accounts {
+				require.Equal(t, w.Accounts[i].Address, rec.Accounts[i].Address)
+			}
+			require.NoError(t, rec.Accounts[2].Decrypt("newpass", rec.Scrypt))
+		})
+	})
 
 	tmpDir := t.TempDir()
 	walletPath := filepath.Join(tmpDir, "wallet.json")
@@ -19,6 +19,8 @@ var (
 	ErrMissingExportedParamName = errors.New("exported method is not allowed to have unnamed parameter")
 	// ErrInvalidExportedRetCount is returned when exported contract method has invalid return values count.
 	ErrInvalidExportedRetCount = errors.New("exported method is not allowed to have more than one return value")
+	// ErrGenericExported is returned when exported contract method has type parameters.
+	ErrGenericExported = errors.New("exported method is not allowed to be generic")
 )
 
 var (
@@ -353,7 +355,7 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 				// functions invoked in variable declarations in imported packages
 				// are marked as used.
 				var name string
-				switch t := n.Fun.(type) {
+				switch t := c.unwrapFuncInstance(n.Fun).(type) {
 				case *ast.Ident:
 					name = c.getIdentName(pkgPath, t.Name)
 				case *ast.SelectorExpr:
@@ -371,6 +373,10 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 				}
 				// exported functions are not allowed to have unnamed parameters  or multiple return values
 				if isMain && n.Name.IsExported() && n.Recv == nil {
+					if n.Type.TypeParams != nil {
+						c.prog.Err = fmt.Errorf("%w: %s", ErrGenericExported, n.Name)
+						return false // Program is invalid.
+					}
 					if n.Type.Params.List != nil {
 						for i, param := range n.Type.Params.List {
 							if param.Names == nil {
@@ -472,7 +478,7 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 			ast.Inspect(fd.decl, func(node ast.Node) bool {
 				switch n := node.(type) {
 				case *ast.CallExpr:
-					switch t := n.Fun.(type) {
+					switch t := c.unwrapFuncInstance(n.Fun).(type) {
 					case *ast.Ident:
 						nextDiff[c.getIdentName(fd.path, t.Name)] = true
 					case *ast.SelectorExpr:
@@ -120,6 +120,11 @@ type codegen struct {
 
 	// Tokens for CALLT instruction
 	callTokens []nef.MethodToken
+
+	// pendingInstances contains generic function instances to be converted.
+	pendingInstances []*funcScope
+	// typeCtx is used to deduplicate generic type instances.
+	typeCtx *types.Context
 }
 
 type labelOffsetType byte
@@ -472,6 +477,16 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 			f = c.newFunc(decl)
 		}
 	}
+	c.convertFuncScope(file, f, pkg, isLambda)
+	return f
+}
+
+// convertFuncScope emits code for the function f which label (if any) is
+// already set.
+func (c *codegen) convertFuncScope(file ast.Node, f *funcScope, pkg *types.Package, isLambda bool) {
+	decl := f.decl
+	isInit := isInitFunc(decl)
+	isDeploy := isDeployFunc(decl)
 
 	f.rng.Start = uint16(c.prog.Len())
 	c.scope = f
@@ -553,7 +568,6 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 			count: f.vars.localsCnt,
 		}
 	}
-	return f
 }
 
 func (c *codegen) Visit(node ast.Node) ast.Visitor {
@@ -598,14 +612,16 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 				multiRet := n.Tok == token.VAR && len(t.Values) != 0 && len(t.Names) != len(t.Values)
 				for _, id := range t.Names {
 					if id.Name != "_" {
+						var index int
 						if c.scope == nil {
 							// it is a global declaration
 							c.newGlobal("", id.Name)
+							index = c.globals[c.getIdentName("", id.Name)]
 						} else {
-							c.scope.newLocal(id.Name)
+							index = c.scope.newLocal(id.Name)
 						}
 						if !multiRet {
-							c.registerDebugVariable(id.Name, t.Type)
+							c.registerDebugVariable(id.Name, t.Type, index)
 						}
 					}
 				}
@@ -662,12 +678,10 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		for i := 0; i < len(n.Lhs); i++ {
 			switch t := n.Lhs[i].(type) {
 			case *ast.Ident:
-				if n.Tok == token.DEFINE {
+				if n.Tok == token.DEFINE && t.Name != "_" {
+					index := c.scope.newLocal(t.Name)
 					if !multiRet {
-						c.registerDebugVariable(t.Name, n.Rhs[i])
-					}
-					if t.Name != "_" {
-						c.scope.newLocal(t.Name)
+						c.registerDebugVariable(t.Name, n.Rhs[i], index)
 					}
 				}
 				if !isAssignOp && (i == 0 || !multiRet) {
@@ -957,9 +971,16 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			isLiteral bool
 		)
 
-		switch fun := n.Fun.(type) {
+		switch fun := c.unwrapFuncInstance(n.Fun).(type) {
 		case *ast.Ident:
 			f, ok = c.getFuncFromIdent(fun)
+			if ok && isGenericDecl(f.decl) {
+				f, ok = c.getFuncInstance(f, c.funcTypeArgs(f, fun))
+				if !ok {
+					c.prog.Err = fmt.Errorf("can't instantiate generic function %s", fun.Name)
+					return nil
+				}
+			}
 			isBuiltin = isGoBuiltin(fun.Name)
 			if !ok && !isBuiltin {
 				name = fun.Name
@@ -976,6 +997,13 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			name, isMethod := c.getFuncNameFromSelector(fun)
 
 			f, ok = c.funcs[name]
+			if ok && isGenericDecl(f.decl) {
+				f, ok = c.getFuncInstance(f, c.funcTypeArgs(f, fun))
+				if !ok {
+					c.prog.Err = fmt.Errorf("can't instantiate generic function %s", name)
+					return nil
+				}
+			}
 			if ok {
 				f.selector = fun.X
 				isBuiltin = isCustomBuiltin(f) || isPotentialCustomBuiltin(f, n)
@@ -2174,6 +2202,10 @@ func (c *codegen) getFuncFromIdent(fun *ast.Ident) (*funcScope, bool) {
 func (c *codegen) getFuncNameFromSelector(e *ast.SelectorExpr) (string, bool) {
 	if c.typeInfo.Selections[e] != nil {
 		typ := c.typeInfo.Types[e.X].Type.String()
+		// Methods of generic types are named after the generic type itself.
+		if i := strings.IndexByte(typ, '['); i >= 0 {
+			typ = typ[:i]
+		}
 		name := c.getIdentName(typ, e.Sel.Name)
 		if name[0] == '*' {
 			name = name[1:]
@@ -2192,6 +2224,9 @@ func (c *codegen) newLambda(u uint16, lit *ast.FuncLit) {
 		Type: lit.Type,
 		Body: lit.Body,
 	}, u)
+	if c.scope != nil {
+		f.typeArgs = c.scope.typeArgs
+	}
 	c.lambda[c.getFuncNameFromDecl("", f.decl)] = f
 }
 
@@ -2240,13 +2275,14 @@ func (c *codegen) compile(info *buildInfo, pkg *packages.Package) error {
 					pkgPath = pkg.Path()
 				}
 				name := c.getFuncNameFromDecl(pkgPath, n)
-				if !isInitFunc(n) && !isDeployFunc(n) && funUsage.funcUsed(name) &&
+				if !isInitFunc(n) && !isDeployFunc(n) && !isGenericDecl(n) && funUsage.funcUsed(name) &&
 					(!isInteropPath(pkg.Path()) && !canInline(pkg.Path(), n.Name.Name, false)) {
 					c.convertFuncDecl(f, n, pkg)
 				}
 			}
 		}
 	})
+	c.convertFuncInstances()
 
 	return c.prog.Err
 }
@@ -2272,6 +2308,7 @@ func newCodegen(info *buildInfo, pkg *packages.Package) *codegen {
 		emittedEvents:    make(map[string][][]string),
 		invokedContracts: make(map[util.Uint160][]string),
 		sequencePoints:   make(map[string][]DebugSeqPoint),
+		typeCtx:          types.NewContext(),
 	}
 }
 
@@ -2382,6 +2419,13 @@ func (c *codegen) writeJumps(b []byte) ([]byte, error) {
 	for _, f := range c.funcs {
 		f.rng.Start, f.rng.End = correctRange(f.rng.Start, f.rng.End, nopOffsets)
 	}
+	// Correct sequence points, every one of them is shifted by the number
+	// of NOPs removed before it.
+	for _, sps := range c.sequencePoints {
+		for i := range sps {
+			sps[i].Opcode -= sort.SearchInts(nopOffsets, sps[i].Opcode)
+		}
+	}
 	return removeNOPs(b, nopOffsets), nil
 }
 
@@ -34,7 +34,8 @@ type DebugInfo struct {
 	EmittedEvents map[string][][]string `json:"-"`
 	// InvokedContracts contains foreign contract invocations.
 	InvokedContracts map[util.Uint160][]string `json:"-"`
-	// StaticVariables contains a list of static variable names and types.
+	// StaticVariables contains a list of static variables in the
+	// "{name},{type},{slot index}" format.
 	StaticVariables []string `json:"static-variables"`
 }
 
@@ -62,7 +63,9 @@ type MethodDebugInfo struct {
 	ReturnTypeExtended *binding.ExtendedType `json:"-"`
 	// ReturnTypeSC is a return type to use in manifest.
 	ReturnTypeSC smartcontract.ParamType `json:"-"`
-	Variables    []string                `json:"variables"`
+	// Variables is a list of the method's local variables in the
+	// "{name},{type},{slot index}" format.
+	Variables []string `json:"variables"`
 	// SeqPoints is a map between source lines and byte-code instruction offsets.
 	SeqPoints []DebugSeqPoint `json:"sequence-points"`
 }
@@ -97,6 +100,14 @@ type DebugSeqPoint struct {
 	EndCol int
 }
 
+// DebugVariable is a named slot item described by the debug information.
+type DebugVariable struct {
+	Name string
+	Type string
+	// Index is the index of the variable in the corresponding slot.
+	Index int
+}
+
 // DebugRange represents the method's section in bytecode.
 type DebugRange struct {
 	Start uint16
@@ -125,9 +136,9 @@ func (c *codegen) saveSequencePoint(n ast.Node) {
 		Opcode:    c.prog.Len(),
 		Document:  c.docIndex[start.Filename],
 		StartLine: start.Line,
-		StartCol:  start.Offset,
+		StartCol:  start.Column,
 		EndLine:   end.Line,
-		EndCol:    end.Offset,
+		EndCol:    end.Column,
 	})
 }
 
@@ -193,6 +204,11 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 	start := len(d.Methods)
 	d.NamedTypes = make(map[string]binding.ExtendedType)
 	for name, scope := range c.funcs {
+		if scope.typeArgs == nil && isGenericDecl(scope.decl) {
+			continue // Only instances of generic functions have any code.
+		}
+		// Types of generic function instance parameters depend on the scope.
+		c.scope = scope
 		m := c.methodInfoFromScope(name, scope, d.NamedTypes)
 		if m.Range.Start == m.Range.End {
 			continue
@@ -207,13 +223,16 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 	return d
 }
 
-func (c *codegen) registerDebugVariable(name string, expr ast.Expr) {
+// registerDebugVariable adds a variable with the given name, type (taken from
+// expr) and slot index to the debug info of the current scope.
+func (c *codegen) registerDebugVariable(name string, expr ast.Expr, index int) {
 	_, vt, _, _ := c.scAndVMTypeFromExpr(expr, nil)
+	v := name + "," + vt.String() + "," + strconv.Itoa(index)
 	if c.scope == nil {
-		c.staticVariables = append(c.staticVariables, name+","+vt.String())
+		c.staticVariables = append(c.staticVariables, v)
 		return
 	}
-	c.scope.variables = append(c.scope.variables, name+","+vt.String())
+	c.scope.variables = append(c.scope.variables, v)
 }
 
 func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[string]binding.ExtendedType) *MethodDebugInfo {
@@ -231,8 +250,12 @@ func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[st
 			})
 		}
 	}
-	ss := strings.Split(name, ".")
-	name = ss[len(ss)-1]
+	if scope.typeArgs != nil {
+		name = scope.name // Full name contains type arguments with package paths.
+	} else {
+		ss := strings.Split(name, ".")
+		name = ss[len(ss)-1]
+	}
 	r, n := utf8.DecodeRuneInString(name)
 	st, vt, rt, et := c.scAndVMReturnTypeFromScope(scope, exts)
 
@@ -499,6 +522,42 @@ func (d *DebugParam) ToManifestParameter() manifest.Parameter {
 	}
 }
 
+// ArgumentVariables returns the method's parameters as arguments slot
+// variables (taking the receiver into account for methods).
+func (m *MethodDebugInfo) ArgumentVariables() []DebugVariable {
+	var (
+		res    = make([]DebugVariable, 0, len(m.Parameters))
+		offset int
+	)
+	if !m.IsFunction {
+		offset = 1 // Receiver is the first argument.
+	}
+	for i, p := range m.Parameters {
+		res = append(res, DebugVariable{Name: p.Name, Type: p.Type, Index: i + offset})
+	}
+	return res
+}
+
+// ParseDebugVariables parses variables in the "{name},{type},{slot index}"
+// format used by DebugInfo.StaticVariables and MethodDebugInfo.Variables
+// skipping invalid ones. Variables are sorted by slot index.
+func ParseDebugVariables(vars []string) []DebugVariable {
+	var res = make([]DebugVariable, 0, len(vars))
+	for _, v := range vars {
+		parts := strings.Split(v, ",")
+		if len(parts) != 3 {
+			continue
+		}
+		index, err := strconv.Atoi(parts[2])
+		if err != nil {
+			continue
+		}
+		res = append(res, DebugVariable{Name: parts[0], Type: parts[1], Index: index})
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Index < res[j].Index })
+	return res
+}
+
 // ToManifestMethod converts MethodDebugInfo to manifest.Method.
 func (m *MethodDebugInfo) ToManifestMethod() manifest.Method {
 	var (
@@ -94,9 +94,9 @@ func _deploy(data interface{}, isUpdate bool) { x := 1; _ = x }
 
 	t.Run("variables", func(t *testing.T) {
 		vars := map[string][]string{
-			"Main":                {"s,ByteString", "res,Integer"},
-			manifest.MethodInit:   {"a,Integer", "x,ByteString"},
-			manifest.MethodDeploy: {"x,Integer"},
+			"Main":                {"s,ByteString,0", "res,Integer,1"},
+			manifest.MethodInit:   {"a,Integer,0", "x,ByteString,0"},
+			manifest.MethodDeploy: {"x,Integer,0"},
 		}
 		for i := range d.Methods {
 			v, ok := vars[d.Methods[i].ID]
@@ -107,7 +107,7 @@ func _deploy(data interface{}, isUpdate bool) { x := 1; _ = x }
 	})
 
 	t.Run("static variables", func(t *testing.T) {
-		require.Equal(t, []string{"staticVar,Integer"}, d.StaticVariables)
+		require.Equal(t, []string{"staticVar,Integer,0"}, d.StaticVariables)
 	})
 
 	t.Run("param types", func(t *testing.T) {
@@ -326,7 +326,34 @@ func TestSequencePoints(t *testing.T) {
 	ps := d.Methods[0].SeqPoints
 	require.Equal(t, 2, len(ps))
 	require.Equal(t, 4, ps[0].StartLine)
+	require.Equal(t, 4, ps[0].StartCol)
+	require.Equal(t, 4, ps[0].EndLine)
+	require.Equal(t, 15, ps[0].EndCol)
 	require.Equal(t, 6, ps[1].StartLine)
+	require.Equal(t, 3, ps[1].StartCol)
+	require.Equal(t, 15, ps[1].EndCol)
+}
+
+func TestDebugVariables(t *testing.T) {
+	require.Equal(t, []DebugVariable{
+		{Name: "a", Type: "Integer", Index: 0},
+		{Name: "c", Type: "ByteString", Index: 1},
+		{Name: "b", Type: "Boolean", Index: 2},
+	}, ParseDebugVariables([]string{"b,Boolean,2", "bad", "a,Integer,0", "x,Any,notanumber", "c,ByteString,1"}))
+
+	m := &MethodDebugInfo{
+		IsFunction: true,
+		Parameters: []DebugParam{{Name: "x", Type: "Integer"}, {Name: "y", Type: "Array"}},
+	}
+	require.Equal(t, []DebugVariable{
+		{Name: "x", Type: "Integer", Index: 0},
+		{Name: "y", Type: "Array", Index: 1},
+	}, m.ArgumentVariables())
+	m.IsFunction = false
+	require.Equal(t, []DebugVariable{
+		{Name: "x", Type: "Integer", Index: 1},
+		{Name: "y", Type: "Array", Index: 2},
+	}, m.ArgumentVariables())
 }
 
 func TestDebugInfo_MarshalJSON(t *testing.T) {
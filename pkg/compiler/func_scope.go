@@ -47,6 +47,10 @@ type funcScope struct {
 
 	// Local variable counter.
 	i int
+
+	// typeArgs maps type parameters to the actual types for generic
+	// function instances.
+	typeArgs map[*types.TypeParam]types.Type
 }
 
 type deferInfo struct {
@@ -81,12 +85,7 @@ func (c *codegen) newFuncScope(decl *ast.FuncDecl, label uint16) *funcScope {
 func (c *codegen) getFuncNameFromDecl(pkgPath string, decl *ast.FuncDecl) string {
 	name := decl.Name.Name
 	if decl.Recv != nil {
-		switch t := decl.Recv.List[0].Type.(type) {
-		case *ast.Ident:
-			name = t.Name + "." + name
-		case *ast.StarExpr:
-			name = t.X.(*ast.Ident).Name + "." + name
-		}
+		name = recvTypeName(decl.Recv.List[0].Type) + "." + name
 	}
 	return c.getIdentName(pkgPath, name)
 }
@@ -399,3 +399,110 @@ func TestUnnamedMethodReceiver(t *testing.T) {
 	}`
 	eval(t, src, big.NewInt(2))
 }
+
+func TestGenericFunction(t *testing.T) {
+	t.Run("simple", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			return min(3, 2)
+		}
+		func min[T int | int64](a, b T) T {
+			if a < b {
+				return a
+			}
+			return b
+		}`
+		eval(t, src, big.NewInt(2))
+	})
+	t.Run("explicit type arguments", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			return max[int](3, 7)
+		}
+		func max[T ~int](a, b T) T {
+			if a > b {
+				return a
+			}
+			return b
+		}`
+		eval(t, src, big.NewInt(7))
+	})
+	t.Run("multiple instances", func(t *testing.T) {
+		src := `package foo
+		func Main() string {
+			if sum([]int{1, 2, 3}) != 6 {
+				panic("bad int sum")
+			}
+			return sum([]string{"a", "b", "c"})
+		}
+		func sum[T int | string](items []T) T {
+			var res T
+			for _, v := range items {
+				res += v
+			}
+			return res
+		}`
+		eval(t, src, []byte("abc"))
+	})
+	t.Run("zero value", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			if zero[string]() != "" || zero[bool]() {
+				panic("bad zero value")
+			}
+			return zero[int]()
+		}
+		func zero[T any]() T {
+			var x T
+			return x
+		}`
+		eval(t, src, big.NewInt(0))
+	})
+	t.Run("nested instantiation", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			return first([]int{4, 5, 6})
+		}
+		func first[T any](items []T) T {
+			return at(items, 0)
+		}
+		func at[T any](items []T, i int) T {
+			return items[i]
+		}`
+		eval(t, src, big.NewInt(4))
+	})
+	t.Run("imported", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/generic"
+		func Main() int {
+			return generic.Min(10, 5) + generic.Min[int](1, 2)
+		}`
+		eval(t, src, big.NewInt(6))
+	})
+	t.Run("exported", func(t *testing.T) {
+		src := `package foo
+		func Main[T any](a T) T {
+			return a
+		}`
+		_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+		require.ErrorIs(t, err, compiler.ErrGenericExported)
+	})
+}
+
+func TestGenericDebugInfo(t *testing.T) {
+	src := `package foo
+	func Main() int {
+		return id(1) + len(id("abc"))
+	}
+	func id[T any](a T) T {
+		return a
+	}`
+	_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	var names []string
+	for _, m := range di.Methods {
+		names = append(names, m.ID)
+	}
+	require.ElementsMatch(t, []string{"Main", "id[int]", "id[string]"}, names)
+}
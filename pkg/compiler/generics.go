@@ -0,0 +1,289 @@
+package compiler
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+)
+
+// isGenericDecl returns true if decl is either a generic function or a method
+// of a generic type. Such declarations are never converted directly, code is
+// generated for their instances only.
+func isGenericDecl(decl *ast.FuncDecl) bool {
+	if decl.Type.TypeParams != nil {
+		return true
+	}
+	if decl.Recv == nil {
+		return false
+	}
+	typ := decl.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	switch typ.(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		return true
+	}
+	return false
+}
+
+// recvTypeName returns the name of the receiver type stripping pointers and
+// type parameters, e.g. `*Pair[K, V]` -> `Pair`.
+func recvTypeName(typ ast.Expr) string {
+	switch t := typ.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return recvTypeName(t.X)
+	case *ast.IndexExpr:
+		return recvTypeName(t.X)
+	case *ast.IndexListExpr:
+		return recvTypeName(t.X)
+	}
+	return ""
+}
+
+// funcIdent returns an identifier denoting function in the call expression
+// (possibly with explicit type arguments). It returns nil for anything else.
+func funcIdent(e ast.Expr) *ast.Ident {
+	switch t := e.(type) {
+	case *ast.Ident:
+		return t
+	case *ast.SelectorExpr:
+		return t.Sel
+	case *ast.IndexExpr:
+		return funcIdent(t.X)
+	case *ast.IndexListExpr:
+		return funcIdent(t.X)
+	}
+	return nil
+}
+
+// instanceOf returns generic function or type instance information for the
+// identifier if it denotes one.
+func (c *codegen) instanceOf(id *ast.Ident) (types.Instance, bool) {
+	for i := len(c.pkgInfoInline) - 1; i >= 0; i-- {
+		if inst, ok := c.pkgInfoInline[i].TypesInfo.Instances[id]; ok {
+			return inst, true
+		}
+	}
+	inst, ok := c.typeInfo.Instances[id]
+	return inst, ok
+}
+
+// unwrapFuncInstance strips explicit type arguments from the generic function
+// instantiation expression like `Min[int]` or `util.Min[int]`. Any other
+// expression is returned as is.
+func (c *codegen) unwrapFuncInstance(e ast.Expr) ast.Expr {
+	var x ast.Expr
+	switch t := e.(type) {
+	case *ast.IndexExpr:
+		x = t.X
+	case *ast.IndexListExpr:
+		x = t.X
+	default:
+		return e
+	}
+	id := funcIdent(x)
+	if id == nil {
+		return e
+	}
+	inst, ok := c.instanceOf(id)
+	if !ok {
+		return e
+	}
+	if _, ok := inst.Type.(*types.Signature); !ok {
+		return e // Generic type conversion.
+	}
+	return x
+}
+
+// funcTypeArgs returns (substituted) type arguments used to call generic
+// function or method f via fun expression.
+func (c *codegen) funcTypeArgs(f *funcScope, fun ast.Expr) []types.Type {
+	if f.decl.Recv != nil {
+		sel, ok := fun.(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+		typ := c.typeOf(sel.X)
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		named, ok := typ.(*types.Named)
+		if !ok {
+			return nil
+		}
+		return typeListToSlice(named.TypeArgs())
+	}
+	id := funcIdent(fun)
+	if id == nil {
+		return nil
+	}
+	inst, ok := c.instanceOf(id)
+	if !ok {
+		return nil
+	}
+	targs := typeListToSlice(inst.TypeArgs)
+	for i := range targs {
+		targs[i] = c.substType(targs[i])
+	}
+	return targs
+}
+
+func typeListToSlice(l *types.TypeList) []types.Type {
+	if l == nil {
+		return nil
+	}
+	res := make([]types.Type, l.Len())
+	for i := range res {
+		res[i] = l.At(i)
+	}
+	return res
+}
+
+// typeArgsString returns type arguments list representation suitable for
+// function names.
+func typeArgsString(targs []types.Type, q types.Qualifier) string {
+	ss := make([]string, len(targs))
+	for i := range targs {
+		ss[i] = types.TypeString(targs[i], q)
+	}
+	return "[" + strings.Join(ss, ",") + "]"
+}
+
+// getFuncInstance returns function scope for the generic function f
+// instantiated with targs. New instances are put into the queue to be
+// converted after all regular functions.
+func (c *codegen) getFuncInstance(f *funcScope, targs []types.Type) (*funcScope, bool) {
+	if len(targs) == 0 {
+		return nil, false
+	}
+	name := f.decl.Name.Name
+	if f.decl.Recv != nil {
+		name = recvTypeName(f.decl.Recv.List[0].Type) + "." + name
+	}
+	name = f.pkg.Path() + "." + name + typeArgsString(targs, nil)
+	if inst, ok := c.funcs[name]; ok {
+		return inst, true
+	}
+
+	obj, ok := c.packageCache[f.pkg.Path()].TypesInfo.Defs[f.decl.Name].(*types.Func)
+	if !ok {
+		return nil, false
+	}
+	sig := obj.Type().(*types.Signature)
+	tparams := sig.TypeParams()
+	if f.decl.Recv != nil {
+		tparams = sig.RecvTypeParams()
+	}
+	if tparams.Len() != len(targs) {
+		return nil, false
+	}
+	subst := make(map[*types.TypeParam]types.Type, len(targs))
+	for i := range targs {
+		subst[tparams.At(i)] = targs[i]
+	}
+
+	inst := &funcScope{
+		name: f.name + typeArgsString(targs, func(p *types.Package) string {
+			return p.Name()
+		}),
+		decl:      f.decl,
+		pkg:       f.pkg,
+		file:      f.file,
+		label:     c.newLabel(),
+		vars:      newVarScope(),
+		voidCalls: map[*ast.CallExpr]bool{},
+		variables: []string{},
+		i:         -1,
+		typeArgs:  subst,
+	}
+	c.funcs[name] = inst
+	c.pendingInstances = append(c.pendingInstances, inst)
+	return inst, true
+}
+
+// convertFuncInstances generates code for all generic function instances
+// used by the program. Instances can refer to other instances, so this is
+// repeated until there is nothing left.
+func (c *codegen) convertFuncInstances() {
+	for len(c.pendingInstances) != 0 && c.prog.Err == nil {
+		f := c.pendingInstances[0]
+		c.pendingInstances = c.pendingInstances[1:]
+
+		pkg := c.packageCache[f.pkg.Path()]
+		c.typeInfo = pkg.TypesInfo
+		c.currPkg = pkg
+		c.fillImportMap(f.file, pkg)
+		c.setLabel(f.label)
+		c.convertFuncScope(f.file, f, f.pkg, false)
+	}
+}
+
+// substType replaces type parameters in typ with the type arguments of the
+// function instance being converted.
+func (c *codegen) substType(typ types.Type) types.Type {
+	if typ == nil || c.scope == nil || len(c.scope.typeArgs) == 0 {
+		return typ
+	}
+	return c.subst(typ, c.scope.typeArgs)
+}
+
+func (c *codegen) subst(typ types.Type, m map[*types.TypeParam]types.Type) types.Type {
+	switch t := typ.(type) {
+	case *types.TypeParam:
+		if r, ok := m[t]; ok {
+			return r
+		}
+		return t
+	case *types.Pointer:
+		return types.NewPointer(c.subst(t.Elem(), m))
+	case *types.Slice:
+		return types.NewSlice(c.subst(t.Elem(), m))
+	case *types.Array:
+		return types.NewArray(c.subst(t.Elem(), m), t.Len())
+	case *types.Map:
+		return types.NewMap(c.subst(t.Key(), m), c.subst(t.Elem(), m))
+	case *types.Chan:
+		return types.NewChan(t.Dir(), c.subst(t.Elem(), m))
+	case *types.Tuple:
+		if t == nil {
+			return t
+		}
+		vars := make([]*types.Var, t.Len())
+		for i := range vars {
+			v := t.At(i)
+			vars[i] = types.NewVar(v.Pos(), v.Pkg(), v.Name(), c.subst(v.Type(), m))
+		}
+		return types.NewTuple(vars...)
+	case *types.Signature:
+		params, _ := c.subst(t.Params(), m).(*types.Tuple)
+		results, _ := c.subst(t.Results(), m).(*types.Tuple)
+		return types.NewSignatureType(t.Recv(), nil, nil, params, results, t.Variadic())
+	case *types.Struct:
+		fields := make([]*types.Var, t.NumFields())
+		tags := make([]string, t.NumFields())
+		for i := range fields {
+			f := t.Field(i)
+			fields[i] = types.NewField(f.Pos(), f.Pkg(), f.Name(), c.subst(f.Type(), m), f.Embedded())
+			tags[i] = t.Tag(i)
+		}
+		return types.NewStruct(fields, tags)
+	case *types.Named:
+		targs := typeListToSlice(t.TypeArgs())
+		if len(targs) == 0 {
+			return t
+		}
+		for i := range targs {
+			targs[i] = c.subst(targs[i], m)
+		}
+		inst, err := types.Instantiate(c.typeCtx, t.Origin(), targs, false)
+		if err != nil {
+			return t
+		}
+		return inst
+	default:
+		return t
+	}
+}
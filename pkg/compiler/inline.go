@@ -23,6 +23,10 @@ import (
 //	   <inline body of f directly>
 //	}
 func (c *codegen) inlineCall(f *funcScope, n *ast.CallExpr) {
+	// Inlined body has its own sequence points, but the call site
+	// should be visible to debuggers too.
+	c.saveSequencePoint(n)
+
 	offSz := len(c.inlineContext)
 	c.inlineContext = append(c.inlineContext, inlineContextSingle{
 		labelOffset: len(c.labelList),
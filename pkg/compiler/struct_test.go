@@ -450,3 +450,57 @@ func TestStructs(t *testing.T) {
 		})
 	}
 }
+
+func TestGenericType(t *testing.T) {
+	t.Run("struct", func(t *testing.T) {
+		src := `package foo
+		type pair[K, V any] struct {
+			key   K
+			value V
+		}
+		func newPair[K, V any](k K, v V) pair[K, V] {
+			return pair[K, V]{key: k, value: v}
+		}
+		func (p pair[K, V]) swap() pair[V, K] {
+			return pair[V, K]{key: p.value, value: p.key}
+		}
+		func Main() int {
+			p := newPair("answer", 42).swap()
+			if p.value != "answer" {
+				panic("bad value")
+			}
+			return p.key
+		}`
+		eval(t, src, big.NewInt(42))
+	})
+	t.Run("default field values", func(t *testing.T) {
+		src := `package foo
+		type box[T any] struct {
+			value T
+		}
+		func Main() int {
+			b := box[string]{}
+			if b.value != "" {
+				panic("bad value")
+			}
+			return box[int]{}.value
+		}`
+		eval(t, src, big.NewInt(0))
+	})
+	t.Run("imported", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/generic"
+		func Main() int {
+			s := &generic.Stack[int]{}
+			s.Push(1)
+			s.Push(2)
+			ss := &generic.Stack[string]{}
+			ss.Push("neo")
+			if ss.Peek() != "neo" {
+				panic("bad string")
+			}
+			return s.Peek() + s.Len() + ss.Len()
+		}`
+		eval(t, src, big.NewInt(5))
+	})
+}
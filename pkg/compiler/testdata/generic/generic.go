@@ -0,0 +1,34 @@
+package generic
+
+// Number is a set of integer types.
+type Number interface {
+	~int | ~int64 | ~uint64
+}
+
+// Min returns the smallest of two numbers.
+func Min[T Number](a, b T) T {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// Stack is a simple generic stack.
+type Stack[T any] struct {
+	items []T
+}
+
+// Push adds x to the top of the stack.
+func (s *Stack[T]) Push(x T) {
+	s.items = append(s.items, x)
+}
+
+// Peek returns the top of the stack.
+func (s *Stack[T]) Peek() T {
+	return s.items[len(s.items)-1]
+}
+
+// Len returns the number of items in the stack.
+func (s *Stack[T]) Len() int {
+	return len(s.items)
+}
@@ -8,6 +8,12 @@ import (
 )
 
 func (c *codegen) typeAndValueOf(e ast.Expr) types.TypeAndValue {
+	tv := c.rawTypeAndValueOf(e)
+	tv.Type = c.substType(tv.Type)
+	return tv
+}
+
+func (c *codegen) rawTypeAndValueOf(e ast.Expr) types.TypeAndValue {
 	for i := len(c.pkgInfoInline) - 1; i >= 0; i-- {
 		if tv, ok := c.pkgInfoInline[i].TypesInfo.Types[e]; ok {
 			return tv
@@ -28,6 +34,10 @@ func (c *codegen) typeAndValueOf(e ast.Expr) types.TypeAndValue {
 }
 
 func (c *codegen) typeOf(e ast.Expr) types.Type {
+	return c.substType(c.rawTypeOf(e))
+}
+
+func (c *codegen) rawTypeOf(e ast.Expr) types.Type {
 	for i := len(c.pkgInfoInline) - 1; i >= 0; i-- {
 		if typ := c.pkgInfoInline[i].TypesInfo.TypeOf(e); typ != nil {
 			return typ
@@ -29,6 +29,8 @@ type ApplicationConfiguration struct {
 	DialTimeout int64  `yaml:"DialTimeout"`
 	LogLevel    string `yaml:"LogLevel"`
 	LogPath     string `yaml:"LogPath"`
+	// MemPoolPersistence allows to keep pooled transactions across node restarts.
+	MemPoolPersistence MemPoolPersistence `yaml:"MemPoolPersistence"`
 	// Deprecated: this option is moved to the P2P section.
 	MaxPeers int `yaml:"MaxPeers"`
 	// Deprecated: this option is moved to the P2P section.
@@ -87,6 +89,7 @@ func (a *ApplicationConfiguration) EqualsButServices(o *ApplicationConfiguration
 		a.ExtensiblePoolSize != o.ExtensiblePoolSize || //nolint:staticcheck // SA1019: a.ExtensiblePoolSize is deprecated
 		a.P2P.ExtensiblePoolSize != o.P2P.ExtensiblePoolSize ||
 		a.LogPath != o.LogPath ||
+		a.MemPoolPersistence != o.MemPoolPersistence ||
 		a.MaxPeers != o.MaxPeers || //nolint:staticcheck // SA1019: a.MaxPeers is deprecated
 		a.P2P.MaxPeers != o.P2P.MaxPeers ||
 		a.MinPeers != o.MinPeers || //nolint:staticcheck // SA1019: a.MinPeers is deprecated
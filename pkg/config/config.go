@@ -19,6 +19,13 @@ const (
 	// DefaultMaxIteratorResultItems is the default upper bound of traversed
 	// iterator items per JSON-RPC response.
 	DefaultMaxIteratorResultItems = 100
+	// DefaultMaxFindStoragePageSize is the default number of storage items
+	// returned in a single findstorage* JSON-RPC response.
+	DefaultMaxFindStoragePageSize = 50
+	// DefaultMaxMempoolTransactionsPageSize is the default number of
+	// transactions returned in a single getmempooltransactions JSON-RPC
+	// response.
+	DefaultMaxMempoolTransactionsPageSize = 100
 )
 
 // Version is the version of the node, set at the build time.
@@ -71,9 +78,11 @@ func LoadFile(configPath string) (Config, error) {
 				PingTimeout:  90 * time.Second,
 			},
 			RPC: RPC{
-				MaxIteratorResultItems: DefaultMaxIteratorResultItems,
-				MaxFindResultItems:     100,
-				MaxNEP11Tokens:         100,
+				MaxIteratorResultItems:         DefaultMaxIteratorResultItems,
+				MaxFindResultItems:             100,
+				MaxFindStoragePageSize:         DefaultMaxFindStoragePageSize,
+				MaxMempoolTransactionsPageSize: DefaultMaxMempoolTransactionsPageSize,
+				MaxNEP11Tokens:                 100,
 			},
 		},
 	}
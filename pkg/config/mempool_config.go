@@ -0,0 +1,15 @@
+package config
+
+import "time"
+
+// MemPoolPersistence contains memory pool persistence settings.
+type MemPoolPersistence struct {
+	// Enabled turns on saving pooled transactions and P2P notary requests
+	// to the file on node shutdown and restoring them on startup.
+	Enabled bool `yaml:"Enabled"`
+	// File is the file memory pool contents are stored in.
+	File string `yaml:"File"`
+	// SaveInterval is the interval of periodic memory pool dumps, they're
+	// only made on shutdown if it's zero.
+	SaveInterval time.Duration `yaml:"SaveInterval"`
+}
@@ -4,17 +4,18 @@ import "time"
 
 // OracleConfiguration is a config for the oracle module.
 type OracleConfiguration struct {
-	Enabled               bool               `yaml:"Enabled"`
-	AllowPrivateHost      bool               `yaml:"AllowPrivateHost"`
-	AllowedContentTypes   []string           `yaml:"AllowedContentTypes"`
-	Nodes                 []string           `yaml:"Nodes"`
-	NeoFS                 NeoFSConfiguration `yaml:"NeoFS"`
-	MaxTaskTimeout        time.Duration      `yaml:"MaxTaskTimeout"`
-	RefreshInterval       time.Duration      `yaml:"RefreshInterval"`
-	MaxConcurrentRequests int                `yaml:"MaxConcurrentRequests"`
-	RequestTimeout        time.Duration      `yaml:"RequestTimeout"`
-	ResponseTimeout       time.Duration      `yaml:"ResponseTimeout"`
-	UnlockWallet          Wallet             `yaml:"UnlockWallet"`
+	Enabled               bool                                 `yaml:"Enabled"`
+	AllowPrivateHost      bool                                 `yaml:"AllowPrivateHost"`
+	AllowedContentTypes   []string                             `yaml:"AllowedContentTypes"`
+	Nodes                 []string                             `yaml:"Nodes"`
+	NeoFS                 NeoFSConfiguration                   `yaml:"NeoFS"`
+	Schemes               map[string]OracleSchemeConfiguration `yaml:"Schemes"`
+	MaxTaskTimeout        time.Duration                        `yaml:"MaxTaskTimeout"`
+	RefreshInterval       time.Duration                        `yaml:"RefreshInterval"`
+	MaxConcurrentRequests int                                  `yaml:"MaxConcurrentRequests"`
+	RequestTimeout        time.Duration                        `yaml:"RequestTimeout"`
+	ResponseTimeout       time.Duration                        `yaml:"ResponseTimeout"`
+	UnlockWallet          Wallet                               `yaml:"UnlockWallet"`
 }
 
 // NeoFSConfiguration is a config for the NeoFS service.
@@ -22,3 +23,12 @@ type NeoFSConfiguration struct {
 	Nodes   []string      `yaml:"Nodes"`
 	Timeout time.Duration `yaml:"Timeout"`
 }
+
+// OracleSchemeConfiguration is a config for an additional (not https or
+// neofs) oracle request URL scheme. The "data" scheme needs no parameters,
+// any other scheme is treated as a content-addressed one served by the
+// given set of HTTP gateways.
+type OracleSchemeConfiguration struct {
+	Gateways []string      `yaml:"Gateways"`
+	Timeout  time.Duration `yaml:"Timeout"`
+}
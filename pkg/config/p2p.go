@@ -7,6 +7,15 @@ type P2P struct {
 	// Addresses stores the node address list in the form of "[host]:[port][:announcedPort]".
 	Addresses        []string `yaml:"Addresses"`
 	AttemptConnPeers int      `yaml:"AttemptConnPeers"`
+	// BanDuration is the time misbehaving peers are banned for (negative
+	// value disables automatic bans).
+	BanDuration time.Duration `yaml:"BanDuration"`
+	// BanListFile is the file banned peers are stored in to survive node
+	// restarts, bans are only kept in memory if it's empty.
+	BanListFile string `yaml:"BanListFile"`
+	// BanScore is the misbehavior score that gets a peer banned (negative
+	// value disables automatic bans).
+	BanScore int `yaml:"BanScore"`
 	// BroadcastFactor is the factor (0-100) controlling gossip fan-out number optimization.
 	BroadcastFactor    int           `yaml:"BroadcastFactor"`
 	DialTimeout        time.Duration `yaml:"DialTimeout"`
@@ -16,4 +25,6 @@ type P2P struct {
 	PingInterval       time.Duration `yaml:"PingInterval"`
 	PingTimeout        time.Duration `yaml:"PingTimeout"`
 	ProtoTickInterval  time.Duration `yaml:"ProtoTickInterval"`
+	// ZstdCompression enables zstd payload compression negotiation.
+	ZstdCompression bool `yaml:"ZstdCompression"`
 }
@@ -7,21 +7,50 @@ import (
 type (
 	// RPC is an RPC service configuration information.
 	RPC struct {
-		BasicService         `yaml:",inline"`
-		EnableCORSWorkaround bool `yaml:"EnableCORSWorkaround"`
+		BasicService `yaml:",inline"`
+		// Admin configures administrative RPC methods.
+		Admin                RPCAdmin `yaml:"Admin"`
+		EnableCORSWorkaround bool     `yaml:"EnableCORSWorkaround"`
+		// GraphQL configures GraphQL endpoint.
+		GraphQL RPCGraphQL `yaml:"GraphQL"`
 		// MaxGasInvoke is the maximum amount of GAS which
 		// can be spent during an RPC call.
-		MaxGasInvoke           fixedn.Fixed8 `yaml:"MaxGasInvoke"`
-		MaxIteratorResultItems int           `yaml:"MaxIteratorResultItems"`
-		MaxFindResultItems     int           `yaml:"MaxFindResultItems"`
-		MaxNEP11Tokens         int           `yaml:"MaxNEP11Tokens"`
-		MaxWebSocketClients    int           `yaml:"MaxWebSocketClients"`
-		SessionEnabled         bool          `yaml:"SessionEnabled"`
-		SessionExpirationTime  int           `yaml:"SessionExpirationTime"`
-		SessionBackedByMPT     bool          `yaml:"SessionBackedByMPT"`
-		SessionPoolSize        int           `yaml:"SessionPoolSize"`
-		StartWhenSynchronized  bool          `yaml:"StartWhenSynchronized"`
-		TLSConfig              TLS           `yaml:"TLSConfig"`
+		MaxGasInvoke                   fixedn.Fixed8 `yaml:"MaxGasInvoke"`
+		MaxIteratorResultItems         int           `yaml:"MaxIteratorResultItems"`
+		MaxFindResultItems             int           `yaml:"MaxFindResultItems"`
+		MaxFindStoragePageSize         int           `yaml:"MaxFindStoragePageSize"`
+		MaxMempoolTransactionsPageSize int           `yaml:"MaxMempoolTransactionsPageSize"`
+		MaxNEP11Tokens                 int           `yaml:"MaxNEP11Tokens"`
+		MaxWebSocketClients            int           `yaml:"MaxWebSocketClients"`
+		SessionEnabled                 bool          `yaml:"SessionEnabled"`
+		SessionExpirationTime          int           `yaml:"SessionExpirationTime"`
+		SessionBackedByMPT             bool          `yaml:"SessionBackedByMPT"`
+		SessionPoolSize                int           `yaml:"SessionPoolSize"`
+		// SnapshotPath is the directory to store DB snapshots created with
+		// createsnapshot call, it's disabled if empty.
+		SnapshotPath          string `yaml:"SnapshotPath"`
+		StartWhenSynchronized bool   `yaml:"StartWhenSynchronized"`
+		TLSConfig             TLS    `yaml:"TLSConfig"`
+	}
+
+	// RPCAdmin describes administrative RPC methods configuration. These
+	// methods are only available for clients using HTTP basic authentication
+	// with the specified credentials.
+	RPCAdmin struct {
+		Enabled  bool   `yaml:"Enabled"`
+		User     string `yaml:"User"`
+		Password string `yaml:"Password"`
+	}
+
+	// RPCGraphQL describes GraphQL endpoint configuration. The endpoint is
+	// served at "/graphql" path of RPC server addresses if enabled.
+	RPCGraphQL struct {
+		Enabled bool `yaml:"Enabled"`
+		// MaxDepth is the maximum allowed depth of nested selections.
+		MaxDepth int `yaml:"MaxDepth"`
+		// MaxNodes is the maximum number of fields resolved for a single
+		// query (every list element counts separately).
+		MaxNodes int `yaml:"MaxNodes"`
 	}
 
 	// TLS describes SSL/TLS configuration.
@@ -4,4 +4,8 @@ package config
 type Wallet struct {
 	Path     string `yaml:"Path"`
 	Password string `yaml:"Password"`
+	// RemoteSigner is an optional remote signer endpoint (HTTP(S) URL or
+	// unix:///path/to/socket), when set, wallet accounts with keys provided
+	// by the remote signer use it and are not decrypted.
+	RemoteSigner string `yaml:"RemoteSigner"`
 }
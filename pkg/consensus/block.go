@@ -25,7 +25,10 @@ var _ block.Block = (*neoBlock)(nil)
 // Sign implements the block.Block interface.
 func (n *neoBlock) Sign(key crypto.PrivateKey) error {
 	k := key.(*privateKey)
-	sig := k.PrivateKey.SignHashable(uint32(n.network), &n.Block)
+	sig, err := k.SignHashable(n.network, &n.Block)
+	if err != nil {
+		return err
+	}
 	n.signature = sig
 	return nil
 }
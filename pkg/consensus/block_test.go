@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
 
@@ -15,7 +16,7 @@ func TestNeoBlock_Sign(t *testing.T) {
 	b := new(neoBlock)
 	priv, _ := keys.NewPrivateKey()
 
-	require.NoError(t, b.Sign(&privateKey{PrivateKey: priv}))
+	require.NoError(t, b.Sign(&privateKey{Signer: wallet.NewPrivateKeySigner(priv)}))
 	require.NoError(t, b.Verify(&publicKey{PublicKey: priv.PublicKey()}, b.Signature()))
 }
 
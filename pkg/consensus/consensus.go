@@ -26,6 +26,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/remotesigner"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -160,14 +161,18 @@ func NewService(cfg Config) (Service, error) {
 			return nil, err
 		}
 
-		// Check that the wallet password is correct for at least one account.
 		var ok bool
-		for _, acc := range srv.wallet.Accounts {
-			err := acc.Decrypt(srv.Config.Wallet.Password, srv.wallet.Scrypt)
-			if err == nil {
-				ok = true
-				break
+		if len(cfg.Wallet.RemoteSigner) > 0 {
+			n, err := remotesigner.Attach(srv.wallet, cfg.Wallet.RemoteSigner)
+			if err != nil {
+				return nil, fmt.Errorf("can't use remote signer: %w", err)
 			}
+			ok = n > 0
+		}
+		// Check that the wallet password is correct for at least one account
+		// (unless there are accounts with remote signer attached).
+		for i := 0; !ok && i < len(srv.wallet.Accounts); i++ {
+			ok = srv.wallet.Accounts[i].Decrypt(srv.Config.Wallet.Password, srv.wallet.Scrypt) == nil
 		}
 		if !ok {
 			return nil, errors.New("no account with provided password was found")
@@ -398,7 +403,7 @@ func (s *service) getKeyPair(pubs []crypto.PublicKey) (int, crypto.PrivateKey, c
 				}
 			}
 
-			return i, &privateKey{PrivateKey: acc.PrivateKey()}, &publicKey{PublicKey: acc.PublicKey()}
+			return i, &privateKey{Signer: acc.Signer()}, &publicKey{PublicKey: acc.PublicKey()}
 		}
 	}
 	return -1, nil, nil
@@ -241,7 +241,7 @@ func TestService_ValidatePayload(t *testing.T) {
 	srv := newTestService(t)
 	priv, _ := getTestValidator(1)
 	p := new(Payload)
-	p.Sender = priv.GetScriptHash()
+	p.Sender = priv.PublicKey().GetScriptHash()
 	p.SetPayload(&prepareRequest{})
 
 	t.Run("invalid validator index", func(t *testing.T) {
@@ -268,7 +268,7 @@ func TestService_ValidatePayload(t *testing.T) {
 
 	t.Run("normal case", func(t *testing.T) {
 		p.SetValidatorIndex(1)
-		p.Sender = priv.GetScriptHash()
+		p.Sender = priv.PublicKey().GetScriptHash()
 		require.NoError(t, p.Sign(priv))
 		require.True(t, srv.validatePayload(p))
 	})
@@ -374,7 +374,7 @@ func TestService_OnPayload(t *testing.T) {
 
 	p = new(Payload)
 	p.SetValidatorIndex(1)
-	p.Sender = priv.GetScriptHash()
+	p.Sender = priv.PublicKey().GetScriptHash()
 	p.SetPayload(&prepareRequest{})
 	require.NoError(t, p.Sign(priv))
 	require.NoError(t, srv.OnPayload(&p.Extensible))
@@ -511,7 +511,7 @@ func newTestServiceWithChain(t *testing.T, bc *core.Blockchain) *service {
 
 func getTestValidator(i int) (*privateKey, *publicKey) {
 	key := testchain.PrivateKey(i)
-	return &privateKey{PrivateKey: key}, &publicKey{PublicKey: key.PublicKey()}
+	return &privateKey{Signer: wallet.NewPrivateKeySigner(key)}, &publicKey{PublicKey: key.PublicKey()}
 }
 
 func newSingleTestChain(t *testing.T) *core.Blockchain {
@@ -4,18 +4,22 @@ import (
 	"crypto/sha256"
 	"errors"
 
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 )
 
-// privateKey is a wrapper around keys.PrivateKey
-// which implements the crypto.PrivateKey interface.
+// privateKey is a wrapper around wallet.Signer (which can be either an
+// in-process key or an external signer) which implements the
+// crypto.PrivateKey interface.
 type privateKey struct {
-	*keys.PrivateKey
+	wallet.Signer
 }
 
-// Sign implements the dbft's crypto.PrivateKey interface.
-func (p *privateKey) Sign(data []byte) ([]byte, error) {
-	return p.PrivateKey.Sign(data), nil
+// SignHashable signs the given Hashable item for the given network.
+func (p *privateKey) SignHashable(net netmode.Magic, hh hash.Hashable) ([]byte, error) {
+	return p.Sign(hash.GetSignedData(uint32(net), hh))
 }
 
 // publicKey is a wrapper around keys.PublicKey
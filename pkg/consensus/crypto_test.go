@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
 
@@ -11,7 +12,7 @@ func TestCrypt(t *testing.T) {
 	key, err := keys.NewPrivateKey()
 	require.NoError(t, err)
 
-	priv := privateKey{key}
+	priv := privateKey{wallet.NewPrivateKeySigner(key)}
 
 	key1, err := keys.NewPrivateKey()
 	require.NoError(t, err)
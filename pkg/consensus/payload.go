@@ -130,7 +130,10 @@ func (p *Payload) EncodeBinary(w *io.BinWriter) {
 // It also sets corresponding verification and invocation scripts.
 func (p *Payload) Sign(key *privateKey) error {
 	p.encodeData()
-	sig := key.PrivateKey.SignHashable(uint32(p.network), &p.Extensible)
+	sig, err := key.SignHashable(p.network, &p.Extensible)
+	if err != nil {
+		return err
+	}
 
 	buf := io.NewBufBinWriter()
 	emit.Bytes(buf.BinWriter, sig)
@@ -16,6 +16,7 @@ import (
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -257,7 +258,7 @@ func TestPayload_Sign(t *testing.T) {
 	key, err := keys.NewPrivateKey()
 	require.NoError(t, err)
 
-	priv := &privateKey{key}
+	priv := &privateKey{wallet.NewPrivateKeySigner(key)}
 
 	p := randomPayload(t, prepareRequestType)
 	h := priv.PublicKey().GetScriptHash()
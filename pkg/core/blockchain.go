@@ -2,11 +2,14 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"os"
+	"path/filepath"
 	"sort"
 	"sync"
 	"sync/atomic"
@@ -181,10 +184,47 @@ type Blockchain struct {
 
 	stateRoot *stateroot.Module
 
+	// onExecHook stores vm.OnExecHook to be set for all VMs created.
+	onExecHook atomic.Value
+
 	// Notification subsystem.
 	events  chan bcEvent
 	subCh   chan interface{}
 	unsubCh chan interface{}
+
+	// snapshotCh is used to pass DB snapshot requests to the Run loop.
+	snapshotCh chan snapshotRequest
+	// snapshotLock protects pendingSnapshot and snapshotCancel.
+	snapshotLock sync.Mutex
+	// pendingSnapshot is a snapshot request waiting for some block to be
+	// stored.
+	pendingSnapshot *pendingSnapshot
+	// snapshotCancel cancels the snapshot being made, it's nil if there is
+	// none.
+	snapshotCancel context.CancelFunc
+	// snapshotWG is used to wait for the snapshot copy to finish before
+	// closing the DB.
+	snapshotWG sync.WaitGroup
+}
+
+// snapshotRequest is a request to persist all pending changes and to take a
+// point-in-time view of the DB in the Run loop.
+type snapshotRequest struct {
+	res chan snapshotResult
+}
+
+// snapshotResult is the result of snapshotRequest processing.
+type snapshotResult struct {
+	view   storage.StoreSnapshot
+	height uint32
+	err    error
+}
+
+// pendingSnapshot is a snapshot to be taken right after the block with the
+// given index is stored.
+type pendingSnapshot struct {
+	height uint32
+	res    chan snapshotResult
 }
 
 // StateRoot represents local state root module.
@@ -197,6 +237,7 @@ type StateRoot interface {
 	GetStateProof(root util.Uint256, key []byte) ([][]byte, error)
 	GetStateRoot(height uint32) (*state.MPTRoot, error)
 	GetLatestStateHeight(root util.Uint256) (uint32, error)
+	SeekStates(root util.Uint256, rng storage.SeekRange, f func(k, v []byte) bool)
 }
 
 // bcEvent is an internal event generated by the Blockchain and then
@@ -314,6 +355,7 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		events:      make(chan bcEvent),
 		subCh:       make(chan interface{}),
 		unsubCh:     make(chan interface{}),
+		snapshotCh:  make(chan snapshotRequest),
 		contracts:   *native.NewContracts(cfg.ProtocolConfiguration),
 	}
 
@@ -370,6 +412,15 @@ func (bc *Blockchain) SetNotary(mod native.NotaryService) {
 	bc.contracts.Designate.NotaryService.Store(&mod)
 }
 
+// SetOnExecHook sets a hook that is called before every instruction execution
+// in all VMs created by the Blockchain (for blocks, transactions, witness
+// verification and test invocations), nil value removes it. It's intended for
+// debugging/testing purposes like code coverage collection since it affects
+// the performance significantly.
+func (bc *Blockchain) SetOnExecHook(h vm.OnExecHook) {
+	bc.onExecHook.Store(h)
+}
+
 func (bc *Blockchain) init() error {
 	// If we could not find the version in the Store, we know that there is nothing stored.
 	ver, err := bc.dao.GetVersion()
@@ -915,6 +966,8 @@ func (bc *Blockchain) Run() {
 	persistTimer := time.NewTimer(persistInterval)
 	defer func() {
 		persistTimer.Stop()
+		bc.CancelSnapshot()
+		bc.snapshotWG.Wait()
 		if _, err := bc.persist(true); err != nil {
 			bc.log.Warn("failed to persist", zap.Error(err))
 		}
@@ -950,8 +1003,192 @@ func (bc *Blockchain) Run() {
 				interval = time.Microsecond // Reset doesn't work with zero value
 			}
 			persistTimer.Reset(interval)
+		case req := <-bc.snapshotCh:
+			req.res <- bc.snapshot()
+		}
+	}
+}
+
+// Snapshot starts making a consistent copy of the underlying persistent DB at
+// the given height while the chain is running. When the block with the given
+// index is stored (immediately if it's the current one), all pending changes
+// are persisted and a point-in-time view of the DB is taken, new blocks are
+// not added during this short phase. The view is then copied to the given path
+// (DB directory for LevelDB or file for BoltDB) in a separate goroutine
+// without blocking the chain. The copy is created under the "<path>.tmp" name
+// and renamed to the path when it's complete, it can be used as a regular DB
+// for a new node. The returned channel receives the result of the process
+// (nil if the snapshot is created successfully). The height must not be lower
+// than the current chain height and only one snapshot can be made at a time,
+// it can be canceled with CancelSnapshot and it's canceled automatically when
+// the chain is stopped. It only works for running Blockchain (see Run) with
+// the DB implementing storage.Snapshotter.
+func (bc *Blockchain) Snapshot(path string, height uint32) (<-chan error, error) {
+	if _, ok := bc.store.(storage.Snapshotter); !ok {
+		return nil, errors.New("DB doesn't support snapshots")
+	}
+	if !bc.isRunning.Load().(bool) {
+		return nil, errors.New("blockchain is not running")
+	}
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("%s already exists", path)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	bc.snapshotLock.Lock()
+	select {
+	case <-bc.stopCh:
+		bc.snapshotLock.Unlock()
+		cancel()
+		return nil, errors.New("blockchain is stopped")
+	default:
+	}
+	if bc.snapshotCancel != nil {
+		bc.snapshotLock.Unlock()
+		cancel()
+		return nil, errors.New("another snapshot is in progress")
+	}
+	bc.snapshotCancel = cancel
+	bc.snapshotWG.Add(1)
+	bc.snapshotLock.Unlock()
+
+	finish := func() {
+		bc.snapshotLock.Lock()
+		bc.snapshotCancel = nil
+		bc.snapshotLock.Unlock()
+		cancel()
+	}
+	viewCh, err := bc.requestSnapshot(height)
+	if err != nil {
+		finish()
+		bc.snapshotWG.Done()
+		return nil, err
+	}
+	done := make(chan error, 1)
+	go func() {
+		defer bc.snapshotWG.Done()
+		err := bc.copySnapshot(ctx, path, height, viewCh)
+		finish()
+		done <- err
+	}()
+	return done, nil
+}
+
+// CancelSnapshot cancels the snapshot started with Snapshot (its channel then
+// receives an error), it returns false if there is no snapshot being made.
+func (bc *Blockchain) CancelSnapshot() bool {
+	bc.snapshotLock.Lock()
+	defer bc.snapshotLock.Unlock()
+	if bc.snapshotCancel == nil {
+		return false
+	}
+	bc.snapshotCancel()
+	return true
+}
+
+// requestSnapshot makes a DB snapshot to be taken right after the block with
+// the given index is stored (immediately if it's the current block), the
+// result is sent to the channel returned.
+func (bc *Blockchain) requestSnapshot(height uint32) (chan snapshotResult, error) {
+	bc.addLock.Lock()
+	defer bc.addLock.Unlock()
+
+	var (
+		cur = bc.BlockHeight()
+		res = make(chan snapshotResult, 1)
+	)
+	switch {
+	case cur > height:
+		return nil, fmt.Errorf("block %d is already stored (current height is %d)", height, cur)
+	case cur == height:
+		r := bc.takeSnapshot()
+		if r.err != nil {
+			return nil, r.err
+		}
+		res <- r
+	default:
+		bc.snapshotLock.Lock()
+		bc.pendingSnapshot = &pendingSnapshot{height: height, res: res}
+		bc.snapshotLock.Unlock()
+	}
+	return res, nil
+}
+
+// copySnapshot waits for the DB snapshot requested with requestSnapshot and
+// copies it to the given path unless the context is canceled.
+func (bc *Blockchain) copySnapshot(ctx context.Context, path string, height uint32, viewCh <-chan snapshotResult) error {
+	var res snapshotResult
+	select {
+	case res = <-viewCh:
+	case <-ctx.Done():
+		bc.snapshotLock.Lock()
+		if bc.pendingSnapshot != nil && bc.pendingSnapshot.res == viewCh {
+			bc.pendingSnapshot = nil
+		}
+		bc.snapshotLock.Unlock()
+		select {
+		case res = <-viewCh: // Could've been taken just before the cancellation.
+			if res.view != nil {
+				res.view.Release()
+			}
+		default:
 		}
+		return errors.New("snapshot is canceled")
+	}
+	if res.err != nil {
+		return res.err
+	}
+	defer res.view.Release()
+	if res.height != height {
+		return fmt.Errorf("unexpected snapshot height %d", res.height)
+	}
+
+	var (
+		start = time.Now()
+		tmp   = path + ".tmp"
+	)
+	if err := os.RemoveAll(tmp); err != nil {
+		return fmt.Errorf("failed to remove stale snapshot: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := res.view.CopyTo(ctx, tmp); err != nil {
+		return fmt.Errorf("failed to copy DB: %w", err)
 	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.RemoveAll(tmp)
+		return fmt.Errorf("failed to move snapshot: %w", err)
+	}
+	bc.log.Info("DB snapshot created",
+		zap.Uint32("blockHeight", height),
+		zap.String("path", path),
+		zap.Duration("took", time.Since(start)))
+	return nil
+}
+
+// takeSnapshot makes the Run loop take a DB snapshot, it must be called with
+// addLock held, so that no blocks are added until the snapshot is taken.
+func (bc *Blockchain) takeSnapshot() snapshotResult {
+	req := snapshotRequest{res: make(chan snapshotResult, 1)}
+	select {
+	case bc.snapshotCh <- req:
+	case <-bc.stopCh:
+		return snapshotResult{err: errors.New("blockchain is stopped")}
+	}
+	return <-req.res
+}
+
+// snapshot persists all pending changes and takes a point-in-time view of the
+// DB, it's executed in the Run loop.
+func (bc *Blockchain) snapshot() snapshotResult {
+	if _, err := bc.persist(true); err != nil {
+		return snapshotResult{err: fmt.Errorf("failed to persist: %w", err)}
+	}
+	view, err := bc.store.(storage.Snapshotter).Snapshot()
+	if err != nil {
+		return snapshotResult{err: fmt.Errorf("failed to take DB snapshot: %w", err)}
+	}
+	return snapshotResult{view: view, height: atomic.LoadUint32(&bc.persistedHeight)}
 }
 
 func (bc *Blockchain) tryRunGC(oldHeight uint32) time.Duration {
@@ -1342,7 +1579,16 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 			}
 		}
 	}
-	return bc.storeBlock(block, mp)
+	err := bc.storeBlock(block, mp)
+	if err == nil {
+		bc.snapshotLock.Lock()
+		if bc.pendingSnapshot != nil && bc.pendingSnapshot.height == block.Index {
+			bc.pendingSnapshot.res <- bc.takeSnapshot()
+			bc.pendingSnapshot = nil
+		}
+		bc.snapshotLock.Unlock()
+	}
+	return err
 }
 
 // AddHeaders processes the given headers and add them to the
@@ -1874,6 +2120,21 @@ func (bc *Blockchain) ForEachNEP11Transfer(acc util.Uint160, newestTimestamp uin
 	return bc.dao.SeekNEP11TransferLog(acc, newestTimestamp, f)
 }
 
+// ForEachAccountNEP17Transfer executes f for each NEP-17 transfer of every
+// account. Every transfer is passed twice (for both sides of it, with
+// different Amount sign and Counterparty) unless it's a mint or burn. It
+// continues iteration until false is returned from f. The last non-nil error
+// is returned.
+func (bc *Blockchain) ForEachAccountNEP17Transfer(f func(util.Uint160, *state.NEP17Transfer) (bool, error)) error {
+	return bc.dao.SeekAllNEP17TransferLogs(f)
+}
+
+// ForEachAccountNEP11Transfer is the same as ForEachAccountNEP17Transfer,
+// but for NEP-11 transfers.
+func (bc *Blockchain) ForEachAccountNEP11Transfer(f func(util.Uint160, *state.NEP11Transfer) (bool, error)) error {
+	return bc.dao.SeekAllNEP11TransferLogs(f)
+}
+
 // GetNEP17Contracts returns the list of deployed NEP-17 contracts.
 func (bc *Blockchain) GetNEP17Contracts() []util.Uint160 {
 	return bc.contracts.Management.GetNEP17Contracts(bc.dao)
@@ -2014,6 +2275,13 @@ func (bc *Blockchain) GetStorageItem(id int32, key []byte) state.StorageItem {
 	return bc.dao.GetStorageItem(id, key)
 }
 
+// SeekStorage performs seek operation over the current storage of the contract
+// with the given ID. f is called for every item matching the range (the prefix
+// itself is cut from the key passed to f), iteration stops when f returns false.
+func (bc *Blockchain) SeekStorage(id int32, rng storage.SeekRange, f func(k, v []byte) bool) {
+	bc.dao.Seek(id, rng, f)
+}
+
 // GetBlock returns a Block by the given hash.
 func (bc *Blockchain) GetBlock(hash util.Uint256) (*block.Block, error) {
 	topBlock := bc.topBlock.Load()
@@ -2823,6 +3091,9 @@ func (bc *Blockchain) newInteropContext(trigger trigger.Type, d *dao.Simple, blo
 	}
 	ic := interop.NewContext(trigger, bc, d, baseExecFee, baseStorageFee, native.GetContract, bc.contracts.Contracts, contract.LoadToken, block, tx, bc.log)
 	ic.Functions = systemInterops
+	if h, ok := bc.onExecHook.Load().(vm.OnExecHook); ok {
+		ic.OnExecHook = h
+	}
 	switch {
 	case tx != nil:
 		ic.Container = tx
@@ -4,6 +4,7 @@ import (
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"sync/atomic"
 	"testing"
@@ -14,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
@@ -361,3 +363,123 @@ func TestBlockchain_IsRunning(t *testing.T) {
 	chain.Close()
 	require.False(t, chain.isRunning.Load().(bool))
 }
+
+func TestBlockchain_Snapshot(t *testing.T) {
+	t.Run("unsupported DB", func(t *testing.T) {
+		bc := newTestChain(t)
+		_, err := bc.Snapshot(filepath.Join(t.TempDir(), "snapshot"), bc.BlockHeight())
+		require.Error(t, err)
+	})
+
+	ps, err := storage.NewLevelDBStore(dbconfig.LevelDBOptions{DataDirectoryPath: t.TempDir()})
+	require.NoError(t, err)
+	bc := initTestChain(t, ps, nil)
+	t.Cleanup(bc.Close)
+
+	dir := filepath.Join(t.TempDir(), "snapshots")
+	_, err = bc.Snapshot(filepath.Join(dir, "0"), 0)
+	require.Error(t, err) // Not running.
+
+	go bc.Run()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, bc.AddBlock(bc.newBlock()))
+	}
+	checkSnapshot := func(t *testing.T, path string, height uint32) {
+		snapStore, err := storage.NewLevelDBStore(dbconfig.LevelDBOptions{DataDirectoryPath: path})
+		require.NoError(t, err)
+		snapChain := newTestChainWithCustomCfgAndStore(t, snapStore, nil)
+		require.Equal(t, height, snapChain.BlockHeight())
+		require.Equal(t, bc.GetHeaderHash(height), snapChain.CurrentBlockHash())
+	}
+
+	// Past block.
+	_, err = bc.Snapshot(filepath.Join(dir, "2"), 2)
+	require.Error(t, err)
+
+	// Current block.
+	path := filepath.Join(dir, "3")
+	done, err := bc.Snapshot(path, 3)
+	require.NoError(t, err)
+	require.NoError(t, <-done)
+	_, err = bc.Snapshot(path, 3) // Already exists.
+	require.Error(t, err)
+	checkSnapshot(t, path, 3)
+
+	// Future block.
+	path = filepath.Join(dir, "5")
+	done, err = bc.Snapshot(path, 5)
+	require.NoError(t, err)
+	_, err = bc.Snapshot(filepath.Join(dir, "6"), 6) // Only one at a time.
+	require.Error(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, bc.AddBlock(bc.newBlock()))
+	}
+	require.NoError(t, <-done)
+	require.Equal(t, uint32(6), bc.BlockHeight())
+	checkSnapshot(t, path, 5)
+
+	// Canceled future block.
+	require.False(t, bc.CancelSnapshot())
+	path = filepath.Join(dir, "8")
+	done, err = bc.Snapshot(path, 8)
+	require.NoError(t, err)
+	require.True(t, bc.CancelSnapshot())
+	require.Error(t, <-done)
+	require.False(t, bc.CancelSnapshot())
+	for i := 0; i < 2; i++ {
+		require.NoError(t, bc.AddBlock(bc.newBlock()))
+	}
+	require.NoDirExists(t, path)
+	require.NoDirExists(t, path+".tmp")
+
+	// New snapshot can be made after the cancellation.
+	path = filepath.Join(dir, "9")
+	done, err = bc.Snapshot(path, 9)
+	require.NoError(t, err)
+	require.NoError(t, bc.AddBlock(bc.newBlock()))
+	require.NoError(t, <-done)
+	checkSnapshot(t, path, 9)
+}
+
+func TestBlockchain_SnapshotShutdown(t *testing.T) {
+	newChain := func(t *testing.T) (*Blockchain, string) {
+		dbPath := filepath.Join(t.TempDir(), "chain.bolt")
+		ps, err := storage.NewBoltDBStore(dbconfig.BoltDBOptions{FilePath: dbPath})
+		require.NoError(t, err)
+		bc := initTestChain(t, ps, nil)
+		go bc.Run()
+		for i := 0; i < 3; i++ {
+			require.NoError(t, bc.AddBlock(bc.newBlock()))
+		}
+		return bc, filepath.Join(t.TempDir(), "snapshot.bolt")
+	}
+
+	t.Run("future block", func(t *testing.T) {
+		bc, path := newChain(t)
+		done, err := bc.Snapshot(path, 5)
+		require.NoError(t, err)
+		bc.Close()
+		require.Error(t, <-done)
+		require.NoFileExists(t, path)
+		require.NoFileExists(t, path+".tmp")
+	})
+	t.Run("copy in progress", func(t *testing.T) {
+		bc, path := newChain(t)
+		done, err := bc.Snapshot(path, bc.BlockHeight())
+		require.NoError(t, err)
+		bc.Close() // Waits for the copy to finish or to be aborted.
+		select {
+		case err = <-done:
+		default:
+			t.Fatal("snapshot is still being made after the chain is closed")
+		}
+		if err != nil {
+			require.NoFileExists(t, path)
+		} else {
+			require.FileExists(t, path)
+		}
+		require.NoFileExists(t, path+".tmp")
+		_, err = bc.Snapshot(filepath.Join(t.TempDir(), "snapshot.bolt"), bc.BlockHeight())
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,109 @@
+package chaindump
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// CSVWriter is a RowWriter producing a set of CSV files (one per table, named
+// after the table) with a header line. NULL values are represented by empty
+// fields.
+type CSVWriter struct {
+	files   map[*Table]*os.File
+	writers map[*Table]*csv.Writer
+}
+
+// NewCSVWriter creates CSV files for all exported tables in the given
+// directory (it must exist) and returns a CSVWriter for them.
+func NewCSVWriter(dir string) (*CSVWriter, error) {
+	c := &CSVWriter{
+		files:   make(map[*Table]*os.File, len(Tables)),
+		writers: make(map[*Table]*csv.Writer, len(Tables)),
+	}
+	for _, t := range Tables {
+		f, err := os.Create(filepath.Join(dir, t.Name+".csv"))
+		if err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+		c.files[t] = f
+		c.writers[t] = csv.NewWriter(f)
+
+		header := make([]string, len(t.Columns))
+		for i := range t.Columns {
+			header[i] = t.Columns[i].Name
+		}
+		if err := c.writers[t].Write(header); err != nil {
+			_ = c.Close()
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// WriteRow implements the RowWriter interface.
+func (c *CSVWriter) WriteRow(t *Table, values []interface{}) error {
+	w, ok := c.writers[t]
+	if !ok {
+		return fmt.Errorf("unknown table %s", t.Name)
+	}
+	if len(values) != len(t.Columns) {
+		return fmt.Errorf("%s: expected %d values, got %d", t.Name, len(t.Columns), len(values))
+	}
+	record := make([]string, len(values))
+	for i, v := range values {
+		str, err := csvValue(v)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name, t.Columns[i].Name, err)
+		}
+		record[i] = str
+	}
+	return w.Write(record)
+}
+
+// Close flushes all data and closes the files. It's safe to call it
+// multiple times.
+func (c *CSVWriter) Close() error {
+	var errs []error
+	for t, f := range c.files {
+		if w, ok := c.writers[t]; ok {
+			w.Flush()
+			if err := w.Error(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		if err := f.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	c.files = nil
+	c.writers = nil
+	if len(errs) != 0 {
+		return fmt.Errorf("failed to close CSV files: %w", errs[0])
+	}
+	return nil
+}
+
+func csvValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case *big.Int:
+		return v.String(), nil
+	case string:
+		return v, nil
+	case json.RawMessage:
+		return string(v), nil
+	default:
+		return "", fmt.Errorf("unexpected value type %T", v)
+	}
+}
@@ -0,0 +1,373 @@
+package chaindump
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Exporter is an interface to get the data to export from.
+type Exporter interface {
+	ForEachAccountNEP11Transfer(f func(util.Uint160, *state.NEP11Transfer) (bool, error)) error
+	ForEachAccountNEP17Transfer(f func(util.Uint160, *state.NEP17Transfer) (bool, error)) error
+	GetAppExecResults(util.Uint256, trigger.Type) ([]state.AppExecResult, error)
+	GetBlock(hash util.Uint256) (*block.Block, error)
+	GetContractScriptHash(id int32) (util.Uint160, error)
+	GetHeaderHash(uint32) util.Uint256
+}
+
+// RowWriter is an interface for the exported data output formats.
+type RowWriter interface {
+	// WriteRow writes a single row into the given table. Values follow
+	// the table columns, they're either nil (NULL) or have a type
+	// corresponding to the column type (see ColumnType).
+	WriteRow(t *Table, values []interface{}) error
+}
+
+// ColumnType is a type of the exported table column.
+type ColumnType byte
+
+// Column types, the values are int64 for ColumnInteger, *big.Int or uint64 for
+// ColumnNumeric, string for ColumnText and json.RawMessage for ColumnJSON.
+const (
+	ColumnInteger ColumnType = iota
+	ColumnNumeric
+	ColumnText
+	ColumnJSON
+)
+
+// Column is a single column of the exported table.
+type Column struct {
+	Name     string
+	Type     ColumnType
+	Nullable bool
+}
+
+// Table describes a table of the exported data.
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+// Exported tables. Hashes are stored as 0x-prefixed LE strings (the same way
+// RPC server returns them), accounts are stored as addresses.
+var (
+	BlocksTable = &Table{Name: "blocks", Columns: []Column{
+		{Name: "index", Type: ColumnInteger},
+		{Name: "hash", Type: ColumnText},
+		{Name: "version", Type: ColumnInteger},
+		{Name: "prev_hash", Type: ColumnText},
+		{Name: "merkle_root", Type: ColumnText},
+		{Name: "timestamp", Type: ColumnInteger},
+		{Name: "nonce", Type: ColumnNumeric},
+		{Name: "primary_index", Type: ColumnInteger},
+		{Name: "next_consensus", Type: ColumnText},
+		{Name: "tx_count", Type: ColumnInteger},
+	}}
+	TransactionsTable = &Table{Name: "transactions", Columns: []Column{
+		{Name: "hash", Type: ColumnText},
+		{Name: "block_index", Type: ColumnInteger},
+		{Name: "position", Type: ColumnInteger},
+		{Name: "version", Type: ColumnInteger},
+		{Name: "nonce", Type: ColumnInteger},
+		{Name: "sender", Type: ColumnText},
+		{Name: "system_fee", Type: ColumnInteger},
+		{Name: "network_fee", Type: ColumnInteger},
+		{Name: "valid_until_block", Type: ColumnInteger},
+		{Name: "script", Type: ColumnText},
+		{Name: "size", Type: ColumnInteger},
+	}}
+	SignersTable = &Table{Name: "signers", Columns: []Column{
+		{Name: "tx_hash", Type: ColumnText},
+		{Name: "position", Type: ColumnInteger},
+		{Name: "account", Type: ColumnText},
+		{Name: "scopes", Type: ColumnText},
+		{Name: "allowed_contracts", Type: ColumnJSON, Nullable: true},
+		{Name: "allowed_groups", Type: ColumnJSON, Nullable: true},
+		{Name: "rules", Type: ColumnJSON, Nullable: true},
+	}}
+	ExecutionsTable = &Table{Name: "executions", Columns: []Column{
+		{Name: "container", Type: ColumnText},
+		{Name: "trigger", Type: ColumnText},
+		{Name: "vm_state", Type: ColumnText},
+		{Name: "gas_consumed", Type: ColumnInteger},
+		{Name: "exception", Type: ColumnText, Nullable: true},
+		{Name: "stack", Type: ColumnJSON},
+	}}
+	NotificationsTable = &Table{Name: "notifications", Columns: []Column{
+		{Name: "container", Type: ColumnText},
+		{Name: "trigger", Type: ColumnText},
+		{Name: "position", Type: ColumnInteger},
+		{Name: "contract", Type: ColumnText},
+		{Name: "event_name", Type: ColumnText},
+		{Name: "state", Type: ColumnJSON},
+	}}
+	NEP17TransfersTable = &Table{Name: "nep17_transfers", Columns: transferColumns()}
+	NEP11TransfersTable = &Table{Name: "nep11_transfers", Columns: append(transferColumns(),
+		Column{Name: "token_id", Type: ColumnText})}
+
+	// Tables is a list of all exported tables.
+	Tables = []*Table{BlocksTable, TransactionsTable, SignersTable, ExecutionsTable,
+		NotificationsTable, NEP17TransfersTable, NEP11TransfersTable}
+)
+
+// transferColumns returns the columns of token transfer tables. Every
+// transfer is stored for both accounts participating in it (with positive
+// amount for the receiver and negative one for the sender), counterparty is
+// NULL for mints and burns. Asset is the hash of the token contract, it's NULL
+// if the contract is destroyed (asset_id is always present). Container is the
+// hash of the transaction (or block) the transfer was made in.
+func transferColumns() []Column {
+	return []Column{
+		{Name: "account", Type: ColumnText},
+		{Name: "asset", Type: ColumnText, Nullable: true},
+		{Name: "asset_id", Type: ColumnInteger},
+		{Name: "counterparty", Type: ColumnText, Nullable: true},
+		{Name: "amount", Type: ColumnNumeric},
+		{Name: "block_index", Type: ColumnInteger},
+		{Name: "timestamp", Type: ColumnInteger},
+		{Name: "container", Type: ColumnText},
+	}
+}
+
+// Export writes the data of count blocks starting from start (blocks,
+// transactions with their signers, application logs and token transfers)
+// to the provided RowWriter.
+func Export(bc Exporter, w RowWriter, start, count uint32) error {
+	for i := start; i < start+count; i++ {
+		b, err := bc.GetBlock(bc.GetHeaderHash(i))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", i, err)
+		}
+		if err = exportBlock(bc, w, b); err != nil {
+			return fmt.Errorf("failed to export block %d: %w", i, err)
+		}
+	}
+	assets := make(map[int32]interface{})
+	assetHash := func(id int32) (interface{}, error) {
+		if h, ok := assets[id]; ok {
+			return h, nil
+		}
+		h, err := bc.GetContractScriptHash(id)
+		switch {
+		case errors.Is(err, storage.ErrKeyNotFound):
+			// Destroyed contract, transfers are still exported.
+			assets[id] = nil
+		case err != nil:
+			return nil, fmt.Errorf("failed to get contract %d: %w", id, err)
+		default:
+			assets[id] = "0x" + h.StringLE()
+		}
+		return assets[id], nil
+	}
+	transferRow := func(acc util.Uint160, t *state.NEP17Transfer) ([]interface{}, error) {
+		asset, err := assetHash(t.Asset)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{address.Uint160ToString(acc), asset, int64(t.Asset), accountValue(t.Counterparty),
+			t.Amount, int64(t.Block), int64(t.Timestamp), "0x" + t.Tx.StringLE()}, nil
+	}
+	err := bc.ForEachAccountNEP17Transfer(func(acc util.Uint160, t *state.NEP17Transfer) (bool, error) {
+		if t.Block < start || t.Block >= start+count {
+			return true, nil
+		}
+		row, err := transferRow(acc, t)
+		if err != nil {
+			return false, err
+		}
+		return true, w.WriteRow(NEP17TransfersTable, row)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export NEP-17 transfers: %w", err)
+	}
+	err = bc.ForEachAccountNEP11Transfer(func(acc util.Uint160, t *state.NEP11Transfer) (bool, error) {
+		if t.Block < start || t.Block >= start+count {
+			return true, nil
+		}
+		row, err := transferRow(acc, &t.NEP17Transfer)
+		if err != nil {
+			return false, err
+		}
+		return true, w.WriteRow(NEP11TransfersTable, append(row, hex.EncodeToString(t.ID)))
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export NEP-11 transfers: %w", err)
+	}
+	return nil
+}
+
+func exportBlock(bc Exporter, w RowWriter, b *block.Block) error {
+	err := w.WriteRow(BlocksTable, []interface{}{
+		int64(b.Index),
+		"0x" + b.Hash().StringLE(),
+		int64(b.Version),
+		"0x" + b.PrevHash.StringLE(),
+		"0x" + b.MerkleRoot.StringLE(),
+		int64(b.Timestamp),
+		b.Nonce,
+		int64(b.PrimaryIndex),
+		address.Uint160ToString(b.NextConsensus),
+		int64(len(b.Transactions)),
+	})
+	if err != nil {
+		return err
+	}
+	if err = exportAppLogs(bc, w, b.Hash(), trigger.OnPersist|trigger.PostPersist); err != nil {
+		return err
+	}
+	for i, tx := range b.Transactions {
+		if err = exportTransaction(w, b.Index, i, tx); err != nil {
+			return err
+		}
+		if err = exportAppLogs(bc, w, tx.Hash(), trigger.Application); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportTransaction(w RowWriter, index uint32, pos int, tx *transaction.Transaction) error {
+	txHash := "0x" + tx.Hash().StringLE()
+	err := w.WriteRow(TransactionsTable, []interface{}{
+		txHash,
+		int64(index),
+		int64(pos),
+		int64(tx.Version),
+		int64(tx.Nonce),
+		address.Uint160ToString(tx.Sender()),
+		tx.SystemFee,
+		tx.NetworkFee,
+		int64(tx.ValidUntilBlock),
+		hex.EncodeToString(tx.Script),
+		int64(tx.Size()),
+	})
+	if err != nil {
+		return err
+	}
+	for i, s := range tx.Signers {
+		scopes, err := json.Marshal(s.Scopes)
+		if err != nil {
+			return err
+		}
+		var scopesStr string
+		if err = json.Unmarshal(scopes, &scopesStr); err != nil {
+			return err
+		}
+		var contracts, groups, rules interface{}
+		if s.Scopes&transaction.CustomContracts != 0 {
+			if contracts, err = jsonValue(s.AllowedContracts); err != nil {
+				return err
+			}
+		}
+		if s.Scopes&transaction.CustomGroups != 0 {
+			if groups, err = jsonValue(s.AllowedGroups); err != nil {
+				return err
+			}
+		}
+		if s.Scopes&transaction.Rules != 0 {
+			if rules, err = jsonValue(s.Rules); err != nil {
+				return err
+			}
+		}
+		err = w.WriteRow(SignersTable, []interface{}{
+			txHash,
+			int64(i),
+			address.Uint160ToString(s.Account),
+			scopesStr,
+			contracts,
+			groups,
+			rules,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportAppLogs(bc Exporter, w RowWriter, h util.Uint256, trig trigger.Type) error {
+	aers, err := bc.GetAppExecResults(h, trig)
+	if err != nil {
+		// Application logs can be absent for old blocks if
+		// RemoveUntraceableBlocks is enabled.
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get application log for %s: %w", h.StringLE(), err)
+	}
+	container := "0x" + h.StringLE()
+	for _, aer := range aers {
+		stack := make([]json.RawMessage, len(aer.Stack))
+		for i := range aer.Stack {
+			stack[i] = itemJSON(aer.Stack[i])
+		}
+		stackData, err := json.Marshal(stack)
+		if err != nil {
+			return err
+		}
+		var exception interface{}
+		if aer.FaultException != "" {
+			exception = aer.FaultException
+		}
+		err = w.WriteRow(ExecutionsTable, []interface{}{
+			container,
+			aer.Trigger.String(),
+			aer.VMState.String(),
+			aer.GasConsumed,
+			exception,
+			json.RawMessage(stackData),
+		})
+		if err != nil {
+			return err
+		}
+		for i, ev := range aer.Events {
+			err = w.WriteRow(NotificationsTable, []interface{}{
+				container,
+				aer.Trigger.String(),
+				int64(i),
+				"0x" + ev.ScriptHash.StringLE(),
+				ev.Name,
+				itemJSON(ev.Item),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// jsonValue marshals v into a ColumnJSON value.
+func jsonValue(v interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	return data, err
+}
+
+// itemJSON converts the stack item to JSON the same way application logs do
+// it.
+func itemJSON(item stackitem.Item) json.RawMessage {
+	data, err := stackitem.ToJSONWithTypes(item)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("error: %v", err))
+	}
+	return data
+}
+
+// accountValue returns an address for the given account or nil if it's empty.
+func accountValue(u util.Uint160) interface{} {
+	if u.Equals(util.Uint160{}) {
+		return nil
+	}
+	return address.Uint160ToString(u)
+}
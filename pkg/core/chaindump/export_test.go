@@ -0,0 +1,202 @@
+package chaindump_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+type memRowWriter map[*chaindump.Table][][]interface{}
+
+func (m memRowWriter) WriteRow(t *chaindump.Table, values []interface{}) error {
+	m[t] = append(m[t], values)
+	return nil
+}
+
+func TestExport(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	neoCommittee := e.CommitteeInvoker(e.NativeHash(t, "NeoToken"))
+
+	to := e.NewAccount(t)
+	h := neoCommittee.Invoke(t, true, "transfer", neoCommittee.CommitteeHash, to.ScriptHash(), 1000, nil)
+
+	neoID := bc.GetContractState(e.NativeHash(t, "NeoToken")).ID
+
+	rows := make(memRowWriter)
+	require.NoError(t, chaindump.Export(bc, rows, 0, bc.BlockHeight()+1))
+
+	require.Equal(t, int(bc.BlockHeight()+1), len(rows[chaindump.BlocksTable]))
+	last := rows[chaindump.BlocksTable][bc.BlockHeight()]
+	require.Equal(t, int64(bc.BlockHeight()), last[0])
+	require.Equal(t, "0x"+bc.CurrentBlockHash().StringLE(), last[1])
+	require.Equal(t, int64(1), last[9])
+
+	var txRow []interface{}
+	for _, row := range rows[chaindump.TransactionsTable] {
+		if row[0] == "0x"+h.StringLE() {
+			txRow = row
+		}
+	}
+	require.NotNil(t, txRow)
+	require.Equal(t, int64(bc.BlockHeight()), txRow[1])
+	require.Equal(t, address.Uint160ToString(neoCommittee.CommitteeHash), txRow[5])
+
+	var signers int
+	for _, row := range rows[chaindump.SignersTable] {
+		if row[0] == "0x"+h.StringLE() {
+			signers++
+			require.Equal(t, "Global", row[3])
+			require.Nil(t, row[4])
+		}
+	}
+	require.Equal(t, 1, signers)
+
+	var (
+		txExecs  int
+		transfer int
+	)
+	for _, row := range rows[chaindump.ExecutionsTable] {
+		if row[0] == "0x"+h.StringLE() {
+			txExecs++
+			require.Equal(t, "Application", row[1])
+			require.Equal(t, "HALT", row[2])
+			require.Nil(t, row[4])
+			require.Equal(t, json.RawMessage(`[{"type":"Boolean","value":true}]`), row[5])
+		}
+	}
+	require.Equal(t, 1, txExecs)
+	for _, row := range rows[chaindump.NotificationsTable] {
+		if row[0] == "0x"+h.StringLE() && row[3] == "0x"+e.NativeHash(t, "NeoToken").StringLE() {
+			transfer++
+			require.Equal(t, "Transfer", row[4])
+		}
+	}
+	require.Equal(t, 1, transfer)
+
+	// Transfer is stored for both sides.
+	var sides []string
+	for _, row := range rows[chaindump.NEP17TransfersTable] {
+		if row[7] != "0x"+h.StringLE() || row[1] != "0x"+e.NativeHash(t, "NeoToken").StringLE() {
+			continue
+		}
+		require.Equal(t, int64(neoID), row[2])
+		switch row[0] {
+		case address.Uint160ToString(to.ScriptHash()):
+			require.Equal(t, big.NewInt(1000), row[4])
+			require.Equal(t, address.Uint160ToString(neoCommittee.CommitteeHash), row[3])
+			sides = append(sides, "to")
+		case address.Uint160ToString(neoCommittee.CommitteeHash):
+			require.Equal(t, big.NewInt(-1000), row[4])
+			require.Equal(t, address.Uint160ToString(to.ScriptHash()), row[3])
+			sides = append(sides, "from")
+		}
+	}
+	require.ElementsMatch(t, []string{"to", "from"}, sides)
+	require.Equal(t, 0, len(rows[chaindump.NEP11TransfersTable]))
+
+	t.Run("range", func(t *testing.T) {
+		rows := make(memRowWriter)
+		require.NoError(t, chaindump.Export(bc, rows, bc.BlockHeight(), 1))
+		require.Equal(t, 1, len(rows[chaindump.BlocksTable]))
+		for _, row := range rows[chaindump.NEP17TransfersTable] {
+			require.Equal(t, int64(bc.BlockHeight()), row[5])
+		}
+		require.Error(t, chaindump.Export(bc, rows, bc.BlockHeight(), 2))
+	})
+	t.Run("destroyed asset", func(t *testing.T) {
+		rows := make(memRowWriter)
+		require.NoError(t, chaindump.Export(destroyedExporter{bc, neoID}, rows, 0, bc.BlockHeight()+1))
+		var found bool
+		for _, row := range rows[chaindump.NEP17TransfersTable] {
+			if row[2] == int64(neoID) {
+				found = true
+				require.Nil(t, row[1])
+			}
+		}
+		require.True(t, found)
+	})
+}
+
+// destroyedExporter pretends that the contract with the given ID is destroyed.
+type destroyedExporter struct {
+	chaindump.Exporter
+	id int32
+}
+
+func (d destroyedExporter) GetContractScriptHash(id int32) (util.Uint160, error) {
+	if id == d.id {
+		return util.Uint160{}, storage.ErrKeyNotFound
+	}
+	return d.Exporter.GetContractScriptHash(id)
+}
+
+func TestSQLWriter(t *testing.T) {
+	var (
+		buf bytes.Buffer
+		w   = chaindump.NewSQLWriter(&buf)
+		tbl = &chaindump.Table{Name: "test", Columns: []chaindump.Column{
+			{Name: "i", Type: chaindump.ColumnInteger},
+			{Name: "n", Type: chaindump.ColumnNumeric},
+			{Name: "s", Type: chaindump.ColumnText, Nullable: true},
+			{Name: "j", Type: chaindump.ColumnJSON},
+		}}
+	)
+	require.NoError(t, w.WriteSchema())
+	require.Contains(t, buf.String(), "CREATE TABLE IF NOT EXISTS blocks (\n\t\"index\" BIGINT NOT NULL,\n")
+	buf.Reset()
+
+	require.NoError(t, w.WriteRow(tbl, []interface{}{int64(-1), big.NewInt(42), "it's", json.RawMessage(`{"a":1}`)}))
+	require.NoError(t, w.WriteRow(tbl, []interface{}{int64(1), uint64(1 << 63), nil, json.RawMessage(`[]`)}))
+	require.Error(t, w.WriteRow(tbl, []interface{}{int64(1)}))
+	require.Error(t, w.WriteRow(tbl, []interface{}{1, nil, nil, nil}))
+	require.NoError(t, w.Close())
+	require.Equal(t, `INSERT INTO test ("i", "n", "s", "j") VALUES (-1, 42, 'it''s', '{"a":1}');
+INSERT INTO test ("i", "n", "s", "j") VALUES (1, 9223372036854775808, NULL, '[]');
+COMMIT;
+`, buf.String())
+}
+
+func TestCSVWriter(t *testing.T) {
+	t.Run("bad directory", func(t *testing.T) {
+		_, err := chaindump.NewCSVWriter(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+
+	dir := t.TempDir()
+	w, err := chaindump.NewCSVWriter(dir)
+	require.NoError(t, err)
+	require.Error(t, w.WriteRow(&chaindump.Table{Name: "unknown"}, nil))
+	require.Error(t, w.WriteRow(chaindump.BlocksTable, []interface{}{int64(1)}))
+	require.NoError(t, w.WriteRow(chaindump.ExecutionsTable, []interface{}{
+		"0xabcd", "Application", "FAULT", int64(100), "some, error", json.RawMessage(`[]`),
+	}))
+	require.NoError(t, w.WriteRow(chaindump.ExecutionsTable, []interface{}{
+		"0xabcd", "Application", "HALT", int64(100), nil, json.RawMessage(`[{"type":"Integer","value":"1"}]`),
+	}))
+	require.NoError(t, w.Close())
+	require.NoError(t, w.Close())
+
+	for _, tbl := range chaindump.Tables {
+		require.FileExists(t, filepath.Join(dir, tbl.Name+".csv"))
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "executions.csv"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"container,trigger,vm_state,gas_consumed,exception,stack",
+		`0xabcd,Application,FAULT,100,"some, error",[]`,
+		`0xabcd,Application,HALT,100,,"[{""type"":""Integer"",""value"":""1""}]"`,
+	}, strings.Split(strings.TrimSpace(string(data)), "\n"))
+}
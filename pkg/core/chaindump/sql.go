@@ -0,0 +1,121 @@
+package chaindump
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// SQLWriter is a RowWriter producing PostgreSQL-compatible SQL script with
+// table definitions and INSERT statements.
+type SQLWriter struct {
+	w io.Writer
+}
+
+// NewSQLWriter returns a new SQLWriter writing to w.
+func NewSQLWriter(w io.Writer) *SQLWriter {
+	return &SQLWriter{w: w}
+}
+
+// WriteSchema writes CREATE TABLE statements for all exported tables, it
+// also starts a transaction that is to be committed with Close.
+func (s *SQLWriter) WriteSchema() error {
+	var b strings.Builder
+	b.WriteString("BEGIN;\n")
+	for _, t := range Tables {
+		fmt.Fprintf(&b, "CREATE TABLE IF NOT EXISTS %s (\n", t.Name)
+		for i, c := range t.Columns {
+			fmt.Fprintf(&b, "\t%s %s", quoteIdent(c.Name), sqlType(c.Type))
+			if !c.Nullable {
+				b.WriteString(" NOT NULL")
+			}
+			if i != len(t.Columns)-1 {
+				b.WriteByte(',')
+			}
+			b.WriteByte('\n')
+		}
+		b.WriteString(");\n")
+	}
+	_, err := io.WriteString(s.w, b.String())
+	return err
+}
+
+// WriteRow implements the RowWriter interface.
+func (s *SQLWriter) WriteRow(t *Table, values []interface{}) error {
+	if len(values) != len(t.Columns) {
+		return fmt.Errorf("%s: expected %d values, got %d", t.Name, len(t.Columns), len(values))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "INSERT INTO %s (", t.Name)
+	for i, c := range t.Columns {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(quoteIdent(c.Name))
+	}
+	b.WriteString(") VALUES (")
+	for i, v := range values {
+		if i != 0 {
+			b.WriteString(", ")
+		}
+		str, err := sqlValue(v)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name, t.Columns[i].Name, err)
+		}
+		b.WriteString(str)
+	}
+	b.WriteString(");\n")
+	_, err := io.WriteString(s.w, b.String())
+	return err
+}
+
+// Close finishes the transaction started by WriteSchema.
+func (s *SQLWriter) Close() error {
+	_, err := io.WriteString(s.w, "COMMIT;\n")
+	return err
+}
+
+func sqlType(t ColumnType) string {
+	switch t {
+	case ColumnInteger:
+		return "BIGINT"
+	case ColumnNumeric:
+		return "NUMERIC"
+	case ColumnJSON:
+		return "JSONB"
+	default:
+		return "TEXT"
+	}
+}
+
+// quoteIdent quotes column names as some of them (like "index") are reserved
+// words.
+func quoteIdent(s string) string {
+	return `"` + s + `"`
+}
+
+func sqlValue(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "NULL", nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	case *big.Int:
+		return v.String(), nil
+	case string:
+		return quoteString(v), nil
+	case json.RawMessage:
+		return quoteString(string(v)), nil
+	default:
+		return "", fmt.Errorf("unexpected value type %T", v)
+	}
+}
+
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
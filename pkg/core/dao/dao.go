@@ -203,6 +203,60 @@ func (dao *Simple) SeekNEP17TransferLog(acc util.Uint160, newestTimestamp uint64
 	return seekErr
 }
 
+// SeekAllNEP17TransferLogs executes f for each NEP-17 transfer stored in the
+// logs of all accounts. Accounts are iterated over in descending order and
+// transfers of every account go from the newest to the oldest one. Notice
+// that every transfer between two accounts is stored in the logs of both of
+// them. It continues iteration until false is returned from f. The last
+// non-nil error is returned.
+func (dao *Simple) SeekAllNEP17TransferLogs(f func(util.Uint160, *state.NEP17Transfer) (bool, error)) error {
+	return dao.seekAllTokenTransferLogs(false, func(acc util.Uint160, lg *state.TokenTransferLog) (bool, error) {
+		return lg.ForEachNEP17(func(t *state.NEP17Transfer) (bool, error) {
+			return f(acc, t)
+		})
+	})
+}
+
+// SeekAllNEP11TransferLogs is the same as SeekAllNEP17TransferLogs, but for
+// NEP-11 transfers.
+func (dao *Simple) SeekAllNEP11TransferLogs(f func(util.Uint160, *state.NEP11Transfer) (bool, error)) error {
+	return dao.seekAllTokenTransferLogs(true, func(acc util.Uint160, lg *state.TokenTransferLog) (bool, error) {
+		return lg.ForEachNEP11(func(t *state.NEP11Transfer) (bool, error) {
+			return f(acc, t)
+		})
+	})
+}
+
+func (dao *Simple) seekAllTokenTransferLogs(isNEP11 bool, f func(util.Uint160, *state.TokenTransferLog) (bool, error)) error {
+	var (
+		prefix  = []byte{byte(storage.STNEP17Transfers)}
+		seekErr error
+	)
+	if isNEP11 {
+		prefix[0] = byte(storage.STNEP11Transfers)
+	}
+	dao.Store.Seek(storage.SeekRange{
+		Prefix:    prefix,
+		Backwards: true,
+	}, func(k, v []byte) bool {
+		if len(k) < 1+util.Uint160Size {
+			seekErr = fmt.Errorf("invalid transfer log key: %x", k)
+			return false
+		}
+		acc, err := util.Uint160DecodeBytesBE(k[1 : 1+util.Uint160Size])
+		if err != nil {
+			seekErr = err
+			return false
+		}
+		cont, err := f(acc, &state.TokenTransferLog{Raw: v})
+		if err != nil {
+			seekErr = err
+		}
+		return cont
+	})
+	return seekErr
+}
+
 // SeekNEP11TransferLog executes f for each NEP-11 transfer in log starting from
 // the transfer with the newest timestamp up to the oldest transfer. It continues
 // iteration until false is returned from f. The last non-nil error is returned.
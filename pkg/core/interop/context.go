@@ -59,6 +59,7 @@ type Context struct {
 	Log              *zap.Logger
 	VM               *vm.VM
 	Functions        []Function
+	OnExecHook       vm.OnExecHook
 	Invocations      map[util.Uint160]int
 	cancelFuncs      []context.CancelFunc
 	getContract      func(*dao.Simple, util.Uint160) (*state.Contract, error)
@@ -335,6 +336,7 @@ func (ic *Context) initVM(v *vm.VM) {
 	v.GasLimit = -1
 	v.SyscallHandler = ic.SyscallHandler
 	v.SetPriceGetter(ic.GetPrice)
+	v.SetOnExecHook(ic.OnExecHook)
 	ic.VM = v
 }
 
@@ -490,6 +490,8 @@ func (mp *Pool) TryGetData(hash util.Uint256) (interface{}, bool) {
 }
 
 // GetVerifiedTransactions returns a slice of transactions with their fees.
+// Transactions are ordered by their priority, the most prioritized ones come
+// first.
 func (mp *Pool) GetVerifiedTransactions() []*transaction.Transaction {
 	mp.lock.RLock()
 	defer mp.lock.RUnlock()
@@ -0,0 +1,125 @@
+package mempool
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+// dumpFileVersion is the version of memory pool dump file format.
+const dumpFileVersion = 0
+
+// DumpEntry is a pooled transaction stored by WriteFile along with the data
+// attached to it (if any).
+type DumpEntry struct {
+	Tx *transaction.Transaction
+	// Data is the serialized data attached to the transaction, it's empty
+	// if there is no data or it doesn't implement io.Serializable.
+	Data []byte
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (e *DumpEntry) EncodeBinary(w *io.BinWriter) {
+	e.Tx.EncodeBinary(w)
+	w.WriteVarBytes(e.Data)
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (e *DumpEntry) DecodeBinary(r *io.BinReader) {
+	e.Tx = new(transaction.Transaction)
+	e.Tx.DecodeBinary(r)
+	e.Data = r.ReadVarBytes()
+	if r.Err == nil && len(e.Data) == 0 {
+		e.Data = nil
+	}
+}
+
+// Dump returns all verified transactions of the pool with serialized data
+// attached to them. Transactions are ordered by their priority, the most
+// prioritized ones come first.
+func (mp *Pool) Dump() []DumpEntry {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+
+	var res = make([]DumpEntry, len(mp.verifiedTxes))
+	for i := range mp.verifiedTxes {
+		res[i].Tx = mp.verifiedTxes[i].txn
+		if s, ok := mp.verifiedTxes[i].data.(io.Serializable); ok {
+			bw := io.NewBufBinWriter()
+			s.EncodeBinary(bw.BinWriter)
+			if bw.Err == nil {
+				res[i].Data = bw.Bytes()
+			}
+		}
+	}
+	return res
+}
+
+// WriteFile stores dumps of the given pools (see Pool.Dump) into the file
+// bound to the given network, the file is replaced atomically.
+func WriteFile(file string, magic netmode.Magic, pools ...*Pool) error {
+	bw := io.NewBufBinWriter()
+	bw.WriteB(dumpFileVersion)
+	bw.WriteU32LE(uint32(magic))
+	bw.WriteVarUint(uint64(len(pools)))
+	for _, mp := range pools {
+		bw.WriteArray(mp.Dump())
+	}
+	if bw.Err != nil {
+		return bw.Err
+	}
+	var tmp = file + ".tmp"
+	err := os.MkdirAll(filepath.Dir(file), os.ModePerm)
+	if err == nil {
+		err = os.WriteFile(tmp, bw.Bytes(), 0644)
+	}
+	if err == nil {
+		err = os.Rename(tmp, file)
+	}
+	return err
+}
+
+// ReadFile reads pool dumps stored by WriteFile, it returns a list of entries
+// for every pool in the order they were passed to WriteFile. Missing file is
+// not an error, nothing is returned in this case.
+func ReadFile(file string, magic netmode.Magic) ([][]DumpEntry, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	br := io.NewBinReaderFromBuf(data)
+	ver := br.ReadB()
+	if br.Err == nil && ver != dumpFileVersion {
+		return nil, fmt.Errorf("unsupported file version %d", ver)
+	}
+	m := netmode.Magic(br.ReadU32LE())
+	if br.Err == nil && m != magic {
+		return nil, fmt.Errorf("network mismatch: file is for %s, node is running %s", m, magic)
+	}
+	n := br.ReadVarUint()
+	if br.Err == nil && n > uint64(br.Len()) {
+		return nil, fmt.Errorf("invalid number of pools %d", n)
+	}
+	var res = make([][]DumpEntry, n)
+	for i := range res {
+		br.ReadArray(&res[i])
+		if br.Err != nil {
+			break
+		}
+	}
+	if br.Err == nil && br.Len() != 0 {
+		br.Err = errors.New("additional data after the dump")
+	}
+	if br.Err != nil {
+		return nil, br.Err
+	}
+	return res, nil
+}
@@ -0,0 +1,84 @@
+package mempool
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDumpFile(t *testing.T) {
+	var (
+		fs   = &FeerStub{balance: 10000000}
+		file = filepath.Join(t.TempDir(), "dir", "mempool.dump")
+		mp   = New(10, 0, false)
+		dmp  = New(10, 0, false)
+	)
+	newTx := func(nonce uint32) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nonce
+		tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+		tx.Scripts = []transaction.Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
+		_ = tx.Size()
+		_ = tx.Hash()
+		return tx
+	}
+	tx1, tx2, data := newTx(1), newTx(2), newTx(3)
+	require.NoError(t, mp.Add(tx1, fs))
+	require.NoError(t, mp.Add(tx2, fs, "not serializable"))
+	require.NoError(t, dmp.Add(tx1, fs, data))
+
+	// No file.
+	dump, err := ReadFile(file, netmode.UnitTestNet)
+	require.NoError(t, err)
+	require.Nil(t, dump)
+
+	require.NoError(t, WriteFile(file, netmode.UnitTestNet, mp, dmp))
+	dump, err = ReadFile(file, netmode.UnitTestNet)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dump))
+	require.Equal(t, mp.Dump(), dump[0])
+	require.Equal(t, 2, len(dump[0]))
+	require.Nil(t, dump[0][0].Data)
+	require.Nil(t, dump[0][1].Data)
+	require.Equal(t, 1, len(dump[1]))
+	require.Equal(t, tx1, dump[1][0].Tx)
+	actual, err := transaction.NewTransactionFromBytes(dump[1][0].Data)
+	require.NoError(t, err)
+	require.Equal(t, data, actual)
+
+	t.Run("empty", func(t *testing.T) {
+		require.NoError(t, WriteFile(file, netmode.UnitTestNet, New(10, 0, false)))
+		dump, err := ReadFile(file, netmode.UnitTestNet)
+		require.NoError(t, err)
+		require.Equal(t, [][]DumpEntry{{}}, dump)
+	})
+	t.Run("wrong network", func(t *testing.T) {
+		require.NoError(t, WriteFile(file, netmode.TestNet, mp))
+		_, err := ReadFile(file, netmode.UnitTestNet)
+		require.Error(t, err)
+	})
+	t.Run("bad data", func(t *testing.T) {
+		require.NoError(t, WriteFile(file, netmode.UnitTestNet, mp, dmp))
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+
+		require.NoError(t, os.WriteFile(file, append(data, 0), 0644))
+		_, err = ReadFile(file, netmode.UnitTestNet)
+		require.Error(t, err)
+
+		require.NoError(t, os.WriteFile(file, data[:len(data)-1], 0644))
+		_, err = ReadFile(file, netmode.UnitTestNet)
+		require.Error(t, err)
+
+		data[0] = dumpFileVersion + 1
+		require.NoError(t, os.WriteFile(file, data, 0644))
+		_, err = ReadFile(file, netmode.UnitTestNet)
+		require.Error(t, err)
+	})
+}
@@ -91,6 +91,25 @@ func (s *Module) FindStates(root util.Uint256, prefix, start []byte, max int) ([
 	return tr.Find(prefix, start, max)
 }
 
+// SeekStates traverses over the contract storage items stored in the MPT with
+// the specified root. Range prefix is expected to consist of the contract ID
+// and the desired storage item key prefix, both are cut from keys passed to
+// `f` (which matches Blockchain's SeekStorage behaviour). Range start (if any)
+// is applied the same way FindStates does it. Traversal is stopped when `f`
+// returns false.
+func (s *Module) SeekStates(root util.Uint256, rng storage.SeekRange, f func(k, v []byte) bool) {
+	// Allow accessing old values, it's RO thing.
+	store := mpt.NewTrieStore(root, s.mode&^mpt.ModeGCFlag, storage.NewMemCachedStore(s.Store))
+	// TrieStore expects storage keys, STStorage prefix is stripped by it.
+	key := make([]byte, len(rng.Prefix)+1)
+	key[0] = byte(storage.STStorage)
+	copy(key[1:], rng.Prefix)
+	rng.Prefix = key
+	store.Seek(rng, func(k, v []byte) bool {
+		return f(k[len(key):], v)
+	})
+}
+
 // GetStateProof returns proof of having key in the MPT with the specified root.
 func (s *Module) GetStateProof(root util.Uint256, key []byte) ([][]byte, error) {
 	// Allow accessing old values, it's RO thing.
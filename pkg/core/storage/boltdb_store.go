@@ -2,9 +2,11 @@ package storage
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"sync"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -19,6 +21,12 @@ var Bucket = []byte("DB")
 // blockchain data.
 type BoltDBStore struct {
 	db *bbolt.DB
+
+	// snapLock serializes DB changes with snapshot reads, it also protects
+	// snaps.
+	snapLock sync.RWMutex
+	// snaps contains all unreleased snapshots.
+	snaps map[*boltDBSnapshot]struct{}
 }
 
 // NewBoltDBStore returns a new ready to use BoltDB storage with created bucket.
@@ -64,7 +72,7 @@ func NewBoltDBStore(cfg dbconfig.BoltDBOptions) (*BoltDBStore, error) {
 		return nil, err
 	}
 
-	return &BoltDBStore{db: db}, nil
+	return &BoltDBStore{db: db, snaps: make(map[*boltDBSnapshot]struct{})}, nil
 }
 
 // Get implements the Store interface.
@@ -88,10 +96,15 @@ func (s *BoltDBStore) Get(key []byte) (val []byte, err error) {
 func (s *BoltDBStore) PutChangeSet(puts map[string][]byte, stores map[string][]byte) error {
 	var err error
 
+	s.snapLock.Lock()
+	defer s.snapLock.Unlock()
 	return s.db.Update(func(tx *bbolt.Tx) error {
 		b := tx.Bucket(Bucket)
 		for _, m := range []map[string][]byte{puts, stores} {
 			for k, v := range m {
+				if len(s.snaps) != 0 {
+					s.saveOld([]byte(k), b.Get([]byte(k)))
+				}
 				if v != nil {
 					err = b.Put([]byte(k), v)
 				} else {
@@ -108,8 +121,11 @@ func (s *BoltDBStore) PutChangeSet(puts map[string][]byte, stores map[string][]b
 
 // SeekGC implements the Store interface.
 func (s *BoltDBStore) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error {
+	s.snapLock.Lock()
+	defer s.snapLock.Unlock()
 	return boltSeek(s.db.Update, rng, func(c *bbolt.Cursor, k, v []byte) (bool, error) {
 		if !keep(k, v) {
+			s.saveOld(k, v)
 			if err := c.Delete(); err != nil {
 				return false, err
 			}
@@ -165,6 +181,150 @@ func boltSeek(txopener func(func(*bbolt.Tx) error) error, rng SeekRange, f func(
 	})
 }
 
+// saveOld remembers the value the key had before the change for all snapshots
+// that don't have it yet, it must be called with snapLock held before the key
+// is changed.
+func (s *BoltDBStore) saveOld(k, v []byte) {
+	for snap := range s.snaps {
+		if _, ok := snap.old[string(k)]; !ok {
+			if v != nil {
+				v = slice.Copy(v)
+			}
+			snap.old[string(k)] = v
+		}
+	}
+}
+
+// boltDBSnapshot is a StoreSnapshot implementation for BoltDBStore. BoltDB
+// can't grow its file while there is an open read-only transaction, so the
+// snapshot doesn't keep one. Instead, the store saves the old values of all
+// keys changed after the snapshot creation and the data is copied in chunks
+// using short transactions.
+type boltDBSnapshot struct {
+	store *BoltDBStore
+	// old contains the values keys had when the snapshot was taken (nil
+	// for keys that didn't exist), it's protected by the store's snapLock.
+	old map[string][]byte
+}
+
+// Snapshot implements the Snapshotter interface. Notice that the old values
+// of all keys changed after the snapshot creation are kept in memory until
+// the snapshot is released.
+func (s *BoltDBStore) Snapshot() (StoreSnapshot, error) {
+	snap := &boltDBSnapshot{store: s, old: make(map[string][]byte)}
+	s.snapLock.Lock()
+	s.snaps[snap] = struct{}{}
+	s.snapLock.Unlock()
+	return snap, nil
+}
+
+// CopyTo implements the StoreSnapshot interface. It creates a new BoltDB at
+// the given path and copies all data from the snapshot there.
+func (s *boltDBSnapshot) CopyTo(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	dst, err := NewBoltDBStore(dbconfig.BoltDBOptions{FilePath: path})
+	if err != nil {
+		return err
+	}
+	err = s.copyTo(ctx, dst.db)
+	closeErr := dst.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(path)
+	}
+	return err
+}
+
+func (s *boltDBSnapshot) copyTo(ctx context.Context, dst *bbolt.DB) error {
+	var (
+		batch = make([]KeyValue, 0, snapshotBatchSize)
+		last  []byte
+		done  bool
+	)
+	for !done {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		batch = batch[:0]
+		s.store.snapLock.RLock()
+		err := s.store.db.View(func(tx *bbolt.Tx) error {
+			var (
+				c    = tx.Bucket(Bucket).Cursor()
+				k, v []byte
+			)
+			if last == nil {
+				k, v = c.First()
+			} else if k, v = c.Seek(last); bytes.Equal(k, last) {
+				k, v = c.Next()
+			}
+			for i := 0; k != nil && i < snapshotBatchSize; k, v = c.Next() {
+				i++
+				last = slice.Copy(k)
+				if _, ok := s.old[string(k)]; ok {
+					continue // Changed, the old value is copied below.
+				}
+				batch = append(batch, KeyValue{Key: last, Value: slice.Copy(v)})
+			}
+			done = k == nil
+			return nil
+		})
+		s.store.snapLock.RUnlock()
+		if err != nil {
+			return err
+		}
+		if err := putBoltBatch(dst, batch); err != nil {
+			return err
+		}
+	}
+
+	batch = batch[:0]
+	s.store.snapLock.RLock()
+	for k, v := range s.old {
+		if v != nil {
+			batch = append(batch, KeyValue{Key: []byte(k), Value: v})
+		}
+	}
+	s.store.snapLock.RUnlock()
+	for len(batch) != 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n := len(batch)
+		if n > snapshotBatchSize {
+			n = snapshotBatchSize
+		}
+		if err := putBoltBatch(dst, batch[:n]); err != nil {
+			return err
+		}
+		batch = batch[n:]
+	}
+	return nil
+}
+
+// putBoltBatch writes the given KV pairs into the DB in a single transaction.
+func putBoltBatch(db *bbolt.DB, kvs []KeyValue) error {
+	return db.Update(func(tx *bbolt.Tx) error {
+		b := tx.Bucket(Bucket)
+		for _, kv := range kvs {
+			if err := b.Put(kv.Key, kv.Value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Release implements the StoreSnapshot interface.
+func (s *boltDBSnapshot) Release() {
+	s.store.snapLock.Lock()
+	delete(s.store.snaps, s)
+	s.store.snapLock.Unlock()
+}
+
 // Close releases all db resources.
 func (s *BoltDBStore) Close() error {
 	return s.db.Close()
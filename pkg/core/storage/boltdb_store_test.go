@@ -56,3 +56,13 @@ func TestROBoltDB(t *testing.T) {
 	require.Error(t, err)
 	require.True(t, strings.Contains(err.Error(), "root bucket does not exist"))
 }
+
+func TestBoltDBSnapshot(t *testing.T) {
+	s := newBoltStoreForTesting(t)
+	t.Cleanup(func() { require.NoError(t, s.Close()) })
+
+	path := filepath.Join(t.TempDir(), "snapshot", "test_bolt_db")
+	testStoreSnapshot(t, s, path, func() (Store, error) {
+		return NewBoltDBStore(dbconfig.BoltDBOptions{FilePath: path, ReadOnly: true})
+	})
+}
@@ -1,8 +1,10 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -119,6 +121,77 @@ func (s *LevelDBStore) seek(iter iterator.Iterator, backwards bool, f func(k, v
 	iter.Release()
 }
 
+// snapshotBatchSize is the number of KV pairs written into the snapshot DB
+// at once.
+const snapshotBatchSize = 10000
+
+// levelDBSnapshot is a StoreSnapshot implementation for LevelDBStore.
+type levelDBSnapshot struct {
+	snap *leveldb.Snapshot
+}
+
+// Snapshot implements the Snapshotter interface.
+func (s *LevelDBStore) Snapshot() (StoreSnapshot, error) {
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LevelDB snapshot: %w", err)
+	}
+	return &levelDBSnapshot{snap: snap}, nil
+}
+
+// CopyTo implements the StoreSnapshot interface. It creates a new LevelDB at
+// the given path and copies all data from the snapshot there.
+func (s *levelDBSnapshot) CopyTo(ctx context.Context, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	dst, err := leveldb.OpenFile(path, &opt.Options{
+		ErrorIfExist: true,
+		Filter:       filter.NewBloomFilter(10),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create LevelDB instance: %w", err)
+	}
+	err = copyLevelDBSnapshot(ctx, s.snap, dst)
+	closeErr := dst.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.RemoveAll(path)
+	}
+	return err
+}
+
+// Release implements the StoreSnapshot interface.
+func (s *levelDBSnapshot) Release() {
+	s.snap.Release()
+}
+
+func copyLevelDBSnapshot(ctx context.Context, snap *leveldb.Snapshot, dst *leveldb.DB) error {
+	var (
+		batch = new(leveldb.Batch)
+		iter  = snap.NewIterator(nil, nil)
+	)
+	defer iter.Release()
+	for iter.Next() {
+		batch.Put(iter.Key(), iter.Value())
+		if batch.Len() >= snapshotBatchSize {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := dst.Write(batch, nil); err != nil {
+				return err
+			}
+			batch.Reset()
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return dst.Write(batch, nil)
+}
+
 // Close implements the Store interface.
 func (s *LevelDBStore) Close() error {
 	return s.db.Close()
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
@@ -43,3 +44,13 @@ func TestROLevelDB(t *testing.T) {
 	putErr := store.PutChangeSet(map[string][]byte{"one": []byte("one")}, nil)
 	require.ErrorIs(t, putErr, leveldb.ErrReadOnly)
 }
+
+func TestLevelDBSnapshot(t *testing.T) {
+	s := newLevelDBForTesting(t)
+	t.Cleanup(func() { require.NoError(t, s.Close()) })
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	testStoreSnapshot(t, s, path, func() (Store, error) {
+		return NewLevelDBStore(dbconfig.LevelDBOptions{DataDirectoryPath: path, ReadOnly: true})
+	})
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -97,6 +98,28 @@ type (
 		Close() error
 	}
 
+	// Snapshotter is implemented by persistent Stores that can make a
+	// consistent copy of their data while being used.
+	Snapshotter interface {
+		// Snapshot returns a point-in-time view of the Store. It's cheap
+		// to get and it's not affected by subsequent Store changes.
+		Snapshot() (StoreSnapshot, error)
+	}
+
+	// StoreSnapshot is a point-in-time view of the Store returned from
+	// Snapshotter. It can be copied into a new DB of the same type while the
+	// Store is being used.
+	StoreSnapshot interface {
+		// CopyTo creates a copy of the view at the given path (DB
+		// directory or file) that can be opened as a regular DB, the path
+		// must not exist. The copy is made in chunks and it's aborted
+		// (with the path removed) when the context is canceled.
+		CopyTo(ctx context.Context, path string) error
+		// Release frees resources held by the view, it must always be
+		// called when the view is no longer needed.
+		Release()
+	}
+
 	// KeyPrefix is a constant byte added as a prefix for each key
 	// stored.
 	KeyPrefix uint8
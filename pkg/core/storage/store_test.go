@@ -1,6 +1,10 @@
 package storage
 
 import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dboper"
@@ -27,3 +31,43 @@ func TestBatchToOperations(t *testing.T) {
 	}
 	require.Equal(t, o, BatchToOperations(b))
 }
+
+// testStoreSnapshot checks Snapshotter implementation of the given Store, open
+// is used to open the snapshot created at the given path.
+func testStoreSnapshot(t *testing.T, s Store, path string, open func() (Store, error)) {
+	puts := make(map[string][]byte)
+	for i := 0; i < 2*snapshotBatchSize+1; i++ {
+		puts["key"+strconv.Itoa(i)] = []byte(strconv.Itoa(i))
+	}
+	require.NoError(t, s.PutChangeSet(puts, nil))
+	view, err := s.(Snapshotter).Snapshot()
+	require.NoError(t, err)
+
+	// Changes made after the snapshot creation are not in the snapshot.
+	require.NoError(t, s.PutChangeSet(map[string][]byte{"key0": nil, "key1": {1}, "new": {1}}, nil))
+	require.NoError(t, s.SeekGC(SeekRange{Prefix: []byte("key2")}, func(k, _ []byte) bool {
+		return string(k) != "key2"
+	}))
+
+	// Canceled copy leaves nothing behind.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, view.CopyTo(ctx, path), context.Canceled)
+	_, err = os.Stat(path)
+	require.True(t, errors.Is(err, os.ErrNotExist))
+
+	require.NoError(t, view.CopyTo(context.Background(), path))
+	require.Error(t, view.CopyTo(context.Background(), path))
+	view.Release()
+
+	snap, err := open()
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, snap.Close()) })
+
+	var actual = make(map[string][]byte)
+	snap.Seek(SeekRange{}, func(k, v []byte) bool {
+		actual[string(k)] = append([]byte{}, v...)
+		return true
+	})
+	require.Equal(t, puts, actual)
+}
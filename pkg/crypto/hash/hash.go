@@ -16,7 +16,10 @@ type Hashable interface {
 	Hash() util.Uint256
 }
 
-func getSignedData(net uint32, hh Hashable) []byte {
+// GetSignedData returns the data that is signed for the Hashable item in the
+// given network (network magic followed by the item's hash), its SHA256 hash
+// is NetSha256.
+func GetSignedData(net uint32, hh Hashable) []byte {
 	var b = make([]byte, 4+util.Uint256Size)
 	binary.LittleEndian.PutUint32(b, net)
 	h := hh.Hash()
@@ -27,7 +30,7 @@ func getSignedData(net uint32, hh Hashable) []byte {
 // NetSha256 calculates a network-specific hash of the Hashable item that can then
 // be signed/verified.
 func NetSha256(net uint32, hh Hashable) util.Uint256 {
-	return Sha256(getSignedData(net, hh))
+	return Sha256(GetSignedData(net, hh))
 }
 
 // Sha256 hashes the incoming byte slice
@@ -0,0 +1,9 @@
+/*
+Package hd implements hierarchical deterministic key derivation for Neo keys.
+
+Neo uses NIST P-256 (secp256r1) curve, so BIP-32 can't be applied directly,
+instead derivation follows the SLIP-0010 specification that generalizes
+BIP-32 for this curve. Paths are BIP-44-style strings like "m/44'/888'/0'/0/0"
+where 888 is the Neo coin type registered in SLIP-0044.
+*/
+package hd
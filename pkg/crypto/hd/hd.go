@@ -0,0 +1,155 @@
+package hd
+
+import (
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+const (
+	// HardenedKeyStart is the first hardened child index, indices starting
+	// from it can only be derived from the private key.
+	HardenedKeyStart uint32 = 0x80000000
+	// NeoCoinType is the SLIP-0044 coin type of Neo.
+	NeoCoinType uint32 = 888
+
+	// MinSeedLen is the minimum allowed seed length in bytes.
+	MinSeedLen = 16
+	// MaxSeedLen is the maximum allowed seed length in bytes.
+	MaxSeedLen = 64
+
+	// masterKeyHMAC is the SLIP-0010 curve-specific HMAC key for P-256.
+	masterKeyHMAC = "Nist256p1 seed"
+)
+
+// ErrInvalidPath is returned when derivation path can't be parsed.
+var ErrInvalidPath = errors.New("invalid derivation path")
+
+// Key is an extended private key, that is a key with a chain code allowing
+// to derive child keys from it.
+type Key struct {
+	PrivateKey *keys.PrivateKey
+	ChainCode  []byte
+}
+
+// NewMasterKey creates a master extended key from the given seed (which
+// usually is a BIP-39 seed).
+func NewMasterKey(seed []byte) (*Key, error) {
+	if len(seed) < MinSeedLen || len(seed) > MaxSeedLen {
+		return nil, fmt.Errorf("invalid seed length: %d, expected %d to %d bytes", len(seed), MinSeedLen, MaxSeedLen)
+	}
+	var (
+		n    = elliptic.P256().Params().N
+		data = seed
+	)
+	for {
+		i := hmacSHA512([]byte(masterKeyHMAC), data)
+		k := new(big.Int).SetBytes(i[:32])
+		if k.Sign() != 0 && k.Cmp(n) < 0 {
+			return newKey(i[:32], i[32:])
+		}
+		data = i
+	}
+}
+
+// Child derives a child key with the given index. Indices starting from
+// HardenedKeyStart produce hardened keys.
+func (k *Key) Child(index uint32) (*Key, error) {
+	var (
+		n    = elliptic.P256().Params().N
+		par  = k.PrivateKey.D
+		data []byte
+	)
+	if index >= HardenedKeyStart {
+		data = make([]byte, 1+32+4)
+		_ = par.FillBytes(data[1:33])
+	} else {
+		pub := k.PrivateKey.PublicKey().Bytes()
+		data = make([]byte, len(pub)+4)
+		copy(data, pub)
+	}
+	binary.BigEndian.PutUint32(data[len(data)-4:], index)
+	for {
+		i := hmacSHA512(k.ChainCode, data)
+		il := new(big.Int).SetBytes(i[:32])
+		if il.Cmp(n) < 0 {
+			il.Add(il, par)
+			il.Mod(il, n)
+			if il.Sign() != 0 {
+				return newKey(il.FillBytes(make([]byte, 32)), i[32:])
+			}
+		}
+		data = make([]byte, 1+32+4)
+		data[0] = 1
+		copy(data[1:33], i[32:])
+		binary.BigEndian.PutUint32(data[33:], index)
+	}
+}
+
+// Derive derives a key using the given path relative to k which should be
+// a master key (path starts with "m").
+func (k *Key) Derive(path string) (*Key, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	var res = k
+	for _, i := range indices {
+		res, err = res.Child(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// ParsePath parses BIP-32 derivation path like "m/44'/888'/0'/0/0" into a
+// list of child indices. Both "'" and "h" (or "H") suffixes are accepted for
+// hardened indices.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w: %q doesn't start with 'm'", ErrInvalidPath, path)
+	}
+	var res = make([]uint32, 0, len(parts)-1)
+	for _, p := range parts[1:] {
+		var hardened bool
+		if s := strings.TrimRight(p, "'hH"); len(s) == len(p)-1 {
+			hardened = true
+			p = s
+		}
+		i, err := strconv.ParseUint(p, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad element %q", ErrInvalidPath, p)
+		}
+		if hardened {
+			i += uint64(HardenedKeyStart)
+		}
+		res = append(res, uint32(i))
+	}
+	return res, nil
+}
+
+func newKey(priv []byte, chainCode []byte) (*Key, error) {
+	pk, err := keys.NewPrivateKeyFromBytes(priv)
+	if err != nil {
+		return nil, err
+	}
+	cc := make([]byte, 32)
+	copy(cc, chainCode)
+	return &Key{PrivateKey: pk, ChainCode: cc}, nil
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	h := hmac.New(sha512.New, key)
+	_, _ = h.Write(data)
+	return h.Sum(nil)
+}
@@ -45,6 +45,9 @@ var (
 	ErrUnknownScriptContainer = NewError(RPCErrorCode, "Unknown script container", "")
 	// ErrUnknownStateRoot is returned when requested state root is not found.
 	ErrUnknownStateRoot = NewError(RPCErrorCode, "Unknown state root", "")
+	// ErrAccessDenied is returned for administrative methods called without
+	// proper credentials.
+	ErrAccessDenied = NewError(RPCErrorCode, "Access denied", "")
 	// ErrAlreadyExists represents SubmitError with code -501.
 	ErrAlreadyExists = NewSubmitError(-501, "Block or transaction already exists and cannot be sent repeatedly.")
 	// ErrOutOfMemory represents SubmitError with code -502.
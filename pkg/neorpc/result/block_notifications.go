@@ -0,0 +1,18 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+)
+
+// BlockNotifications represents notifications emitted during block processing
+// grouped by the trigger type.
+type BlockNotifications struct {
+	// OnPersist contains notifications emitted by the block-level execution
+	// happening before any transactions.
+	OnPersist []state.ContainedNotificationEvent `json:"onpersist"`
+	// Application contains notifications emitted by transactions of the block.
+	Application []state.ContainedNotificationEvent `json:"application"`
+	// PostPersist contains notifications emitted by the block-level execution
+	// happening after all transactions.
+	PostPersist []state.ContainedNotificationEvent `json:"postpersist"`
+}
@@ -0,0 +1,11 @@
+package result
+
+// FindStorage represents the result of findstorage* calls, it's a page of
+// contract storage items. Next is the key (including prefix) of the first item
+// of the following page to be used as a start key in the subsequent call, it's
+// only set if Truncated is.
+type FindStorage struct {
+	Results   []KeyValue `json:"results"`
+	Next      []byte     `json:"next,omitempty"`
+	Truncated bool       `json:"truncated"`
+}
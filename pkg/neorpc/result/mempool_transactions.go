@@ -0,0 +1,89 @@
+package result
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// MempoolTransactions represents a result of getmempooltransactions RPC call.
+type MempoolTransactions struct {
+	Height uint32 `json:"height"`
+	// Count is the total number of transactions in the memory pool.
+	Count        int                  `json:"count"`
+	Transactions []MempoolTransaction `json:"transactions"`
+	// Truncated is set if there are more transactions matching the request
+	// after the ones returned.
+	Truncated bool `json:"truncated"`
+	// Sender is only present if transactions of a single sender are
+	// requested.
+	Sender *MempoolSender `json:"sender,omitempty"`
+}
+
+// MempoolTransaction is a memory pool transaction along with its priority
+// data.
+type MempoolTransaction struct {
+	transaction.Transaction
+	MempoolTransactionMetadata
+}
+
+// MempoolTransactionMetadata is an auxiliary struct for proper
+// MempoolTransaction marshaling.
+type MempoolTransactionMetadata struct {
+	// Position is the position of the transaction in the memory pool
+	// priority order, zero is the most prioritized one.
+	Position int `json:"position"`
+	// NetworkFeePerByte is the network fee per byte paid by the transaction,
+	// it's the primary sorting criterion for memory pool transactions.
+	NetworkFeePerByte int64 `json:"netfeeperbyte,string"`
+	// Conflicts contains hashes of the transactions this one conflicts with
+	// (as specified by its Conflicts attributes).
+	Conflicts []util.Uint256 `json:"conflicts"`
+}
+
+// MempoolSender contains the memory pool data for a single transaction sender.
+type MempoolSender struct {
+	// Balance is the GAS balance of the sender.
+	Balance int64 `json:"balance,string"`
+	// Fees is the sum of system and network fees of all sender's transactions
+	// in the memory pool. Transactions can't be added to the pool if this
+	// value exceeds sender's balance.
+	Fees int64 `json:"fees,string"`
+	// Count is the number of sender's transactions in the memory pool (all
+	// of them, not just the ones returned).
+	Count int `json:"count"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t MempoolTransaction) MarshalJSON() ([]byte, error) {
+	output, err := json.Marshal(t.MempoolTransactionMetadata)
+	if err != nil {
+		return nil, err
+	}
+	txBytes, err := json.Marshal(&t.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	// Transaction fields are kept at the same level as metadata ones, the
+	// same way TransactionOutputRaw does it.
+	if output[len(output)-1] != '}' || txBytes[0] != '{' {
+		return nil, errors.New("can't merge internal jsons")
+	}
+	output[len(output)-1] = ','
+	output = append(output, txBytes[1:]...)
+	return output, nil
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *MempoolTransaction) UnmarshalJSON(data []byte) error {
+	meta := new(MempoolTransactionMetadata)
+	err := json.Unmarshal(data, meta)
+	if err != nil {
+		return err
+	}
+	t.MempoolTransactionMetadata = *meta
+	return json.Unmarshal(data, &t.Transaction)
+}
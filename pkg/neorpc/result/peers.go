@@ -10,6 +10,8 @@ type (
 		Unconnected Peers `json:"unconnected"`
 		Connected   Peers `json:"connected"`
 		Bad         Peers `json:"bad"`
+		// Banned is a NeoGo extension listing peers banned for misbehavior.
+		Banned []BannedPeer `json:"banned,omitempty"`
 	}
 
 	// Peers represents a slice of peers.
@@ -20,6 +22,16 @@ type (
 		Address string `json:"address"`
 		Port    string `json:"port"`
 	}
+
+	// BannedPeer represents a banned peer.
+	BannedPeer struct {
+		// Address is the banned IP address.
+		Address string `json:"address"`
+		// Until is the ban expiration time (Unix timestamp in milliseconds).
+		Until uint64 `json:"until"`
+		// Reason is the human-readable ban reason.
+		Reason string `json:"reason"`
+	}
 )
 
 // NewGetPeers creates a new GetPeers structure.
@@ -0,0 +1,9 @@
+package result
+
+// Snapshot represents the result of createsnapshot call.
+type Snapshot struct {
+	// Height is the latest block stored in the snapshot.
+	Height uint32 `json:"height"`
+	// Path is the snapshot location on the node's filesystem.
+	Path string `json:"path"`
+}
@@ -34,13 +34,24 @@ type Executor struct {
 	Committee     Signer
 	CommitteeHash util.Uint160
 	Contracts     map[string]*Contract
+
+	// validateNotifications enables notification checks, see
+	// EnableNotificationValidation.
+	validateNotifications bool
 }
 
 // NewExecutor creates a new executor instance from the provided blockchain and committee.
+// If coverage collection is enabled (see CoverProfileEnv), it also sets up the
+// chain for it and writes coverage profile at the end of the test.
 func NewExecutor(t testing.TB, bc *core.Blockchain, validator, committee Signer) *Executor {
 	checkMultiSigner(t, validator)
 	checkMultiSigner(t, committee)
 
+	if isCoverageEnabled() {
+		bc.SetOnExecHook(coverageHook)
+		t.Cleanup(func() { reportCoverage(t) })
+	}
+
 	return &Executor{
 		Chain:         bc,
 		Validator:     validator,
@@ -330,7 +341,14 @@ func (e *Executor) NewUnsignedBlock(t testing.TB, txs ...*transaction.Transactio
 func (e *Executor) AddNewBlock(t testing.TB, txs ...*transaction.Transaction) *block.Block {
 	b := e.NewUnsignedBlock(t, txs...)
 	e.SignBlock(b)
+	var states map[util.Uint160][]byte
+	if e.validateNotifications {
+		states = e.contractStates()
+	}
 	require.NoError(t, e.Chain.AddBlock(b))
+	if e.validateNotifications {
+		e.checkNotifications(t, b, states)
+	}
 	return b
 }
 
@@ -19,6 +19,10 @@ type Contract struct {
 	Hash     util.Uint160
 	NEF      *nef.File
 	Manifest *manifest.Manifest
+	// DebugInfo is only available for contracts compiled with CompileFile
+	// or CompileSource, it's used for coverage collection (see
+	// CoverProfileEnv).
+	DebugInfo *compiler.DebugInfo
 }
 
 // contracts caches the compiled contracts from FS across multiple tests.
@@ -35,11 +39,14 @@ func CompileSource(t testing.TB, sender util.Uint160, src io.Reader, opts *compi
 	m, err := compiler.CreateManifest(di, opts)
 	require.NoError(t, err)
 
-	return &Contract{
-		Hash:     state.CreateContractHash(sender, ne.Checksum, m.Name),
-		NEF:      ne,
-		Manifest: m,
+	c := &Contract{
+		Hash:      state.CreateContractHash(sender, ne.Checksum, m.Name),
+		NEF:       ne,
+		Manifest:  m,
+		DebugInfo: di,
 	}
+	addScriptToCoverage(c)
+	return c
 }
 
 // CompileFile compiles a contract from the file and returns its NEF, manifest and hash.
@@ -72,10 +79,12 @@ func CompileFile(t testing.TB, sender util.Uint160, srcPath string, configPath s
 	require.NoError(t, err)
 
 	c := &Contract{
-		Hash:     state.CreateContractHash(sender, ne.Checksum, m.Name),
-		NEF:      ne,
-		Manifest: m,
+		Hash:      state.CreateContractHash(sender, ne.Checksum, m.Name),
+		NEF:       ne,
+		Manifest:  m,
+		DebugInfo: di,
 	}
+	addScriptToCoverage(c)
 	contracts[srcPath] = c
 	return c
 }
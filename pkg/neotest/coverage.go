@@ -0,0 +1,176 @@
+package neotest
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+// CoverProfileEnv is the name of environment variable that enables contract
+// code coverage collection. It should contain a path to the file where
+// coverage profile in the standard Go format is written, so that it can be
+// processed with `go tool cover` (like `go tool cover -html=<file>`). Only
+// contracts compiled with CompileFile or CompileSource are tracked. The
+// profile is rewritten (with all the data collected so far) after every test
+// that creates an Executor, so use different files for different packages if
+// they're tested in parallel.
+const CoverProfileEnv = "NEOTEST_COVERPROFILE"
+
+// coverage stores raw coverage data for all tracked contracts.
+var coverage = struct {
+	lock    sync.Mutex
+	scripts map[util.Uint160]*scriptCoverage
+}{
+	scripts: make(map[util.Uint160]*scriptCoverage),
+}
+
+// scriptCoverage is a set of executed instructions of the contract along with
+// its debug info.
+type scriptCoverage struct {
+	debugInfo *compiler.DebugInfo
+	visited   map[int]bool
+}
+
+// coverBlock is a single source code block of the coverage profile.
+type coverBlock struct {
+	file      string
+	startLine int
+	startCol  int
+	endLine   int
+	endCol    int
+	covered   bool
+}
+
+// isCoverageEnabled checks whether contract coverage collection is enabled.
+func isCoverageEnabled() bool {
+	return os.Getenv(CoverProfileEnv) != ""
+}
+
+// addScriptToCoverage starts tracking the given contract if coverage
+// collection is enabled.
+func addScriptToCoverage(c *Contract) {
+	if !isCoverageEnabled() || c.DebugInfo == nil {
+		return
+	}
+	coverage.lock.Lock()
+	defer coverage.lock.Unlock()
+	if _, ok := coverage.scripts[c.Hash]; !ok {
+		coverage.scripts[c.Hash] = &scriptCoverage{
+			debugInfo: c.DebugInfo,
+			visited:   make(map[int]bool),
+		}
+	}
+}
+
+// coverageHook is a VM hook marking executed instructions of tracked
+// contracts.
+func coverageHook(scriptHash util.Uint160, offset int, _ opcode.Opcode) {
+	coverage.lock.Lock()
+	defer coverage.lock.Unlock()
+	if cov, ok := coverage.scripts[scriptHash]; ok {
+		cov.visited[offset] = true
+	}
+}
+
+// reportCoverage writes coverage profile to the file specified by
+// CoverProfileEnv.
+func reportCoverage(t testing.TB) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeCoverProfile(buf))
+	require.NoError(t, os.WriteFile(os.Getenv(CoverProfileEnv), buf.Bytes(), 0644))
+}
+
+// writeCoverProfile writes coverage profile for all tracked contracts to w.
+// Sequence points are used as statements, the statement is considered to be
+// covered if its first instruction was executed. Only "set" mode is supported
+// since contracts can be executed for fee estimations as well.
+func writeCoverProfile(w io.Writer) error {
+	type blockKey struct {
+		file                                 string
+		startLine, startCol, endLine, endCol int
+	}
+	var (
+		blocks  = make(map[blockKey]*coverBlock)
+		absDocs = make(map[string]string)
+	)
+
+	coverage.lock.Lock()
+	for _, cov := range coverage.scripts {
+		for _, m := range cov.debugInfo.Methods {
+			for _, sp := range m.SeqPoints {
+				if sp.Document < 0 || sp.Document >= len(cov.debugInfo.Documents) {
+					continue
+				}
+				doc := cov.debugInfo.Documents[sp.Document]
+				file, ok := absDocs[doc]
+				if !ok {
+					file = doc
+					if abs, err := filepath.Abs(doc); err == nil {
+						file = abs
+					}
+					absDocs[doc] = file
+				}
+				k := blockKey{file, sp.StartLine, sp.StartCol, sp.EndLine, sp.EndCol}
+				b, ok := blocks[k]
+				if !ok {
+					b = &coverBlock{
+						file:      file,
+						startLine: sp.StartLine,
+						startCol:  sp.StartCol,
+						endLine:   sp.EndLine,
+						endCol:    sp.EndCol,
+					}
+					blocks[k] = b
+				}
+				b.covered = b.covered || cov.visited[sp.Opcode]
+			}
+		}
+	}
+	coverage.lock.Unlock()
+
+	var res = make([]*coverBlock, 0, len(blocks))
+	for _, b := range blocks {
+		res = append(res, b)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].file != res[j].file {
+			return res[i].file < res[j].file
+		}
+		if res[i].startLine != res[j].startLine {
+			return res[i].startLine < res[j].startLine
+		}
+		if res[i].startCol != res[j].startCol {
+			return res[i].startCol < res[j].startCol
+		}
+		if res[i].endLine != res[j].endLine {
+			return res[i].endLine < res[j].endLine
+		}
+		return res[i].endCol < res[j].endCol
+	})
+
+	if _, err := fmt.Fprintln(w, "mode: set"); err != nil {
+		return err
+	}
+	for _, b := range res {
+		var count int
+		if b.covered {
+			count = 1
+		}
+		_, err := fmt.Fprintf(w, "%s:%d.%d,%d.%d 1 %d\n", b.file,
+			b.startLine, b.startCol, b.endLine, b.endCol, count)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
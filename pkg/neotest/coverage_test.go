@@ -0,0 +1,46 @@
+package neotest_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoverProfile(t *testing.T) {
+	src := "package foo\n" +
+		"func Main(a int) int {\n" +
+		"\tif a > 0 {\n" +
+		"\t\treturn 1\n" +
+		"\t}\n" +
+		"\treturn 2\n" +
+		"}\n"
+	profile := filepath.Join(t.TempDir(), "cover.out")
+	t.Setenv(neotest.CoverProfileEnv, profile)
+
+	t.Run("invoke", func(t *testing.T) {
+		bc, acc := chain.NewSingle(t)
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		c := neotest.CompileSource(t, e.Validator.ScriptHash(), strings.NewReader(src), &compiler.Options{Name: "Coverage"})
+		e.DeployContract(t, c, nil)
+
+		inv := e.CommitteeInvoker(c.Hash)
+		inv.Invoke(t, 1, "main", 5)
+		_, err := inv.TestInvoke(t, "main", 6)
+		require.NoError(t, err)
+	})
+
+	// Profile is written when the test above finishes.
+	data, err := os.ReadFile(profile)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Equal(t, 3, len(lines), string(data))
+	require.Equal(t, "mode: set", lines[0])
+	require.True(t, strings.HasSuffix(lines[1], "contract.go:4.3,4.11 1 1"), lines[1])
+	require.True(t, strings.HasSuffix(lines[2], "contract.go:6.2,6.10 1 0"), lines[2])
+}
@@ -15,5 +15,18 @@ Usually it's used like this:
 Higher-order methods provided in Executor and ContractInvoker hide the details
 of transaction creation for the most part, but there are lower-level methods as
 well that can be used for specific tasks.
+
+It's also possible to collect code coverage for contracts compiled with
+Compile* functions. Set NEOTEST_COVERPROFILE environment variable (see
+CoverProfileEnv) to the profile file path when running tests and then use the
+standard Go tooling to inspect it:
+
+	NEOTEST_COVERPROFILE=contract.out go test ./...
+	go tool cover -html=contract.out
+
+Notifications emitted by contracts can be validated against their manifests
+with EnableNotificationValidation, every event that is not declared in the
+emitting contract's ABI at the moment of emission (or has parameters not
+matching the declaration) fails the test then.
 */
 package neotest
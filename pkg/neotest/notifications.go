@@ -0,0 +1,233 @@
+package neotest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+// EnableNotificationValidation makes Executor (and all invokers created from
+// it) check every notification emitted during the execution of blocks added
+// via AddNewBlock (and thus any higher-level method that adds blocks) against
+// the emitting contract's manifest. Notifications are checked the same way
+// runtime.Notify does it (see manifest.Event.CheckCompliance), so Null values
+// are only accepted for parameter types that allow them. Every notification is
+// checked against the manifest in force at the moment it was emitted, so
+// contracts updated in the same block are handled properly. The test fails if
+// the event is not declared in the manifest or if the number of its parameters
+// or their types don't match the declaration, the failure message contains
+// the difference for every parameter. Notifications emitted by a contract in
+// the transaction updating it pass if they comply with either the old or the
+// new manifest. Notifications emitted between several updates of the same
+// contract in one block (or after its destruction) can't be checked and are
+// skipped.
+func (e *Executor) EnableNotificationValidation() {
+	e.validateNotifications = true
+}
+
+// contractStates returns serialized states of all deployed non-native
+// contracts, it's used to get manifests that were in force before the block.
+func (e *Executor) contractStates() map[util.Uint160][]byte {
+	var res = make(map[util.Uint160][]byte)
+	e.Chain.SeekStorage(native.ManagementContractID, storage.SeekRange{Prefix: []byte{native.PrefixContract}}, func(k, v []byte) bool {
+		h, err := util.Uint160DecodeBytesBE(k)
+		if err == nil {
+			res[h] = append([]byte{}, v...)
+		}
+		return true
+	})
+	return res
+}
+
+// checkNotifications validates notifications of all executions of the block
+// against the manifests of contracts emitting them. states are serialized
+// contract states before the block was processed (see contractStates).
+func (e *Executor) checkNotifications(t testing.TB, b *block.Block, states map[util.Uint160][]byte) {
+	aers, err := e.Chain.GetAppExecResults(b.Hash(), trigger.OnPersist)
+	require.NoError(t, err)
+	for _, tx := range b.Transactions {
+		res, err := e.Chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		require.NoError(t, err)
+		aers = append(aers, res...)
+	}
+	res, err := e.Chain.GetAppExecResults(b.Hash(), trigger.PostPersist)
+	require.NoError(t, err)
+	aers = append(aers, res...)
+
+	var (
+		mgmt      = e.NativeHash(t, nativenames.Management)
+		changes   = make(map[util.Uint160]int)
+		manifests = make(map[util.Uint160]*manifest.Manifest)
+		errs      []string
+	)
+	// Count contract changes to know which one is the last in the block,
+	// manifests between the first and the last one are not known.
+	for _, aer := range aers {
+		for _, ev := range aer.Events {
+			if h, ok := contractChange(mgmt, ev); ok {
+				changes[h]++
+			}
+		}
+	}
+	getManifest := func(h util.Uint160) *manifest.Manifest {
+		if m, ok := manifests[h]; ok {
+			return m
+		}
+		var m *manifest.Manifest
+		if v, ok := states[h]; ok {
+			var cs = new(state.Contract)
+			if stackitem.DeserializeConvertible(v, cs) == nil {
+				m = &cs.Manifest
+			}
+		} else if cs := e.Chain.GetContractState(h); cs != nil && changes[h] == 0 {
+			// Native contract or the one unaffected by this block.
+			m = &cs.Manifest
+		}
+		manifests[h] = m
+		return m
+	}
+	for _, aer := range aers {
+		for i, ev := range aer.Events {
+			var candidates []*manifest.Manifest
+			if m := getManifest(ev.ScriptHash); m != nil {
+				candidates = append(candidates, m)
+			}
+			// _deploy method is executed with the new manifest before the
+			// change is announced, but the contract could also emit events
+			// with the old one before calling update.
+			if name, ok := nextChange(mgmt, aer.Events[i+1:], ev.ScriptHash); ok &&
+				name != "Destroy" && changes[ev.ScriptHash] == 1 {
+				if cs := e.Chain.GetContractState(ev.ScriptHash); cs != nil {
+					candidates = append(candidates, &cs.Manifest)
+				}
+			}
+			var err error
+			for _, m := range candidates {
+				if err = checkNotification(m, ev); err == nil {
+					break
+				}
+			}
+			if err != nil {
+				var container = "transaction " + aer.Container.StringLE()
+				if aer.Trigger != trigger.Application {
+					container = fmt.Sprintf("block %d %s", b.Index, aer.Trigger)
+				}
+				errs = append(errs, fmt.Sprintf("%s, notification #%d: %s", container, i, err))
+			}
+			h, ok := contractChange(mgmt, ev)
+			if !ok {
+				continue
+			}
+			changes[h]--
+			manifests[h] = nil
+			if changes[h] == 0 && ev.Name != "Destroy" {
+				if cs := e.Chain.GetContractState(h); cs != nil {
+					manifests[h] = &cs.Manifest
+				}
+			}
+		}
+	}
+	if len(errs) != 0 {
+		require.Fail(t, "notifications don't match contract manifests", strings.Join(errs, "\n"))
+	}
+}
+
+// contractChange returns the hash of the contract deployed, updated or
+// destroyed if the notification is emitted by ContractManagement for that.
+func contractChange(mgmt util.Uint160, ev state.NotificationEvent) (util.Uint160, bool) {
+	if !ev.ScriptHash.Equals(mgmt) || (ev.Name != "Deploy" && ev.Name != "Update" && ev.Name != "Destroy") {
+		return util.Uint160{}, false
+	}
+	arr, ok := ev.Item.Value().([]stackitem.Item)
+	if !ok || len(arr) != 1 {
+		return util.Uint160{}, false
+	}
+	b, err := arr[0].TryBytes()
+	if err != nil {
+		return util.Uint160{}, false
+	}
+	h, err := util.Uint160DecodeBytesBE(b)
+	return h, err == nil
+}
+
+// nextChange returns the name of the first ContractManagement notification
+// about the change of the given contract among events.
+func nextChange(mgmt util.Uint160, events []state.NotificationEvent, h util.Uint160) (string, bool) {
+	for _, ev := range events {
+		if changed, ok := contractChange(mgmt, ev); ok && changed.Equals(h) {
+			return ev.Name, true
+		}
+	}
+	return "", false
+}
+
+// checkNotification validates a single notification against the manifest of
+// its emitter.
+func checkNotification(m *manifest.Manifest, ev state.NotificationEvent) error {
+	var (
+		emitter = fmt.Sprintf("%s (%s)", m.Name, ev.ScriptHash.StringLE())
+		decl    = m.ABI.GetEvent(ev.Name)
+	)
+	if decl == nil {
+		names := make([]string, 0, len(m.ABI.Events))
+		for _, d := range m.ABI.Events {
+			names = append(names, fmt.Sprintf("%q", d.Name))
+		}
+		return fmt.Errorf("contract %s emitted undeclared event %q, declared events: [%s]",
+			emitter, ev.Name, strings.Join(names, ", "))
+	}
+	items := ev.Item.Value().([]stackitem.Item)
+	if decl.CheckCompliance(items) == nil {
+		return nil
+	}
+	return fmt.Errorf("event %q of contract %s doesn't match the manifest:\n%s",
+		ev.Name, emitter, strings.Join(diffEventParameters(decl.Parameters, items), "\n"))
+}
+
+// diffEventParameters compares notification items with the declared event
+// parameters, it returns one line for every mismatch.
+func diffEventParameters(params []manifest.Parameter, items []stackitem.Item) []string {
+	var diff []string
+	if len(params) != len(items) {
+		diff = append(diff, fmt.Sprintf("\texpected %d parameters, got %d", len(params), len(items)))
+	}
+	for i := 0; i < len(params) || i < len(items); i++ {
+		switch {
+		case i >= len(items):
+			diff = append(diff, fmt.Sprintf("\t- #%d %s (%s): missing", i, params[i].Name, params[i].Type))
+		case i >= len(params):
+			diff = append(diff, fmt.Sprintf("\t+ #%d: unexpected %s", i, describeItem(items[i])))
+		case !params[i].Type.Match(items[i]):
+			diff = append(diff, fmt.Sprintf("\t~ #%d %s: expected %s, got %s", i, params[i].Name, params[i].Type, describeItem(items[i])))
+		}
+	}
+	return diff
+}
+
+// describeItem returns a short human-readable description of the item.
+func describeItem(item stackitem.Item) string {
+	switch item.Type() {
+	case stackitem.ByteArrayT, stackitem.BufferT:
+		b, _ := item.TryBytes()
+		return fmt.Sprintf("%s of %d bytes", item.Type(), len(b))
+	case stackitem.BooleanT, stackitem.IntegerT:
+		return fmt.Sprintf("%s (%v)", item.Type(), item.Value())
+	case stackitem.ArrayT, stackitem.StructT:
+		return fmt.Sprintf("%s of %d elements", item.Type(), len(item.Value().([]stackitem.Item)))
+	case stackitem.AnyT:
+		return "Null"
+	default:
+		return item.Type().String()
+	}
+}
@@ -0,0 +1,145 @@
+package neotest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+// failRecorder is a testing.TB that records failures instead of failing the
+// test.
+type failRecorder struct {
+	testing.TB
+	errs []string
+}
+
+func (f *failRecorder) Errorf(format string, args ...interface{}) {
+	f.errs = append(f.errs, fmt.Sprintf(format, args...))
+}
+
+func (f *failRecorder) FailNow() {}
+
+func TestNotificationValidation(t *testing.T) {
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		)
+		func _deploy(_ interface{}, isUpdate bool) {
+			if isUpdate {
+				runtime.Notify("Unknown")
+			}
+		}
+		func Update(script, manifest []byte) {
+			management.Update(script, manifest)
+		}
+		func Good() {
+			runtime.Notify("Transfer", nil, interop.Hash160(make([]byte, 20)), 1)
+		}
+		func BadType() {
+			runtime.Notify("Transfer", []byte{1, 2, 3}, interop.Hash160(make([]byte, 20)), "one")
+		}
+		func BadNull() {
+			runtime.Notify("Transfer", nil, nil, nil)
+		}
+		func BadArity() {
+			runtime.Notify("Transfer", 1)
+		}
+		func Undeclared() {
+			runtime.Notify("Unknown")
+		}`
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	e.EnableNotificationValidation()
+	compile := func(events ...manifest.Event) *neotest.Contract {
+		return neotest.CompileSource(t, e.Validator.ScriptHash(), strings.NewReader(src), &compiler.Options{
+			Name:           "Events",
+			NoEventsCheck:  true,
+			ContractEvents: events,
+			Permissions:    []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+		})
+	}
+	c := compile(manifest.Event{
+		Name: "Transfer",
+		Parameters: []manifest.Parameter{
+			manifest.NewParameter("from", smartcontract.Hash160Type),
+			manifest.NewParameter("to", smartcontract.Hash160Type),
+			manifest.NewParameter("amount", smartcontract.IntegerType),
+		},
+	})
+	// Deployment emits native contract notifications which are valid.
+	e.DeployContract(t, c, nil)
+	inv := e.CommitteeInvoker(c.Hash)
+	inv.Invoke(t, nil, "good")
+
+	check := func(t *testing.T, method string, expected ...string) {
+		r := &failRecorder{TB: t}
+		tx := inv.PrepareInvoke(t, method)
+		e.AddNewBlock(r, tx)
+		e.CheckHalt(t, tx.Hash())
+		require.Equal(t, 1, len(r.errs))
+		require.Contains(t, r.errs[0], "transaction "+tx.Hash().StringLE()+", notification #0")
+		for _, s := range expected {
+			require.Contains(t, r.errs[0], s)
+		}
+	}
+	t.Run("type mismatch", func(t *testing.T) {
+		check(t, "badType",
+			`event "Transfer" of contract Events (`+c.Hash.StringLE()+`) doesn't match the manifest`,
+			"~ #0 from: expected Hash160, got ByteString of 3 bytes",
+			"~ #2 amount: expected Integer, got ByteString of 3 bytes")
+	})
+	t.Run("null mismatch", func(t *testing.T) {
+		check(t, "badNull", "~ #2 amount: expected Integer, got Null")
+	})
+	t.Run("arity mismatch", func(t *testing.T) {
+		check(t, "badArity",
+			"expected 3 parameters, got 1",
+			"~ #0 from: expected Hash160, got Integer (1)",
+			"- #1 to (Hash160): missing",
+			"- #2 amount (Integer): missing")
+	})
+	t.Run("undeclared", func(t *testing.T) {
+		check(t, "undeclared", `emitted undeclared event "Unknown", declared events: ["Transfer"]`)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		r := &failRecorder{TB: t}
+		tx := e.NewInvoker(c.Hash, acc).PrepareInvoke(t, "undeclared")
+		e.AddNewBlock(r, tx)
+		e.CheckHalt(t, tx.Hash())
+		require.Equal(t, 0, len(r.errs))
+	})
+	t.Run("update", func(t *testing.T) {
+		updated := compile(manifest.Event{Name: "Unknown"})
+		rawManifest, err := json.Marshal(updated.Manifest)
+		require.NoError(t, err)
+		rawNef, err := updated.NEF.Bytes()
+		require.NoError(t, err)
+
+		// Every notification is checked against the manifest in force
+		// at the moment of emission, _deploy uses the new one.
+		r := &failRecorder{TB: t}
+		txs := []*transaction.Transaction{
+			inv.PrepareInvoke(t, "good"),
+			inv.PrepareInvoke(t, "update", rawNef, rawManifest),
+			inv.PrepareInvoke(t, "undeclared"),
+		}
+		e.AddNewBlock(r, txs...)
+		e.CheckHalt(t, txs[1].Hash())
+		e.CheckHalt(t, txs[2].Hash())
+		require.Equal(t, 0, len(r.errs))
+
+		check(t, "good", `emitted undeclared event "Transfer", declared events: ["Unknown"]`)
+	})
+}
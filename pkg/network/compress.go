@@ -5,6 +5,7 @@ import (
 	"errors"
 
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/network/zstd"
 	"github.com/pierrec/lz4"
 )
 
@@ -38,3 +39,13 @@ func decompress(source []byte) ([]byte, error) {
 	}
 	return dest, nil
 }
+
+// compressZstd compresses bytes using zstd.
+func compressZstd(source []byte) []byte {
+	return zstd.Compress(source)
+}
+
+// decompressZstd decompresses bytes using zstd.
+func decompressZstd(source []byte) ([]byte, error) {
+	return zstd.Decompress(source, payload.MaxSize)
+}
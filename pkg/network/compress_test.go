@@ -0,0 +1,180 @@
+package network
+
+import (
+	"os"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// mptDataBatchSize is the size of MPTData payloads used for benchmarks, it's
+// close to the size of responses to partial state requests.
+const mptDataBatchSize = 64 * 1024
+
+// getCompressionBenchPayloads returns serialized block payloads of the chain
+// used in RPC server tests (see core.TestCreateBasicChain) and serialized
+// MPTData payloads containing the state of this chain.
+func getCompressionBenchPayloads(b *testing.B) ([][]byte, [][]byte) {
+	f, err := os.Open("../services/rpcsrv/testdata/testblocks.acc")
+	require.NoError(b, err)
+	defer f.Close()
+
+	cfg, err := config.Load("../../config", netmode.UnitTestNet)
+	require.NoError(b, err)
+	chain, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.Blockchain(), zap.NewNop())
+	require.NoError(b, err)
+	go chain.Run()
+	defer chain.Close()
+
+	var (
+		br      = io.NewBinReaderFromIO(f)
+		nBlocks = br.ReadU32LE()
+		blocks  [][]byte
+	)
+	require.NoError(b, br.Err)
+	for i := 0; i < int(nBlocks); i++ {
+		_ = br.ReadU32LE()
+		blk := block.New(false)
+		blk.DecodeBinary(br)
+		require.NoError(b, br.Err)
+		require.NoError(b, chain.AddBlock(blk))
+		w := io.NewBufBinWriter()
+		blk.EncodeBinary(w.BinWriter)
+		require.NoError(b, w.Err)
+		blocks = append(blocks, w.Bytes())
+	}
+
+	var (
+		mptData [][]byte
+		batch   payload.MPTData
+		size    int
+		flush   = func() {
+			w := io.NewBufBinWriter()
+			batch.EncodeBinary(w.BinWriter)
+			require.NoError(b, w.Err)
+			mptData = append(mptData, w.Bytes())
+			batch.Nodes, size = nil, 0
+		}
+	)
+	root := chain.GetStateModule().CurrentLocalStateRoot()
+	err = chain.GetStateSyncModule().Traverse(root, func(_ mpt.Node, node []byte) bool {
+		batch.Nodes = append(batch.Nodes, node)
+		size += io.GetVarSize(node)
+		if size >= mptDataBatchSize {
+			flush()
+		}
+		return false
+	})
+	require.NoError(b, err)
+	if len(batch.Nodes) > 0 {
+		flush()
+	}
+	return blocks, mptData
+}
+
+// BenchmarkCompressionRatio compares LZ4 and zstd compression of real block and
+// MPTData payloads, "ratio" metric is the size of compressed payloads relative
+// to the original one (payloads not exceeding CompressionMinSize are not
+// compressed, the same way they're sent to peers).
+func BenchmarkCompressionRatio(b *testing.B) {
+	blocks, mptData := getCompressionBenchPayloads(b)
+	algos := map[string]func([]byte) []byte{
+		"lz4": func(data []byte) []byte {
+			c, err := compress(data)
+			require.NoError(b, err)
+			return c
+		},
+		"zstd": compressZstd,
+	}
+	for dataName, payloads := range map[string][][]byte{
+		"blocks":  blocks,
+		"mptdata": mptData,
+	} {
+		var total int
+		for _, p := range payloads {
+			total += len(p)
+		}
+		for algoName, compressFunc := range algos {
+			b.Run(dataName+"/"+algoName, func(b *testing.B) {
+				var compressed int
+				b.SetBytes(int64(total))
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					compressed = 0
+					for _, p := range payloads {
+						if len(p) > CompressionMinSize {
+							compressed += len(compressFunc(p))
+						} else {
+							compressed += len(p)
+						}
+					}
+				}
+				b.ReportMetric(float64(compressed)/float64(total), "ratio")
+			})
+		}
+	}
+}
+
+// BenchmarkDecompression measures LZ4 and zstd decompression speed for real
+// block and MPTData payloads.
+func BenchmarkDecompression(b *testing.B) {
+	blocks, mptData := getCompressionBenchPayloads(b)
+	type algo struct {
+		compress   func([]byte) []byte
+		decompress func([]byte) ([]byte, error)
+	}
+	algos := map[string]algo{
+		"lz4": {
+			compress: func(data []byte) []byte {
+				c, err := compress(data)
+				require.NoError(b, err)
+				return c
+			},
+			decompress: decompress,
+		},
+		"zstd": {
+			compress:   compressZstd,
+			decompress: decompressZstd,
+		},
+	}
+	for dataName, payloads := range map[string][][]byte{
+		"blocks":  blocks,
+		"mptdata": mptData,
+	} {
+		for algoName, a := range algos {
+			var (
+				compressed [][]byte
+				total      int
+			)
+			for _, p := range payloads {
+				if len(p) > CompressionMinSize {
+					compressed = append(compressed, a.compress(p))
+					total += len(p)
+				}
+			}
+			b.Run(dataName+"/"+algoName, func(b *testing.B) {
+				b.SetBytes(int64(total))
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					for _, c := range compressed {
+						_, err := a.decompress(c)
+						if err != nil {
+							b.Fatal(err)
+						}
+					}
+				}
+			})
+		}
+	}
+}
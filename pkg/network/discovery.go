@@ -23,11 +23,13 @@ var (
 // Discoverer is an interface that is responsible for maintaining
 // a healthy connection pool.
 type Discoverer interface {
+	AddPeer(string)
 	BackFill(...string)
 	GetFanOut() int
 	NetworkSize() int
 	PoolCount() int
 	RequestRemote(int)
+	RemovePeer(string)
 	RegisterSelf(AddressablePeer)
 	RegisterGood(AddressablePeer)
 	RegisterConnected(AddressablePeer)
@@ -59,6 +61,7 @@ type DefaultDiscovery struct {
 	optimalFanOut    int32
 	networkSize      int32
 	requestCh        chan int
+	reputation       *reputation
 }
 
 // NewDefaultDiscovery returns a new DefaultDiscovery.
@@ -82,8 +85,10 @@ func NewDefaultDiscovery(addrs []string, dt time.Duration, ts Transporter) *Defa
 	return d
 }
 
-func newDefaultDiscovery(addrs []string, dt time.Duration, ts Transporter) Discoverer {
-	return NewDefaultDiscovery(addrs, dt, ts)
+func newDefaultDiscovery(addrs []string, dt time.Duration, ts Transporter, rep *reputation) Discoverer {
+	d := NewDefaultDiscovery(addrs, dt, ts)
+	d.reputation = rep
+	return d
 }
 
 // BackFill implements the Discoverer interface and will backfill
@@ -97,7 +102,7 @@ func (d *DefaultDiscovery) BackFill(addrs ...string) {
 func (d *DefaultDiscovery) backfill(addrs ...string) {
 	for _, addr := range addrs {
 		if d.badAddrs[addr] || d.connectedAddrs[addr] || d.handshakedAddrs[addr] ||
-			d.unconnectedAddrs[addr] > 0 {
+			d.unconnectedAddrs[addr] > 0 || d.reputation.isBanned(addr) {
 			continue
 		}
 		d.pushToPoolOrDrop(addr)
@@ -105,6 +110,37 @@ func (d *DefaultDiscovery) backfill(addrs ...string) {
 	d.updateNetSize()
 }
 
+// AddPeer implements the Discoverer interface, it adds the given address to
+// the seed list (so that the node reconnects to it when the connection is
+// lost) and tries to connect to it.
+func (d *DefaultDiscovery) AddPeer(addr string) {
+	d.lock.Lock()
+	if ip, ok := d.seeds[addr]; (ok && ip != "") || d.attempted[addr] || d.connectedAddrs[addr] {
+		d.lock.Unlock()
+		return
+	}
+	d.seeds[addr] = ""
+	delete(d.badAddrs, addr)
+	delete(d.unconnectedAddrs, addr)
+	d.attempted[addr] = true
+	d.updateNetSize()
+	d.lock.Unlock()
+	atomic.AddInt32(&d.outstanding, 1)
+	go d.tryAddress(addr)
+}
+
+// RemovePeer implements the Discoverer interface, it removes the given
+// address from the seed list and from the set of known addresses, so it's
+// not reconnected to (until it's received from some other peer again).
+func (d *DefaultDiscovery) RemovePeer(addr string) {
+	d.lock.Lock()
+	delete(d.seeds, addr)
+	delete(d.unconnectedAddrs, addr)
+	delete(d.goodAddrs, addr)
+	d.updateNetSize()
+	d.lock.Unlock()
+}
+
 // PoolCount returns the number of the available node addresses.
 func (d *DefaultDiscovery) PoolCount() int {
 	d.lock.RLock()
@@ -124,24 +160,43 @@ func (d *DefaultDiscovery) pushToPoolOrDrop(addr string) {
 	}
 }
 
-// RequestRemote tries to establish a connection with n nodes.
+// RequestRemote tries to establish a connection with n nodes. Addresses of
+// peers with better reputation are preferred, banned ones are never used.
 func (d *DefaultDiscovery) RequestRemote(requested int) {
 	outstanding := int(atomic.LoadInt32(&d.outstanding))
 	requested -= outstanding
 	for ; requested > 0; requested-- {
-		var nextAddr string
+		var (
+			nextAddr  string
+			nextScore int
+			dropped   bool
+		)
 		d.lock.Lock()
 		for addr := range d.unconnectedAddrs {
-			if !d.connectedAddrs[addr] && !d.handshakedAddrs[addr] && !d.attempted[addr] {
-				nextAddr = addr
-				break
+			if d.connectedAddrs[addr] || d.handshakedAddrs[addr] || d.attempted[addr] {
+				continue
 			}
+			if d.reputation.isBanned(addr) {
+				delete(d.unconnectedAddrs, addr)
+				dropped = true
+				continue
+			}
+			score := d.reputation.score(addr)
+			if nextAddr == "" || score < nextScore {
+				nextAddr, nextScore = addr, score
+				if score == 0 {
+					break
+				}
+			}
+		}
+		if dropped {
+			d.updateNetSize()
 		}
 
 		if nextAddr == "" {
 			// Empty pool, try seeds.
 			for addr, ip := range d.seeds {
-				if ip == "" && !d.attempted[addr] {
+				if ip == "" && !d.attempted[addr] && !d.reputation.isBanned(addr) {
 					nextAddr = addr
 					break
 				}
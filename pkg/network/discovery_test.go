@@ -233,3 +233,72 @@ func TestSeedDiscovery(t *testing.T) {
 		}
 	}
 }
+
+func TestDiscoveryReputation(t *testing.T) {
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	rep, err := newReputation(defaultBanScore, time.Hour, nil, "", nil)
+	require.NoError(t, err)
+	d := newDefaultDiscovery([]string{"4.4.4.4:10333"}, time.Second/16, ts, rep).(*DefaultDiscovery)
+	tryMaxWait = 1 // Don't waste time.
+
+	rep.ban("1.1.1.1", 0, "test")
+	rep.ban("4.4.4.4", 0, "test")
+	require.False(t, rep.misbehave("2.2.2.2:20333", penaltyUselessResponse, "test"))
+
+	// Banned addresses are not added.
+	d.BackFill("1.1.1.1:10333", "2.2.2.2:10333", "3.3.3.3:10333")
+	require.Equal(t, 2, d.PoolCount())
+
+	// Better reputation is preferred.
+	d.RequestRemote(1)
+	select {
+	case a := <-ts.dialCh:
+		require.Equal(t, "3.3.3.3:10333", a)
+		d.RegisterConnected(&fakeAPeer{addr: a, peer: a})
+	case <-time.After(time.Second):
+		t.Fatalf("timeout expecting for transport dial")
+	}
+
+	// Banned after being added to the pool.
+	rep.ban("2.2.2.2", 0, "test")
+	d.RequestRemote(1)
+	select {
+	case a := <-ts.dialCh:
+		t.Fatalf("unexpected dial to %s", a)
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.Equal(t, 0, d.PoolCount())
+}
+
+func TestDiscoveryAddRemovePeer(t *testing.T) {
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	d := NewDefaultDiscovery(nil, time.Second/16, ts)
+	tryMaxWait = 1 // Don't waste time.
+
+	d.AddPeer("1.1.1.1:10333")
+	select {
+	case a := <-ts.dialCh:
+		require.Equal(t, "1.1.1.1:10333", a)
+	case <-time.After(time.Second):
+		t.Fatalf("timeout expecting for transport dial")
+	}
+	// Already being connected to.
+	d.AddPeer("1.1.1.1:10333")
+	select {
+	case a := <-ts.dialCh:
+		t.Fatalf("unexpected dial to %s", a)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	p := &fakeAPeer{addr: "1.1.1.1:10333", peer: "1.1.1.1:10333", version: &payload.Version{}}
+	d.RegisterConnected(p)
+	d.RegisterGood(p)
+	require.Equal(t, 1, len(d.GoodPeers()))
+
+	d.RemovePeer("1.1.1.1:10333")
+	require.Equal(t, 0, len(d.GoodPeers()))
+	d.UnregisterConnected(p, false)
+	require.Equal(t, 0, d.PoolCount())
+}
@@ -24,10 +24,24 @@ type testDiscovery struct {
 	connected    []string
 	unregistered []string
 	backfill     []string
+	added        []string
+	removed      []string
 }
 
-func newTestDiscovery([]string, time.Duration, Transporter) Discoverer { return new(testDiscovery) }
+func newTestDiscovery([]string, time.Duration, Transporter, *reputation) Discoverer {
+	return new(testDiscovery)
+}
 
+func (d *testDiscovery) AddPeer(addr string) {
+	d.Lock()
+	defer d.Unlock()
+	d.added = append(d.added, addr)
+}
+func (d *testDiscovery) RemovePeer(addr string) {
+	d.Lock()
+	defer d.Unlock()
+	d.removed = append(d.removed, addr)
+}
 func (d *testDiscovery) BackFill(addrs ...string) {
 	d.Lock()
 	defer d.Unlock()
@@ -82,6 +96,7 @@ type localPeer struct {
 	lastBlockIndex uint32
 	handshaked     int32 // TODO: use atomic.Bool after #2626.
 	isFullNode     bool
+	zstd           bool
 	t              *testing.T
 	messageHandler func(t *testing.T, msg *Message)
 	pingSent       int
@@ -195,6 +210,9 @@ func (p *localPeer) IsFullNode() bool {
 	return p.isFullNode
 }
 
+func (p *localPeer) SupportsZstd() bool {
+	return p.zstd
+}
 func (p *localPeer) AddGetAddrSent() {
 	p.getAddrSent++
 }
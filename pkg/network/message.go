@@ -18,7 +18,8 @@ const CompressionMinSize = 1024
 // Message is a complete message sent between nodes.
 type Message struct {
 	// Flags that represents whether a message is compressed.
-	// 0 for None, 1 for Compressed.
+	// 0 for None, 1 for Compressed (LZ4), 2 for ZstdCompressed. ZstdSupport
+	// can also be set for version messages.
 	Flags MessageFlag
 	// Command is a byte command code.
 	Command CommandType
@@ -32,6 +33,10 @@ type Message struct {
 	// StateRootInHeader specifies if the state root is included in the block header.
 	// This is needed for correct decoding.
 	StateRootInHeader bool
+
+	// useZstd makes payload compressed with zstd instead of LZ4, it can only
+	// be set for peers that support it.
+	useZstd bool
 }
 
 // MessageFlag represents compression level of a message payload.
@@ -40,7 +45,14 @@ type MessageFlag byte
 // Possible message flags.
 const (
 	Compressed MessageFlag = 1 << iota
-	None       MessageFlag = 0
+	// ZstdCompressed is a NeoGo extension, it's only used for peers
+	// announcing ZstdSupport.
+	ZstdCompressed
+	// ZstdSupport is a NeoGo extension, it's set in version messages of
+	// nodes accepting ZstdCompressed payloads. Other nodes (C# ones included)
+	// ignore unknown message flags, so it doesn't affect the handshake.
+	ZstdSupport MessageFlag = 0x80
+	None        MessageFlag = 0
 )
 
 // CommandType represents the type of a message command.
@@ -99,6 +111,9 @@ func (m *Message) Decode(br *io.BinReader) error {
 	m.Flags = MessageFlag(br.ReadB())
 	m.Command = CommandType(br.ReadB())
 	l := br.ReadVarUint()
+	if br.Err != nil {
+		return br.Err
+	}
 	// check the length first in order not to allocate memory
 	// for an empty compressed payload
 	if l == 0 {
@@ -106,30 +121,39 @@ func (m *Message) Decode(br *io.BinReader) error {
 		case CMDFilterClear, CMDGetAddr, CMDMempool, CMDVerack:
 			m.Payload = payload.NewNullPayload()
 		default:
-			return fmt.Errorf("unexpected empty payload: %s", m.Command)
+			return protocolViolation(fmt.Errorf("unexpected empty payload: %s", m.Command))
 		}
 		return nil
 	}
 	if l > payload.MaxSize {
-		return errors.New("invalid payload size")
+		return protocolViolation(errors.New("invalid payload size"))
 	}
 	m.compressedPayload = make([]byte, l)
 	br.ReadBytes(m.compressedPayload)
 	if br.Err != nil {
 		return br.Err
 	}
-	return m.decodePayload()
+	return protocolViolation(m.decodePayload())
 }
 
 func (m *Message) decodePayload() error {
 	buf := m.compressedPayload
 	// try decompression
-	if m.Flags&Compressed != 0 {
+	switch {
+	case m.Flags&(Compressed|ZstdCompressed) == Compressed|ZstdCompressed:
+		return errors.New("invalid compression flags")
+	case m.Flags&Compressed != 0:
 		d, err := decompress(m.compressedPayload)
 		if err != nil {
 			return err
 		}
 		buf = d
+	case m.Flags&ZstdCompressed != 0:
+		d, err := decompressZstd(m.compressedPayload)
+		if err != nil {
+			return err
+		}
+		buf = d
 	}
 
 	var p payload.Payload
@@ -224,7 +248,7 @@ func (m *Message) tryCompressPayload() error {
 		return buf.Err
 	}
 	compressedPayload := buf.Bytes()
-	if m.Flags&Compressed == 0 {
+	if m.Flags&(Compressed|ZstdCompressed) == 0 {
 		switch m.Payload.(type) {
 		case *payload.Headers, *payload.MerkleBlock, payload.NullPayload,
 			*payload.Inventory, *payload.MPTInventory:
@@ -233,6 +257,11 @@ func (m *Message) tryCompressPayload() error {
 			size := len(compressedPayload)
 			// try compression
 			if size > CompressionMinSize {
+				if m.useZstd {
+					compressedPayload = compressZstd(compressedPayload)
+					m.Flags |= ZstdCompressed
+					break
+				}
 				c, err := compress(compressedPayload)
 				if err == nil {
 					compressedPayload = c
@@ -25,6 +25,22 @@ func TestMessageDecodeFuzzCases(t *testing.T) {
 	require.NotPanics(t, func() { _ = m.Decode(r) })
 }
 
+func TestMessageDecodeViolation(t *testing.T) {
+	for _, c := range []struct {
+		raw     []byte
+		penalty int
+	}{
+		{[]byte{0, byte(CMDInv), 0}, penaltyInvalidPayload},          // Empty payload.
+		{[]byte{0, byte(CMDInv), 1, 0xff}, penaltyInvalidPayload},    // Invalid payload.
+		{[]byte{0xff, byte(CMDInv), 1, 0xff}, penaltyInvalidPayload}, // Invalid compression flags.
+		{[]byte{0, byte(CMDInv), 0xfe, 0, 0, 0}, 0},                  // Truncated, it's a network error.
+	} {
+		err := new(Message).Decode(io.NewBinReaderFromBuf(c.raw))
+		require.Error(t, err)
+		require.Equal(t, c.penalty, dropPenalty(err), err)
+	}
+}
+
 func TestEncodeDecodeVersion(t *testing.T) {
 	// message with tiny payload, shouldn't be compressed
 	expected := NewMessage(CMDVersion, &payload.Version{
@@ -171,6 +187,33 @@ func TestEncodeDecodeBlock(t *testing.T) {
 	})
 }
 
+func TestEncodeDecodeZstd(t *testing.T) {
+	expected := NewMessage(CMDBlock, newDummyBlock(12, 20))
+	expected.useZstd = true
+	data, err := testserdes.Encode(expected)
+	require.NoError(t, err)
+	require.Equal(t, ZstdCompressed, expected.Flags)
+
+	actual := &Message{}
+	require.NoError(t, testserdes.Decode(data, actual))
+	require.Equal(t, ZstdCompressed, actual.Flags)
+	require.Equal(t, expected.Payload, actual.Payload)
+
+	t.Run("both flags", func(t *testing.T) {
+		data := append([]byte{}, data...)
+		data[0] |= byte(Compressed)
+		require.Error(t, testserdes.Decode(data, &Message{}))
+	})
+	t.Run("LZ4 payload", func(t *testing.T) {
+		m := NewMessage(CMDBlock, newDummyBlock(12, 20))
+		data, err := testserdes.Encode(m)
+		require.NoError(t, err)
+		require.Equal(t, Compressed, m.Flags)
+		data[0] = byte(ZstdCompressed)
+		require.Error(t, testserdes.Decode(data, &Message{}))
+	})
+}
+
 func TestEncodeDecodeGetBlock(t *testing.T) {
 	t.Run("good, Count>0", func(t *testing.T) {
 		testEncodeDecode(t, CMDGetBlocks, &payload.GetBlocks{
@@ -64,6 +64,9 @@ type Peer interface {
 	LastBlockIndex() uint32
 	Handshaked() bool
 	IsFullNode() bool
+	// SupportsZstd returns true if the peer accepts zstd-compressed payloads
+	// (it has set ZstdSupport flag in its version message).
+	SupportsZstd() bool
 
 	// SetPingTimer adds an outgoing ping to the counter and sets a PingTimeout
 	// timer that will shut the connection down in case of no response.
@@ -0,0 +1,368 @@
+package network
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultBanScore    = 100
+	defaultBanDuration = 24 * time.Hour
+
+	// Misbehavior penalties, the peer is banned when its score reaches
+	// ServerConfig.BanScore.
+	penaltyInvalidPayload  = 50
+	penaltyUselessResponse = 10
+
+	// scoreDecayInterval is the time it takes for the peer score to be
+	// decreased by one point.
+	scoreDecayInterval = time.Minute
+	// maxScoredPeers is the number of peer scores after which the ones that
+	// decayed completely are removed.
+	maxScoredPeers = 10000
+)
+
+var errBanned = errors.New("peer is banned")
+
+// localError wraps errors caused by our node itself (like failures to
+// request or send data) rather than by the peer, they're never penalized.
+type localError struct {
+	err error
+}
+
+// localFailure wraps the given error (if any) into localError.
+func localFailure(err error) error {
+	if err == nil {
+		return nil
+	}
+	return localError{err: err}
+}
+
+// Error implements the error interface.
+func (e localError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the original error.
+func (e localError) Unwrap() error {
+	return e.err
+}
+
+// protocolError wraps errors caused by explicit protocol violations of the
+// peer (like malformed messages or unexpected commands), only these are
+// penalized when the peer is dropped.
+type protocolError struct {
+	err error
+}
+
+// protocolViolation wraps the given error (if any) into protocolError.
+func protocolViolation(err error) error {
+	if err == nil {
+		return nil
+	}
+	return protocolError{err: err}
+}
+
+// Error implements the error interface.
+func (e protocolError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the original error.
+func (e protocolError) Unwrap() error {
+	return e.err
+}
+
+// PeerBan contains information about a banned peer.
+type PeerBan struct {
+	// Address is the banned IP address (without port).
+	Address string `json:"address"`
+	// Until is the time the ban expires at.
+	Until time.Time `json:"until"`
+	// Reason is the human-readable ban reason.
+	Reason string `json:"reason"`
+}
+
+// peerScore is an accumulated misbehavior score of a single peer.
+type peerScore struct {
+	score   int
+	updated time.Time
+}
+
+// reputation tracks peer misbehavior and bans peers (by their IP addresses)
+// when it's excessive. Loopback and exempt (seed) addresses are never
+// penalized and automatic bans are disabled completely if the ban score or
+// duration is negative, manual bans work in any case. All methods are safe
+// to be used with nil receiver, nothing is ever banned in this case.
+type reputation struct {
+	lock        sync.RWMutex
+	banScore    int
+	banDuration time.Duration
+	exempt      map[string]bool
+	scores      map[string]*peerScore
+	bans        map[string]PeerBan
+
+	// fileLock serializes ban list file writes.
+	fileLock sync.Mutex
+	file     string
+	log      *zap.Logger
+
+	// now is the time source, it's replaced in tests.
+	now func() time.Time
+}
+
+// newReputation creates a new reputation tracker and loads the ban list from
+// the given file if it's not empty. Hosts of the exempt addresses are never
+// penalized.
+func newReputation(banScore int, banDuration time.Duration, exempt []string, file string, log *zap.Logger) (*reputation, error) {
+	r := &reputation{
+		banScore:    banScore,
+		banDuration: banDuration,
+		exempt:      make(map[string]bool, len(exempt)),
+		scores:      make(map[string]*peerScore),
+		bans:        make(map[string]PeerBan),
+		file:        file,
+		log:         log,
+		now:         time.Now,
+	}
+	for _, addr := range exempt {
+		r.exempt[hostOf(addr)] = true
+	}
+	if file == "" {
+		return r, nil
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return r, nil
+		}
+		return nil, fmt.Errorf("failed to read ban list: %w", err)
+	}
+	var bans []PeerBan
+	if err := json.Unmarshal(data, &bans); err != nil {
+		return nil, fmt.Errorf("failed to parse ban list: %w", err)
+	}
+	now := r.now()
+	for _, b := range bans {
+		if b.Until.After(now) {
+			r.bans[b.Address] = b
+		}
+	}
+	return r, nil
+}
+
+// hostOf returns the host part of the given address if it has any port, the
+// address itself is returned otherwise.
+func hostOf(addr string) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	return host
+}
+
+// isBanned checks whether the host of the given address is banned.
+func (r *reputation) isBanned(addr string) bool {
+	if r == nil {
+		return false
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	b, ok := r.bans[hostOf(addr)]
+	return ok && b.Until.After(r.now())
+}
+
+// score returns the current misbehavior score of the address' host, the
+// lower it is, the better.
+func (r *reputation) score(addr string) int {
+	if r == nil {
+		return 0
+	}
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	s, ok := r.scores[hostOf(addr)]
+	if !ok {
+		return 0
+	}
+	return s.current(r.now())
+}
+
+// isExempt checks whether the host can't be penalized.
+func (r *reputation) isExempt(host string) bool {
+	if r.exempt[host] {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// current returns the score with decay applied.
+func (s *peerScore) current(now time.Time) int {
+	var v = s.score - int(now.Sub(s.updated)/scoreDecayInterval)
+	if v < 0 {
+		return 0
+	}
+	return v
+}
+
+// misbehave adds the penalty to the address' host score and bans it if the
+// score is too high. It returns true if the host is banned as a result of
+// this call. Expired ban of the host (if any) is removed, so it can be banned
+// again.
+func (r *reputation) misbehave(addr string, penalty int, reason string) bool {
+	if r == nil || penalty <= 0 || r.banScore <= 0 || r.banDuration <= 0 {
+		return false
+	}
+	var (
+		host = hostOf(addr)
+		now  = r.now()
+	)
+	if r.isExempt(host) {
+		return false
+	}
+	r.lock.Lock()
+	if b, ok := r.bans[host]; ok {
+		if b.Until.After(now) {
+			r.lock.Unlock()
+			return false
+		}
+		delete(r.bans, host)
+	}
+	s, ok := r.scores[host]
+	if !ok {
+		if len(r.scores) >= maxScoredPeers {
+			for h, v := range r.scores {
+				if v.current(now) == 0 {
+					delete(r.scores, h)
+				}
+			}
+		}
+		s = new(peerScore)
+		r.scores[host] = s
+	}
+	s.score = s.current(now) + penalty
+	s.updated = now
+	if s.score < r.banScore {
+		r.lock.Unlock()
+		return false
+	}
+	delete(r.scores, host)
+	r.bans[host] = PeerBan{
+		Address: host,
+		Until:   now.Add(r.banDuration),
+		Reason:  reason,
+	}
+	r.lock.Unlock()
+	r.save()
+	return true
+}
+
+// ban bans the address' host for the given duration (or for the default
+// duration if it's not positive).
+func (r *reputation) ban(addr string, d time.Duration, reason string) {
+	if r == nil {
+		return
+	}
+	if d <= 0 {
+		d = r.banDuration
+	}
+	if d <= 0 {
+		d = defaultBanDuration
+	}
+	host := hostOf(addr)
+	r.lock.Lock()
+	delete(r.scores, host)
+	r.bans[host] = PeerBan{
+		Address: host,
+		Until:   r.now().Add(d),
+		Reason:  reason,
+	}
+	r.lock.Unlock()
+	r.save()
+}
+
+// unban removes the address' host from the ban list, it returns false if
+// it wasn't banned.
+func (r *reputation) unban(addr string) bool {
+	if r == nil {
+		return false
+	}
+	host := hostOf(addr)
+	r.lock.Lock()
+	_, ok := r.bans[host]
+	delete(r.bans, host)
+	delete(r.scores, host)
+	r.lock.Unlock()
+	if ok {
+		r.save()
+	}
+	return ok
+}
+
+// banList returns active bans sorted by address.
+func (r *reputation) banList() []PeerBan {
+	if r == nil {
+		return []PeerBan{}
+	}
+	var now = r.now()
+	r.lock.Lock()
+	res := make([]PeerBan, 0, len(r.bans))
+	for h, b := range r.bans {
+		if !b.Until.After(now) {
+			delete(r.bans, h)
+			continue
+		}
+		res = append(res, b)
+	}
+	r.lock.Unlock()
+	sort.Slice(res, func(i, j int) bool { return res[i].Address < res[j].Address })
+	return res
+}
+
+// save writes the ban list to the file (if it's configured), errors are only
+// logged since there is nothing else to do with them.
+func (r *reputation) save() {
+	if r.file == "" {
+		return
+	}
+	r.fileLock.Lock()
+	defer r.fileLock.Unlock()
+	data, err := json.Marshal(r.banList())
+	if err == nil {
+		var tmp = r.file + ".tmp"
+		err = os.MkdirAll(filepath.Dir(r.file), os.ModePerm)
+		if err == nil {
+			err = os.WriteFile(tmp, data, 0644)
+		}
+		if err == nil {
+			err = os.Rename(tmp, r.file)
+		}
+	}
+	if err != nil {
+		r.log.Warn("failed to save ban list", zap.String("file", r.file), zap.Error(err))
+	}
+}
+
+// dropPenalty returns the penalty for the peer disconnected with the given
+// reason. Only explicit protocol violations (see protocolViolation) are
+// penalized, network errors, timeouts, local failures and errors caused by
+// our node's own state are not.
+func dropPenalty(reason error) int {
+	var (
+		locErr   localError
+		protoErr protocolError
+	)
+	if errors.As(reason, &locErr) || !errors.As(reason, &protoErr) {
+		return 0
+	}
+	return penaltyInvalidPayload
+}
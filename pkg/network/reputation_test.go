@@ -0,0 +1,199 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func newTestReputation(t *testing.T, file string) (*reputation, *time.Time) {
+	r, err := newReputation(defaultBanScore, time.Hour, nil, file, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	now := time.Now().UTC().Round(time.Second)
+	r.now = func() time.Time { return now }
+	return r, &now
+}
+
+func TestReputation(t *testing.T) {
+	r, now := newTestReputation(t, "")
+
+	require.False(t, r.misbehave("1.2.3.4:10333", penaltyInvalidPayload, "invalid"))
+	require.Equal(t, penaltyInvalidPayload, r.score("1.2.3.4:20333"))
+	require.Equal(t, penaltyInvalidPayload, r.score("1.2.3.4"))
+	require.Equal(t, 0, r.score("4.3.2.1:10333"))
+	require.False(t, r.isBanned("1.2.3.4:10333"))
+
+	t.Run("decay", func(t *testing.T) {
+		*now = now.Add(10 * scoreDecayInterval)
+		require.Equal(t, penaltyInvalidPayload-10, r.score("1.2.3.4"))
+		require.False(t, r.misbehave("1.2.3.4:10333", penaltyUselessResponse, "useless"))
+		require.Equal(t, penaltyInvalidPayload-10+penaltyUselessResponse, r.score("1.2.3.4"))
+
+		*now = now.Add(time.Hour)
+		require.Equal(t, 0, r.score("1.2.3.4"))
+	})
+	t.Run("ban", func(t *testing.T) {
+		require.False(t, r.misbehave("1.2.3.4:10333", penaltyInvalidPayload, "invalid"))
+		require.True(t, r.misbehave("1.2.3.4:10333", penaltyInvalidPayload, "invalid again"))
+		require.True(t, r.isBanned("1.2.3.4:10333"))
+		require.True(t, r.isBanned("1.2.3.4"))
+		require.False(t, r.isBanned("4.3.2.1"))
+		// Already banned.
+		require.False(t, r.misbehave("1.2.3.4:10333", penaltyInvalidPayload, "invalid"))
+		require.Equal(t, []PeerBan{{
+			Address: "1.2.3.4",
+			Until:   now.Add(time.Hour),
+			Reason:  "invalid again",
+		}}, r.banList())
+
+		*now = now.Add(time.Hour)
+		require.False(t, r.isBanned("1.2.3.4"))
+		require.Equal(t, []PeerBan{}, r.banList())
+		require.Equal(t, 0, r.score("1.2.3.4"))
+	})
+	t.Run("ban after expiry", func(t *testing.T) {
+		require.True(t, r.misbehave("5.5.5.5:10333", defaultBanScore, "invalid"))
+		require.True(t, r.isBanned("5.5.5.5"))
+
+		// No banList() call here, stale ban must be dropped by misbehave.
+		*now = now.Add(time.Hour)
+		require.False(t, r.isBanned("5.5.5.5"))
+		require.False(t, r.misbehave("5.5.5.5:10333", penaltyInvalidPayload, "invalid"))
+		require.Equal(t, penaltyInvalidPayload, r.score("5.5.5.5"))
+		require.True(t, r.misbehave("5.5.5.5:10333", penaltyInvalidPayload, "invalid again"))
+		require.True(t, r.isBanned("5.5.5.5"))
+		require.Equal(t, []PeerBan{{
+			Address: "5.5.5.5",
+			Until:   now.Add(time.Hour),
+			Reason:  "invalid again",
+		}}, r.banList())
+		require.True(t, r.unban("5.5.5.5"))
+	})
+	t.Run("manual ban", func(t *testing.T) {
+		r.ban("[::1]:10333", 2*time.Hour, "admin")
+		r.ban("1.1.1.1", 0, "admin")
+		require.True(t, r.isBanned("[::1]:20333"))
+		require.True(t, r.isBanned("1.1.1.1:20333"))
+		bans := r.banList()
+		require.Equal(t, 2, len(bans))
+		require.Equal(t, "1.1.1.1", bans[0].Address)
+		require.Equal(t, now.Add(time.Hour), bans[0].Until)
+		require.Equal(t, "::1", bans[1].Address)
+		require.Equal(t, now.Add(2*time.Hour), bans[1].Until)
+
+		require.True(t, r.unban("::1"))
+		require.False(t, r.unban("::1"))
+		require.False(t, r.isBanned("[::1]:20333"))
+	})
+	t.Run("exempt", func(t *testing.T) {
+		r, err := newReputation(defaultBanScore, time.Hour, []string{"6.6.6.6:10333", "seed.example.com:10333"}, "", zaptest.NewLogger(t))
+		require.NoError(t, err)
+		for _, addr := range []string{"6.6.6.6:20333", "seed.example.com:10333", "127.0.0.1:10333", "[::1]:10333"} {
+			require.False(t, r.misbehave(addr, defaultBanScore, "invalid"), addr)
+			require.Equal(t, 0, r.score(addr), addr)
+			require.False(t, r.isBanned(addr), addr)
+		}
+		// Manual bans still work.
+		r.ban("127.0.0.1", 0, "admin")
+		require.True(t, r.isBanned("127.0.0.1:10333"))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		for _, cfg := range []struct {
+			score int
+			dur   time.Duration
+		}{{-1, time.Hour}, {defaultBanScore, -1}} {
+			r, err := newReputation(cfg.score, cfg.dur, nil, "", zaptest.NewLogger(t))
+			require.NoError(t, err)
+			require.False(t, r.misbehave("1.2.3.4:10333", defaultBanScore, "invalid"))
+			require.Equal(t, 0, r.score("1.2.3.4"))
+			require.False(t, r.isBanned("1.2.3.4"))
+
+			// Manual bans still work, with the default duration if needed.
+			r.ban("1.2.3.4", 0, "admin")
+			require.True(t, r.isBanned("1.2.3.4"))
+			expected := cfg.dur
+			if expected <= 0 {
+				expected = defaultBanDuration
+			}
+			require.WithinDuration(t, time.Now().Add(expected), r.banList()[0].Until, time.Minute)
+		}
+	})
+	t.Run("nil", func(t *testing.T) {
+		var r *reputation
+		require.False(t, r.misbehave("1.2.3.4", penaltyInvalidPayload*10, "invalid"))
+		require.False(t, r.isBanned("1.2.3.4"))
+		require.Equal(t, 0, r.score("1.2.3.4"))
+		require.Equal(t, []PeerBan{}, r.banList())
+	})
+}
+
+func TestReputationPersistence(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "subdir", "bans.json")
+	r, now := newTestReputation(t, file)
+	r.ban("1.1.1.1", time.Hour, "admin")
+	r.ban("2.2.2.2", 3*time.Hour, "admin")
+	require.True(t, r.misbehave("3.3.3.3:10333", defaultBanScore, "invalid"))
+	require.FileExists(t, file)
+
+	r2, err := newReputation(defaultBanScore, time.Hour, nil, file, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	r2.now = r.now
+	require.Equal(t, r.banList(), r2.banList())
+
+	require.True(t, r2.unban("3.3.3.3"))
+	*now = now.Add(2 * time.Hour)
+	r2.ban("4.4.4.4", time.Hour, "admin")
+
+	// Expired bans are not stored and not loaded.
+	r3, err := newReputation(defaultBanScore, time.Hour, nil, file, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	r3.now = r.now
+	bans := r3.banList()
+	require.Equal(t, 2, len(bans))
+	require.Equal(t, "2.2.2.2", bans[0].Address)
+	require.Equal(t, "4.4.4.4", bans[1].Address)
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := newReputation(defaultBanScore, time.Hour, nil, filepath.Join(t.TempDir(), "bans.json"), zaptest.NewLogger(t))
+		require.NoError(t, err)
+	})
+	t.Run("invalid file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "bans.json")
+		require.NoError(t, os.WriteFile(file, []byte("[{"), 0644))
+		_, err := newReputation(defaultBanScore, time.Hour, nil, file, zaptest.NewLogger(t))
+		require.Error(t, err)
+	})
+}
+
+func TestDropPenalty(t *testing.T) {
+	for _, err := range []error{
+		nil,
+		io.EOF,
+		&net.OpError{Op: "read", Err: errors.New("connection reset by peer")},
+		errIdenticalID,
+		errAlreadyConnected,
+		errMaxPeers,
+		errServerShutdown,
+		errBanned,
+		errGone,
+		errPingPong,
+		errInvalidInvType,
+		errors.New("invalid height"),
+		fmt.Errorf("handling extensible message: %w", errors.New("disallowed sender")),
+		localFailure(errors.New("failed to encode message")),
+		localFailure(protocolViolation(errInvalidInvType)),
+		fmt.Errorf("handling ping message: %w", localFailure(errors.New("can't request blocks"))),
+	} {
+		require.Equal(t, 0, dropPenalty(err), err)
+	}
+	require.Equal(t, penaltyInvalidPayload, dropPenalty(protocolViolation(errInvalidInvType)))
+	require.Equal(t, penaltyInvalidPayload, dropPenalty(fmt.Errorf("handling pong message: %w", protocolViolation(errUnexpectedPong))))
+}
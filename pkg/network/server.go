@@ -50,6 +50,8 @@ var (
 	errMaxPeers         = errors.New("max peers reached")
 	errServerShutdown   = errors.New("server shutdown")
 	errInvalidInvType   = errors.New("invalid inventory type")
+	errPeerRemoved      = errors.New("peer removed")
+	errNotConnected     = errors.New("peer is not connected")
 )
 
 type (
@@ -99,6 +101,9 @@ type (
 
 		transports        []Transporter
 		discovery         Discoverer
+		reputation        *reputation
+		memPoolFileLock   sync.Mutex
+		memPoolRestored   chan struct{}
 		chain             Ledger
 		bQueue            *blockQueue
 		bSyncQueue        *blockQueue
@@ -161,7 +166,7 @@ func NewServer(config ServerConfig, chain Ledger, stSync StateSync, log *zap.Log
 
 func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSync, log *zap.Logger,
 	newTransport func(*Server, string) Transporter,
-	newDiscovery func([]string, time.Duration, Transporter) Discoverer,
+	newDiscovery func([]string, time.Duration, Transporter, *reputation) Discoverer,
 ) (*Server, error) {
 	if log == nil {
 		return nil, errors.New("logger is a required parameter")
@@ -174,26 +179,27 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 	}
 
 	s := &Server{
-		ServerConfig:   config,
-		chain:          chain,
-		id:             randomID(),
-		config:         chain.GetConfig().ProtocolConfiguration,
-		quit:           make(chan struct{}),
-		relayFin:       make(chan struct{}),
-		register:       make(chan Peer),
-		unregister:     make(chan peerDrop),
-		handshake:      make(chan Peer),
-		txInMap:        make(map[util.Uint256]struct{}),
-		peers:          make(map[Peer]bool),
-		syncReached:    atomic.NewBool(false),
-		mempool:        chain.GetMemPool(),
-		extensiblePool: extpool.New(chain, config.ExtensiblePoolSize),
-		log:            log,
-		txin:           make(chan *transaction.Transaction, 64),
-		transactions:   make(chan *transaction.Transaction, 64),
-		services:       make(map[string]Service),
-		extensHandlers: make(map[string]func(*payload.Extensible) error),
-		stateSync:      stSync,
+		ServerConfig:    config,
+		chain:           chain,
+		id:              randomID(),
+		config:          chain.GetConfig().ProtocolConfiguration,
+		quit:            make(chan struct{}),
+		relayFin:        make(chan struct{}),
+		memPoolRestored: make(chan struct{}),
+		register:        make(chan Peer),
+		unregister:      make(chan peerDrop),
+		handshake:       make(chan Peer),
+		txInMap:         make(map[util.Uint256]struct{}),
+		peers:           make(map[Peer]bool),
+		syncReached:     atomic.NewBool(false),
+		mempool:         chain.GetMemPool(),
+		extensiblePool:  extpool.New(chain, config.ExtensiblePoolSize),
+		log:             log,
+		txin:            make(chan *transaction.Transaction, 64),
+		transactions:    make(chan *transaction.Transaction, 64),
+		services:        make(map[string]Service),
+		extensHandlers:  make(map[string]func(*payload.Extensible) error),
+		stateSync:       stSync,
 	}
 	if chain.P2PSigExtensionsEnabled() {
 		s.notaryFeer = NewNotaryFeer(chain)
@@ -238,9 +244,25 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		s.BroadcastFactor = defaultBroadcastFactor
 	}
 
+	if s.BanScore == 0 {
+		s.BanScore = defaultBanScore
+	}
+
+	if s.BanDuration == 0 {
+		s.BanDuration = defaultBanDuration
+	}
+
 	if len(s.ServerConfig.Addresses) == 0 {
 		return nil, errors.New("no bind addresses configured")
 	}
+	if s.MemPoolPersistence.Enabled && s.MemPoolPersistence.File == "" {
+		return nil, errors.New("memory pool persistence is enabled, but no file is configured")
+	}
+	rep, err := newReputation(s.BanScore, s.BanDuration, s.Seeds, s.BanListFile, s.log)
+	if err != nil {
+		return nil, err
+	}
+	s.reputation = rep
 	transports := make([]Transporter, len(s.ServerConfig.Addresses))
 	for i, addr := range s.ServerConfig.Addresses {
 		transports[i] = newTransport(s, addr.Address)
@@ -252,6 +274,7 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		// Here we need to pick up a single transporter, it will be used to
 		// dial, and it doesn't matter which one.
 		s.transports[0],
+		s.reputation,
 	)
 
 	return s, nil
@@ -271,6 +294,7 @@ func (s *Server) Start(errChan chan error) {
 
 	s.tryStartServices()
 	s.initStaleMemPools()
+	go s.restoreMemPool()
 
 	var txThreads = optimalNumOfThreads()
 	for i := 0; i < txThreads; i++ {
@@ -280,6 +304,9 @@ func (s *Server) Start(errChan chan error) {
 	go s.relayBlocksLoop()
 	go s.bQueue.run()
 	go s.bSyncQueue.run()
+	if s.MemPoolPersistence.Enabled && s.MemPoolPersistence.SaveInterval > 0 {
+		go s.memPoolSaveLoop()
+	}
 	for _, tr := range s.transports {
 		go tr.Accept()
 	}
@@ -307,6 +334,7 @@ func (s *Server) Shutdown() {
 	if s.chain.P2PSigExtensionsEnabled() {
 		s.notaryRequestPool.StopSubscriptions()
 	}
+	s.saveMemPool()
 	close(s.quit)
 	<-s.relayFin
 }
@@ -395,6 +423,91 @@ func (s *Server) BadPeers() []string {
 	return s.discovery.BadPeers()
 }
 
+// AddPeer adds the given address (in "host:port" form) to the seed list, so
+// that the node connects to it and reconnects when the connection is lost.
+func (s *Server) AddPeer(addr string) error {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return fmt.Errorf("invalid address: %w", err)
+	}
+	if s.reputation.isBanned(addr) {
+		return errBanned
+	}
+	s.discovery.AddPeer(addr)
+	return nil
+}
+
+// DisconnectPeer drops connections with the peer having the given address
+// (either the one it listens at or the one of connection) and removes this
+// address from the seed list and from the set of known good addresses. The
+// peer can still connect to the node or be received via P2P address exchange,
+// use BanPeer to prevent this.
+func (s *Server) DisconnectPeer(addr string) error {
+	var found bool
+
+	s.discovery.RemovePeer(addr)
+	s.lock.RLock()
+	for p := range s.peers {
+		if p.PeerAddr().String() == addr || p.ConnectionAddr() == addr || p.RemoteAddr().String() == addr {
+			found = true
+			// It will send us unregister signal.
+			go p.Disconnect(errPeerRemoved)
+		}
+	}
+	s.lock.RUnlock()
+	if !found {
+		return errNotConnected
+	}
+	return nil
+}
+
+// BannedPeers returns a list of currently banned peers.
+func (s *Server) BannedPeers() []PeerBan {
+	return s.reputation.banList()
+}
+
+// BanPeer bans the IP address of the given address (port is optional) for
+// the specified duration (ServerConfig.BanDuration is used if it's not
+// positive) and drops all connections with it.
+func (s *Server) BanPeer(addr string, d time.Duration, reason string) error {
+	if net.ParseIP(hostOf(addr)) == nil {
+		return fmt.Errorf("invalid IP address: %s", addr)
+	}
+	s.reputation.ban(addr, d, reason)
+	s.dropBanned(hostOf(addr))
+	return nil
+}
+
+// UnbanPeer removes the host of the given address from the ban list, it
+// returns false if it wasn't banned.
+func (s *Server) UnbanPeer(addr string) bool {
+	return s.reputation.unban(addr)
+}
+
+// misbehave adds the penalty to the peer's misbehavior score and drops all
+// connections with its host if it gets banned as a result.
+func (s *Server) misbehave(p Peer, penalty int, reason string) {
+	addr := p.RemoteAddr().String()
+	if s.reputation.misbehave(addr, penalty, reason) {
+		s.log.Warn("peer banned",
+			zap.String("addr", hostOf(addr)),
+			zap.String("reason", reason),
+			zap.Duration("duration", s.BanDuration))
+		s.dropBanned(hostOf(addr))
+	}
+}
+
+// dropBanned disconnects all peers with the given host.
+func (s *Server) dropBanned(host string) {
+	s.lock.RLock()
+	for p := range s.peers {
+		if hostOf(p.RemoteAddr().String()) == host {
+			// It will send us unregister signal.
+			go p.Disconnect(errBanned)
+		}
+	}
+	s.lock.RUnlock()
+}
+
 // ConnectedPeers returns a list of currently connected peers.
 func (s *Server) ConnectedPeers() []string {
 	s.lock.RLock()
@@ -464,7 +577,10 @@ func (s *Server) run() {
 			s.lock.Unlock()
 			peerCount := s.PeerCount()
 			s.log.Info("new peer connected", zap.Stringer("addr", p.RemoteAddr()), zap.Int("peerCount", peerCount))
-			if peerCount > s.MaxPeers {
+			if s.reputation.isBanned(p.RemoteAddr().String()) {
+				// It will send us unregister signal.
+				go p.Disconnect(errBanned)
+			} else if peerCount > s.MaxPeers {
 				s.lock.RLock()
 				// Pick a random peer and drop connection to it.
 				for peer := range s.peers {
@@ -488,6 +604,9 @@ func (s *Server) run() {
 				if errors.Is(drop.reason, errIdenticalID) {
 					s.discovery.RegisterSelf(drop.peer)
 				} else {
+					if penalty := dropPenalty(drop.reason); penalty > 0 {
+						s.misbehave(drop.peer, penalty, drop.reason.Error())
+					}
 					s.discovery.UnregisterConnected(drop.peer, errors.Is(drop.reason, errAlreadyConnected))
 				}
 				updatePeersConnectedMetric(s.PeerCount())
@@ -644,7 +763,18 @@ func (s *Server) getVersionMsg(localAddr net.Addr) (*Message, error) {
 		s.UserAgent,
 		capabilities,
 	)
-	return NewMessage(CMDVersion, payload), nil
+	msg := NewMessage(CMDVersion, payload)
+	if s.ZstdCompression {
+		msg.Flags |= ZstdSupport
+	}
+	return msg, nil
+}
+
+// useZstd checks whether zstd compression can be used for messages sent to the
+// peer, it requires both sides to support it. Peers not setting ZstdSupport
+// flag (like C# nodes) always get LZ4-compressed messages.
+func (s *Server) useZstd(p Peer) bool {
+	return s.ZstdCompression && p.SupportsZstd()
 }
 
 // IsInSync answers the question of whether the server is in sync with the
@@ -741,10 +871,13 @@ func (s *Server) handlePing(p Peer, ping *payload.Ping) error {
 	return p.EnqueueP2PMessage(NewMessage(CMDPong, payload.NewPing(s.chain.BlockHeight(), s.id)))
 }
 
+// requestBlocksOrHeaders requests headers, blocks or MPT nodes from the peer
+// depending on the node state. Any error returned is a local one, it's not
+// caused by the peer.
 func (s *Server) requestBlocksOrHeaders(p Peer) error {
 	if s.stateSync.NeedHeaders() {
 		if s.chain.HeaderHeight() < p.LastBlockIndex() {
-			return s.requestHeaders(p)
+			return localFailure(s.requestHeaders(p))
 		}
 		return nil
 	}
@@ -761,10 +894,10 @@ func (s *Server) requestBlocksOrHeaders(p Peer) error {
 	}
 	err := s.requestBlocks(bq, p)
 	if err != nil {
-		return err
+		return localFailure(err)
 	}
 	if requestMPTNodes {
-		return s.requestMPTNodes(p, s.stateSync.GetUnknownMPTNodesBatch(payload.MaxMPTHashesCount))
+		return localFailure(s.requestMPTNodes(p, s.stateSync.GetUnknownMPTNodesBatch(payload.MaxMPTHashesCount)))
 	}
 	return nil
 }
@@ -846,6 +979,7 @@ func (s *Server) handleGetDataCmd(p Peer, inv *payload.Inventory) error {
 		notFound []util.Uint256
 		reply    = io.NewBufBinWriter()
 		send     = p.EnqueueP2PPacket
+		useZstd  = s.useZstd(p)
 	)
 	if inv.Type == payload.ExtensibleType {
 		send = p.EnqueueHPPacket
@@ -880,6 +1014,7 @@ func (s *Server) handleGetDataCmd(p Peer, inv *payload.Inventory) error {
 			}
 		}
 		if msg != nil {
+			msg.useZstd = useZstd
 			err = addMessageToPacket(reply, msg, send)
 			if err != nil {
 				return err
@@ -903,7 +1038,7 @@ func (s *Server) handleGetDataCmd(p Peer, inv *payload.Inventory) error {
 func addMessageToPacket(batch *io.BufBinWriter, msg *Message, send func([]byte) error) error {
 	err := msg.Encode(batch.BinWriter)
 	if err != nil {
-		return err
+		return localFailure(err)
 	}
 	if batch.Len() > payload.MaxSize/2 {
 		err = send(batch.Bytes())
@@ -944,7 +1079,7 @@ func (s *Server) handleGetMPTDataCmd(p Peer, inv *payload.MPTInventory) error {
 				return false
 			})
 		if err != nil {
-			return fmt.Errorf("failed to traverse MPT starting from %s: %w", h.StringBE(), err)
+			return localFailure(fmt.Errorf("failed to traverse MPT starting from %s: %w", h.StringBE(), err))
 		}
 	}
 	if len(resp.Nodes) > 0 {
@@ -1004,7 +1139,10 @@ func (s *Server) handleGetBlocksCmd(p Peer, gb *payload.GetBlocks) error {
 
 // handleGetBlockByIndexCmd processes the getblockbyindex request.
 func (s *Server) handleGetBlockByIndexCmd(p Peer, gbd *payload.GetBlockByIndex) error {
-	var reply = io.NewBufBinWriter()
+	var (
+		reply   = io.NewBufBinWriter()
+		useZstd = s.useZstd(p)
+	)
 	count := gbd.Count
 	if gbd.Count < 0 || gbd.Count > payload.MaxHashesCount {
 		count = payload.MaxHashesCount
@@ -1018,7 +1156,9 @@ func (s *Server) handleGetBlockByIndexCmd(p Peer, gbd *payload.GetBlockByIndex)
 		if err != nil {
 			break
 		}
-		err = addMessageToPacket(reply, NewMessage(CMDBlock, b), p.EnqueueP2PPacket)
+		msg := NewMessage(CMDBlock, b)
+		msg.useZstd = useZstd
+		err = addMessageToPacket(reply, msg, p.EnqueueP2PPacket)
 		if err != nil {
 			return err
 		}
@@ -1209,14 +1349,19 @@ func (s *Server) broadcastP2PNotaryRequestPayload(_ *transaction.Transaction, da
 // handleAddrCmd will process the received addresses.
 func (s *Server) handleAddrCmd(p Peer, addrs *payload.AddressList) error {
 	if !p.CanProcessAddr() {
-		return errors.New("unexpected addr received")
+		return protocolViolation(errors.New("unexpected addr received"))
 	}
+	var useful bool
 	for _, a := range addrs.Addrs {
 		addr, err := a.GetTCPAddress()
 		if err == nil {
 			s.discovery.BackFill(addr)
+			useful = true
 		}
 	}
+	if len(addrs.Addrs) > 0 && !useful {
+		s.misbehave(p, penaltyUselessResponse, "no valid addresses in addr response")
+	}
 	return nil
 }
 
@@ -1303,7 +1448,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 	if peer.Handshaked() {
 		if inv, ok := msg.Payload.(*payload.Inventory); ok {
 			if !inv.Type.Valid(s.chain.P2PSigExtensionsEnabled()) || len(inv.Hashes) == 0 {
-				return errInvalidInvType
+				return protocolViolation(errInvalidInvType)
 			}
 		}
 		switch msg.Command {
@@ -1359,7 +1504,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 			pong := msg.Payload.(*payload.Ping)
 			return s.handlePong(peer, pong)
 		case CMDVersion, CMDVerack:
-			return fmt.Errorf("received '%s' after the handshake", msg.Command.String())
+			return protocolViolation(fmt.Errorf("received '%s' after the handshake", msg.Command.String()))
 		}
 	} else {
 		switch msg.Command {
@@ -1373,7 +1518,7 @@ func (s *Server) handleMessage(peer Peer, msg *Message) error {
 			}
 			go peer.StartProtocol()
 		default:
-			return fmt.Errorf("received '%s' during handshake", msg.Command.String())
+			return protocolViolation(fmt.Errorf("received '%s' during handshake", msg.Command.String()))
 		}
 	}
 	return nil
@@ -1616,6 +1761,97 @@ func (s *Server) initStaleMemPools() {
 	}
 }
 
+// saveMemPool writes the contents of the memory pool and the notary request
+// pool to the file (if persistence is enabled), errors are only logged since
+// there is nothing else to do with them. The file is left intact until the
+// previously saved pool is restored.
+func (s *Server) saveMemPool() {
+	if !s.MemPoolPersistence.Enabled {
+		return
+	}
+	select {
+	case <-s.memPoolRestored:
+	default:
+		s.log.Info("memory pool is not restored yet, not saving it")
+		return
+	}
+	var pools = []*mempool.Pool{s.mempool}
+	if s.chain.P2PSigExtensionsEnabled() {
+		pools = append(pools, s.notaryRequestPool)
+	}
+	s.memPoolFileLock.Lock()
+	defer s.memPoolFileLock.Unlock()
+	err := mempool.WriteFile(s.MemPoolPersistence.File, s.Net, pools...)
+	if err != nil {
+		s.log.Warn("failed to save memory pool", zap.String("file", s.MemPoolPersistence.File), zap.Error(err))
+		return
+	}
+	s.log.Debug("memory pool saved", zap.Int("transactions", s.mempool.Count()))
+}
+
+// restoreMemPool reads transactions and notary requests saved previously (if
+// persistence is enabled) and adds them to the pools, every one of them is
+// verified against the current chain state, so the ones that became invalid
+// are dropped. It's run in a separate goroutine not to delay the server start,
+// restoration stops when the server is shut down.
+func (s *Server) restoreMemPool() {
+	defer close(s.memPoolRestored)
+	if !s.MemPoolPersistence.Enabled {
+		return
+	}
+	dump, err := mempool.ReadFile(s.MemPoolPersistence.File, s.Net)
+	if err != nil {
+		s.log.Warn("failed to restore memory pool", zap.String("file", s.MemPoolPersistence.File), zap.Error(err))
+		return
+	}
+	var total, pooled int
+	for i, entries := range dump {
+		if i > 0 && !s.chain.P2PSigExtensionsEnabled() {
+			break
+		}
+		for _, e := range entries {
+			select {
+			case <-s.quit:
+				return
+			default:
+			}
+			total++
+			if i == 0 {
+				err = s.verifyAndPoolTX(e.Tx)
+			} else {
+				var r *payload.P2PNotaryRequest
+				r, err = payload.NewP2PNotaryRequestFromBytes(e.Data)
+				if err == nil {
+					err = s.verifyAndPoolNotaryRequest(r)
+				}
+			}
+			if err != nil {
+				s.log.Debug("dropping saved transaction", zap.Stringer("hash", e.Tx.Hash()), zap.Error(err))
+				continue
+			}
+			pooled++
+		}
+	}
+	s.log.Info("memory pool restored",
+		zap.Int("restored", pooled),
+		zap.Int("dropped", total-pooled))
+}
+
+// memPoolSaveLoop periodically saves the memory pool until the server is
+// stopped.
+func (s *Server) memPoolSaveLoop() {
+	t := time.NewTicker(s.MemPoolPersistence.SaveInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-t.C:
+			s.saveMemPool()
+		}
+	}
+}
+
 // broadcastTxLoop is a loop for batching and sending
 // transactions hashes in an INV payload.
 func (s *Server) broadcastTxLoop() {
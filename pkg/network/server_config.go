@@ -76,6 +76,27 @@ type (
 
 		// BroadcastFactor is the factor (0-100) for fan-out optimization.
 		BroadcastFactor int
+
+		// ZstdCompression enables zstd payload compression for peers
+		// supporting it.
+		ZstdCompression bool
+
+		// BanScore is the misbehavior score that gets a peer banned, the
+		// default is used if it's zero, negative value disables automatic
+		// bans.
+		BanScore int
+
+		// BanDuration is the time misbehaving peers are banned for, the
+		// default is used if it's zero, negative value disables automatic
+		// bans.
+		BanDuration time.Duration
+
+		// BanListFile is the file to store banned peers in, bans are not
+		// persisted if it's empty.
+		BanListFile string
+
+		// MemPoolPersistence contains memory pool persistence settings.
+		MemPoolPersistence config.MemPoolPersistence
 	}
 )
 
@@ -147,6 +168,11 @@ func NewServerConfig(cfg config.Config) (ServerConfig, error) {
 		StateRootCfg:       appConfig.StateRoot,
 		ExtensiblePoolSize: extPoolSize,
 		BroadcastFactor:    broadcastFactor,
+		ZstdCompression:    appConfig.P2P.ZstdCompression,
+		BanScore:           appConfig.P2P.BanScore,
+		BanDuration:        appConfig.P2P.BanDuration,
+		BanListFile:        appConfig.P2P.BanListFile,
+		MemPoolPersistence: appConfig.MemPoolPersistence,
 	}
 	return c, nil
 }
@@ -3,8 +3,11 @@ package network
 import (
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"net"
+	"os"
+	"path/filepath"
 	"strconv"
 	"sync"
 	atomic2 "sync/atomic"
@@ -13,11 +16,13 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/fakechain"
 	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/consensus"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -71,12 +76,16 @@ func TestNewServer(t *testing.T) {
 		require.Equal(t, defaultMinPeers, s.ServerConfig.MinPeers)
 		require.Equal(t, defaultMaxPeers, s.ServerConfig.MaxPeers)
 		require.Equal(t, defaultAttemptConnPeers, s.ServerConfig.AttemptConnPeers)
+		require.Equal(t, defaultBanScore, s.ServerConfig.BanScore)
+		require.Equal(t, defaultBanDuration, s.ServerConfig.BanDuration)
 	})
 	t.Run("don't defaults", func(t *testing.T) {
 		cfg := ServerConfig{
 			MinPeers:         1,
 			MaxPeers:         2,
 			AttemptConnPeers: 3,
+			BanScore:         -1,
+			BanDuration:      -1,
 		}
 		s = newTestServer(t, cfg)
 
@@ -84,6 +93,8 @@ func TestNewServer(t *testing.T) {
 		require.Equal(t, 1, s.ServerConfig.MinPeers)
 		require.Equal(t, 2, s.ServerConfig.MaxPeers)
 		require.Equal(t, 3, s.ServerConfig.AttemptConnPeers)
+		require.Equal(t, -1, s.ServerConfig.BanScore)
+		require.Equal(t, time.Duration(-1), s.ServerConfig.BanDuration)
 	})
 }
 
@@ -673,6 +684,64 @@ func TestGetBlockByIndex(t *testing.T) {
 	})
 }
 
+func TestZstdCompression(t *testing.T) {
+	newPeer := func(t *testing.T, s *Server, zstd bool) *localPeer {
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		p.version = &payload.Version{}
+		p.zstd = zstd
+		return p
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{UserAgent: "/test/"})
+		m, err := s.getVersionMsg(nil)
+		require.NoError(t, err)
+		require.Equal(t, None, m.Flags)
+		require.False(t, s.useZstd(newPeer(t, s, true)))
+	})
+	t.Run("enabled", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{UserAgent: "/test/", ZstdCompression: true})
+		m, err := s.getVersionMsg(nil)
+		require.NoError(t, err)
+		require.Equal(t, ZstdSupport, m.Flags)
+
+		// The flag doesn't change version message encoding, so it can be
+		// decoded by nodes that don't know about it.
+		data, err := testserdes.Encode(m)
+		require.NoError(t, err)
+		plain, err := testserdes.Encode(NewMessage(CMDVersion, m.Payload))
+		require.NoError(t, err)
+		require.Equal(t, plain[1:], data[1:])
+		actual := &Message{}
+		require.NoError(t, testserdes.Decode(data, actual))
+		require.Equal(t, ZstdSupport, actual.Flags)
+		require.Equal(t, m.Payload, actual.Payload)
+
+		require.True(t, s.useZstd(newPeer(t, s, true)))
+		require.False(t, s.useZstd(newPeer(t, s, false)))
+	})
+	t.Run("getblockbyindex", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{UserAgent: "/test/", ZstdCompression: true})
+		b := newDummyBlock(12, 20)
+		s.chain.(*fakechain.FakeChain).PutBlock(b)
+		for flag, p := range map[MessageFlag]*localPeer{
+			ZstdCompressed: newPeer(t, s, true),
+			Compressed:     newPeer(t, s, false),
+		} {
+			var received bool
+			p.messageHandler = func(t *testing.T, msg *Message) {
+				require.Equal(t, CMDBlock, msg.Command)
+				require.Equal(t, flag, msg.Flags)
+				require.Equal(t, b, msg.Payload)
+				received = true
+			}
+			s.testHandleMessage(t, p, CMDGetBlockByIndex, &payload.GetBlockByIndex{IndexStart: b.Index, Count: 1})
+			require.True(t, received)
+		}
+	})
+}
+
 func TestGetHeaders(t *testing.T) {
 	s, blocks := initGetBlocksTest(t)
 
@@ -1004,6 +1073,88 @@ func TestAddrs(t *testing.T) {
 		msg := NewMessage(CMDAddr, pl)
 		require.Error(t, s.handleMessage(p, msg))
 	})
+	t.Run("useless response", func(t *testing.T) {
+		addr := p.RemoteAddr().String()
+		p.getAddrSent = 1
+		s.testHandleMessage(t, p, CMDAddr, payload.NewAddressList(0))
+		require.Equal(t, 0, s.reputation.score(addr))
+
+		p.getAddrSent = 1
+		s.testHandleMessage(t, p, CMDAddr, &payload.AddressList{Addrs: pl.Addrs[1:2]})
+		require.Equal(t, penaltyUselessResponse, s.reputation.score(addr))
+	})
+}
+
+func TestPeerBan(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	startWithCleanup(t, s)
+
+	ps := make([]*localPeer, 4)
+	for i := range ps {
+		ps[i] = newLocalPeer(t, s)
+		ps[i].netaddr.Port = i + 1
+	}
+	// Network errors don't affect reputation.
+	s.register <- ps[0]
+	ps[0].Disconnect(io.EOF)
+	require.Eventually(t, func() bool { return s.PeerCount() == 0 }, time.Second, time.Millisecond*10)
+	require.Equal(t, 0, s.reputation.score(ps[0].RemoteAddr().String()))
+
+	// Two protocol violations get the host banned.
+	for _, p := range ps[1:3] {
+		s.register <- p
+		p.Disconnect(protocolViolation(errInvalidInvType))
+	}
+	require.Eventually(t, func() bool { return len(s.BannedPeers()) == 1 }, time.Second, time.Millisecond*10)
+	require.Equal(t, "0.0.0.0", s.BannedPeers()[0].Address)
+
+	// Banned host can't connect.
+	s.register <- ps[3]
+	require.Eventually(t, func() bool {
+		err, ok := ps[3].droppedWith.Load().(error)
+		return ok && errors.Is(err, errBanned)
+	}, time.Second, time.Millisecond*10)
+
+	require.True(t, s.UnbanPeer("0.0.0.0:1"))
+	require.Equal(t, 0, len(s.BannedPeers()))
+
+	t.Run("manual", func(t *testing.T) {
+		p := newLocalPeer(t, s)
+		s.register <- p
+		require.Eventually(t, func() bool { return s.PeerCount() == 1 }, time.Second, time.Millisecond*10)
+		require.Error(t, s.BanPeer("localhost", time.Minute, "test"))
+		require.NoError(t, s.BanPeer("0.0.0.0", time.Minute, "test"))
+		require.Eventually(t, func() bool {
+			err, ok := p.droppedWith.Load().(error)
+			return ok && errors.Is(err, errBanned)
+		}, time.Second, time.Millisecond*10)
+		bans := s.BannedPeers()
+		require.Equal(t, 1, len(bans))
+		require.Equal(t, "test", bans[0].Reason)
+	})
+}
+
+func TestAddDisconnectPeer(t *testing.T) {
+	s := newTestServer(t, ServerConfig{})
+	startWithCleanup(t, s)
+
+	require.Error(t, s.AddPeer("1.2.3.4"))
+	require.NoError(t, s.AddPeer("1.2.3.4:10333"))
+	require.NoError(t, s.BanPeer("4.3.2.1", time.Minute, "test"))
+	require.ErrorIs(t, s.AddPeer("4.3.2.1:10333"), errBanned)
+	require.Equal(t, []string{"1.2.3.4:10333"}, s.discovery.(*testDiscovery).added)
+
+	require.ErrorIs(t, s.DisconnectPeer("1.2.3.4:10333"), errNotConnected)
+
+	p := newLocalPeer(t, s)
+	p.netaddr.Port = 10333
+	s.register <- p
+	require.Eventually(t, func() bool { return s.PeerCount() == 1 }, time.Second, time.Millisecond*10)
+	require.NoError(t, s.DisconnectPeer("0.0.0.0:10333"))
+	require.Eventually(t, func() bool { return s.PeerCount() == 0 }, time.Second, time.Millisecond*10)
+	require.ErrorIs(t, p.droppedWith.Load().(error), errPeerRemoved)
+	require.Equal(t, 0, s.reputation.score("0.0.0.0"))
+	require.Equal(t, []string{"1.2.3.4:10333", "0.0.0.0:10333"}, s.discovery.(*testDiscovery).removed)
 }
 
 type feerStub struct {
@@ -1153,3 +1304,104 @@ func TestServer_Port(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint16(123), actual)
 }
+
+func newDummyNotaryRequest() *payload.P2PNotaryRequest {
+	mainTx := &transaction.Transaction{
+		Attributes:      []transaction.Attribute{{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 1}}},
+		Script:          []byte{0, 1, 2},
+		ValidUntilBlock: 123,
+		Signers:         []transaction.Signer{{Account: util.Uint160{1, 5, 9}}},
+		Scripts:         []transaction.Witness{{InvocationScript: []byte{1, 4, 7}, VerificationScript: []byte{3, 6, 9}}},
+	}
+	fallbackTx := &transaction.Transaction{
+		Script:          []byte{3, 2, 1},
+		ValidUntilBlock: 123,
+		Attributes: []transaction.Attribute{
+			{Type: transaction.NotValidBeforeT, Value: &transaction.NotValidBefore{Height: 123}},
+			{Type: transaction.ConflictsT, Value: &transaction.Conflicts{Hash: mainTx.Hash()}},
+			{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 0}},
+		},
+		Signers: []transaction.Signer{{Account: util.Uint160{1, 4, 7}}, {Account: util.Uint160{9, 8, 7}}},
+		Scripts: []transaction.Witness{
+			{InvocationScript: append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, make([]byte, keys.SignatureLen)...), VerificationScript: []byte{}},
+			{InvocationScript: []byte{1, 2, 3}, VerificationScript: []byte{1, 2, 3}}},
+	}
+	r := &payload.P2PNotaryRequest{
+		MainTransaction:     mainTx,
+		FallbackTransaction: fallbackTx,
+		Witness:             transaction.Witness{InvocationScript: []byte{1, 2, 3}, VerificationScript: []byte{7, 8, 9}},
+	}
+	// Initialize caches for comparison.
+	_ = mainTx.Size()
+	_ = mainTx.Hash()
+	_ = fallbackTx.Size()
+	_ = fallbackTx.Hash()
+	_ = r.Hash()
+	return r
+}
+
+func TestServerMemPoolPersistence(t *testing.T) {
+	var (
+		file = filepath.Join(t.TempDir(), "mempool.dump")
+		cfg  = ServerConfig{
+			Addresses:          []config.AnnounceableAddress{{Address: ":0"}},
+			MemPoolPersistence: config.MemPoolPersistence{Enabled: true, File: file},
+		}
+		newServer = func(t *testing.T, chain *fakechain.FakeChain) *Server {
+			s, err := newServerFromConstructors(cfg, chain, new(fakechain.FakeStateSync), zaptest.NewLogger(t), newFakeTransp, newTestDiscovery)
+			require.NoError(t, err)
+			return s
+		}
+	)
+
+	t.Run("no file", func(t *testing.T) {
+		cfg := cfg
+		cfg.MemPoolPersistence.File = ""
+		_, err := newServerFromConstructors(cfg, fakechain.NewFakeChain(), new(fakechain.FakeStateSync), zaptest.NewLogger(t), newFakeTransp, newTestDiscovery)
+		require.Error(t, err)
+	})
+
+	chain := fakechain.NewFakeChain()
+	chain.UtilityTokenBalance = big.NewInt(100_0000_0000)
+	s := newServer(t, chain)
+	s.restoreMemPool() // Nothing to restore yet.
+	tx := newDummyTx()
+	require.NoError(t, s.mempool.Add(tx, chain))
+	r := newDummyNotaryRequest()
+	require.NoError(t, s.notaryRequestPool.Add(r.FallbackTransaction, chain, r))
+	s.saveMemPool()
+	dump, err := mempool.ReadFile(file, s.Net)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(dump))
+	require.Equal(t, 1, len(dump[0]))
+	require.Equal(t, tx, dump[0][0].Tx)
+	require.Equal(t, 1, len(dump[1]))
+	actual, err := payload.NewP2PNotaryRequestFromBytes(dump[1][0].Data)
+	require.NoError(t, err)
+	require.Equal(t, r.Hash(), actual.Hash())
+
+	t.Run("not restored", func(t *testing.T) {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		s := newServer(t, fakechain.NewFakeChain())
+		s.saveMemPool()
+		actual, err := os.ReadFile(file)
+		require.NoError(t, err)
+		require.Equal(t, data, actual)
+	})
+
+	restored := make(chan *transaction.Transaction, 1)
+	chain = fakechain.NewFakeChain()
+	chain.PoolTxF = func(tx *transaction.Transaction) error {
+		restored <- tx
+		return nil
+	}
+	s = newServer(t, chain)
+	startWithCleanup(t, s)
+	select {
+	case actual := <-restored:
+		require.Equal(t, tx.Hash(), actual.Hash())
+	case <-time.After(2 * time.Second):
+		t.Fatal("transaction wasn't restored")
+	}
+}
@@ -54,6 +54,8 @@ type TCPPeer struct {
 	finale     sync.Once
 	handShake  handShakeStage
 	isFullNode bool
+	// zstd is set if the peer accepts zstd-compressed payloads.
+	zstd bool
 
 	done     chan struct{}
 	sendQ    chan []byte
@@ -113,9 +115,10 @@ func (p *TCPPeer) BroadcastHPPacket(ctx context.Context, msg []byte) error {
 // putMessageIntoQueue serializes the given Message and puts it into given queue if
 // the peer has done handshaking.
 func (p *TCPPeer) putMsgIntoQueue(queue chan<- []byte, msg *Message) error {
+	msg.useZstd = p.server.useZstd(p)
 	b, err := msg.Bytes()
 	if err != nil {
-		return err
+		return localFailure(err)
 	}
 	return p.putPacketIntoQueue(context.Background(), queue, b)
 }
@@ -174,6 +177,11 @@ func (p *TCPPeer) handleConn() {
 			} else if err != nil {
 				break
 			}
+			if msg.Command == CMDVersion {
+				p.lock.Lock()
+				p.zstd = msg.Flags&ZstdSupport != 0
+				p.lock.Unlock()
+			}
 			p.incoming <- msg
 		}
 	}
@@ -315,6 +323,13 @@ func (p *TCPPeer) IsFullNode() bool {
 	return p.handshaked() && p.isFullNode
 }
 
+// SupportsZstd implements the Peer interface.
+func (p *TCPPeer) SupportsZstd() bool {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.zstd
+}
+
 // SendVersion checks for the handshake state and sends a message to the peer.
 func (p *TCPPeer) SendVersion() error {
 	msg, err := p.server.getVersionMsg(p.conn.LocalAddr())
@@ -338,7 +353,7 @@ func (p *TCPPeer) HandleVersion(version *payload.Version) error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 	if p.handShake&versionReceived != 0 {
-		return errors.New("invalid handshake: already received Version")
+		return protocolViolation(errors.New("invalid handshake: already received Version"))
 	}
 	p.version = version
 	for _, cap := range version.Capabilities {
@@ -382,10 +397,10 @@ func (p *TCPPeer) HandleVersionAck() error {
 		return errors.New("invalid handshake: received VersionAck, but no version sent yet")
 	}
 	if p.handShake&versionReceived == 0 {
-		return errors.New("invalid handshake: received VersionAck, but no version received yet")
+		return protocolViolation(errors.New("invalid handshake: received VersionAck, but no version received yet"))
 	}
 	if p.handShake&verAckReceived != 0 {
-		return errors.New("invalid handshake: already received VersionAck")
+		return protocolViolation(errors.New("invalid handshake: already received VersionAck"))
 	}
 	p.handShake |= verAckReceived
 	return nil
@@ -485,7 +500,7 @@ func (p *TCPPeer) HandlePong(pong *payload.Ping) error {
 	p.pingTimer = nil
 	p.pingSent--
 	if p.pingSent < 0 {
-		return errUnexpectedPong
+		return protocolViolation(errUnexpectedPong)
 	}
 	p.lastBlockIndex = pong.LastBlockIndex
 	return nil
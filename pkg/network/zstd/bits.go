@@ -0,0 +1,116 @@
+package zstd
+
+import (
+	"math/bits"
+)
+
+// backwardReader reads bitstreams written backwards (FSE and Huffman-coded
+// data). Bits are read starting from the highest one of the last byte (after
+// the padding marker), reading past the beginning of the stream returns
+// zeroes.
+type backwardReader struct {
+	b []byte
+	// pos is the number of bits left in the stream, it can be negative if
+	// the stream has been overread.
+	pos int
+}
+
+// forwardReader reads little-endian bitstreams (FSE table descriptions).
+type forwardReader struct {
+	b   []byte
+	pos int
+}
+
+// backwardWriter writes bitstreams to be read by backwardReader.
+type backwardWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits uint
+}
+
+func newBackwardReader(b []byte) (*backwardReader, error) {
+	if len(b) == 0 || b[len(b)-1] == 0 {
+		return nil, errCorrupted("invalid bitstream end")
+	}
+	return &backwardReader{
+		b:   b,
+		pos: (len(b)-1)*8 + bits.Len8(b[len(b)-1]) - 1,
+	}, nil
+}
+
+// peek returns the next n bits (n <= 56) without consuming them.
+func (r *backwardReader) peek(n uint8) uint64 {
+	if n == 0 || r.pos <= 0 {
+		return 0
+	}
+	var (
+		start = r.pos - int(n)
+		shift uint
+	)
+	if start < 0 {
+		shift = uint(-start)
+		start = 0
+	}
+	var v uint64
+	for i, j := 0, start>>3; i < 8 && j < len(r.b); i, j = i+1, j+1 {
+		v |= uint64(r.b[j]) << (8 * i)
+	}
+	v >>= uint(start & 7)
+	v &= 1<<uint(r.pos-start) - 1
+	return v << shift
+}
+
+// read reads n bits (n <= 56).
+func (r *backwardReader) read(n uint8) uint64 {
+	v := r.peek(n)
+	r.pos -= int(n)
+	return v
+}
+
+// read reads n bits (n <= 32).
+func (r *forwardReader) read(n uint) uint32 {
+	v := r.peek(n)
+	r.pos += int(n)
+	return v
+}
+
+// peek returns the next n bits (n <= 32) without consuming them, bits past
+// the end of the stream are zeroes.
+func (r *forwardReader) peek(n uint) uint32 {
+	var v uint64
+	for i, j := 0, r.pos>>3; i < 5 && j < len(r.b); i, j = i+1, j+1 {
+		v |= uint64(r.b[j]) << (8 * i)
+	}
+	v >>= uint(r.pos & 7)
+	return uint32(v & (1<<n - 1))
+}
+
+// overflow checks whether more bits than available were read.
+func (r *forwardReader) overflow() bool {
+	return r.pos > len(r.b)*8
+}
+
+// consumed returns the number of bytes (partially) read.
+func (r *forwardReader) consumed() int {
+	return (r.pos + 7) / 8
+}
+
+// add writes the lowest n bits (n <= 32) of v.
+func (w *backwardWriter) add(v uint64, n uint8) {
+	w.acc |= (v & (1<<n - 1)) << w.nbits
+	w.nbits += uint(n)
+	for w.nbits >= 8 {
+		w.buf = append(w.buf, byte(w.acc))
+		w.acc >>= 8
+		w.nbits -= 8
+	}
+}
+
+// close adds the end marker and returns the resulting stream.
+func (w *backwardWriter) close() []byte {
+	w.add(1, 1)
+	if w.nbits > 0 {
+		w.buf = append(w.buf, byte(w.acc))
+	}
+	return w.buf
+}
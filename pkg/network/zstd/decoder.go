@@ -0,0 +1,530 @@
+package zstd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+)
+
+// decoder contains the state of decompression.
+type decoder struct {
+	maxSize int
+	out     []byte
+
+	// Frame state, it's reset for every frame.
+	frameStart int
+	reps       [3]int
+	huff       *huffTable
+	llTable    *fseDecTable
+	ofTable    *fseDecTable
+	mlTable    *fseDecTable
+}
+
+const (
+	blockRaw = iota
+	blockRLE
+	blockCompressed
+	blockReserved
+)
+
+const (
+	literalsRaw = iota
+	literalsRLE
+	literalsCompressed
+	literalsTreeless
+)
+
+const (
+	modePredefined = iota
+	modeRLE
+	modeFSE
+	modeRepeat
+)
+
+// Literal length and match length codes baselines and the number of extra
+// bits, RFC 8878, 3.1.1.3.2.1.1.
+var (
+	llBaselines = [maxLLSymbol + 1]uint32{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096,
+		8192, 16384, 32768, 65536,
+	}
+	llExtraBits = [maxLLSymbol + 1]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+	mlBaselines = [maxMLSymbol + 1]uint32{
+		3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539,
+	}
+	mlExtraBits = [maxMLSymbol + 1]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11,
+		12, 13, 14, 15, 16,
+	}
+)
+
+func (d *decoder) decompress(src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, errCorrupted("no frames")
+	}
+	d.out = []byte{}
+	for len(src) > 0 {
+		if len(src) < 4 {
+			return nil, errCorrupted("truncated frame")
+		}
+		magic := binary.LittleEndian.Uint32(src)
+		if magic&skippableMagicMask == skippableMagicValue {
+			if len(src) < 8 {
+				return nil, errCorrupted("truncated skippable frame")
+			}
+			size := binary.LittleEndian.Uint32(src[4:])
+			if uint64(len(src)-8) < uint64(size) {
+				return nil, errCorrupted("truncated skippable frame")
+			}
+			src = src[8+int(size):]
+			continue
+		}
+		if magic != frameMagic {
+			return nil, errCorrupted("invalid magic")
+		}
+		n, err := d.decodeFrame(src[4:])
+		if err != nil {
+			return nil, err
+		}
+		src = src[4+n:]
+	}
+	return d.out, nil
+}
+
+// decodeFrame decodes a single frame (without magic number) and returns the
+// number of bytes consumed.
+func (d *decoder) decodeFrame(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, errCorrupted("truncated frame header")
+	}
+	var (
+		fhd         = b[0]
+		fcsFlag     = fhd >> 6
+		single      = fhd&0x20 != 0
+		hasChecksum = fhd&0x04 != 0
+		dictIDSize  = [4]int{0, 1, 2, 4}[fhd&3]
+		fcsSize     = [4]int{0, 2, 4, 8}[fcsFlag]
+		pos         = 1
+	)
+	if fhd&0x08 != 0 {
+		return 0, errCorrupted("reserved frame header bit is set")
+	}
+	if fcsFlag == 0 && single {
+		fcsSize = 1
+	}
+	if !single {
+		pos++ // Window descriptor, window size is limited by maxSize anyway.
+	}
+	if len(b) < pos+dictIDSize+fcsSize {
+		return 0, errCorrupted("truncated frame header")
+	}
+	var dictID uint32
+	for i := 0; i < dictIDSize; i++ {
+		dictID |= uint32(b[pos+i]) << (8 * i)
+	}
+	if dictID != 0 {
+		return 0, errors.New("zstd dictionaries are not supported")
+	}
+	pos += dictIDSize
+	var fcs uint64
+	for i := 0; i < fcsSize; i++ {
+		fcs |= uint64(b[pos+i]) << (8 * i)
+	}
+	if fcsSize == 2 {
+		fcs += 256
+	}
+	pos += fcsSize
+	if fcsSize != 0 && fcs > uint64(d.maxSize-len(d.out)) {
+		return 0, ErrTooBig
+	}
+
+	d.frameStart = len(d.out)
+	d.reps = [3]int{1, 4, 8}
+	d.huff, d.llTable, d.ofTable, d.mlTable = nil, nil, nil, nil
+	for last := false; !last; {
+		if len(b) < pos+3 {
+			return 0, errCorrupted("truncated block header")
+		}
+		h := uint32(b[pos]) | uint32(b[pos+1])<<8 | uint32(b[pos+2])<<16
+		pos += 3
+		last = h&1 != 0
+		size := int(h >> 3)
+		if size > maxBlockSize {
+			return 0, errCorrupted("block is too big")
+		}
+		switch h >> 1 & 3 {
+		case blockRaw:
+			if len(b) < pos+size {
+				return 0, errCorrupted("truncated block")
+			}
+			if err := d.grow(size); err != nil {
+				return 0, err
+			}
+			d.out = append(d.out, b[pos:pos+size]...)
+			pos += size
+		case blockRLE:
+			if len(b) < pos+1 {
+				return 0, errCorrupted("truncated block")
+			}
+			if err := d.grow(size); err != nil {
+				return 0, err
+			}
+			for i := 0; i < size; i++ {
+				d.out = append(d.out, b[pos])
+			}
+			pos++
+		case blockCompressed:
+			if len(b) < pos+size {
+				return 0, errCorrupted("truncated block")
+			}
+			if err := d.decodeBlock(b[pos : pos+size]); err != nil {
+				return 0, err
+			}
+			pos += size
+		default:
+			return 0, errCorrupted("reserved block type")
+		}
+	}
+	if fcsSize != 0 && uint64(len(d.out)-d.frameStart) != fcs {
+		return 0, errCorrupted("frame content size mismatch")
+	}
+	if hasChecksum {
+		if len(b) < pos+4 {
+			return 0, errCorrupted("truncated checksum")
+		}
+		if uint32(xxhash64(d.out[d.frameStart:])) != binary.LittleEndian.Uint32(b[pos:]) {
+			return 0, errCorrupted("checksum mismatch")
+		}
+		pos += 4
+	}
+	return pos, nil
+}
+
+// grow checks whether n more bytes can be added to the output.
+func (d *decoder) grow(n int) error {
+	if n > d.maxSize-len(d.out) {
+		return ErrTooBig
+	}
+	return nil
+}
+
+// decodeBlock decodes compressed block contents.
+func (d *decoder) decodeBlock(b []byte) error {
+	lits, n, err := d.decodeLiterals(b)
+	if err != nil {
+		return err
+	}
+	b = b[n:]
+	if len(b) == 0 {
+		return errCorrupted("no sequences section")
+	}
+	var (
+		nbSeq = int(b[0])
+		pos   = 1
+	)
+	switch {
+	case nbSeq == 0:
+		if len(b) != 1 {
+			return errCorrupted("trailing block data")
+		}
+		if err := d.grow(len(lits)); err != nil {
+			return err
+		}
+		d.out = append(d.out, lits...)
+		return nil
+	case nbSeq < 128:
+	case nbSeq < 255:
+		if len(b) < 2 {
+			return errCorrupted("truncated sequences header")
+		}
+		nbSeq = (nbSeq-128)<<8 + int(b[1])
+		pos = 2
+	default:
+		if len(b) < 3 {
+			return errCorrupted("truncated sequences header")
+		}
+		nbSeq = int(b[1]) + int(b[2])<<8 + 0x7F00
+		pos = 3
+	}
+	if len(b) < pos+1 {
+		return errCorrupted("truncated sequences header")
+	}
+	modes := b[pos]
+	pos++
+	if modes&3 != 0 {
+		return errCorrupted("reserved sequences mode bits are set")
+	}
+	d.llTable, n, err = readSeqTable(b[pos:], modes>>6, d.llTable, predefLLDec, maxLLSymbol, maxLLAccuracyLog)
+	if err != nil {
+		return err
+	}
+	pos += n
+	d.ofTable, n, err = readSeqTable(b[pos:], modes>>4&3, d.ofTable, predefOFDec, maxOFSymbol, maxOFAccuracyLog)
+	if err != nil {
+		return err
+	}
+	pos += n
+	d.mlTable, n, err = readSeqTable(b[pos:], modes>>2&3, d.mlTable, predefMLDec, maxMLSymbol, maxMLAccuracyLog)
+	if err != nil {
+		return err
+	}
+	pos += n
+	return d.execSequences(b[pos:], nbSeq, lits)
+}
+
+// readSeqTable reads the decoding table for the given mode, it returns the
+// table and the number of bytes consumed.
+func readSeqTable(b []byte, mode uint8, prev, predef *fseDecTable, maxSymbol int, maxLog uint8) (*fseDecTable, int, error) {
+	switch mode {
+	case modePredefined:
+		return predef, 0, nil
+	case modeRLE:
+		if len(b) == 0 {
+			return nil, 0, errCorrupted("truncated RLE sequence table")
+		}
+		if int(b[0]) > maxSymbol {
+			return nil, 0, errCorrupted("invalid RLE sequence symbol")
+		}
+		return newRLETable(b[0]), 1, nil
+	case modeFSE:
+		norm, accuracyLog, n, err := readDistribution(b, maxSymbol, maxLog)
+		if err != nil {
+			return nil, 0, err
+		}
+		t, err := newDecTable(norm, accuracyLog)
+		if err != nil {
+			return nil, 0, err
+		}
+		return t, n, nil
+	default:
+		if prev == nil {
+			return nil, 0, errCorrupted("no previous sequence table")
+		}
+		return prev, 0, nil
+	}
+}
+
+// execSequences decodes sequences from the bitstream and executes them.
+func (d *decoder) execSequences(b []byte, nbSeq int, lits []byte) error {
+	r, err := newBackwardReader(b)
+	if err != nil {
+		return err
+	}
+	var (
+		ll, of, ml = d.llTable, d.ofTable, d.mlTable
+		llState    = r.read(ll.accuracyLog)
+		ofState    = r.read(of.accuracyLog)
+		mlState    = r.read(ml.accuracyLog)
+	)
+	for i := 0; i < nbSeq; i++ {
+		var (
+			llEntry = ll.states[llState]
+			ofEntry = of.states[ofState]
+			mlEntry = ml.states[mlState]
+
+			ofValue   = 1<<ofEntry.symbol + int(r.read(ofEntry.symbol))
+			matchLen  = int(mlBaselines[mlEntry.symbol]) + int(r.read(mlExtraBits[mlEntry.symbol]))
+			litLen    = int(llBaselines[llEntry.symbol]) + int(r.read(llExtraBits[llEntry.symbol]))
+			offset, e = d.offset(ofValue, litLen)
+		)
+		if e != nil {
+			return e
+		}
+		if i != nbSeq-1 {
+			llState = uint64(llEntry.baseline) + r.read(llEntry.nbBits)
+			mlState = uint64(mlEntry.baseline) + r.read(mlEntry.nbBits)
+			ofState = uint64(ofEntry.baseline) + r.read(ofEntry.nbBits)
+		}
+
+		if litLen > len(lits) {
+			return errCorrupted("not enough literals")
+		}
+		if err := d.grow(litLen + matchLen); err != nil {
+			return err
+		}
+		d.out = append(d.out, lits[:litLen]...)
+		lits = lits[litLen:]
+		if offset > len(d.out)-d.frameStart {
+			return errCorrupted("invalid match offset")
+		}
+		for src := len(d.out) - offset; matchLen > 0; {
+			n := matchLen
+			if n > offset {
+				n = offset
+			}
+			d.out = append(d.out, d.out[src:src+n]...)
+			src += n
+			matchLen -= n
+		}
+	}
+	if r.pos != 0 {
+		return errCorrupted("invalid sequences bitstream size")
+	}
+	if err := d.grow(len(lits)); err != nil {
+		return err
+	}
+	d.out = append(d.out, lits...)
+	return nil
+}
+
+// offset converts offset value to the actual offset updating repeated offsets.
+func (d *decoder) offset(value int, litLen int) (int, error) {
+	if value > 3 {
+		offset := value - 3
+		d.reps = [3]int{offset, d.reps[0], d.reps[1]}
+		return offset, nil
+	}
+	if litLen == 0 {
+		value++
+	}
+	var offset int
+	switch value {
+	case 1:
+		return d.reps[0], nil
+	case 2:
+		offset = d.reps[1]
+		d.reps = [3]int{offset, d.reps[0], d.reps[2]}
+	case 3:
+		offset = d.reps[2]
+		d.reps = [3]int{offset, d.reps[0], d.reps[1]}
+	default:
+		offset = d.reps[0] - 1
+		if offset == 0 {
+			return 0, errCorrupted("invalid repeated offset")
+		}
+		d.reps = [3]int{offset, d.reps[0], d.reps[1]}
+	}
+	return offset, nil
+}
+
+// decodeLiterals decodes literals section, it returns literals and the
+// number of bytes consumed.
+func (d *decoder) decodeLiterals(b []byte) ([]byte, int, error) {
+	if len(b) == 0 {
+		return nil, 0, errCorrupted("no literals section")
+	}
+	var (
+		typ        = b[0] & 3
+		sizeFormat = b[0] >> 2 & 3
+	)
+	if typ == literalsRaw || typ == literalsRLE {
+		var size, hs int
+		switch sizeFormat {
+		case 0, 2:
+			size, hs = int(b[0]>>3), 1
+		case 1:
+			if len(b) < 2 {
+				return nil, 0, errCorrupted("truncated literals header")
+			}
+			size, hs = int(b[0]>>4)+int(b[1])<<4, 2
+		default:
+			if len(b) < 3 {
+				return nil, 0, errCorrupted("truncated literals header")
+			}
+			size, hs = int(b[0]>>4)+int(b[1])<<4+int(b[2])<<12, 3
+		}
+		if size > maxBlockSize {
+			return nil, 0, errCorrupted("too many literals")
+		}
+		if typ == literalsRLE {
+			if len(b) < hs+1 {
+				return nil, 0, errCorrupted("truncated literals")
+			}
+			return bytes.Repeat(b[hs:hs+1], size), hs + 1, nil
+		}
+		if len(b) < hs+size {
+			return nil, 0, errCorrupted("truncated literals")
+		}
+		return b[hs : hs+size], hs + size, nil
+	}
+
+	var (
+		regenSize, compSize, hs int
+		streams                 = 4
+	)
+	switch sizeFormat {
+	case 0, 1:
+		if sizeFormat == 0 {
+			streams = 1
+		}
+		if len(b) < 3 {
+			return nil, 0, errCorrupted("truncated literals header")
+		}
+		v := int(b[0]) | int(b[1])<<8 | int(b[2])<<16
+		regenSize, compSize, hs = v>>4&0x3FF, v>>14&0x3FF, 3
+	case 2:
+		if len(b) < 4 {
+			return nil, 0, errCorrupted("truncated literals header")
+		}
+		v := int(binary.LittleEndian.Uint32(b))
+		regenSize, compSize, hs = v>>4&0x3FFF, v>>18&0x3FFF, 4
+	default:
+		if len(b) < 5 {
+			return nil, 0, errCorrupted("truncated literals header")
+		}
+		v := int(binary.LittleEndian.Uint32(b)) | int(b[4])<<32
+		regenSize, compSize, hs = v>>4&0x3FFFF, v>>22&0x3FFFF, 5
+	}
+	if regenSize > maxBlockSize {
+		return nil, 0, errCorrupted("too many literals")
+	}
+	if len(b) < hs+compSize {
+		return nil, 0, errCorrupted("truncated literals")
+	}
+	data := b[hs : hs+compSize]
+	if typ == literalsCompressed {
+		t, n, err := readHuffTable(data)
+		if err != nil {
+			return nil, 0, err
+		}
+		d.huff = t
+		data = data[n:]
+	} else if d.huff == nil {
+		return nil, 0, errCorrupted("no previous Huffman table")
+	}
+
+	lits := make([]byte, regenSize)
+	if streams == 1 {
+		if err := d.huff.decode(lits, data); err != nil {
+			return nil, 0, err
+		}
+		return lits, hs + compSize, nil
+	}
+	if len(data) < 6 {
+		return nil, 0, errCorrupted("truncated jump table")
+	}
+	var (
+		sizes = [4]int{
+			int(binary.LittleEndian.Uint16(data)),
+			int(binary.LittleEndian.Uint16(data[2:])),
+			int(binary.LittleEndian.Uint16(data[4:])),
+		}
+		segment = (regenSize + 3) / 4
+	)
+	data = data[6:]
+	sizes[3] = len(data) - sizes[0] - sizes[1] - sizes[2]
+	if sizes[3] < 0 || regenSize < 3*segment {
+		return nil, 0, errCorrupted("invalid jump table")
+	}
+	for i := 0; i < 4; i++ {
+		end := (i + 1) * segment
+		if i == 3 {
+			end = regenSize
+		}
+		if err := d.huff.decode(lits[i*segment:end], data[:sizes[i]]); err != nil {
+			return nil, 0, err
+		}
+		data = data[sizes[i]:]
+	}
+	return lits, hs + compSize, nil
+}
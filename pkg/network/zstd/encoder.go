@@ -0,0 +1,461 @@
+package zstd
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+type (
+	// encoder is a greedy single-pass hash table based compressor.
+	encoder struct {
+		hashLog uint8
+		// table contains the last position (+1) for every hashed 4-byte
+		// sequence.
+		table []int32
+		reps  [3]int
+	}
+
+	// sequence is a pair of literals and a match, offset is an offset value
+	// (either a repeated offset code or an offset+3).
+	sequence struct {
+		litLen   int
+		matchLen int
+		offset   int
+	}
+)
+
+const (
+	minMatch = 4
+	// minFSESequences is the minimum number of sequences to use custom FSE
+	// tables for.
+	minFSESequences = 64
+	minHashLog      = 10
+	maxHashLog      = 16
+	maxOffValue     = 1<<(maxOFPredefSymbol+1) - 1
+
+	// maxOFPredefSymbol is the maximum offset code supported by predefined
+	// distribution.
+	maxOFPredefSymbol = 28
+)
+
+func newEncoder(size int) *encoder {
+	hashLog := bits.Len(uint(size))
+	if hashLog < minHashLog {
+		hashLog = minHashLog
+	}
+	if hashLog > maxHashLog {
+		hashLog = maxHashLog
+	}
+	return &encoder{
+		hashLog: uint8(hashLog),
+		table:   make([]int32, 1<<hashLog),
+		reps:    [3]int{1, 4, 8},
+	}
+}
+
+func (e *encoder) compress(src []byte) []byte {
+	var (
+		size = uint64(len(src))
+		dst  = make([]byte, 4, 16+len(src)/2)
+	)
+	binary.LittleEndian.PutUint32(dst, frameMagic)
+	// Single segment frames always have content size and it's used as the
+	// window size.
+	switch {
+	case size < 256:
+		dst = append(dst, 0x20, byte(size))
+	case size < 65536+256:
+		dst = append(dst, 0x60, byte(size-256), byte((size-256)>>8))
+	case size <= 0xFFFFFFFF:
+		dst = append(dst, 0xA0, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint32(dst[len(dst)-4:], uint32(size))
+	default:
+		dst = append(dst, 0xE0, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.LittleEndian.PutUint64(dst[len(dst)-8:], size)
+	}
+	if len(src) == 0 {
+		return appendBlockHeader(dst, blockRaw, 0, true)
+	}
+	for start := 0; start < len(src); start += maxBlockSize {
+		end := start + maxBlockSize
+		if end > len(src) {
+			end = len(src)
+		}
+		dst = e.appendBlock(dst, src, start, end, end == len(src))
+	}
+	return dst
+}
+
+func appendBlockHeader(dst []byte, typ int, size int, last bool) []byte {
+	h := uint32(size)<<3 | uint32(typ)<<1
+	if last {
+		h |= 1
+	}
+	return append(dst, byte(h), byte(h>>8), byte(h>>16))
+}
+
+// appendBlock compresses src[start:end] into a block, previous data are used
+// as a window.
+func (e *encoder) appendBlock(dst []byte, src []byte, start, end int, last bool) []byte {
+	block := src[start:end]
+	if isRLE(block) {
+		dst = appendBlockHeader(dst, blockRLE, len(block), last)
+		return append(dst, block[0])
+	}
+	var (
+		reps       = e.reps
+		seqs, lits = e.findSequences(src, start, end)
+	)
+	if len(seqs) != 0 {
+		var (
+			hdr  = len(dst)
+			body []byte
+		)
+		dst = appendBlockHeader(dst, blockCompressed, 0, last)
+		dst = appendLiterals(dst, lits)
+		dst = appendSequences(dst, seqs)
+		body = dst[hdr+3:]
+		if len(body) < len(block) {
+			appendBlockHeader(dst[:hdr], blockCompressed, len(body), last)
+			return dst
+		}
+		dst = dst[:hdr]
+		// The decoder won't see these sequences.
+		e.reps = reps
+	}
+	dst = appendBlockHeader(dst, blockRaw, len(block), last)
+	return append(dst, block...)
+}
+
+func isRLE(b []byte) bool {
+	for i := 1; i < len(b); i++ {
+		if b[i] != b[0] {
+			return false
+		}
+	}
+	return len(b) > 1
+}
+
+func (e *encoder) hash(v uint32) uint32 {
+	return (v * 2654435761) >> (32 - e.hashLog)
+}
+
+// findSequences finds matches in src[start:end] and returns sequences along
+// with all of the literals.
+func (e *encoder) findSequences(src []byte, start, end int) ([]sequence, []byte) {
+	var (
+		seqs   []sequence
+		lits   = make([]byte, 0, end-start)
+		anchor = start
+	)
+	for i := start; i+minMatch <= end; {
+		cur := binary.LittleEndian.Uint32(src[i:])
+		if i > anchor && i >= e.reps[0] && binary.LittleEndian.Uint32(src[i-e.reps[0]:]) == cur {
+			matchLen := minMatch + matchLength(src[i+minMatch:end], src[i-e.reps[0]+minMatch:])
+			lits = append(lits, src[anchor:i]...)
+			seqs = append(seqs, sequence{litLen: i - anchor, matchLen: matchLen, offset: 1})
+			i += matchLen
+			anchor = i
+			continue
+		}
+		var (
+			h    = e.hash(cur)
+			cand = int(e.table[h]) - 1
+		)
+		e.table[h] = int32(i + 1)
+		if cand < 0 || i-cand+3 > maxOffValue || binary.LittleEndian.Uint32(src[cand:]) != cur {
+			i += 1 + (i-anchor)>>6
+			continue
+		}
+		for i > anchor && cand > 0 && src[i-1] == src[cand-1] {
+			i--
+			cand--
+		}
+		var (
+			offset   = i - cand
+			matchLen = minMatch + matchLength(src[i+minMatch:end], src[cand+minMatch:])
+		)
+		lits = append(lits, src[anchor:i]...)
+		seqs = append(seqs, sequence{litLen: i - anchor, matchLen: matchLen, offset: offset + 3})
+		e.reps = [3]int{offset, e.reps[0], e.reps[1]}
+		i += matchLen
+		anchor = i
+		// Positions inside the match are likely to be useful later.
+		for _, p := range [2]int{i - matchLen + 1, i - 2} {
+			if p+minMatch <= end {
+				e.table[e.hash(binary.LittleEndian.Uint32(src[p:]))] = int32(p + 1)
+			}
+		}
+	}
+	lits = append(lits, src[anchor:end]...)
+	return seqs, lits
+}
+
+// matchLength returns the length of the common prefix of a and b.
+func matchLength(a, b []byte) int {
+	var n int
+	for n < len(a) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
+
+// appendLiterals appends literals section, Huffman-compressed if that's
+// beneficial.
+func appendLiterals(dst []byte, lits []byte) []byte {
+	if len(lits) >= minHuffLiterals {
+		var (
+			counts   = make([]int, 256)
+			distinct int
+		)
+		for _, b := range lits {
+			if counts[b] == 0 {
+				distinct++
+			}
+			counts[b]++
+		}
+		if distinct == 1 {
+			return append(appendLiteralsHeader(dst, literalsRLE, len(lits)), lits[0])
+		}
+		if res, ok := appendHuffLiterals(dst, lits, counts); ok {
+			return res
+		}
+	}
+	return append(appendLiteralsHeader(dst, literalsRaw, len(lits)), lits...)
+}
+
+// appendLiteralsHeader appends raw or RLE literals section header.
+func appendLiteralsHeader(dst []byte, typ int, size int) []byte {
+	switch {
+	case size < 32:
+		return append(dst, byte(typ|size<<3))
+	case size < 4096:
+		return append(dst, byte(typ|1<<2|size<<4), byte(size>>4))
+	default:
+		return append(dst, byte(typ|3<<2|size<<4), byte(size>>4), byte(size>>12))
+	}
+}
+
+// appendHuffLiterals appends Huffman-compressed literals section, it returns
+// false if it's not smaller than the raw one.
+func appendHuffLiterals(dst []byte, lits []byte, counts []int) ([]byte, bool) {
+	var (
+		lengths = huffLengths(counts)
+		last    int
+		maxBits uint8
+	)
+	for s, l := range lengths {
+		if l != 0 {
+			last = s
+		}
+		if l > maxBits {
+			maxBits = l
+		}
+	}
+	weights := make([]uint8, last)
+	for s := range weights {
+		if lengths[s] != 0 {
+			weights[s] = maxBits + 1 - lengths[s]
+		}
+	}
+	var (
+		hdr     = len(dst)
+		streams = 4
+		sf      = 3
+		codes   = huffCodes(lengths, maxBits)
+		ok      bool
+	)
+	dst = append(dst, 0, 0, 0, 0, 0)
+	dst, ok = appendHuffWeights(dst, weights)
+	if !ok {
+		return dst[:hdr], false
+	}
+	if len(lits) < 1024 {
+		streams = 1
+		dst = appendHuffStream(dst, lits, codes)
+	} else {
+		var (
+			segment = (len(lits) + 3) / 4
+			jump    = len(dst)
+		)
+		dst = append(dst, 0, 0, 0, 0, 0, 0)
+		for i := 0; i < 4; i++ {
+			var (
+				start = len(dst)
+				end   = (i + 1) * segment
+			)
+			if i == 3 {
+				end = len(lits)
+			}
+			dst = appendHuffStream(dst, lits[i*segment:end], codes)
+			if i < 3 {
+				size := len(dst) - start
+				if size > 0xFFFF {
+					return dst[:hdr], false
+				}
+				binary.LittleEndian.PutUint16(dst[jump+2*i:], uint16(size))
+			}
+		}
+	}
+	var (
+		regenSize = len(lits)
+		compSize  = len(dst) - hdr - 5
+		hs        = 5
+	)
+	switch {
+	case regenSize < 1<<10 && compSize < 1<<10:
+		hs, sf = 3, 1
+		if streams == 1 {
+			sf = 0
+		}
+	case regenSize < 1<<14 && compSize < 1<<14:
+		hs, sf = 4, 2
+	case regenSize >= 1<<18 || compSize >= 1<<18:
+		return dst[:hdr], false
+	}
+	if hs+compSize >= len(lits)+3 {
+		return dst[:hdr], false
+	}
+	var v uint64
+	switch hs {
+	case 3:
+		v = uint64(regenSize)<<4 | uint64(compSize)<<14
+	case 4:
+		v = uint64(regenSize)<<4 | uint64(compSize)<<18
+	default:
+		v = uint64(regenSize)<<4 | uint64(compSize)<<22
+	}
+	v |= uint64(literalsCompressed) | uint64(sf)<<2
+	for i := 0; i < hs; i++ {
+		dst[hdr+i] = byte(v >> (8 * i))
+	}
+	copy(dst[hdr+hs:], dst[hdr+5:])
+	return dst[:len(dst)-5+hs], true
+}
+
+// appendSequences appends sequences section.
+func appendSequences(dst []byte, seqs []sequence) []byte {
+	n := len(seqs)
+	switch {
+	case n < 128:
+		dst = append(dst, byte(n))
+	case n < 0x7F00:
+		dst = append(dst, byte(n>>8+128), byte(n))
+	default:
+		dst = append(dst, 255, byte(n-0x7F00), byte((n-0x7F00)>>8))
+	}
+
+	var (
+		codes   = make([][3]uint8, n)
+		columns = [3][]uint8{make([]uint8, n), make([]uint8, n), make([]uint8, n)}
+	)
+	for i := range seqs {
+		codes[i] = [3]uint8{llCode(seqs[i].litLen), ofCode(seqs[i].offset), mlCode(seqs[i].matchLen)}
+		for j := range codes[i] {
+			columns[j][i] = codes[i][j]
+		}
+	}
+	var (
+		modes = len(dst)
+		llt   *fseEncTable
+		oft   *fseEncTable
+		mlt   *fseEncTable
+		mode  uint8
+	)
+	dst = append(dst, 0)
+	dst, llt, mode = appendSeqTable(dst, columns[0], maxLLSymbol, maxLLAccuracyLog, predefLLEnc)
+	dst[modes] |= mode << 6
+	dst, oft, mode = appendSeqTable(dst, columns[1], maxOFPredefSymbol, maxOFAccuracyLog, predefOFEnc)
+	dst[modes] |= mode << 4
+	dst, mlt, mode = appendSeqTable(dst, columns[2], maxMLSymbol, maxMLAccuracyLog, predefMLEnc)
+	dst[modes] |= mode << 2
+
+	var (
+		w          = backwardWriter{buf: dst}
+		ll, of, ml fseEncoder
+	)
+	ll.init(llt, codes[n-1][0])
+	of.init(oft, codes[n-1][1])
+	ml.init(mlt, codes[n-1][2])
+	addExtraBits(&w, seqs[n-1], codes[n-1])
+	for i := n - 2; i >= 0; i-- {
+		of.encode(&w, codes[i][1])
+		ml.encode(&w, codes[i][2])
+		ll.encode(&w, codes[i][0])
+		addExtraBits(&w, seqs[i], codes[i])
+	}
+	ml.flush(&w)
+	of.flush(&w)
+	ll.flush(&w)
+	return w.close()
+}
+
+// appendSeqTable chooses the encoding table for sequence codes and appends its
+// description, it returns the table (nil for RLE) and the mode used.
+func appendSeqTable(dst []byte, codes []uint8, maxSymbol int, maxLog uint8, predef *fseEncTable) ([]byte, *fseEncTable, uint8) {
+	var (
+		counts   = make([]int, maxSymbol+1)
+		distinct int
+	)
+	for _, c := range codes {
+		if counts[c] == 0 {
+			distinct++
+		}
+		counts[c]++
+	}
+	if distinct == 1 && len(codes) > 1 {
+		return append(dst, codes[0]), nil, modeRLE
+	}
+	if len(codes) < minFSESequences {
+		return dst, predef, modePredefined
+	}
+	accuracyLog := uint8(bits.Len(uint(len(codes))) - 2)
+	for accuracyLog < maxLog && 1<<accuracyLog < 2*distinct {
+		accuracyLog++
+	}
+	if accuracyLog < minAccuracyLog {
+		accuracyLog = minAccuracyLog
+	}
+	if accuracyLog > maxLog {
+		accuracyLog = maxLog
+	}
+	norm := normalizeCounts(counts, len(codes), accuracyLog)
+	if norm == nil {
+		return dst, predef, modePredefined
+	}
+	return appendDistribution(dst, norm, accuracyLog), newEncTable(norm, accuracyLog), modeFSE
+}
+
+// addExtraBits writes sequence values in the reverse order of reading them.
+func addExtraBits(w *backwardWriter, s sequence, codes [3]uint8) {
+	w.add(uint64(s.litLen)-uint64(llBaselines[codes[0]]), llExtraBits[codes[0]])
+	w.add(uint64(s.matchLen)-uint64(mlBaselines[codes[2]]), mlExtraBits[codes[2]])
+	w.add(uint64(s.offset), codes[1])
+}
+
+func llCode(litLen int) uint8 {
+	if litLen < 16 {
+		return uint8(litLen)
+	}
+	return findCode(llBaselines[:], litLen)
+}
+
+func mlCode(matchLen int) uint8 {
+	if matchLen < 35 {
+		return uint8(matchLen - 3)
+	}
+	return findCode(mlBaselines[:], matchLen)
+}
+
+func ofCode(offset int) uint8 {
+	return uint8(bits.Len(uint(offset)) - 1)
+}
+
+// findCode returns the last code with the baseline not exceeding v.
+func findCode(baselines []uint32, v int) uint8 {
+	i := len(baselines) - 1
+	for int(baselines[i]) > v {
+		i--
+	}
+	return uint8(i)
+}
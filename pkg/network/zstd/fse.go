@@ -0,0 +1,414 @@
+package zstd
+
+import (
+	"math/bits"
+)
+
+type (
+	// fseDecEntry is a single FSE decoding table state.
+	fseDecEntry struct {
+		symbol   uint8
+		nbBits   uint8
+		baseline uint16
+	}
+
+	// fseDecTable is an FSE decoding table.
+	fseDecTable struct {
+		accuracyLog uint8
+		states      []fseDecEntry
+	}
+
+	// fseEncSymbol contains parameters used to encode a symbol.
+	fseEncSymbol struct {
+		deltaFindState int32
+		deltaNbBits    uint32
+	}
+
+	// fseEncTable is an FSE encoding table.
+	fseEncTable struct {
+		accuracyLog uint8
+		states      []uint16
+		symbols     []fseEncSymbol
+	}
+
+	// fseEncoder is an FSE encoder state, encoders with nil table don't
+	// write anything (that's what RLE mode needs).
+	fseEncoder struct {
+		t     *fseEncTable
+		state uint32
+	}
+)
+
+const (
+	minAccuracyLog = 5
+
+	maxLLSymbol = 35
+	maxMLSymbol = 52
+	maxOFSymbol = 31
+
+	maxLLAccuracyLog = 9
+	maxMLAccuracyLog = 9
+	maxOFAccuracyLog = 8
+
+	predefLLAccuracyLog = 6
+	predefMLAccuracyLog = 6
+	predefOFAccuracyLog = 5
+)
+
+// Predefined distributions from RFC 8878, 3.1.1.3.2.2.
+var (
+	predefLLDist = []int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	}
+	predefMLDist = []int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	}
+	predefOFDist = []int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	}
+
+	predefLLDec = mustDecTable(predefLLDist, predefLLAccuracyLog)
+	predefMLDec = mustDecTable(predefMLDist, predefMLAccuracyLog)
+	predefOFDec = mustDecTable(predefOFDist, predefOFAccuracyLog)
+
+	predefLLEnc = newEncTable(predefLLDist, predefLLAccuracyLog)
+	predefMLEnc = newEncTable(predefMLDist, predefMLAccuracyLog)
+	predefOFEnc = newEncTable(predefOFDist, predefOFAccuracyLog)
+)
+
+func mustDecTable(norm []int16, accuracyLog uint8) *fseDecTable {
+	t, err := newDecTable(norm, accuracyLog)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// spreadSymbols distributes symbols over the table according to their
+// normalized counts, it returns symbols for each state.
+func spreadSymbols(norm []int16, accuracyLog uint8) ([]uint8, error) {
+	var (
+		size          = 1 << accuracyLog
+		mask          = size - 1
+		highThreshold = size - 1
+		table         = make([]uint8, size)
+		total         int
+	)
+	for s, c := range norm {
+		if c == -1 {
+			total++
+			if highThreshold < 0 {
+				return nil, errCorrupted("invalid FSE distribution")
+			}
+			table[highThreshold] = uint8(s)
+			highThreshold--
+		} else {
+			total += int(c)
+		}
+	}
+	if total != size {
+		return nil, errCorrupted("invalid FSE distribution")
+	}
+	var (
+		step = (size >> 1) + (size >> 3) + 3
+		pos  int
+	)
+	for s, c := range norm {
+		for i := 0; i < int(c); i++ {
+			table[pos] = uint8(s)
+			pos = (pos + step) & mask
+			for pos > highThreshold {
+				pos = (pos + step) & mask
+			}
+		}
+	}
+	if pos != 0 {
+		return nil, errCorrupted("invalid FSE distribution")
+	}
+	return table, nil
+}
+
+func newDecTable(norm []int16, accuracyLog uint8) (*fseDecTable, error) {
+	symbols, err := spreadSymbols(norm, accuracyLog)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		size = 1 << accuracyLog
+		next = make([]int, len(norm))
+		t    = &fseDecTable{
+			accuracyLog: accuracyLog,
+			states:      make([]fseDecEntry, size),
+		}
+	)
+	for s, c := range norm {
+		if c == -1 {
+			next[s] = 1
+		} else {
+			next[s] = int(c)
+		}
+	}
+	for u, s := range symbols {
+		n := next[s]
+		next[s]++
+		nbBits := int(accuracyLog) - (bits.Len(uint(n)) - 1)
+		t.states[u] = fseDecEntry{
+			symbol:   s,
+			nbBits:   uint8(nbBits),
+			baseline: uint16(n<<nbBits - size),
+		}
+	}
+	return t, nil
+}
+
+// newRLETable returns a decoding table that always returns the same symbol.
+func newRLETable(symbol uint8) *fseDecTable {
+	return &fseDecTable{states: []fseDecEntry{{symbol: symbol}}}
+}
+
+// readDistribution reads FSE table description (normalized counts). It
+// returns the distribution, accuracy log and the number of bytes consumed.
+func readDistribution(b []byte, maxSymbol int, maxLog uint8) ([]int16, uint8, int, error) {
+	var (
+		r           = forwardReader{b: b}
+		accuracyLog = uint8(r.read(4)) + minAccuracyLog
+	)
+	if accuracyLog > maxLog {
+		return nil, 0, 0, errCorrupted("FSE accuracy log is too big")
+	}
+	var (
+		norm      = make([]int16, 0, maxSymbol+1)
+		remaining = 1<<accuracyLog + 1
+		threshold = 1 << accuracyLog
+		nbBits    = uint(accuracyLog) + 1
+		prev0     bool
+	)
+	for remaining > 1 {
+		if prev0 {
+			for {
+				n := r.read(2)
+				for i := uint32(0); i < n; i++ {
+					norm = append(norm, 0)
+				}
+				if n != 3 || r.overflow() {
+					break
+				}
+			}
+		}
+		if len(norm) > maxSymbol {
+			return nil, 0, 0, errCorrupted("too many FSE symbols")
+		}
+		var (
+			max   = 2*threshold - 1 - remaining
+			count = int(r.peek(nbBits - 1))
+		)
+		if count < max {
+			r.pos += int(nbBits - 1)
+		} else {
+			count = int(r.read(nbBits))
+			if count >= threshold {
+				count -= max
+			}
+		}
+		if r.overflow() {
+			return nil, 0, 0, errCorrupted("truncated FSE table")
+		}
+		count--
+		if count < 0 {
+			remaining--
+		} else {
+			remaining -= count
+		}
+		norm = append(norm, int16(count))
+		prev0 = count == 0
+		for remaining < threshold {
+			nbBits--
+			threshold >>= 1
+		}
+	}
+	if remaining != 1 {
+		return nil, 0, 0, errCorrupted("invalid FSE distribution")
+	}
+	return norm, accuracyLog, r.consumed(), nil
+}
+
+func newEncTable(norm []int16, accuracyLog uint8) *fseEncTable {
+	symbols, err := spreadSymbols(norm, accuracyLog)
+	if err != nil {
+		panic(err)
+	}
+	var (
+		size  = 1 << accuracyLog
+		cumul = make([]int, len(norm)+1)
+		t     = &fseEncTable{
+			accuracyLog: accuracyLog,
+			states:      make([]uint16, size),
+			symbols:     make([]fseEncSymbol, len(norm)),
+		}
+	)
+	for s, c := range norm {
+		if c == -1 {
+			c = 1
+		}
+		cumul[s+1] = cumul[s] + int(c)
+	}
+	for u, s := range symbols {
+		t.states[cumul[s]] = uint16(size + u)
+		cumul[s]++
+	}
+	var total int
+	for s, c := range norm {
+		switch c {
+		case 0:
+			t.symbols[s].deltaNbBits = uint32(int(accuracyLog+1)<<16 - size)
+		case -1, 1:
+			t.symbols[s].deltaNbBits = uint32(int(accuracyLog)<<16 - size)
+			t.symbols[s].deltaFindState = int32(total - 1)
+			total++
+		default:
+			maxBitsOut := int(accuracyLog) - (bits.Len(uint(c-1)) - 1)
+			minStatePlus := int(c) << maxBitsOut
+			t.symbols[s].deltaNbBits = uint32(maxBitsOut<<16 - minStatePlus)
+			t.symbols[s].deltaFindState = int32(total - int(c))
+			total += int(c)
+		}
+	}
+	return t
+}
+
+// init initializes the encoder with the first symbol to encode (that is the
+// last one to be decoded).
+func (e *fseEncoder) init(t *fseEncTable, symbol uint8) {
+	e.t = t
+	if t == nil {
+		return
+	}
+	st := t.symbols[symbol]
+	nbBitsOut := (st.deltaNbBits + 1<<15) >> 16
+	value := nbBitsOut<<16 - st.deltaNbBits
+	e.state = uint32(t.states[int32(value>>nbBitsOut)+st.deltaFindState])
+}
+
+// encode writes the bits needed to get to the current state from the state
+// of the given symbol.
+func (e *fseEncoder) encode(w *backwardWriter, symbol uint8) {
+	if e.t == nil {
+		return
+	}
+	st := e.t.symbols[symbol]
+	nbBitsOut := (e.state + st.deltaNbBits) >> 16
+	w.add(uint64(e.state), uint8(nbBitsOut))
+	e.state = uint32(e.t.states[int32(e.state>>nbBitsOut)+st.deltaFindState])
+}
+
+// flush writes the final encoder state.
+func (e *fseEncoder) flush(w *backwardWriter) {
+	if e.t == nil {
+		return
+	}
+	w.add(uint64(e.state), e.t.accuracyLog)
+}
+
+// normalizeCounts approximates symbol counts with a distribution having the
+// sum of 1<<accuracyLog. It returns nil if that's not possible.
+func normalizeCounts(counts []int, total int, accuracyLog uint8) []int16 {
+	var (
+		size      = 1 << accuracyLog
+		norm      = make([]int16, len(counts))
+		remaining = size
+		largest   = -1
+	)
+	for s, c := range counts {
+		if c == 0 {
+			continue
+		}
+		p := c * size / total
+		if p == 0 {
+			norm[s] = -1
+			remaining--
+			continue
+		}
+		if (c*size%total)*2 >= total {
+			p++
+		}
+		norm[s] = int16(p)
+		remaining -= p
+		if largest < 0 || c > counts[largest] {
+			largest = s
+		}
+	}
+	if largest < 0 || int(norm[largest])+remaining <= 0 {
+		return nil
+	}
+	norm[largest] += int16(remaining)
+	return norm
+}
+
+// appendDistribution appends FSE table description, it's the reverse of
+// readDistribution.
+func appendDistribution(dst []byte, norm []int16, accuracyLog uint8) []byte {
+	var (
+		acc       uint64
+		nbAcc     uint
+		remaining = 1<<accuracyLog + 1
+		threshold = 1 << accuracyLog
+		nbBits    = uint(accuracyLog) + 1
+		prev0     bool
+		put       = func(v int, n uint) {
+			acc |= uint64(v) << nbAcc
+			nbAcc += n
+			for nbAcc >= 8 {
+				dst = append(dst, byte(acc))
+				acc >>= 8
+				nbAcc -= 8
+			}
+		}
+	)
+	put(int(accuracyLog-minAccuracyLog), 4)
+	for s := 0; remaining > 1 && s < len(norm); {
+		if prev0 {
+			start := s
+			for norm[s] == 0 {
+				s++
+			}
+			for ; s >= start+3; start += 3 {
+				put(3, 2)
+			}
+			put(s-start, 2)
+		}
+		var (
+			count = int(norm[s])
+			max   = 2*threshold - 1 - remaining
+		)
+		s++
+		if count < 0 {
+			remaining--
+		} else {
+			remaining -= count
+		}
+		count++
+		if count >= threshold {
+			count += max
+		}
+		if count < max {
+			put(count, nbBits-1)
+		} else {
+			put(count, nbBits)
+		}
+		prev0 = count == 1
+		for remaining < threshold {
+			nbBits--
+			threshold >>= 1
+		}
+	}
+	if nbAcc > 0 {
+		dst = append(dst, byte(acc))
+	}
+	return dst
+}
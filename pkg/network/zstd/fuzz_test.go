@@ -0,0 +1,47 @@
+package zstd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// maxFuzzSize is the decompressed data limit used for fuzzing, it's lower
+// than the P2P payload limit to speed things up, but it's still bigger than
+// the maximum block size.
+const maxFuzzSize = 1 << 20
+
+func FuzzDecompress(f *testing.F) {
+	files, err := filepath.Glob(filepath.Join("testdata", "*.zst"))
+	require.NoError(f, err)
+	for _, name := range files {
+		data, err := os.ReadFile(name)
+		require.NoError(f, err)
+		f.Add(data)
+	}
+	sample, err := os.ReadFile(filepath.Join("testdata", "sample.txt"))
+	require.NoError(f, err)
+	f.Add(Compress(sample))
+	f.Add(Compress(make([]byte, 3*maxBlockSize+1)))
+	f.Add(Compress(nil))
+
+	f.Fuzz(func(t *testing.T, src []byte) {
+		var (
+			res []byte
+			err error
+		)
+		require.NotPanics(t, func() { res, err = Decompress(src, maxFuzzSize) })
+		if err != nil {
+			return
+		}
+		require.LessOrEqual(t, len(res), maxFuzzSize)
+
+		// Whatever is decompressed successfully must survive compression.
+		actual, err := Decompress(Compress(res), len(res))
+		require.NoError(t, err)
+		require.Equal(t, len(res), len(actual))
+		require.Equal(t, res, actual)
+	})
+}
@@ -0,0 +1,335 @@
+package zstd
+
+import (
+	"bytes"
+	"math/bits"
+	"sort"
+)
+
+type (
+	// huffEntry is a single Huffman decoding table entry.
+	huffEntry struct {
+		symbol uint8
+		nbBits uint8
+	}
+
+	// huffTable is a Huffman decoding table indexed by the next maxBits
+	// bits of the stream.
+	huffTable struct {
+		maxBits uint8
+		entries []huffEntry
+	}
+)
+
+const (
+	maxHuffBits       = 11
+	maxHuffWeightsLog = 6
+)
+
+// readHuffTable reads Huffman tree description, it returns the decoding
+// table and the number of bytes consumed.
+func readHuffTable(b []byte) (*huffTable, int, error) {
+	if len(b) == 0 {
+		return nil, 0, errCorrupted("no Huffman tree description")
+	}
+	var (
+		header   = int(b[0])
+		weights  []uint8
+		consumed int
+	)
+	if header < 128 {
+		consumed = 1 + header
+		if len(b) < consumed || header == 0 {
+			return nil, 0, errCorrupted("truncated Huffman tree description")
+		}
+		var err error
+		weights, err = readHuffWeights(b[1:consumed])
+		if err != nil {
+			return nil, 0, err
+		}
+	} else {
+		n := header - 127
+		consumed = 1 + (n+1)/2
+		if len(b) < consumed {
+			return nil, 0, errCorrupted("truncated Huffman tree description")
+		}
+		weights = make([]uint8, n)
+		for i := range weights {
+			w := b[1+i/2]
+			if i%2 == 0 {
+				w >>= 4
+			}
+			weights[i] = w & 0xf
+		}
+	}
+	t, err := newHuffTable(weights)
+	if err != nil {
+		return nil, 0, err
+	}
+	return t, consumed, nil
+}
+
+// readHuffWeights decodes FSE-compressed Huffman weights.
+func readHuffWeights(b []byte) ([]uint8, error) {
+	norm, accuracyLog, n, err := readDistribution(b, maxHuffBits+1, maxHuffWeightsLog)
+	if err != nil {
+		return nil, err
+	}
+	t, err := newDecTable(norm, accuracyLog)
+	if err != nil {
+		return nil, err
+	}
+	r, err := newBackwardReader(b[n:])
+	if err != nil {
+		return nil, err
+	}
+	var (
+		weights = make([]uint8, 0, 255)
+		s1      = uint16(r.read(accuracyLog))
+		s2      = uint16(r.read(accuracyLog))
+	)
+	for len(weights) < 254 {
+		e := t.states[s1]
+		weights = append(weights, e.symbol)
+		s1 = e.baseline + uint16(r.read(e.nbBits))
+		if r.pos < 0 {
+			weights = append(weights, t.states[s2].symbol)
+			return weights, nil
+		}
+		e = t.states[s2]
+		weights = append(weights, e.symbol)
+		s2 = e.baseline + uint16(r.read(e.nbBits))
+		if r.pos < 0 {
+			weights = append(weights, t.states[s1].symbol)
+			return weights, nil
+		}
+	}
+	return nil, errCorrupted("too many Huffman weights")
+}
+
+// newHuffTable creates a decoding table from the list of weights (the last
+// one is implied).
+func newHuffTable(weights []uint8) (*huffTable, error) {
+	var total uint32
+	for _, w := range weights {
+		if w > maxHuffBits {
+			return nil, errCorrupted("invalid Huffman weight")
+		}
+		if w > 0 {
+			total += 1 << (w - 1)
+		}
+	}
+	if total == 0 {
+		return nil, errCorrupted("invalid Huffman weights")
+	}
+	maxBits := bits.Len32(total)
+	rest := uint32(1)<<maxBits - total
+	if maxBits > maxHuffBits || rest&(rest-1) != 0 {
+		return nil, errCorrupted("invalid Huffman weights")
+	}
+	weights = append(weights, uint8(bits.Len32(rest)))
+
+	var (
+		t = &huffTable{
+			maxBits: uint8(maxBits),
+			entries: make([]huffEntry, 1<<maxBits),
+		}
+		pos int
+	)
+	for w := 1; w <= maxBits; w++ {
+		for s := range weights {
+			if int(weights[s]) != w {
+				continue
+			}
+			e := huffEntry{symbol: uint8(s), nbBits: uint8(maxBits + 1 - w)}
+			for i := 0; i < 1<<(w-1); i++ {
+				t.entries[pos+i] = e
+			}
+			pos += 1 << (w - 1)
+		}
+	}
+	return t, nil
+}
+
+// decode decodes a single Huffman-coded stream into dst.
+func (t *huffTable) decode(dst []byte, stream []byte) error {
+	r, err := newBackwardReader(stream)
+	if err != nil {
+		return err
+	}
+	for i := range dst {
+		e := t.entries[r.peek(t.maxBits)]
+		dst[i] = e.symbol
+		r.pos -= int(e.nbBits)
+	}
+	if r.pos != 0 {
+		return errCorrupted("invalid Huffman stream size")
+	}
+	return nil
+}
+
+type (
+	// huffCode is a Huffman code of a symbol.
+	huffCode struct {
+		code   uint16
+		nbBits uint8
+	}
+
+	// huffNode is a Huffman tree node used to calculate code lengths.
+	huffNode struct {
+		count  int
+		parent int
+	}
+)
+
+// minHuffLiterals is the minimum number of literals worth compressing.
+const minHuffLiterals = 64
+
+// huffLengths calculates code lengths (not exceeding maxHuffBits) for
+// symbols with the given counts, at least two symbols must be present.
+func huffLengths(counts []int) []uint8 {
+	counts = append([]int(nil), counts...)
+	for {
+		lengths := huffTreeLengths(counts)
+		var max uint8
+		for _, l := range lengths {
+			if l > max {
+				max = l
+			}
+		}
+		if max <= maxHuffBits {
+			return lengths
+		}
+		// Flatten the distribution until it fits.
+		for i := range counts {
+			counts[i] = (counts[i] + 1) / 2
+		}
+	}
+}
+
+// huffTreeLengths builds Huffman tree and returns code lengths.
+func huffTreeLengths(counts []int) []uint8 {
+	var leaves []int
+	for s, c := range counts {
+		if c > 0 {
+			leaves = append(leaves, s)
+		}
+	}
+	sort.SliceStable(leaves, func(i, j int) bool { return counts[leaves[i]] < counts[leaves[j]] })
+
+	// Leaves are nodes 0..n-1, internal ones follow them in the order of
+	// creation, their counts are non-decreasing.
+	nodes := make([]huffNode, len(leaves), 2*len(leaves)-1)
+	for i, s := range leaves {
+		nodes[i] = huffNode{count: counts[s]}
+	}
+	var leaf, inner = 0, len(leaves)
+	pick := func() int {
+		if leaf < len(leaves) && (inner >= len(nodes) || nodes[leaf].count <= nodes[inner].count) {
+			leaf++
+			return leaf - 1
+		}
+		inner++
+		return inner - 1
+	}
+	for len(nodes) < cap(nodes) {
+		a, b := pick(), pick()
+		nodes = append(nodes, huffNode{count: nodes[a].count + nodes[b].count})
+		nodes[a].parent = len(nodes) - 1
+		nodes[b].parent = len(nodes) - 1
+	}
+
+	var (
+		depths  = make([]uint8, len(nodes))
+		lengths = make([]uint8, len(counts))
+	)
+	for i := len(nodes) - 2; i >= 0; i-- {
+		depths[i] = depths[nodes[i].parent] + 1
+	}
+	for i, s := range leaves {
+		lengths[s] = depths[i]
+	}
+	return lengths
+}
+
+// huffCodes returns canonical codes for the given code lengths, they're
+// assigned the same way newHuffTable does.
+func huffCodes(lengths []uint8, maxBits uint8) []huffCode {
+	var (
+		codes = make([]huffCode, len(lengths))
+		pos   int
+	)
+	for w := 1; w <= int(maxBits); w++ {
+		for s, l := range lengths {
+			if l == 0 || int(maxBits)+1-int(l) != w {
+				continue
+			}
+			codes[s] = huffCode{code: uint16(pos >> (w - 1)), nbBits: l}
+			pos += 1 << (w - 1)
+		}
+	}
+	return codes
+}
+
+// appendHuffWeights appends Huffman tree description for the given weights
+// (the last one is implied), it returns false if weights can't be described.
+func appendHuffWeights(dst []byte, weights []uint8) ([]byte, bool) {
+	var best []byte
+	if len(weights) >= 2 {
+		var (
+			counts      = make([]int, maxHuffBits+1)
+			accuracyLog = uint8(maxHuffWeightsLog)
+		)
+		for _, w := range weights {
+			counts[w]++
+		}
+		if len(weights) < 1<<maxHuffWeightsLog {
+			accuracyLog = minAccuracyLog
+		}
+		if norm := normalizeCounts(counts, len(weights), accuracyLog); norm != nil {
+			var (
+				t      = newEncTable(norm, accuracyLog)
+				desc   = appendDistribution(nil, norm, accuracyLog)
+				w      = backwardWriter{buf: desc}
+				states [2]fseEncoder
+				n      = len(weights)
+			)
+			states[(n-1)%2].init(t, weights[n-1])
+			states[(n-2)%2].init(t, weights[n-2])
+			for i := n - 3; i >= 0; i-- {
+				states[i%2].encode(&w, weights[i])
+			}
+			states[1].flush(&w)
+			states[0].flush(&w)
+			stream := w.close()
+			// Decoding stops on stream overflow which doesn't happen for
+			// some final states, so the result is checked.
+			if len(stream) < 128 {
+				if dec, err := readHuffWeights(stream); err == nil && bytes.Equal(dec, weights) {
+					best = append([]byte{byte(len(stream))}, stream...)
+				}
+			}
+		}
+	}
+	if len(weights) <= 128 && (best == nil || len(best) > 1+(len(weights)+1)/2) {
+		best = append(best[:0], byte(127+len(weights)))
+		for i := 0; i < len(weights); i += 2 {
+			b := weights[i] << 4
+			if i+1 < len(weights) {
+				b |= weights[i+1]
+			}
+			best = append(best, b)
+		}
+	}
+	return append(dst, best...), best != nil
+}
+
+// appendHuffStream appends a Huffman-coded stream of src.
+func appendHuffStream(dst []byte, src []byte, codes []huffCode) []byte {
+	w := backwardWriter{buf: dst}
+	for i := len(src) - 1; i >= 0; i-- {
+		c := codes[src[i]]
+		w.add(uint64(c.code), c.nbBits)
+	}
+	return w.close()
+}
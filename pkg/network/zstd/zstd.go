@@ -0,0 +1,53 @@
+/*
+Package zstd implements Zstandard (RFC 8878) compression used for P2P
+message payloads.
+
+The decoder supports all of the format features except dictionaries. The
+encoder is a simple and fast one, it produces single-frame output with raw
+literals and predefined sequence codes that any conforming decoder can
+handle.
+
+It's a standalone implementation because the module doesn't depend on any
+Zstandard library (github.com/klauspost/compress is not among its
+dependencies) and P2P payloads don't need most of their features. The decoder
+is meant to process untrusted network data: the output can't exceed the
+caller-provided size (the frame header and every block are checked against it
+before the output grows), blocks are limited to 128 KiB, table descriptions
+are validated against the format's bounds and any malformed input returns an
+error. This is checked by FuzzDecompress seeded with frames produced by the
+reference implementation.
+*/
+package zstd
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	frameMagic          = 0xFD2FB528
+	skippableMagicMask  = 0xFFFFFFF0
+	skippableMagicValue = 0x184D2A50
+
+	maxBlockSize = 128 * 1024
+)
+
+// ErrTooBig is returned when decompressed data exceed the limit.
+var ErrTooBig = errors.New("decompressed data are too big")
+
+func errCorrupted(msg string) error {
+	return fmt.Errorf("corrupted zstd data: %s", msg)
+}
+
+// Compress compresses src into a single Zstandard frame.
+func Compress(src []byte) []byte {
+	e := newEncoder(len(src))
+	return e.compress(src)
+}
+
+// Decompress decompresses all of the Zstandard frames from src, the result
+// can't be bigger than maxSize bytes.
+func Decompress(src []byte, maxSize int) ([]byte, error) {
+	d := decoder{maxSize: maxSize}
+	return d.decompress(src)
+}
@@ -0,0 +1,142 @@
+package zstd
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecompressReference(t *testing.T) {
+	sample, err := os.ReadFile(filepath.Join("testdata", "sample.txt"))
+	require.NoError(t, err)
+
+	// Files are compressed with the reference zstd implementation.
+	testCases := map[string][]byte{
+		"sample.1.zst":        sample,
+		"sample.19.zst":       sample,
+		"sample.nocheck.zst":  sample,
+		"sample12.19.zst":     bytes.Repeat(sample, 12),
+		"sample12.nosize.zst": bytes.Repeat(sample, 12),
+		"zeros.zst":           make([]byte, 300000),
+		"empty.zst":           {},
+		"frames.zst":          append(append([]byte{}, sample...), sample...),
+	}
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			src, err := os.ReadFile(filepath.Join("testdata", name))
+			require.NoError(t, err)
+			actual, err := Decompress(src, len(expected))
+			require.NoError(t, err)
+			require.Equal(t, expected, actual)
+
+			if len(expected) > 0 {
+				_, err = Decompress(src, len(expected)-1)
+				require.ErrorIs(t, err, ErrTooBig)
+			}
+		})
+	}
+}
+
+func TestCompressDecompress(t *testing.T) {
+	sample, err := os.ReadFile(filepath.Join("testdata", "sample.txt"))
+	require.NoError(t, err)
+
+	r := rand.New(rand.NewSource(1))
+	random := make([]byte, 200000)
+	r.Read(random)
+	mixed := make([]byte, 300000)
+	for i := range mixed {
+		if i > 16 && r.Intn(3) == 0 {
+			mixed[i] = mixed[i-1-r.Intn(16)]
+		} else {
+			mixed[i] = byte(r.Intn(16))
+		}
+	}
+
+	testCases := map[string][]byte{
+		"empty":      {},
+		"single":     {42},
+		"short":      []byte("neo-go"),
+		"sample":     sample,
+		"multiblock": bytes.Repeat(sample, 20),
+		"zeros":      make([]byte, 3*maxBlockSize+1),
+		"random":     random,
+		"mixed":      mixed,
+	}
+	for _, size := range []int{255, 256, 65791, 65792, maxBlockSize, maxBlockSize + 1} {
+		testCases[fmt.Sprintf("size %d", size)] = bytes.Repeat(sample, size/len(sample)+1)[:size]
+	}
+	for name, data := range testCases {
+		t.Run(name, func(t *testing.T) {
+			compressed := Compress(data)
+			actual, err := Decompress(compressed, len(data))
+			require.NoError(t, err)
+			require.Equal(t, data, actual)
+		})
+	}
+
+	t.Run("ratio", func(t *testing.T) {
+		require.Less(t, len(Compress(sample)), len(sample)/2)
+		require.Less(t, len(Compress(random)), len(random)+len(random)/100)
+	})
+}
+
+func TestDecompressInvalid(t *testing.T) {
+	valid, err := os.ReadFile(filepath.Join("testdata", "sample.1.zst"))
+	require.NoError(t, err)
+
+	check := func(t *testing.T, src []byte) {
+		_, err := Decompress(src, 1<<20)
+		require.Error(t, err)
+	}
+	t.Run("empty", func(t *testing.T) {
+		check(t, []byte{})
+	})
+	t.Run("magic", func(t *testing.T) {
+		src := append([]byte{}, valid...)
+		src[0]++
+		check(t, src)
+	})
+	t.Run("truncated", func(t *testing.T) {
+		for _, n := range []int{3, 4, 6, 10, len(valid) / 2, len(valid) - 1} {
+			check(t, valid[:n])
+		}
+	})
+	t.Run("checksum", func(t *testing.T) {
+		src := append([]byte{}, valid...)
+		src[len(src)-1]++
+		check(t, src)
+	})
+	t.Run("trailing garbage", func(t *testing.T) {
+		check(t, append(append([]byte{}, valid...), 1, 2, 3, 4, 5))
+	})
+	t.Run("dictionary", func(t *testing.T) {
+		src := []byte{0x28, 0xB5, 0x2F, 0xFD}
+		check(t, append(src, 0x21, 1, 0, 1, 0, 0))
+	})
+	t.Run("reserved block", func(t *testing.T) {
+		src := []byte{0x28, 0xB5, 0x2F, 0xFD}
+		check(t, append(src, 0x20, 0, 7, 0, 0))
+	})
+	t.Run("offset", func(t *testing.T) {
+		// A single sequence with no literals and offset 4 (repeated one)
+		// before the start of the frame.
+		src := []byte{0x28, 0xB5, 0x2F, 0xFD}
+		src = append(src, 0x20, 4)
+		src = appendBlockHeader(src, blockCompressed, 4, true)
+		check(t, append(src, 0, 1, 0, 0x80))
+	})
+	t.Run("mutations", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for i := 0; i < 1000; i++ {
+			src := append([]byte{}, valid...)
+			src[r.Intn(len(src))] ^= byte(1 << r.Intn(8))
+			require.NotPanics(t, func() { _, _ = Decompress(src, 1<<20) })
+		}
+	})
+}
@@ -6,6 +6,11 @@ simplifies creating, signing and sending transactions to the network (since
 that's the only way chain state is changed). It's generic enough to be used for
 any contract that you may want to invoke and contract-specific functions can
 build on top of it.
+
+Transactions that are stuck in the memory pool (because of insufficient network
+fee for example) can be replaced with the ones paying more using Conflicts
+attribute (see MakeReplacement and WaitWithFeeBump), provided that the network
+has P2PSigExtensions enabled.
 */
 package actor
 
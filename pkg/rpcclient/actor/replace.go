@@ -0,0 +1,173 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ErrReplacementNotSupported is returned from replacement methods when the
+// network doesn't support Conflicts attribute (P2PSigExtensions are disabled).
+var ErrReplacementNotSupported = errors.New("transaction replacement requires P2PSigExtensions")
+
+// FeeBumpPolicy defines the way WaitWithFeeBump replaces a pending transaction
+// if it's not accepted to the chain in time.
+type FeeBumpPolicy struct {
+	// Interval is the number of blocks to wait for the transaction (or any of
+	// its replacements) before creating the next replacement. Zero value means
+	// one block.
+	Interval uint32
+	// Increase is the network fee increment (in GAS fractions) used for each
+	// replacement, it must be positive.
+	Increase int64
+	// MaxNetworkFee is the upper network fee limit, transaction won't be
+	// replaced if its replacement would exceed this value. Zero value means no
+	// limit.
+	MaxNetworkFee int64
+}
+
+// MakeReplacement creates a signed transaction that replaces the given pending
+// one (see also MakeUnsignedReplacement). It can then be sent to the network
+// with Send, the node will then drop the original transaction from its memory
+// pool in favor of the replacement.
+func (a *Actor) MakeReplacement(tx *transaction.Transaction, netFeeIncrease int64) (*transaction.Transaction, error) {
+	r, err := a.MakeUnsignedReplacement(tx, netFeeIncrease)
+	if err != nil {
+		return nil, err
+	}
+	err = a.Sign(r)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// MakeUnsignedReplacement creates an unsigned transaction that has the same
+// script, system fee, signers and ValidUntilBlock as the given one, but also
+// contains a Conflicts attribute pointing to it and pays higher network fee.
+// The resulting network fee is the original one increased by netFeeIncrease
+// (but not less than required for the new transaction), which makes the new
+// transaction to be preferred over the original one by memory pools. All
+// attributes of the original transaction are retained (including its
+// Conflicts), so that a replacement can be replaced as well without the risk
+// of getting the original transaction accepted. The given transaction must be
+// signed by the same set of accounts that is used by Actor and the network must
+// have P2PSigExtensions enabled for Conflicts attribute to be accepted.
+// TransactionModifier is not applied to the result of this method.
+func (a *Actor) MakeUnsignedReplacement(tx *transaction.Transaction, netFeeIncrease int64) (*transaction.Transaction, error) {
+	if !a.version.Protocol.P2PSigExtensions {
+		return nil, ErrReplacementNotSupported
+	}
+	if netFeeIncrease <= 0 {
+		return nil, errors.New("network fee increase must be positive")
+	}
+	if len(tx.Signers) != len(a.txSigners) {
+		return nil, errors.New("incorrect number of signers in the transaction")
+	}
+	for i := range tx.Signers {
+		if !tx.Signers[i].Account.Equals(a.txSigners[i].Account) {
+			return nil, fmt.Errorf("signer #%d (%s) doesn't match actor's one", i, tx.Signers[i].Account.StringLE())
+		}
+	}
+
+	var origHash = tx.Hash()
+	r := transaction.New(tx.Script, tx.SystemFee)
+	r.Signers = tx.Signers
+	r.ValidUntilBlock = tx.ValidUntilBlock
+	r.Attributes = make([]transaction.Attribute, 0, len(tx.Attributes)+1)
+	r.Attributes = append(r.Attributes, tx.Attributes...)
+	r.Attributes = append(r.Attributes, transaction.Attribute{
+		Type:  transaction.ConflictsT,
+		Value: &transaction.Conflicts{Hash: origHash},
+	})
+	if len(r.Attributes)+len(r.Signers) > transaction.MaxAttributes {
+		return nil, fmt.Errorf("too many attributes for a replacement (limit is %d including signers)", transaction.MaxAttributes)
+	}
+
+	r.Scripts = make([]transaction.Witness, len(a.signers))
+	for i := range a.signers {
+		if !a.signers[i].Account.Contract.Deployed {
+			r.Scripts[i].VerificationScript = a.signers[i].Account.Contract.Script
+		}
+	}
+	minFee, err := a.client.CalculateNetworkFee(r)
+	if err != nil {
+		return nil, fmt.Errorf("calculating network fee: %w", err)
+	}
+	r.NetworkFee = tx.NetworkFee + netFeeIncrease
+	if r.NetworkFee < minFee {
+		r.NetworkFee = minFee
+	}
+	return r, nil
+}
+
+// SendReplacement creates a transaction replacing the given one (see also
+// MakeReplacement) and sends it to the network. It returns the hash of the
+// new transaction and its ValidUntilBlock value (which is the same as the
+// original one).
+func (a *Actor) SendReplacement(tx *transaction.Transaction, netFeeIncrease int64) (util.Uint256, uint32, error) {
+	return a.sendWrapper(a.MakeReplacement(tx, netFeeIncrease))
+}
+
+// WaitWithFeeBump waits for the given (already sent) transaction to be accepted
+// to the chain, replacing it with transactions paying higher network fee (see
+// MakeReplacement) according to the policy given. Every time the transaction
+// (and all of its replacements sent so far) is not accepted within
+// policy.Interval blocks a new replacement is sent, this continues until one of
+// them is accepted or ValidUntilBlock of the original transaction is reached
+// (or policy.MaxNetworkFee doesn't allow to make another replacement, then this
+// method just waits for any of the transactions already sent). It returns the
+// execution result of the transaction accepted which can be the original one or
+// any of its replacements. Waiter of the Actor is used for awaiting, so it must
+// support it and ctx can be used to interrupt the process.
+func (a *Actor) WaitWithFeeBump(ctx context.Context, tx *transaction.Transaction, policy FeeBumpPolicy) (*state.AppExecResult, error) {
+	if policy.Increase <= 0 {
+		return nil, errors.New("network fee increase must be positive")
+	}
+	var (
+		interval = policy.Interval
+		vub      = tx.ValidUntilBlock
+		hashes   = []util.Uint256{tx.Hash()}
+		cur      = tx
+	)
+	if interval == 0 {
+		interval = 1
+	}
+	for {
+		blockCount, err := a.client.GetBlockCount()
+		if err != nil {
+			return nil, fmt.Errorf("can't get block count: %w", err)
+		}
+		var deadline = blockCount + interval - 1
+		if deadline > vub {
+			deadline = vub
+		}
+		res, err := a.Waiter.WaitAny(ctx, deadline, hashes...)
+		if err == nil || deadline == vub || !errors.Is(err, ErrTxNotAccepted) {
+			return res, err
+		}
+		next, err := a.MakeReplacement(cur, policy.Increase)
+		if err == nil && policy.MaxNetworkFee != 0 && next.NetworkFee > policy.MaxNetworkFee {
+			return a.Waiter.WaitAny(ctx, vub, hashes...)
+		}
+		if err == nil {
+			_, _, err = a.Send(next)
+		}
+		if err != nil {
+			// The original transaction might be already accepted or
+			// replacement is not possible for some other reason, but
+			// transactions sent so far can still get into the chain.
+			res, waitErr := a.Waiter.WaitAny(ctx, vub, hashes...)
+			if waitErr != nil {
+				return nil, fmt.Errorf("%w (failed to replace transaction: %v)", waitErr, err)
+			}
+			return res, nil
+		}
+		hashes = append(hashes, next.Hash())
+		cur = next
+	}
+}
@@ -0,0 +1,209 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+type waitAnyCall struct {
+	vub    uint32
+	hashes []util.Uint256
+}
+
+// bumpWaiter emulates chain progress: each WaitAny call moves the chain up to
+// the given vub and accepts the transaction number acceptAt (if any).
+type bumpWaiter struct {
+	client   *RPCClient
+	acceptAt int
+	calls    []waitAnyCall
+}
+
+func (w *bumpWaiter) Wait(h util.Uint256, vub uint32, err error) (*state.AppExecResult, error) {
+	return w.WaitAny(context.TODO(), vub, h)
+}
+
+func (w *bumpWaiter) WaitAny(ctx context.Context, vub uint32, hashes ...util.Uint256) (*state.AppExecResult, error) {
+	w.calls = append(w.calls, waitAnyCall{vub: vub, hashes: append([]util.Uint256{}, hashes...)})
+	if w.acceptAt >= 0 && w.acceptAt < len(hashes) {
+		return &state.AppExecResult{Container: hashes[w.acceptAt]}, nil
+	}
+	w.client.bCount.Store(vub + 1)
+	return nil, ErrTxNotAccepted
+}
+
+func testReplacementActor(t *testing.T) (*RPCClient, *Actor) {
+	client, acc := testRPCAndAccount(t)
+	client.version.Protocol.P2PSigExtensions = true
+	client.netFee = 10
+	a, err := NewSimple(client, acc)
+	require.NoError(t, err)
+	return client, a
+}
+
+func TestMakeReplacement(t *testing.T) {
+	client, a := testReplacementActor(t)
+	script := []byte{1, 2, 3}
+	tx, err := a.MakeUncheckedRun(script, 42, []transaction.Attribute{{Type: transaction.HighPriority}}, nil)
+	require.NoError(t, err)
+
+	// Bad fee increase.
+	_, err = a.MakeReplacement(tx, 0)
+	require.Error(t, err)
+
+	// Good.
+	r, err := a.MakeReplacement(tx, 5)
+	require.NoError(t, err)
+	require.Equal(t, tx.Script, r.Script)
+	require.Equal(t, tx.SystemFee, r.SystemFee)
+	require.Equal(t, tx.ValidUntilBlock, r.ValidUntilBlock)
+	require.Equal(t, tx.Signers, r.Signers)
+	require.Equal(t, tx.NetworkFee+5, r.NetworkFee)
+	require.True(t, r.HasAttribute(transaction.HighPriority))
+	require.Equal(t, []transaction.Attribute{{
+		Type:  transaction.ConflictsT,
+		Value: &transaction.Conflicts{Hash: tx.Hash()},
+	}}, r.GetAttributes(transaction.ConflictsT))
+	require.Equal(t, 1, len(r.Scripts))
+	require.NotEqual(t, tx.Hash(), r.Hash())
+
+	// Replacement of a replacement keeps all conflicts.
+	rr, err := a.MakeReplacement(r, 5)
+	require.NoError(t, err)
+	require.Equal(t, tx.NetworkFee+10, rr.NetworkFee)
+	require.Equal(t, 2, len(rr.GetAttributes(transaction.ConflictsT)))
+
+	// Network fee can't be lower than required.
+	client.netFee = 100
+	r, err = a.MakeUnsignedReplacement(tx, 5)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), r.NetworkFee)
+	require.Nil(t, r.Scripts[0].InvocationScript)
+
+	// Network fee calculation error.
+	client.err = errors.New("")
+	_, err = a.MakeReplacement(tx, 5)
+	require.Error(t, err)
+	client.err = nil
+
+	// Too many attributes.
+	big := *tx
+	big.Attributes = make([]transaction.Attribute, transaction.MaxAttributes-1)
+	for i := range big.Attributes {
+		big.Attributes[i] = transaction.Attribute{Type: transaction.ConflictsT, Value: &transaction.Conflicts{Hash: util.Uint256{byte(i)}}}
+	}
+	_, err = a.MakeReplacement(&big, 5)
+	require.Error(t, err)
+
+	// Signers mismatch.
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	other, err := NewSimple(client, acc)
+	require.NoError(t, err)
+	_, err = other.MakeReplacement(tx, 5)
+	require.Error(t, err)
+	tx2 := *tx
+	tx2.Signers = append(tx2.Signers, transaction.Signer{})
+	_, err = a.MakeReplacement(&tx2, 5)
+	require.Error(t, err)
+
+	// No P2PSigExtensions.
+	client.version.Protocol.P2PSigExtensions = false
+	noExt, err := NewSimple(client, acc)
+	require.NoError(t, err)
+	_, err = noExt.MakeReplacement(tx, 5)
+	require.ErrorIs(t, err, ErrReplacementNotSupported)
+}
+
+func TestSendReplacement(t *testing.T) {
+	client, a := testReplacementActor(t)
+	tx, err := a.MakeUncheckedRun([]byte{1, 2, 3}, 42, nil, nil)
+	require.NoError(t, err)
+
+	client.hash = util.Uint256{1, 2, 3}
+	h, vub, err := a.SendReplacement(tx, 5)
+	require.NoError(t, err)
+	require.Equal(t, client.hash, h)
+	require.Equal(t, tx.ValidUntilBlock, vub)
+
+	_, _, err = a.SendReplacement(tx, -1)
+	require.Error(t, err)
+}
+
+func TestWaitWithFeeBump(t *testing.T) {
+	newTx := func(t *testing.T) (*RPCClient, *Actor, *bumpWaiter, *transaction.Transaction) {
+		client, a := testReplacementActor(t)
+		client.bCount.Store(1)
+		w := &bumpWaiter{client: client, acceptAt: -1}
+		a.Waiter = w
+		tx, err := a.MakeUncheckedRun([]byte{1, 2, 3}, 42, nil, nil)
+		require.NoError(t, err)
+		tx.ValidUntilBlock = 10
+		return client, a, w, tx
+	}
+
+	t.Run("bad policy", func(t *testing.T) {
+		_, a, _, tx := newTx(t)
+		_, err := a.WaitWithFeeBump(context.Background(), tx, FeeBumpPolicy{})
+		require.Error(t, err)
+	})
+	t.Run("accepted immediately", func(t *testing.T) {
+		_, a, w, tx := newTx(t)
+		w.acceptAt = 0
+		res, err := a.WaitWithFeeBump(context.Background(), tx, FeeBumpPolicy{Increase: 5})
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), res.Container)
+		require.Equal(t, []waitAnyCall{{vub: 1, hashes: []util.Uint256{tx.Hash()}}}, w.calls)
+	})
+	t.Run("replacement accepted", func(t *testing.T) {
+		_, a, w, tx := newTx(t)
+		w.acceptAt = 2
+		res, err := a.WaitWithFeeBump(context.Background(), tx, FeeBumpPolicy{Interval: 3, Increase: 5})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(w.calls))
+		require.Equal(t, uint32(3), w.calls[0].vub)
+		require.Equal(t, uint32(6), w.calls[1].vub)
+		require.Equal(t, uint32(9), w.calls[2].vub)
+		require.Equal(t, tx.Hash(), w.calls[2].hashes[0])
+		require.Equal(t, w.calls[2].hashes[2], res.Container)
+	})
+	t.Run("expired", func(t *testing.T) {
+		_, a, w, tx := newTx(t)
+		_, err := a.WaitWithFeeBump(context.Background(), tx, FeeBumpPolicy{Interval: 4, Increase: 5})
+		require.ErrorIs(t, err, ErrTxNotAccepted)
+		require.Equal(t, 3, len(w.calls))
+		require.Equal(t, uint32(10), w.calls[2].vub)
+		require.Equal(t, 3, len(w.calls[2].hashes))
+	})
+	t.Run("max fee", func(t *testing.T) {
+		_, a, w, tx := newTx(t)
+		_, err := a.WaitWithFeeBump(context.Background(), tx, FeeBumpPolicy{Interval: 2, Increase: 5, MaxNetworkFee: tx.NetworkFee + 5})
+		require.ErrorIs(t, err, ErrTxNotAccepted)
+		require.Equal(t, 3, len(w.calls))
+		require.Equal(t, uint32(10), w.calls[2].vub)
+		require.Equal(t, 2, len(w.calls[2].hashes))
+	})
+	t.Run("replacement error", func(t *testing.T) {
+		_, a, w, tx := newTx(t)
+		a.version.Protocol.P2PSigExtensions = false
+		_, err := a.WaitWithFeeBump(context.Background(), tx, FeeBumpPolicy{Increase: 5})
+		require.ErrorIs(t, err, ErrTxNotAccepted)
+		require.Contains(t, err.Error(), ErrReplacementNotSupported.Error())
+		require.Equal(t, []waitAnyCall{
+			{vub: 1, hashes: []util.Uint256{tx.Hash()}},
+			{vub: 10, hashes: []util.Uint256{tx.Hash()}},
+		}, w.calls)
+	})
+	t.Run("not supported", func(t *testing.T) {
+		_, a, _, tx := newTx(t)
+		a.Waiter = NewNullWaiter()
+		_, err := a.WaitWithFeeBump(context.Background(), tx, FeeBumpPolicy{Increase: 5})
+		require.ErrorIs(t, err, ErrAwaitingNotSupported)
+	})
+}
@@ -0,0 +1,279 @@
+package rpcclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Batch is a set of RPC calls that are sent to the server as a single JSON-RPC
+// batch request. It's created with Client.NewBatch, then calls are queued with
+// its methods (that mirror the respective Client methods) and finally Send
+// performs all of them at once. Batch is not thread-safe and can't be reused
+// after Send.
+type Batch struct {
+	c     *Client
+	calls []batchCall
+	sent  bool
+}
+
+// batchCall is a single queued call with a function that converts the raw
+// JSON result into the appropriate Go value.
+type batchCall struct {
+	method string
+	params []interface{}
+	decode func(json.RawMessage) (interface{}, error)
+}
+
+// BatchResult is a result of a single call from the Batch. Value has the same
+// type the respective Client method returns (like *block.Block for
+// GetBlockByIndex or uint32 for GetBlockCount), it's nil if Err is not nil.
+type BatchResult struct {
+	Value interface{}
+	Err   error
+}
+
+// ErrBatchSent is returned from Batch.Send if it was already used.
+var ErrBatchSent = errors.New("batch is already sent")
+
+// NewBatch creates an empty batch of RPC calls.
+func (c *Client) NewBatch() *Batch {
+	return &Batch{c: c}
+}
+
+// Len returns the number of calls queued in the batch.
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// add queues a call, newResp creates a value to unmarshal the result into and
+// post (if not nil) converts it to the final value.
+func (b *Batch) add(method string, params []interface{}, newResp func() interface{}, post func(interface{}) (interface{}, error)) {
+	if params == nil {
+		params = []interface{}{} // neo-project/neo-modules#742
+	}
+	b.calls = append(b.calls, batchCall{
+		method: method,
+		params: params,
+		decode: func(raw json.RawMessage) (interface{}, error) {
+			resp := newResp()
+			if err := json.Unmarshal(raw, resp); err != nil {
+				return nil, err
+			}
+			if post != nil {
+				return post(resp)
+			}
+			return resp, nil
+		},
+	})
+}
+
+// Send performs all queued calls as a single JSON-RPC batch request and returns
+// their results in the same order calls were added. The error returned is
+// a transport-level one (or the one affecting the whole batch), call-specific
+// errors are returned via BatchResult.
+func (b *Batch) Send() ([]BatchResult, error) {
+	if b.sent {
+		return nil, ErrBatchSent
+	}
+	var (
+		calls = b.calls
+		reqs  = make([]*neorpc.Request, len(calls))
+		ids   = make(map[uint64]int, len(calls))
+		res   = make([]BatchResult, len(calls))
+	)
+	b.sent = true
+	if len(calls) == 0 {
+		return res, nil
+	}
+	for i := range calls {
+		reqs[i] = &neorpc.Request{
+			JSONRPC: neorpc.JSONRPCVersion,
+			Method:  calls[i].method,
+			Params:  calls[i].params,
+			ID:      b.c.getNextRequestID(),
+		}
+		ids[reqs[i].ID] = i
+	}
+	resps, err := b.c.batchF(reqs)
+	if err != nil {
+		return nil, err
+	}
+	var done = make([]bool, len(calls))
+	for _, resp := range resps {
+		if resp == nil {
+			continue
+		}
+		id, err := strconv.ParseUint(string(resp.ID), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response ID %s: %w", string(resp.ID), err)
+		}
+		i, ok := ids[id]
+		if !ok || done[i] {
+			return nil, fmt.Errorf("unexpected response ID %d", id)
+		}
+		done[i] = true
+		switch {
+		case resp.Error != nil:
+			res[i].Err = resp.Error
+		case resp.Result == nil:
+			res[i].Err = errors.New("no result returned")
+		default:
+			res[i].Value, res[i].Err = calls[i].decode(resp.Result)
+		}
+	}
+	for i := range done {
+		if !done[i] {
+			res[i].Err = errors.New("no response returned")
+		}
+	}
+	return res, nil
+}
+
+// GetApplicationLog queues GetApplicationLog call, the result is
+// *result.ApplicationLog.
+func (b *Batch) GetApplicationLog(hash util.Uint256, trig *trigger.Type) {
+	var params = []interface{}{hash.StringLE()}
+	if trig != nil {
+		params = append(params, trig.String())
+	}
+	b.add("getapplicationlog", params, func() interface{} { return new(result.ApplicationLog) }, nil)
+}
+
+// GetBestBlockHash queues GetBestBlockHash call, the result is util.Uint256.
+func (b *Batch) GetBestBlockHash() {
+	b.add("getbestblockhash", nil, func() interface{} { return new(util.Uint256) }, func(v interface{}) (interface{}, error) {
+		return *v.(*util.Uint256), nil
+	})
+}
+
+// GetBlockCount queues GetBlockCount call, the result is uint32.
+func (b *Batch) GetBlockCount() {
+	b.add("getblockcount", nil, func() interface{} { return new(uint32) }, func(v interface{}) (interface{}, error) {
+		return *v.(*uint32), nil
+	})
+}
+
+// GetBlockByIndex queues GetBlockByIndex call, the result is *block.Block.
+// In-header stateroot option must be initialized with Init before sending
+// the batch.
+func (b *Batch) GetBlockByIndex(index uint32) {
+	b.getBlock(index)
+}
+
+// GetBlockByHash queues GetBlockByHash call, the result is *block.Block.
+// In-header stateroot option must be initialized with Init before sending
+// the batch.
+func (b *Batch) GetBlockByHash(hash util.Uint256) {
+	b.getBlock(hash.StringLE())
+}
+
+func (b *Batch) getBlock(param interface{}) {
+	b.add("getblock", []interface{}{param}, func() interface{} { return new([]byte) }, func(v interface{}) (interface{}, error) {
+		return b.c.decodeBlock(*v.(*[]byte))
+	})
+}
+
+// GetBlockByIndexVerbose queues GetBlockByIndexVerbose call, the result is
+// *result.Block. In-header stateroot option must be initialized with Init
+// before sending the batch.
+func (b *Batch) GetBlockByIndexVerbose(index uint32) {
+	b.getBlockVerbose(index)
+}
+
+// GetBlockByHashVerbose queues GetBlockByHashVerbose call, the result is
+// *result.Block. In-header stateroot option must be initialized with Init
+// before sending the batch.
+func (b *Batch) GetBlockByHashVerbose(hash util.Uint256) {
+	b.getBlockVerbose(hash.StringLE())
+}
+
+func (b *Batch) getBlockVerbose(param interface{}) {
+	b.calls = append(b.calls, batchCall{
+		method: "getblock",
+		params: []interface{}{param, 1}, // 1 for verbose.
+		decode: func(raw json.RawMessage) (interface{}, error) {
+			sr, err := b.c.StateRootInHeader()
+			if err != nil {
+				return nil, err
+			}
+			resp := new(result.Block)
+			resp.Header.StateRootEnabled = sr
+			if err = json.Unmarshal(raw, resp); err != nil {
+				return nil, err
+			}
+			return resp, nil
+		},
+	})
+}
+
+// GetBlockHash queues GetBlockHash call, the result is util.Uint256.
+func (b *Batch) GetBlockHash(index uint32) {
+	b.add("getblockhash", []interface{}{index}, func() interface{} { return new(util.Uint256) }, func(v interface{}) (interface{}, error) {
+		return *v.(*util.Uint256), nil
+	})
+}
+
+// GetBlockNotifications queues GetBlockNotifications call, the result is
+// *result.BlockNotifications.
+func (b *Batch) GetBlockNotifications(hash util.Uint256, filter *neorpc.NotificationFilter) {
+	var params = []interface{}{hash.StringLE()}
+	if filter != nil {
+		params = append(params, *filter)
+	}
+	b.add("getblocknotifications", params, func() interface{} { return new(result.BlockNotifications) }, nil)
+}
+
+// GetContractStateByHash queues GetContractStateByHash call, the result is
+// *state.Contract.
+func (b *Batch) GetContractStateByHash(hash util.Uint160) {
+	b.add("getcontractstate", []interface{}{hash.StringLE()}, func() interface{} { return new(state.Contract) }, nil)
+}
+
+// GetRawTransaction queues GetRawTransaction call, the result is
+// *transaction.Transaction.
+func (b *Batch) GetRawTransaction(hash util.Uint256) {
+	b.add("getrawtransaction", []interface{}{hash.StringLE()}, func() interface{} { return new([]byte) }, func(v interface{}) (interface{}, error) {
+		return transaction.NewTransactionFromBytes(*v.(*[]byte))
+	})
+}
+
+// GetRawTransactionVerbose queues GetRawTransactionVerbose call, the result is
+// *result.TransactionOutputRaw.
+func (b *Batch) GetRawTransactionVerbose(hash util.Uint256) {
+	b.add("getrawtransaction", []interface{}{hash.StringLE(), 1}, func() interface{} { return new(result.TransactionOutputRaw) }, nil)
+}
+
+// GetTransactionHeight queues GetTransactionHeight call, the result is uint32.
+func (b *Batch) GetTransactionHeight(hash util.Uint256) {
+	b.add("gettransactionheight", []interface{}{hash.StringLE()}, func() interface{} { return new(uint32) }, func(v interface{}) (interface{}, error) {
+		return *v.(*uint32), nil
+	})
+}
+
+// InvokeFunction queues InvokeFunction call, the result is *result.Invoke.
+func (b *Batch) InvokeFunction(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) {
+	var p = []interface{}{contract.StringLE(), operation, params}
+	if signers != nil {
+		p = append(p, signers)
+	}
+	b.add("invokefunction", p, func() interface{} { return new(result.Invoke) }, nil)
+}
+
+// InvokeScript queues InvokeScript call, the result is *result.Invoke.
+func (b *Batch) InvokeScript(script []byte, signers []transaction.Signer) {
+	var p = []interface{}{script}
+	if signers != nil {
+		p = append(p, signers)
+	}
+	b.add("invokescript", p, func() interface{} { return new(result.Invoke) }, nil)
+}
@@ -0,0 +1,70 @@
+package rpcclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatch(t *testing.T) {
+	var response string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, err := w.Write([]byte(response))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+
+	newBatch := func() *Batch {
+		c.latestReqID.Store(0)
+		b := c.NewBatch()
+		b.GetBlockCount()
+		b.GetBlockHash(1)
+		b.GetTransactionHeight(util.Uint256{})
+		return b
+	}
+
+	t.Run("good", func(t *testing.T) {
+		// Responses are intentionally reordered and one is missing.
+		response = `[{"jsonrpc":"2.0","id":3,"error":{"code":-100,"message":"Unknown transaction"}},` +
+			`{"jsonrpc":"2.0","id":1,"result":42}]`
+		res, err := newBatch().Send()
+		require.NoError(t, err)
+		require.Equal(t, 3, len(res))
+		require.NoError(t, res[0].Err)
+		require.Equal(t, uint32(42), res[0].Value)
+		require.Error(t, res[1].Err)
+		require.Nil(t, res[1].Value)
+		var rpcErr *neorpc.Error
+		require.ErrorAs(t, res[2].Err, &rpcErr)
+		require.Equal(t, int64(-100), rpcErr.Code)
+	})
+	t.Run("bad result", func(t *testing.T) {
+		response = `[{"jsonrpc":"2.0","id":1,"result":"notanumber"},{"jsonrpc":"2.0","id":2,"result":"0x773dd2dae4a9c9275290f89b56e67d7363ea4826dfd4fc13cc01cf73a44b0d0e"}]`
+		res, err := newBatch().Send()
+		require.NoError(t, err)
+		require.Error(t, res[0].Err)
+		require.NoError(t, res[1].Err)
+		require.IsType(t, util.Uint256{}, res[1].Value)
+	})
+	t.Run("whole batch error", func(t *testing.T) {
+		response = `{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"Invalid Request"}}`
+		_, err := newBatch().Send()
+		var rpcErr *neorpc.Error
+		require.ErrorAs(t, err, &rpcErr)
+		require.Equal(t, int64(-32600), rpcErr.Code)
+	})
+	t.Run("unexpected ID", func(t *testing.T) {
+		response = `[{"jsonrpc":"2.0","id":100,"result":42}]`
+		_, err := newBatch().Send()
+		require.Error(t, err)
+	})
+}
@@ -38,6 +38,9 @@ type Client struct {
 	ctxCancel func()
 	opts      Options
 	requestF  func(*neorpc.Request) (*neorpc.Response, error)
+	// batchF sends a set of requests as a single JSON-RPC batch, responses
+	// are returned in no particular order.
+	batchF func([]*neorpc.Request) ([]*neorpc.Response, error)
 
 	// reader is an Invoker that has no signers and uses current state,
 	// it's used to implement various getters. It'll be removed eventually,
@@ -70,6 +73,10 @@ type Options struct {
 	RequestTimeout time.Duration
 	// Limit total number of connections per host. No limit by default.
 	MaxConnsPerHost int
+	// Reconnect enables automatic reconnection for WSClient (it's not used
+	// by other clients), nil value disables it. See ReconnectOptions for
+	// details.
+	Reconnect *ReconnectOptions
 }
 
 // cache stores cache values for the RPC client methods.
@@ -140,6 +147,7 @@ func initClient(ctx context.Context, cl *Client, endpoint string, opts Options)
 	cl.getNextRequestID = (cl).getRequestID
 	cl.opts = opts
 	cl.requestF = cl.makeHTTPRequest
+	cl.batchF = cl.makeHTTPBatchRequest
 	cl.reader = invoker.New(cl, nil)
 	return nil
 }
@@ -182,18 +190,26 @@ func (c *Client) Close() {
 }
 
 func (c *Client) performRequest(method string, p []interface{}, v interface{}) error {
+	raw, err := c.requestF(c.newRequest(method, p))
+	return unmarshalResult(raw, err, v)
+}
+
+// newRequest creates a request with the next request ID.
+func (c *Client) newRequest(method string, p []interface{}) *neorpc.Request {
 	if p == nil {
 		p = []interface{}{} // neo-project/neo-modules#742
 	}
-	var r = neorpc.Request{
+	return &neorpc.Request{
 		JSONRPC: neorpc.JSONRPCVersion,
 		Method:  method,
 		Params:  p,
 		ID:      c.getNextRequestID(),
 	}
+}
 
-	raw, err := c.requestF(&r)
-
+// unmarshalResult checks the response (and the error returned along with it)
+// and decodes its result into v.
+func unmarshalResult(raw *neorpc.Response, err error, v interface{}) error {
 	if raw != nil && raw.Error != nil {
 		return raw.Error
 	} else if err != nil {
@@ -205,28 +221,45 @@ func (c *Client) performRequest(method string, p []interface{}, v interface{}) e
 }
 
 func (c *Client) makeHTTPRequest(r *neorpc.Request) (*neorpc.Response, error) {
-	var (
-		buf = new(bytes.Buffer)
-		raw = new(neorpc.Response)
-	)
+	var raw = new(neorpc.Response)
 
-	if err := json.NewEncoder(buf).Encode(r); err != nil {
+	if err := c.doHTTPRequest(r, raw); err != nil {
 		return nil, err
 	}
+	return raw, nil
+}
+
+func (c *Client) makeHTTPBatchRequest(rs []*neorpc.Request) ([]*neorpc.Response, error) {
+	var raw json.RawMessage
+
+	if err := c.doHTTPRequest(rs, &raw); err != nil {
+		return nil, err
+	}
+	return unmarshalBatchResponse(raw)
+}
+
+// doHTTPRequest sends JSON-encoded body to the endpoint and decodes the
+// response into res.
+func (c *Client) doHTTPRequest(body interface{}, res interface{}) error {
+	var buf = new(bytes.Buffer)
+
+	if err := json.NewEncoder(buf).Encode(body); err != nil {
+		return err
+	}
 
 	req, err := http.NewRequest("POST", c.endpoint.String(), buf)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	resp, err := c.cli.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer resp.Body.Close()
 
 	// The node might send us a proper JSON anyway, so look there first and if
 	// it parses, it has more relevant data than HTTP error code.
-	err = json.NewDecoder(resp.Body).Decode(raw)
+	err = json.NewDecoder(resp.Body).Decode(res)
 	if err != nil {
 		if resp.StatusCode != http.StatusOK {
 			err = fmt.Errorf("HTTP %d/%s", resp.StatusCode, http.StatusText(resp.StatusCode))
@@ -234,10 +267,44 @@ func (c *Client) makeHTTPRequest(r *neorpc.Request) (*neorpc.Response, error) {
 			err = fmt.Errorf("JSON decoding: %w", err)
 		}
 	}
-	if err != nil {
-		return nil, err
+	return err
+}
+
+// makeSequentialBatchRequest emulates batch request by sending requests one
+// by one via requestF, it's used by clients that can't send batches natively.
+func (c *Client) makeSequentialBatchRequest(rs []*neorpc.Request) ([]*neorpc.Response, error) {
+	var res = make([]*neorpc.Response, 0, len(rs))
+
+	for _, r := range rs {
+		raw, err := c.requestF(r)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, raw)
 	}
-	return raw, nil
+	return res, nil
+}
+
+// unmarshalBatchResponse decodes JSON-RPC batch response. The server can also
+// reply with a single error for the whole batch, it's returned as an error then.
+func unmarshalBatchResponse(raw json.RawMessage) ([]*neorpc.Response, error) {
+	var res []*neorpc.Response
+
+	raw = bytes.TrimLeft(raw, " \t\r\n")
+	if len(raw) != 0 && raw[0] != '[' {
+		var single = new(neorpc.Response)
+		if err := json.Unmarshal(raw, single); err != nil {
+			return nil, fmt.Errorf("JSON decoding: %w", err)
+		}
+		if single.Error != nil {
+			return nil, single.Error
+		}
+		return nil, errors.New("unexpected non-batch response")
+	}
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, fmt.Errorf("JSON decoding: %w", err)
+	}
+	return res, nil
 }
 
 // Ping attempts to create a connection to the endpoint
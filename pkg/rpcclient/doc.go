@@ -50,7 +50,11 @@ These layers are:
 # Client
 
 After creating a client instance with or without a ClientConfig
-you can interact with the NEO blockchain by its exposed methods.
+you can interact with the NEO blockchain by its exposed methods. Some of them
+can also be queued into a Batch (see NewBatch) to be sent as a single JSON-RPC
+batch request which saves round-trips when many calls are needed. WSClient
+can be configured to automatically reconnect and restore subscriptions after
+connection loss, see ReconnectOptions.
 
 Supported methods
 
@@ -95,8 +99,16 @@ Supported methods
 
 Extensions:
 
+	addpeer
+	banpeer
+	cancelsnapshot
+	createsnapshot
+	disconnectpeer
+	getblocknotifications
 	getblocksysfee
+	listbans
 	submitnotaryrequest
+	unbanpeer
 
 Unsupported methods
 
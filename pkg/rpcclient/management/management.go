@@ -54,13 +54,22 @@ type ContractReader struct {
 // Contract represents a ContractManagement contract client that can be used to
 // invoke all of its methods except 'update' and 'destroy' because they can be
 // called successfully only from the contract itself (that is doing an update
-// or self-destruction).
+// or self-destruction), use Updater for that.
 type Contract struct {
 	ContractReader
 
 	actor Actor
 }
 
+// Updater is a client for conventional 'update' and 'destroy' methods of some
+// deployed contract. Note that it doesn't invoke ContractManagement, it calls
+// the contract being updated or destroyed instead, so this contract must have
+// these methods and call ContractManagement from them.
+type Updater struct {
+	hash  util.Uint160
+	actor Actor
+}
+
 // IDHash is an ID/Hash pair returned by the iterator from the GetContractHashes method.
 type IDHash struct {
 	ID   int32
@@ -97,6 +106,12 @@ func New(actor Actor) *Contract {
 	return &Contract{*NewReader(actor), actor}
 }
 
+// NewUpdater creates an instance of Updater to update or destroy the contract
+// with the given hash using the given Actor.
+func NewUpdater(actor Actor, hash util.Uint160) *Updater {
+	return &Updater{hash, actor}
+}
+
 // GetContract allows to get contract data from its hash. This method is mostly
 // useful for historic invocations since for current contracts there is a direct
 // getcontractstate RPC API that has more options and works faster than going
@@ -257,13 +272,9 @@ func (c *Contract) DeployUnsigned(exe *nef.File, manif *manifest.Manifest, data
 }
 
 func mkDeployScript(exe *nef.File, manif *manifest.Manifest, data interface{}) ([]byte, error) {
-	exeB, err := exe.Bytes()
+	exeB, manifB, err := serializeNEFManifest(exe, manif)
 	if err != nil {
-		return nil, fmt.Errorf("bad NEF: %w", err)
-	}
-	manifB, err := json.Marshal(manif)
-	if err != nil {
-		return nil, fmt.Errorf("bad manifest: %w", err)
+		return nil, err
 	}
 	if data != nil {
 		return smartcontract.CreateCallScript(Hash, "deploy", exeB, manifB, data)
@@ -271,6 +282,84 @@ func mkDeployScript(exe *nef.File, manif *manifest.Manifest, data interface{}) (
 	return smartcontract.CreateCallScript(Hash, "deploy", exeB, manifB)
 }
 
+// Update creates and sends to the network a transaction that updates the
+// contract with the new NEF and manifest. It invokes contract's own 'update'
+// method with serialized NEF, manifest and data (null is passed if it's nil)
+// as parameters, so the contract is expected to have this method with three
+// parameters that calls ContractManagement's 'update' passing these
+// parameters to it. Proper signers (satisfying contract's checks done in
+// 'update') must be used by the Actor.
+func (u *Updater) Update(exe *nef.File, manif *manifest.Manifest, data interface{}) (util.Uint256, uint32, error) {
+	script, err := mkUpdateScript(u.hash, exe, manif, data)
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return u.actor.SendRun(script)
+}
+
+// UpdateTransaction creates and returns a transaction that updates the
+// contract with the new NEF and manifest. See Update for details on the
+// contract requirements.
+func (u *Updater) UpdateTransaction(exe *nef.File, manif *manifest.Manifest, data interface{}) (*transaction.Transaction, error) {
+	script, err := mkUpdateScript(u.hash, exe, manif, data)
+	if err != nil {
+		return nil, err
+	}
+	return u.actor.MakeRun(script)
+}
+
+// UpdateUnsigned creates and returns an unsigned transaction that updates
+// the contract with the new NEF and manifest. See Update for details on the
+// contract requirements.
+func (u *Updater) UpdateUnsigned(exe *nef.File, manif *manifest.Manifest, data interface{}) (*transaction.Transaction, error) {
+	script, err := mkUpdateScript(u.hash, exe, manif, data)
+	if err != nil {
+		return nil, err
+	}
+	return u.actor.MakeUnsignedRun(script, nil)
+}
+
+func mkUpdateScript(contract util.Uint160, exe *nef.File, manif *manifest.Manifest, data interface{}) ([]byte, error) {
+	exeB, manifB, err := serializeNEFManifest(exe, manif)
+	if err != nil {
+		return nil, err
+	}
+	return smartcontract.CreateCallScript(contract, "update", exeB, manifB, data)
+}
+
+func serializeNEFManifest(exe *nef.File, manif *manifest.Manifest) ([]byte, []byte, error) {
+	exeB, err := exe.Bytes()
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad NEF: %w", err)
+	}
+	manifB, err := json.Marshal(manif)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad manifest: %w", err)
+	}
+	return exeB, manifB, nil
+}
+
+// Destroy creates and sends to the network a transaction that destroys the
+// contract. It invokes contract's own 'destroy' method (without parameters),
+// so the contract is expected to have this method that calls
+// ContractManagement's 'destroy'. Proper signers (satisfying contract's
+// checks done in 'destroy') must be used by the Actor.
+func (u *Updater) Destroy() (util.Uint256, uint32, error) {
+	return u.actor.SendCall(u.hash, "destroy")
+}
+
+// DestroyTransaction creates and returns a transaction that destroys the
+// contract. See Destroy for details on the contract requirements.
+func (u *Updater) DestroyTransaction() (*transaction.Transaction, error) {
+	return u.actor.MakeCall(u.hash, "destroy")
+}
+
+// DestroyUnsigned creates and returns an unsigned transaction that destroys
+// the contract. See Destroy for details on the contract requirements.
+func (u *Updater) DestroyUnsigned() (*transaction.Transaction, error) {
+	return u.actor.MakeUnsignedCall(u.hash, "destroy", nil)
+}
+
 // SetMinimumDeploymentFee creates and sends a transaction that changes the
 // minimum GAS amount required to deploy a contract. This method can be called
 // successfully only by the network's committee, so make sure you're using an
@@ -1,6 +1,7 @@
 package management
 
 import (
+	"encoding/json"
 	"errors"
 	"math/big"
 	"testing"
@@ -8,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -16,11 +18,12 @@ import (
 )
 
 type testAct struct {
-	err error
-	res *result.Invoke
-	tx  *transaction.Transaction
-	txh util.Uint256
-	vub uint32
+	err    error
+	res    *result.Invoke
+	tx     *transaction.Transaction
+	txh    util.Uint256
+	vub    uint32
+	script []byte
 }
 
 func (t *testAct) Call(contract util.Uint160, operation string, params ...interface{}) (*result.Invoke, error) {
@@ -36,12 +39,15 @@ func (t *testAct) SendCall(contract util.Uint160, method string, params ...inter
 	return t.txh, t.vub, t.err
 }
 func (t *testAct) MakeRun(script []byte) (*transaction.Transaction, error) {
+	t.script = script
 	return t.tx, t.err
 }
 func (t *testAct) MakeUnsignedRun(script []byte, attrs []transaction.Attribute) (*transaction.Transaction, error) {
+	t.script = script
 	return t.tx, t.err
 }
 func (t *testAct) SendRun(script []byte) (util.Uint256, uint32, error) {
+	t.script = script
 	return t.txh, t.vub, t.err
 }
 func (t *testAct) CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...interface{}) (*result.Invoke, error) {
@@ -326,6 +332,100 @@ func TestDeploy(t *testing.T) {
 	// Unfortunately, manifest _always_ marshals successfully (or panics).
 }
 
+func TestUpdate(t *testing.T) {
+	ta := new(testAct)
+	ctr := util.Uint160{1, 2, 3}
+	upd := NewUpdater(ta, ctr)
+	nefFile, _ := nef.NewFile([]byte{1, 2, 3})
+	manif := manifest.DefaultManifest("stack item")
+
+	ta.err = errors.New("")
+	_, _, err := upd.Update(nefFile, manif, nil)
+	require.Error(t, err)
+
+	for _, m := range []func(exe *nef.File, manif *manifest.Manifest, data interface{}) (*transaction.Transaction, error){
+		upd.UpdateTransaction,
+		upd.UpdateUnsigned,
+	} {
+		_, err = m(nefFile, manif, nil)
+		require.Error(t, err)
+	}
+
+	ta.err = nil
+	ta.txh = util.Uint256{1, 2, 3}
+	ta.vub = 42
+
+	h, vub, err := upd.Update(nefFile, manif, nil)
+	require.NoError(t, err)
+	require.Equal(t, ta.txh, h)
+	require.Equal(t, ta.vub, vub)
+
+	// Null data is passed explicitly.
+	rawNef, err := nefFile.Bytes()
+	require.NoError(t, err)
+	rawManif, err := json.Marshal(manif)
+	require.NoError(t, err)
+	script, err := smartcontract.CreateCallScript(ctr, "update", rawNef, rawManif, nil)
+	require.NoError(t, err)
+	require.Equal(t, script, ta.script)
+
+	ta.tx = transaction.New([]byte{1, 2, 3}, 100500)
+	for _, m := range []func(exe *nef.File, manif *manifest.Manifest, data interface{}) (*transaction.Transaction, error){
+		upd.UpdateTransaction,
+		upd.UpdateUnsigned,
+	} {
+		tx, err := m(nefFile, manif, nil)
+		require.NoError(t, err)
+		require.Equal(t, ta.tx, tx)
+
+		_, err = m(nefFile, manif, map[int]int{})
+		require.Error(t, err)
+	}
+
+	_, _, err = upd.Update(nefFile, manif, 100500)
+	require.NoError(t, err)
+
+	nefFile.Compiler = "intentionally very long compiler string that will make NEF code explode on encoding"
+	_, _, err = upd.Update(nefFile, manif, nil)
+	require.Error(t, err)
+}
+
+func TestDestroy(t *testing.T) {
+	ta := new(testAct)
+	upd := NewUpdater(ta, util.Uint160{1, 2, 3})
+
+	ta.err = errors.New("")
+	_, _, err := upd.Destroy()
+	require.Error(t, err)
+
+	for _, m := range []func() (*transaction.Transaction, error){
+		upd.DestroyTransaction,
+		upd.DestroyUnsigned,
+	} {
+		_, err = m()
+		require.Error(t, err)
+	}
+
+	ta.err = nil
+	ta.txh = util.Uint256{1, 2, 3}
+	ta.vub = 42
+
+	h, vub, err := upd.Destroy()
+	require.NoError(t, err)
+	require.Equal(t, ta.txh, h)
+	require.Equal(t, ta.vub, vub)
+
+	ta.tx = transaction.New([]byte{1, 2, 3}, 100500)
+	for _, m := range []func() (*transaction.Transaction, error){
+		upd.DestroyTransaction,
+		upd.DestroyUnsigned,
+	} {
+		tx, err := m()
+		require.NoError(t, err)
+		require.Equal(t, ta.tx, tx)
+	}
+}
+
 func TestItemsToIDHashesErrors(t *testing.T) {
 	for name, input := range map[string][]stackitem.Item{
 		"not a struct": {stackitem.Make(1)},
@@ -94,20 +94,22 @@ func (c *Client) GetBlockByHash(hash util.Uint256) (*block.Block, error) {
 }
 
 func (c *Client) getBlock(param interface{}) (*block.Block, error) {
-	var (
-		resp []byte
-		err  error
-		b    *block.Block
-	)
-	if err = c.performRequest("getblock", []interface{}{param}, &resp); err != nil {
+	var resp []byte
+
+	if err := c.performRequest("getblock", []interface{}{param}, &resp); err != nil {
 		return nil, err
 	}
-	r := io.NewBinReaderFromBuf(resp)
+	return c.decodeBlock(resp)
+}
+
+// decodeBlock deserializes the block using the stateroot setting of the client.
+func (c *Client) decodeBlock(data []byte) (*block.Block, error) {
+	r := io.NewBinReaderFromBuf(data)
 	sr, err := c.StateRootInHeader()
 	if err != nil {
 		return nil, err
 	}
-	b = block.New(sr)
+	b := block.New(sr)
 	b.DecodeBinary(r)
 	if r.Err != nil {
 		return nil, r.Err
@@ -147,6 +149,23 @@ func (c *Client) getBlockVerbose(param interface{}) (*result.Block, error) {
 	return resp, nil
 }
 
+// GetBlockNotifications returns notifications emitted during the specified
+// block processing grouped by trigger type. Optional filter can be used to get
+// notifications of a particular contract and/or event only.
+func (c *Client) GetBlockNotifications(hash util.Uint256, filter *neorpc.NotificationFilter) (*result.BlockNotifications, error) {
+	var (
+		params = []interface{}{hash.StringLE()}
+		resp   = new(result.BlockNotifications)
+	)
+	if filter != nil {
+		params = append(params, *filter)
+	}
+	if err := c.performRequest("getblocknotifications", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetBlockHash returns the hash value of the corresponding block based on the specified index.
 func (c *Client) GetBlockHash(index uint32) (util.Uint256, error) {
 	var (
@@ -415,6 +434,38 @@ func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	return *resp, nil
 }
 
+// GetMempoolTransactions returns a page of verified memory pool transactions
+// along with their priority data. Offset is the number of transactions to
+// skip, limit is optional (the server's maximum page size is used if it's
+// nil), Truncated flag of the result is set if there are more transactions to
+// fetch. This method is only supported by NeoGo servers.
+func (c *Client) GetMempoolTransactions(offset int, limit *int) (*result.MempoolTransactions, error) {
+	return c.getMempoolTransactions(nil, offset, limit)
+}
+
+// GetMempoolTransactionsBySender returns a page of verified memory pool
+// transactions sent by the given account along with their priority data,
+// sender's GAS balance, the number and the sum of fees of all its pooled
+// transactions. Offset and limit are the same as for GetMempoolTransactions.
+// This method is only supported by NeoGo servers.
+func (c *Client) GetMempoolTransactionsBySender(sender util.Uint160, offset int, limit *int) (*result.MempoolTransactions, error) {
+	return c.getMempoolTransactions(sender.StringLE(), offset, limit)
+}
+
+func (c *Client) getMempoolTransactions(sender interface{}, offset int, limit *int) (*result.MempoolTransactions, error) {
+	var (
+		params = []interface{}{sender, offset}
+		resp   = new(result.MempoolTransactions)
+	)
+	if limit != nil {
+		params = append(params, *limit)
+	}
+	if err := c.performRequest("getmempooltransactions", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetRawTransaction returns a transaction by hash.
 func (c *Client) GetRawTransaction(hash util.Uint256) (*transaction.Transaction, error) {
 	var (
@@ -488,6 +539,53 @@ func (c *Client) FindStates(stateroot util.Uint256, historicalContractHash util.
 	return resp, nil
 }
 
+// FindStorageByHash returns contract storage items by the given contract hash
+// and prefix. If `start` key is specified (it must include the prefix), items
+// starting from this key are being returned (including the item with this
+// key). The number of items returned is limited by the server (see
+// MaxFindStoragePageSize RPC configuration), the result is marked as truncated
+// if there are more items available, use its Next field as `start` in the
+// subsequent call to get them. This method is only supported by NeoGo servers.
+func (c *Client) FindStorageByHash(contractHash util.Uint160, prefix []byte, start []byte) (result.FindStorage, error) {
+	return c.findStorage("findstorage", []interface{}{contractHash.StringLE()}, prefix, start)
+}
+
+// FindStorageByID is the same as FindStorageByHash, but accepts contract ID.
+// This method is only supported by NeoGo servers.
+func (c *Client) FindStorageByID(contractID int32, prefix []byte, start []byte) (result.FindStorage, error) {
+	return c.findStorage("findstorage", []interface{}{contractID}, prefix, start)
+}
+
+// FindStorageByHashHistoric is the same as FindStorageByHash, but returns
+// contract storage items from the state with the given stateroot. Items are
+// returned in MPT traversal order and `start` key is handled the same way
+// FindStates does it, this order can differ from the FindStorageByHash one.
+// This method is only supported by NeoGo servers.
+func (c *Client) FindStorageByHashHistoric(stateroot util.Uint256, contractHash util.Uint160, prefix []byte, start []byte) (result.FindStorage, error) {
+	return c.findStorage("findstoragehistoric", []interface{}{stateroot.StringLE(), contractHash.StringLE()}, prefix, start)
+}
+
+// FindStorageByIDHistoric is the same as FindStorageByHashHistoric, but
+// accepts contract ID. This method is only supported by NeoGo servers.
+func (c *Client) FindStorageByIDHistoric(stateroot util.Uint256, contractID int32, prefix []byte, start []byte) (result.FindStorage, error) {
+	return c.findStorage("findstoragehistoric", []interface{}{stateroot.StringLE(), contractID}, prefix, start)
+}
+
+func (c *Client) findStorage(method string, params []interface{}, prefix []byte, start []byte) (result.FindStorage, error) {
+	var resp result.FindStorage
+	if prefix == nil {
+		prefix = []byte{}
+	}
+	params = append(params, prefix)
+	if start != nil {
+		params = append(params, start)
+	}
+	if err := c.performRequest(method, params, &resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
 // GetStateRootByHeight returns the state root for the specified height.
 func (c *Client) GetStateRootByHeight(height uint32) (*state.MPTRoot, error) {
 	return c.getStateRoot(height)
@@ -917,8 +1015,12 @@ func (c *Client) SignAndPushP2PNotaryRequest(mainTx *transaction.Transaction, fa
 		MainTransaction:     mainTx,
 		FallbackTransaction: fallbackTx,
 	}
+	sig, err := acc.SignHashableErr(m, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign notary request: %w", err)
+	}
 	req.Witness = transaction.Witness{
-		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, acc.SignHashable(m, req)...),
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, sig...),
 		VerificationScript: acc.GetVerificationScript(),
 	}
 	actualHash, err := c.SubmitP2PNotaryRequest(req)
@@ -87,6 +87,24 @@ func getResultBlock1() *result.Block {
 	}
 }
 
+var mempoolTxMoveNeo = `{"position":2,"netfeeperbyte":"9061","conflicts":["0x9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e"],` +
+	strings.TrimPrefix(txMoveNeoVerbose, `{"blockhash":"0x88c1cbf68695f73fb7b7d185c0037ffebdf032327488ebe65e0533d269e7de9b","confirmations":15,"blocktime":1626251469001,"vmstate":"HALT",`)
+
+func getMempoolTxMoveNeo() result.MempoolTransaction {
+	conflict, err := util.Uint256DecodeStringLE("9786cce0dddb524c40ddbdd5e31a41ed1f6b5c8a683c122f627ca4a007a7cf4e")
+	if err != nil {
+		panic(err)
+	}
+	return result.MempoolTransaction{
+		Transaction: getTxMoveNeo().Transaction,
+		MempoolTransactionMetadata: result.MempoolTransactionMetadata{
+			Position:          2,
+			NetworkFeePerByte: 9061,
+			Conflicts:         []util.Uint256{conflict},
+		},
+	}
+}
+
 func getTxMoveNeo() *result.TransactionOutputRaw {
 	b1 := getResultBlock1()
 	txBin, err := base64.StdEncoding.DecodeString(base64TxMoveNeo)
@@ -288,6 +306,37 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getblocknotifications": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetBlockNotifications(util.Uint256{}, nil)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"onpersist":[],"application":[{"container":"0x17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521","contract":"0xd2a4cff31913016155e38e474a2c06d08be276cf","eventname":"Transfer","state":{"type":"Array","value":[{"type":"Integer","value":"1"}]}}],"postpersist":[]}}`,
+			result: func(c *Client) interface{} {
+				txHash, err := util.Uint256DecodeStringLE("17145a039fca704fcdbeb46e6b210af98a1a9e5b9768e46ffc38f71c79ac2521")
+				if err != nil {
+					panic(err)
+				}
+				gasHash, err := util.Uint160DecodeStringLE("d2a4cff31913016155e38e474a2c06d08be276cf")
+				if err != nil {
+					panic(err)
+				}
+				return &result.BlockNotifications{
+					OnPersist: []state.ContainedNotificationEvent{},
+					Application: []state.ContainedNotificationEvent{{
+						Container: txHash,
+						NotificationEvent: state.NotificationEvent{
+							ScriptHash: gasHash,
+							Name:       "Transfer",
+							Item:       stackitem.NewArray([]stackitem.Item{stackitem.NewBigInteger(big.NewInt(1))}),
+						},
+					}},
+					PostPersist: []state.ContainedNotificationEvent{},
+				}
+			},
+		},
+	},
 	"getblocksysfee": {
 		{
 			name: "positive",
@@ -316,6 +365,110 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"cancelsnapshot": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.CancelSnapshot()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":true}`,
+			result: func(c *Client) interface{} {
+				return true
+			},
+		},
+	},
+	"createsnapshot": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.CreateSnapshot()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":42,"path":"/snapshots/snapshot-42"}}`,
+			result: func(c *Client) interface{} {
+				return &result.Snapshot{
+					Height: 42,
+					Path:   "/snapshots/snapshot-42",
+				}
+			},
+		},
+		{
+			name: "positive, at height",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.CreateSnapshotAt(100)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":100,"path":"/snapshots/snapshot-100"}}`,
+			result: func(c *Client) interface{} {
+				return &result.Snapshot{
+					Height: 100,
+					Path:   "/snapshots/snapshot-100",
+				}
+			},
+		},
+	},
+	"addpeer": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return nil, c.AddPeer("1.2.3.4:10333")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":true}`,
+			result: func(c *Client) interface{} {
+				return nil
+			},
+		},
+	},
+	"banpeer": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return nil, c.BanPeer("1.2.3.4", time.Hour, "spam")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":true}`,
+			result: func(c *Client) interface{} {
+				return nil
+			},
+		},
+	},
+	"disconnectpeer": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return nil, c.DisconnectPeer("1.2.3.4:10333")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":true}`,
+			result: func(c *Client) interface{} {
+				return nil
+			},
+		},
+	},
+	"listbans": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.ListBans()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":[{"address":"1.2.3.4","until":1672531200000,"reason":"spam"}]}`,
+			result: func(c *Client) interface{} {
+				return []result.BannedPeer{{
+					Address: "1.2.3.4",
+					Until:   1672531200000,
+					Reason:  "spam",
+				}}
+			},
+		},
+	},
+	"unbanpeer": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.UnbanPeer("1.2.3.4")
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":true}`,
+			result: func(c *Client) interface{} {
+				return true
+			},
+		},
+	},
 	"getconnectioncount": {
 		{
 			name: "positive",
@@ -724,6 +877,53 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getmempooltransactions": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetMempoolTransactions(0, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":1210,"count":1,"transactions":[` + mempoolTxMoveNeo + `],"truncated":false}}`,
+			result: func(c *Client) interface{} {
+				return &result.MempoolTransactions{
+					Height:       1210,
+					Count:        1,
+					Transactions: []result.MempoolTransaction{getMempoolTxMoveNeo()},
+				}
+			},
+		},
+		{
+			name: "offset and limit",
+			invoke: func(c *Client) (interface{}, error) {
+				limit := 1
+				return c.GetMempoolTransactions(2, &limit)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":1210,"count":5,"transactions":[` + mempoolTxMoveNeo + `],"truncated":true}}`,
+			result: func(c *Client) interface{} {
+				return &result.MempoolTransactions{
+					Height:       1210,
+					Count:        5,
+					Transactions: []result.MempoolTransaction{getMempoolTxMoveNeo()},
+					Truncated:    true,
+				}
+			},
+		},
+		{
+			name: "by sender",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.GetMempoolTransactionsBySender(util.Uint160{1, 2, 3}, 0, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"height":1210,"count":3,"transactions":[` + mempoolTxMoveNeo + `],"sender":{"balance":"100500","fees":"15421900","count":1}}}`,
+			result: func(c *Client) interface{} {
+				return &result.MempoolTransactions{
+					Height:       1210,
+					Count:        3,
+					Transactions: []result.MempoolTransaction{getMempoolTxMoveNeo()},
+					Sender:       &result.MempoolSender{Balance: 100500, Fees: 15421900, Count: 1},
+				}
+			},
+		},
+	},
 	"getrawmempool": {
 		{
 			name: "positive",
@@ -854,6 +1054,70 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"findstorage": {
+		{
+			name: "by hash, positive",
+			invoke: func(c *Client) (interface{}, error) {
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				return c.FindStorageByHash(cHash, []byte("aa"), []byte("aa10"))
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"next":"YWE1MA==","truncated":true}}`,
+			result: func(c *Client) interface{} {
+				return result.FindStorage{
+					Results:   []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Next:      []byte("aa50"),
+					Truncated: true,
+				}
+			},
+		},
+		{
+			name: "by ID, positive",
+			invoke: func(c *Client) (interface{}, error) {
+				return c.FindStorageByID(1, []byte("aa"), nil)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"next":"YWE1MA==","truncated":true}}`,
+			result: func(c *Client) interface{} {
+				return result.FindStorage{
+					Results:   []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Next:      []byte("aa50"),
+					Truncated: true,
+				}
+			},
+		},
+	},
+	"findstoragehistoric": {
+		{
+			name: "by hash, positive",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("252e9d73d49c95c7618d40650da504e05183a1b2eed0685e42c360413c329170")
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				return c.FindStorageByHashHistoric(root, cHash, []byte("aa"), []byte("aa10"))
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"next":"YWE1MA==","truncated":true}}`,
+			result: func(c *Client) interface{} {
+				return result.FindStorage{
+					Results:   []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Next:      []byte("aa50"),
+					Truncated: true,
+				}
+			},
+		},
+		{
+			name: "by ID, positive",
+			invoke: func(c *Client) (interface{}, error) {
+				root, _ := util.Uint256DecodeStringLE("252e9d73d49c95c7618d40650da504e05183a1b2eed0685e42c360413c329170")
+				return c.FindStorageByIDHistoric(root, 1, []byte("aa"), nil)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"next":"YWE1MA==","truncated":true}}`,
+			result: func(c *Client) interface{} {
+				return result.FindStorage{
+					Results:   []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Next:      []byte("aa50"),
+					Truncated: true,
+				}
+			},
+		},
+	},
 	"getstateheight": {
 		{
 			name: "positive",
@@ -1522,6 +1786,12 @@ var rpcClientErrorCases = map[string][]rpcClientErrorCase{
 				return nil, c.ValidateAddress("")
 			},
 		},
+		{
+			name: "banpeer_invalid_params_error",
+			invoke: func(c *Client) (interface{}, error) {
+				return nil, c.BanPeer("localhost", 0, "")
+			},
+		},
 	},
 	`{}`: {
 		{
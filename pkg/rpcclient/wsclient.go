@@ -1,10 +1,13 @@
 package rpcclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strconv"
 	"sync"
 	"time"
@@ -43,9 +46,9 @@ type WSClient struct {
 	// versions.
 	Notifications chan Notification
 
-	ws          *websocket.Conn
 	done        chan struct{}
 	requests    chan *neorpc.Request
+	batches     chan []*neorpc.Request
 	shutdown    chan struct{}
 	closeCalled atomic.Bool
 
@@ -62,6 +65,24 @@ type WSClient struct {
 
 	respLock     sync.RWMutex
 	respChannels map[uint64]chan *neorpc.Response
+	// connNum is the number of the current connection, it's incremented on
+	// every reconnection (along with respChannels reset), so it must be
+	// accessed with respLock taken.
+	connNum uint64
+
+	// reconnect contains reconnection settings, it's nil if reconnection is
+	// disabled.
+	reconnect *ReconnectOptions
+	// feeds maps subscription IDs returned to the user to the server-side
+	// ones, it's only used with reconnection enabled and must be accessed
+	// with subscriptionsLock taken.
+	feeds map[string]wsFeed
+	// lastSubID is the latest client-side subscription ID, it must be accessed
+	// with subscriptionsLock taken.
+	lastSubID uint64
+	// lastBlock is the index of the latest block known to the client, it's
+	// only tracked with reconnection enabled.
+	lastBlock atomic.Uint32
 }
 
 // notificationReceiver is an interface aimed to provide WS subscriber functionality
@@ -361,35 +382,24 @@ var errConnClosedByUser = errors.New("connection closed by user")
 // You should call Init method to initialize the network magic the client is
 // operating on.
 func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error) {
-	dialer := websocket.Dialer{HandshakeTimeout: opts.DialTimeout}
-	ws, resp, err := dialer.DialContext(ctx, endpoint, nil)
-	if resp != nil && resp.Body != nil { // Can be non-nil even with error returned.
-		defer resp.Body.Close() // Not exactly required by websocket, but let's do this for bodyclose checker.
-	}
+	ws, err := dialWS(ctx, endpoint, opts.DialTimeout)
 	if err != nil {
-		if resp != nil && resp.Body != nil {
-			var srvErr neorpc.HeaderAndError
-
-			dec := json.NewDecoder(resp.Body)
-			decErr := dec.Decode(&srvErr)
-			if decErr == nil && srvErr.Error != nil {
-				err = srvErr.Error
-			}
-		}
 		return nil, err
 	}
 	wsc := &WSClient{
 		Client:        Client{},
 		Notifications: make(chan Notification),
 
-		ws:            ws,
 		shutdown:      make(chan struct{}),
 		done:          make(chan struct{}),
 		closeCalled:   *atomic.NewBool(false),
 		respChannels:  make(map[uint64]chan *neorpc.Response),
 		requests:      make(chan *neorpc.Request),
+		batches:       make(chan []*neorpc.Request),
 		subscriptions: make(map[string]notificationReceiver),
 		receivers:     make(map[interface{}][]string),
+		feeds:         make(map[string]wsFeed),
+		connNum:       1,
 	}
 
 	err = initClient(ctx, &wsc.Client, endpoint, opts)
@@ -397,13 +407,57 @@ func NewWS(ctx context.Context, endpoint string, opts Options) (*WSClient, error
 		return nil, err
 	}
 	wsc.Client.cli = nil
+	if opts.Reconnect != nil {
+		wsc.reconnect = opts.Reconnect.withDefaults()
+	}
 
-	go wsc.wsReader()
-	go wsc.wsWriter()
+	go wsc.wsReader(ws)
 	wsc.requestF = wsc.makeWsRequest
+	wsc.batchF = wsc.makeWsBatchRequest
+	if wsc.reconnect != nil {
+		count, err := wsc.GetBlockCount()
+		if err != nil {
+			wsc.Close()
+			return nil, fmt.Errorf("failed to get block count: %w", err)
+		}
+		wsc.lastBlock.Store(count - 1)
+	}
 	return wsc, nil
 }
 
+// dialWS establishes websocket connection to the given endpoint. Websocket
+// URLs can't contain user credentials, so if there are any they're moved to
+// the basic authentication header.
+func dialWS(ctx context.Context, endpoint string, timeout time.Duration) (*websocket.Conn, error) {
+	var header http.Header
+	if u, err := url.Parse(endpoint); err == nil && u.User != nil {
+		req := &http.Request{Header: make(http.Header)}
+		pass, _ := u.User.Password()
+		req.SetBasicAuth(u.User.Username(), pass)
+		header = req.Header
+		u.User = nil
+		endpoint = u.String()
+	}
+	dialer := websocket.Dialer{HandshakeTimeout: timeout}
+	ws, resp, err := dialer.DialContext(ctx, endpoint, header)
+	if resp != nil && resp.Body != nil { // Can be non-nil even with error returned.
+		defer resp.Body.Close() // Not exactly required by websocket, but let's do this for bodyclose checker.
+	}
+	if err != nil {
+		if resp != nil && resp.Body != nil {
+			var srvErr neorpc.HeaderAndError
+
+			dec := json.NewDecoder(resp.Body)
+			decErr := dec.Decode(&srvErr)
+			if decErr == nil && srvErr.Error != nil {
+				err = srvErr.Error
+			}
+		}
+		return nil, err
+	}
+	return ws, nil
+}
+
 // Close closes connection to the remote side rendering this client instance
 // unusable.
 func (c *WSClient) Close() {
@@ -420,41 +474,95 @@ func (c *WSClient) Close() {
 	<-c.done
 }
 
-func (c *WSClient) wsReader() {
-	c.ws.SetReadLimit(wsReadLimit)
-	c.ws.SetPongHandler(func(string) error {
-		err := c.ws.SetReadDeadline(time.Now().Add(wsPongLimit))
+func (c *WSClient) wsReader(ws *websocket.Conn) {
+	for {
+		connDone := make(chan struct{})
+		writerErr := make(chan error, 1)
+		go c.wsWriter(ws, connDone, writerErr)
+		connCloseErr := c.readMessages(ws)
+		close(connDone)
+		// Writer error (if any) is the real reason of connection loss.
+		if err := <-writerErr; err != nil {
+			connCloseErr = err
+		}
+		if c.reconnect == nil || c.closeCalled.Load() {
+			if connCloseErr != nil {
+				c.setCloseErr(connCloseErr)
+			}
+			break
+		}
+		var (
+			from = c.lastBlock.Load() + 1
+			conn = c.dropPendingResponses()
+			err  error
+		)
+		ws, err = c.redial()
 		if err != nil {
-			c.setCloseErr(fmt.Errorf("failed to set pong read deadline: %w", err))
+			c.setCloseErr(fmt.Errorf("failed to reconnect after connection loss (%v): %w", connCloseErr, err))
+			break
 		}
-		return err
+		go c.resubscribe(conn, from)
+	}
+	close(c.done)
+	c.respLock.Lock()
+	for _, ch := range c.respChannels {
+		close(ch)
+	}
+	c.respChannels = nil
+	c.respLock.Unlock()
+	close(c.Notifications)
+	c.Client.ctxCancel()
+}
+
+// readMessages reads and handles messages from the given connection until
+// it's closed or broken, it returns the reason of the loop termination.
+func (c *WSClient) readMessages(ws *websocket.Conn) error {
+	ws.SetReadLimit(wsReadLimit)
+	ws.SetPongHandler(func(string) error {
+		err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
+		if err != nil {
+			return fmt.Errorf("failed to set pong read deadline: %w", err)
+		}
+		return nil
 	})
-	var connCloseErr error
-readloop:
 	for {
+		var raw json.RawMessage
 		rr := new(requestResponse)
-		err := c.ws.SetReadDeadline(time.Now().Add(wsPongLimit))
+		err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 		if err != nil {
-			connCloseErr = fmt.Errorf("failed to set response read deadline: %w", err)
-			break readloop
+			return fmt.Errorf("failed to set response read deadline: %w", err)
+		}
+		err = ws.ReadJSON(&raw)
+		if err == nil && isBatch(raw) {
+			var resps []*neorpc.Response
+			err = json.Unmarshal(raw, &resps)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal batch response: %w", err)
+			}
+			for _, resp := range resps {
+				err = c.deliverResponse(resp)
+				if err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		if err == nil {
+			err = json.Unmarshal(raw, rr)
 		}
-		err = c.ws.ReadJSON(rr)
 		if err != nil {
 			// Timeout/connection loss/malformed response.
-			connCloseErr = fmt.Errorf("failed to read JSON response (timeout/connection loss/malformed response): %w", err)
-			break readloop
+			return fmt.Errorf("failed to read JSON response (timeout/connection loss/malformed response): %w", err)
 		}
 		if rr.ID == nil && rr.Method != "" {
 			event, err := neorpc.GetEventIDFromString(rr.Method)
 			if err != nil {
 				// Bad event received.
-				connCloseErr = fmt.Errorf("failed to perse event ID from string %s: %w", rr.Method, err)
-				break readloop
+				return fmt.Errorf("failed to perse event ID from string %s: %w", rr.Method, err)
 			}
 			if event != neorpc.MissedEventID && len(rr.RawParams) != 1 {
 				// Bad event received.
-				connCloseErr = fmt.Errorf("bad event received: %s / %d", event, len(rr.RawParams))
-				break readloop
+				return fmt.Errorf("bad event received: %s / %d", event, len(rr.RawParams))
 			}
 			ntf := Notification{Type: event}
 			switch event {
@@ -462,8 +570,7 @@ readloop:
 				sr, err := c.StateRootInHeader()
 				if err != nil {
 					// Client is not initialized.
-					connCloseErr = fmt.Errorf("failed to fetch StateRootInHeader: %w", err)
-					break readloop
+					return fmt.Errorf("failed to fetch StateRootInHeader: %w", err)
 				}
 				ntf.Value = block.New(sr)
 			case neorpc.TransactionEventID:
@@ -478,88 +585,105 @@ readloop:
 				// No value.
 			default:
 				// Bad event received.
-				connCloseErr = fmt.Errorf("unknown event received: %d", event)
-				break readloop
+				return fmt.Errorf("unknown event received: %d", event)
 			}
 			if event != neorpc.MissedEventID {
 				err = json.Unmarshal(rr.RawParams[0], ntf.Value)
 				if err != nil {
 					// Bad event received.
-					connCloseErr = fmt.Errorf("failed to unmarshal event of type %s from JSON: %w", event, err)
-					break readloop
+					return fmt.Errorf("failed to unmarshal event of type %s from JSON: %w", event, err)
 				}
 			}
+			if event == neorpc.BlockEventID && c.reconnect != nil {
+				c.updateLastBlock(ntf.Value.(*block.Block).Index)
+			}
 			c.notifySubscribers(ntf)
 		} else if rr.ID != nil && (rr.Error != nil || rr.Result != nil) {
-			id, err := strconv.ParseUint(string(rr.ID), 10, 64)
+			err = c.deliverResponse(&rr.Response)
 			if err != nil {
-				connCloseErr = fmt.Errorf("failed to retrieve response ID from string %s: %w", string(rr.ID), err)
-				break readloop // Malformed response (invalid response ID).
-			}
-			ch := c.getResponseChannel(id)
-			if ch == nil {
-				connCloseErr = fmt.Errorf("unknown response channel for response %d", id)
-				break readloop // Unknown response (unexpected response ID).
+				return err // Malformed or unexpected response.
 			}
-			ch <- &rr.Response
 		} else {
 			// Malformed response, neither valid request, nor valid response.
-			connCloseErr = fmt.Errorf("malformed response")
-			break readloop
+			return fmt.Errorf("malformed response")
 		}
 	}
-	if connCloseErr != nil {
-		c.setCloseErr(connCloseErr)
+}
+
+// deliverResponse passes the response to the appropriate response channel.
+func (c *WSClient) deliverResponse(resp *neorpc.Response) error {
+	id, err := strconv.ParseUint(string(resp.ID), 10, 64)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve response ID from string %s: %w", string(resp.ID), err)
 	}
-	close(c.done)
-	c.respLock.Lock()
-	for _, ch := range c.respChannels {
-		close(ch)
+	ch := c.getResponseChannel(id)
+	if ch == nil {
+		return fmt.Errorf("unknown response channel for response %d", id)
 	}
-	c.respChannels = nil
-	c.respLock.Unlock()
-	close(c.Notifications)
-	c.Client.ctxCancel()
+	ch <- resp
+	return nil
 }
 
-func (c *WSClient) wsWriter() {
+// isBatch checks whether the given JSON is an array (batch response).
+func isBatch(raw json.RawMessage) bool {
+	raw = bytes.TrimLeft(raw, " \t\r\n")
+	return len(raw) != 0 && raw[0] == '['
+}
+
+// wsWriter sends requests and pings via the given connection until connDone
+// is closed or client is shut down, it closes the connection on exit and sends
+// the error that made it stop (if any) to res.
+func (c *WSClient) wsWriter(ws *websocket.Conn, connDone <-chan struct{}, res chan<- error) {
 	pingTicker := time.NewTicker(wsPingPeriod)
-	defer c.ws.Close()
+	defer ws.Close()
 	defer pingTicker.Stop()
 	var connCloseErr error
 writeloop:
 	for {
 		select {
 		case <-c.shutdown:
-			return
-		case <-c.done:
-			return
+			break writeloop
+		case <-connDone:
+			break writeloop
 		case req, ok := <-c.requests:
 			if !ok {
-				return
+				break writeloop
 			}
-			if err := c.ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout)); err != nil {
+			if c.getResponseChannel(req.ID) == nil {
+				continue // Request is cancelled because of connection loss.
+			}
+			if err := ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout)); err != nil {
 				connCloseErr = fmt.Errorf("failed to set request write deadline: %w", err)
 				break writeloop
 			}
-			if err := c.ws.WriteJSON(req); err != nil {
+			if err := ws.WriteJSON(req); err != nil {
 				connCloseErr = fmt.Errorf("failed to write JSON request (%s / %d): %w", req.Method, len(req.Params), err)
 				break writeloop
 			}
+		case batch := <-c.batches:
+			if c.getResponseChannel(batch[0].ID) == nil {
+				continue // Batch is cancelled because of connection loss.
+			}
+			if err := ws.SetWriteDeadline(time.Now().Add(c.opts.RequestTimeout)); err != nil {
+				connCloseErr = fmt.Errorf("failed to set request write deadline: %w", err)
+				break writeloop
+			}
+			if err := ws.WriteJSON(batch); err != nil {
+				connCloseErr = fmt.Errorf("failed to write JSON batch request (%d requests): %w", len(batch), err)
+				break writeloop
+			}
 		case <-pingTicker.C:
-			if err := c.ws.SetWriteDeadline(time.Now().Add(wsWriteLimit)); err != nil {
+			if err := ws.SetWriteDeadline(time.Now().Add(wsWriteLimit)); err != nil {
 				connCloseErr = fmt.Errorf("failed to set ping write deadline: %w", err)
 				break writeloop
 			}
-			if err := c.ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
+			if err := ws.WriteMessage(websocket.PingMessage, []byte{}); err != nil {
 				connCloseErr = fmt.Errorf("failed to write ping message: %w", err)
 				break writeloop
 			}
 		}
 	}
-	if connCloseErr != nil {
-		c.setCloseErr(connCloseErr)
-	}
+	res <- connCloseErr
 }
 
 func (c *WSClient) notifySubscribers(ntf Notification) {
@@ -599,31 +723,92 @@ func (c *WSClient) getResponseChannel(id uint64) chan *neorpc.Response {
 }
 
 func (c *WSClient) makeWsRequest(r *neorpc.Request) (*neorpc.Response, error) {
+	resp, _, err := c.makeWsRequestOn(r, 0)
+	return resp, err
+}
+
+// makeWsRequestOn performs the request and returns the number of connection
+// it was performed over. If conn is not zero, the request is only performed
+// if it's still the current connection number.
+func (c *WSClient) makeWsRequestOn(r *neorpc.Request, conn uint64) (*neorpc.Response, uint64, error) {
 	ch := make(chan *neorpc.Response)
 	c.respLock.Lock()
 	select {
 	case <-c.done:
 		c.respLock.Unlock()
-		return nil, errors.New("connection lost before registering response channel")
+		return nil, 0, errors.New("connection lost before registering response channel")
 	default:
-		c.respChannels[r.ID] = ch
+	}
+	if conn != 0 && conn != c.connNum {
 		c.respLock.Unlock()
+		return nil, 0, errConnChanged
 	}
+	conn = c.connNum
+	c.respChannels[r.ID] = ch
+	c.respLock.Unlock()
 	select {
 	case <-c.done:
-		return nil, errors.New("connection lost before sending the request")
+		return nil, 0, errors.New("connection lost before sending the request")
+	case <-ch: // Can only be closed at this stage.
+		return nil, 0, errors.New("connection lost before sending the request")
 	case c.requests <- r:
 	}
 	select {
 	case <-c.done:
-		return nil, errors.New("connection lost while waiting for the response")
-	case resp := <-ch:
+		return nil, 0, errors.New("connection lost while waiting for the response")
+	case resp, ok := <-ch:
+		if !ok {
+			return nil, 0, errors.New("connection lost while waiting for the response")
+		}
 		c.unregisterRespChannel(r.ID)
-		return resp, nil
+		return resp, conn, nil
+	}
+}
+
+func (c *WSClient) makeWsBatchRequest(rs []*neorpc.Request) ([]*neorpc.Response, error) {
+	// Channels are buffered, so that responses can be delivered in any order.
+	chs := make([]chan *neorpc.Response, len(rs))
+	c.respLock.Lock()
+	select {
+	case <-c.done:
+		c.respLock.Unlock()
+		return nil, errors.New("connection lost before registering response channels")
+	default:
+		for i, r := range rs {
+			chs[i] = make(chan *neorpc.Response, 1)
+			c.respChannels[r.ID] = chs[i]
+		}
+		c.respLock.Unlock()
+	}
+	defer func() {
+		for _, r := range rs {
+			c.unregisterRespChannel(r.ID)
+		}
+	}()
+	select {
+	case <-c.done:
+		return nil, errors.New("connection lost before sending the request")
+	case c.batches <- rs:
 	}
+	res := make([]*neorpc.Response, len(rs))
+	for i := range chs {
+		select {
+		case <-c.done:
+			return nil, errors.New("connection lost while waiting for the response")
+		case resp, ok := <-chs[i]:
+			if !ok {
+				return nil, errors.New("connection lost while waiting for the response")
+			}
+			res[i] = resp
+		}
+	}
+	return res, nil
 }
 
 func (c *WSClient) performSubscription(params []interface{}, rcvr notificationReceiver) (string, error) {
+	if c.reconnect != nil {
+		return c.performFeedSubscription(params, rcvr)
+	}
 	var resp string
 
 	if err := c.performRequest("subscribe", params, &resp); err != nil {
@@ -942,6 +1127,9 @@ func (c *WSClient) UnsubscribeAll() error {
 // after WS RPC unsubscription request is completed. Until then the subscriber channel
 // may still receive WS notifications.
 func (c *WSClient) performUnsubscription(id string) error {
+	if c.reconnect != nil {
+		return c.performFeedUnsubscription(id)
+	}
 	var resp bool
 	if err := c.performRequest("unsubscribe", []interface{}{id}, &resp); err != nil {
 		return err
@@ -953,12 +1141,21 @@ func (c *WSClient) performUnsubscription(id string) error {
 	c.subscriptionsLock.Lock()
 	defer c.subscriptionsLock.Unlock()
 
-	rcvr, ok := c.subscriptions[id]
-	if !ok {
+	if _, ok := c.subscriptions[id]; !ok {
 		return errors.New("no subscription with this ID")
 	}
+	c.removeSubscription(id)
+	return nil
+}
+
+// removeSubscription removes subscription with the given ID from the list of
+// subscriptions and receivers, it returns true if there are no more
+// subscriptions for the same receiver channel (and it's not yet closed). It
+// must be called with subscriptionsLock taken.
+func (c *WSClient) removeSubscription(id string) bool {
+	rcvr := c.subscriptions[id]
 	ch := rcvr.Receiver()
-	ids := c.receivers[ch]
+	ids, ok := c.receivers[ch]
 	for i, rcvrID := range ids {
 		if rcvrID == id {
 			ids = append(ids[:i], ids[i+1:]...)
@@ -971,7 +1168,7 @@ func (c *WSClient) performUnsubscription(id string) error {
 		c.receivers[ch] = ids
 	}
 	delete(c.subscriptions, id)
-	return nil
+	return ok && len(ids) == 0
 }
 
 // setCloseErr is a thread-safe method setting closeErr in case if it's not yet set.
@@ -706,3 +706,172 @@ func TestWSClient_ConnClosedError(t *testing.T) {
 		require.True(t, strings.Contains(err.Error(), "failed to read JSON response (timeout/connection loss/malformed response)"), err.Error())
 	})
 }
+
+// reconnectTestServer is a websocket server stub that can drop connections,
+// it assigns subscription IDs per connection and records subscription calls.
+type reconnectTestServer struct {
+	*httptest.Server
+
+	lock   sync.Mutex
+	ws     *websocket.Conn
+	conns  int
+	height uint32
+	// reject makes the server refuse new connections.
+	reject bool
+	// rejectStream makes the server refuse subscriptions to this stream on
+	// all connections except the first one.
+	rejectStream string
+	// subs contains subscription IDs for every stream per connection.
+	subs map[int]map[string]string
+	// unsubs contains unsubscription IDs per connection.
+	unsubs map[int][]string
+}
+
+func newReconnectTestServer(t *testing.T, height uint32) *reconnectTestServer {
+	s := &reconnectTestServer{
+		height: height,
+		subs:   make(map[int]map[string]string),
+		unsubs: make(map[int][]string),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.lock.Lock()
+		if s.reject {
+			s.lock.Unlock()
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var upgrader = websocket.Upgrader{}
+		ws, err := upgrader.Upgrade(w, req, nil)
+		if err != nil {
+			s.lock.Unlock()
+			return
+		}
+		s.conns++
+		s.ws = ws
+		conn := s.conns
+		s.subs[conn] = make(map[string]string)
+		s.lock.Unlock()
+		for {
+			var r struct {
+				ID     json.RawMessage   `json:"id"`
+				Method string            `json:"method"`
+				Params []json.RawMessage `json:"params"`
+			}
+			if err := ws.ReadJSON(&r); err != nil {
+				break
+			}
+			var response string
+			s.lock.Lock()
+			switch r.Method {
+			case "getblockcount":
+				response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":%d}`, r.ID, s.height)
+			case "subscribe":
+				var stream string
+				require.NoError(t, json.Unmarshal(r.Params[0], &stream))
+				if conn > 1 && stream == s.rejectStream {
+					response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"error":{"code":-32602,"message":"Invalid Params"}}`, r.ID)
+					break
+				}
+				id := fmt.Sprintf("%d-%d", conn, len(s.subs[conn]))
+				s.subs[conn][stream] = id
+				response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":"%s"}`, r.ID, id)
+			case "unsubscribe":
+				var id string
+				require.NoError(t, json.Unmarshal(r.Params[0], &id))
+				s.unsubs[conn] = append(s.unsubs[conn], id)
+				response = fmt.Sprintf(`{"jsonrpc":"2.0","id":%s,"result":true}`, r.ID)
+			}
+			s.lock.Unlock()
+			if err := ws.WriteMessage(websocket.TextMessage, []byte(response)); err != nil {
+				break
+			}
+		}
+		ws.Close()
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *reconnectTestServer) dropConnection() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.ws.Close()
+}
+
+func TestWSClientReconnect(t *testing.T) {
+	srv := newReconnectTestServer(t, 10)
+	gaps := make(chan GapEvent, 1)
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), Options{
+		Reconnect: &ReconnectOptions{
+			MinDelay: 10 * time.Millisecond,
+			Gaps:     gaps,
+		},
+	})
+	require.NoError(t, err)
+	t.Cleanup(wsc.Close)
+
+	halt := "HALT"
+	aerCh := make(chan *state.AppExecResult)
+	aerID, err := wsc.ReceiveExecutions(&neorpc.ExecutionFilter{State: &halt}, aerCh)
+	require.NoError(t, err)
+	txCh := make(chan *transaction.Transaction)
+	txID, err := wsc.ReceiveTransactions(nil, txCh)
+	require.NoError(t, err)
+	require.NotEqual(t, aerID, txID)
+
+	srv.lock.Lock()
+	srv.height = 15
+	srv.rejectStream = "transaction_added"
+	srv.lock.Unlock()
+	srv.dropConnection()
+
+	select {
+	case gap := <-gaps:
+		require.Equal(t, GapEvent{From: 10, To: 14}, gap)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no gap event received")
+	}
+	require.NoError(t, wsc.GetError())
+
+	// Refused subscription is dropped.
+	_, ok := <-txCh
+	require.False(t, ok)
+	require.Error(t, wsc.Unsubscribe(txID))
+
+	count, err := wsc.GetBlockCount()
+	require.NoError(t, err)
+	require.Equal(t, uint32(15), count)
+
+	// Client-side ID is the same, but the new server-side one is used.
+	require.NoError(t, wsc.Unsubscribe(aerID))
+	srv.lock.Lock()
+	require.Equal(t, 2, srv.conns)
+	require.Equal(t, []string{srv.subs[2]["transaction_executed"]}, srv.unsubs[2])
+	srv.lock.Unlock()
+	require.Empty(t, wsc.subscriptions)
+}
+
+func TestWSClientReconnectFailure(t *testing.T) {
+	srv := newReconnectTestServer(t, 10)
+	wsc, err := NewWS(context.TODO(), httpURLtoWS(srv.URL), Options{
+		Reconnect: &ReconnectOptions{
+			MinDelay:    time.Millisecond,
+			MaxAttempts: 3,
+		},
+	})
+	require.NoError(t, err)
+
+	srv.lock.Lock()
+	srv.reject = true
+	srv.lock.Unlock()
+	srv.dropConnection()
+
+	select {
+	case <-wsc.Context().Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("client is not closed")
+	}
+	require.ErrorContains(t, wsc.GetError(), "failed to reconnect")
+	_, err = wsc.GetBlockCount()
+	require.Error(t, err)
+}
@@ -0,0 +1,286 @@
+package rpcclient
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+)
+
+const (
+	defaultReconnectMinDelay = time.Second
+	defaultReconnectMaxDelay = 30 * time.Second
+)
+
+// ReconnectOptions contains WSClient automatic reconnection settings. When
+// reconnection is enabled WSClient doesn't shut down on connection loss,
+// instead it tries to establish a new connection (using exponential backoff
+// between attempts) and then repeats all active subscriptions with their
+// filters over it. Subscription IDs returned to the user don't change in
+// this process. Requests that were waiting for responses when the connection
+// got lost fail, but new ones wait for the reconnection to happen. Receiver
+// channels are not closed on reconnection, but if the server refuses to
+// repeat some subscription, its receiver is closed the same way it's done on
+// MissedEvent (if there are no other subscriptions for this channel).
+type ReconnectOptions struct {
+	// MinDelay is the delay before the second reconnection attempt (the first
+	// one is made immediately after connection loss), 1 second by default.
+	// Every subsequent delay is twice the previous one.
+	MinDelay time.Duration
+	// MaxDelay is the maximum delay between reconnection attempts, 30 seconds
+	// by default.
+	MaxDelay time.Duration
+	// MaxAttempts is the number of failed reconnection attempts after which
+	// WSClient gives up and shuts down the same way it does without
+	// reconnection enabled (see GetError). Zero value means no limit.
+	MaxAttempts int
+	// Gaps is an optional channel that receives a GapEvent after every
+	// successful reconnection (when all subscriptions are restored). It must
+	// be properly read and drained, failing to do so will block subsequent
+	// reconnections. It's never closed by WSClient.
+	Gaps chan<- GapEvent
+}
+
+// GapEvent describes the range of blocks the client could miss events for
+// because of connection loss. Both From and To are inclusive, From is the
+// block following the latest one the client knew about before the
+// connection loss (it's tracked via block notifications and chain height
+// obtained on every connection) and To is the latest block at the moment of
+// subscriptions restoration. If From is greater than To, then no blocks were
+// missed, but some mempool-related events (like transaction_added or
+// notary_request_event) still could be.
+type GapEvent struct {
+	From uint32
+	To   uint32
+}
+
+// wsFeed is a server-side subscription.
+type wsFeed struct {
+	// id is the server-side subscription ID.
+	id string
+	// conn is the number of connection this subscription was made over.
+	conn uint64
+}
+
+// errConnChanged is returned when request can't be performed because the
+// connection it's supposed to be made over is lost.
+var errConnChanged = errors.New("connection changed")
+
+// withDefaults returns a copy of reconnection options with default values set.
+func (o *ReconnectOptions) withDefaults() *ReconnectOptions {
+	var res = *o
+	if res.MinDelay <= 0 {
+		res.MinDelay = defaultReconnectMinDelay
+	}
+	if res.MaxDelay <= 0 {
+		res.MaxDelay = defaultReconnectMaxDelay
+	}
+	if res.MaxDelay < res.MinDelay {
+		res.MaxDelay = res.MinDelay
+	}
+	return &res
+}
+
+// redial tries to establish a new connection to the endpoint according to
+// the reconnection settings.
+func (c *WSClient) redial() (*websocket.Conn, error) {
+	var delay = c.reconnect.MinDelay
+	for attempt := 1; ; attempt++ {
+		ws, err := dialWS(c.ctx, c.endpoint.String(), c.opts.DialTimeout)
+		if err == nil {
+			return ws, nil
+		}
+		if c.reconnect.MaxAttempts > 0 && attempt >= c.reconnect.MaxAttempts {
+			return nil, err
+		}
+		t := time.NewTimer(delay)
+		select {
+		case <-c.shutdown:
+			t.Stop()
+			return nil, errConnClosedByUser
+		case <-c.ctx.Done():
+			t.Stop()
+			return nil, c.ctx.Err()
+		case <-t.C:
+		}
+		delay *= 2
+		if delay > c.reconnect.MaxDelay {
+			delay = c.reconnect.MaxDelay
+		}
+	}
+}
+
+// dropPendingResponses fails all requests waiting for responses from the lost
+// connection and increments connection number. It returns the number of the
+// next connection.
+func (c *WSClient) dropPendingResponses() uint64 {
+	c.respLock.Lock()
+	defer c.respLock.Unlock()
+
+	for _, ch := range c.respChannels {
+		close(ch)
+	}
+	c.respChannels = make(map[uint64]chan *neorpc.Response)
+	c.connNum++
+	return c.connNum
+}
+
+// currentConn returns the number of the current connection.
+func (c *WSClient) currentConn() uint64 {
+	c.respLock.RLock()
+	defer c.respLock.RUnlock()
+	return c.connNum
+}
+
+// updateLastBlock updates the latest known block index if the given one is
+// higher.
+func (c *WSClient) updateLastBlock(index uint32) {
+	for {
+		last := c.lastBlock.Load()
+		if index <= last || c.lastBlock.CAS(last, index) {
+			return
+		}
+	}
+}
+
+// performRequestOn is similar to performRequest, but it uses the given
+// connection (if it's not zero) and returns the number of connection used.
+func (c *WSClient) performRequestOn(method string, p []interface{}, v interface{}, conn uint64) (uint64, error) {
+	raw, conn, err := c.makeWsRequestOn(c.newRequest(method, p), conn)
+	return conn, unmarshalResult(raw, err, v)
+}
+
+// subscriptionParams returns subscribe method parameters for the given
+// receiver.
+func subscriptionParams(rcvr notificationReceiver) []interface{} {
+	var params = []interface{}{rcvr.EventID().String()}
+	if flt := rcvr.Filter(); flt != nil {
+		params = append(params, flt)
+	}
+	return params
+}
+
+// performFeedSubscription is a reconnection-aware version of performSubscription,
+// it returns a client-side subscription ID that doesn't change on reconnection.
+func (c *WSClient) performFeedSubscription(params []interface{}, rcvr notificationReceiver) (string, error) {
+	for {
+		var resp string
+
+		conn, err := c.performRequestOn("subscribe", params, &resp, 0)
+		if err != nil {
+			return "", err
+		}
+
+		c.subscriptionsLock.Lock()
+		// If the connection is lost after the subscription, the server-side
+		// feed is lost too, so the subscription has to be repeated. Otherwise
+		// it's added before the resubscription snapshot is taken.
+		if conn == c.currentConn() {
+			c.lastSubID++
+			id := strconv.FormatUint(c.lastSubID, 10)
+			c.subscriptions[id] = rcvr
+			c.feeds[id] = wsFeed{id: resp, conn: conn}
+			ch := rcvr.Receiver()
+			c.receivers[ch] = append(c.receivers[ch], id)
+			c.subscriptionsLock.Unlock()
+			return id, nil
+		}
+		c.subscriptionsLock.Unlock()
+	}
+}
+
+// performFeedUnsubscription is a reconnection-aware version of
+// performUnsubscription. Subscription is removed locally before sending the
+// request, so it's not resubscribed if the connection is lost in the process.
+func (c *WSClient) performFeedUnsubscription(id string) error {
+	c.subscriptionsLock.Lock()
+	feed, ok := c.feeds[id]
+	if !ok {
+		c.subscriptionsLock.Unlock()
+		return errors.New("no subscription with this ID")
+	}
+	c.removeSubscription(id)
+	delete(c.feeds, id)
+	c.subscriptionsLock.Unlock()
+
+	var resp bool
+	if _, err := c.performRequestOn("unsubscribe", []interface{}{feed.id}, &resp, feed.conn); err != nil {
+		if c.currentConn() != feed.conn {
+			return nil // Server-side feed is gone along with the connection.
+		}
+		return err
+	}
+	if !resp {
+		return errors.New("unsubscribe method returned false result")
+	}
+	return nil
+}
+
+// resubscribe repeats all subscriptions over the connection with the given
+// number and sends a GapEvent when it's done. It stops if the connection is
+// lost again, the next resubscription will finish the job then.
+func (c *WSClient) resubscribe(conn uint64, from uint32) {
+	var ids []string
+
+	c.subscriptionsLock.RLock()
+	for id, rcvr := range c.subscriptions {
+		if _, ok := c.receivers[rcvr.Receiver()]; !ok {
+			continue // Receiver is closed after MissedEvent.
+		}
+		if c.feeds[id].conn != conn {
+			ids = append(ids, id)
+		}
+	}
+	c.subscriptionsLock.RUnlock()
+
+	for _, id := range ids {
+		c.subscriptionsLock.RLock()
+		rcvr, ok := c.subscriptions[id]
+		c.subscriptionsLock.RUnlock()
+		if !ok {
+			continue // Unsubscribed concurrently.
+		}
+
+		var srvID string
+		_, err := c.performRequestOn("subscribe", subscriptionParams(rcvr), &srvID, conn)
+		if err != nil {
+			var rpcErr *neorpc.Error
+			if !errors.As(err, &rpcErr) {
+				return // Connection is lost.
+			}
+			c.subscriptionsLock.Lock()
+			if _, ok := c.subscriptions[id]; ok {
+				delete(c.feeds, id)
+				if c.removeSubscription(id) {
+					rcvr.Close()
+				}
+			}
+			c.subscriptionsLock.Unlock()
+			continue
+		}
+		c.subscriptionsLock.Lock()
+		_, ok = c.subscriptions[id]
+		if ok && c.feeds[id].conn < conn {
+			c.feeds[id] = wsFeed{id: srvID, conn: conn}
+		}
+		c.subscriptionsLock.Unlock()
+		if !ok {
+			// Unsubscribed while being resubscribed.
+			_, _ = c.performRequestOn("unsubscribe", []interface{}{srvID}, new(bool), conn)
+		}
+	}
+
+	var count uint32
+	if _, err := c.performRequestOn("getblockcount", nil, &count, conn); err != nil {
+		return
+	}
+	c.updateLastBlock(count - 1)
+	if c.reconnect.Gaps != nil {
+		select {
+		case c.reconnect.Gaps <- GapEvent{From: from, To: count - 1}:
+		case <-c.done:
+		}
+	}
+}
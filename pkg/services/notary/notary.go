@@ -22,6 +22,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/remotesigner"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -132,11 +133,15 @@ func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction fu
 	}
 
 	haveAccount := false
-	for _, acc := range wallet.Accounts {
-		if err := acc.Decrypt(w.Password, wallet.Scrypt); err == nil {
-			haveAccount = true
-			break
+	if len(w.RemoteSigner) > 0 {
+		n, err := remotesigner.Attach(wallet, w.RemoteSigner)
+		if err != nil {
+			return nil, fmt.Errorf("can't use remote signer: %w", err)
 		}
+		haveAccount = n > 0
+	}
+	for i := 0; !haveAccount && i < len(wallet.Accounts); i++ {
+		haveAccount = wallet.Accounts[i].Decrypt(w.Password, wallet.Scrypt) == nil
 	}
 	if !haveAccount {
 		return nil, errors.New("no wallet account could be unlocked")
@@ -381,8 +386,10 @@ func (n *Notary) PostPersist() {
 		if r.minNotValidBefore <= currHeight { // then at least one of the fallbacks can already be sent.
 			for _, fb := range r.fallbacks {
 				if nvb := fb.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height; nvb <= currHeight {
-					// Ignore the error, wait for the next block to resend them
-					_ = n.finalize(acc, fb, h)
+					// Wait for the next block to resend them in case of error.
+					if err := n.finalize(acc, fb, h); err != nil {
+						n.Config.Log.Debug("failed to finalize fallback transaction", zap.Error(err))
+					}
 				}
 			}
 		}
@@ -391,8 +398,12 @@ func (n *Notary) PostPersist() {
 
 // finalize adds missing Notary witnesses to the transaction (main or fallback) and pushes it to the network.
 func (n *Notary) finalize(acc *wallet.Account, tx *transaction.Transaction, h util.Uint256) error {
+	sig, err := acc.SignHashableErr(n.Network, tx)
+	if err != nil {
+		return fmt.Errorf("failed to sign transaction: %w", err)
+	}
 	notaryWitness := transaction.Witness{
-		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, acc.SignHashable(n.Network, tx)...),
+		InvocationScript:   append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, sig...),
 		VerificationScript: []byte{},
 	}
 	for i, signer := range tx.Signers {
@@ -1,6 +1,8 @@
 package notary
 
 import (
+	"errors"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/fakechain"
@@ -13,6 +15,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/remotesigner"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
 )
@@ -44,6 +48,75 @@ func TestWallet(t *testing.T) {
 		_, err := NewNotary(cfg, netmode.UnitTestNet, mempool.New(1, 1, true), nil)
 		require.NoError(t, err)
 	})
+
+	t.Run("remote signer", func(t *testing.T) {
+		w, err := wallet.NewWalletFromFile("./testdata/notary1.json")
+		require.NoError(t, err)
+		var privs []*keys.PrivateKey
+		for _, acc := range w.Accounts {
+			if acc.Decrypt("one", w.Scrypt) == nil {
+				privs = append(privs, acc.PrivateKey())
+			}
+		}
+		require.NotEmpty(t, privs)
+		srv := httptest.NewServer(remotesigner.NewServer(privs...))
+		t.Cleanup(srv.Close)
+
+		cfg.MainCfg.UnlockWallet.Path = "./testdata/notary1.json"
+		cfg.MainCfg.UnlockWallet.Password = "invalid"
+		cfg.MainCfg.UnlockWallet.RemoteSigner = "http://" + t.TempDir() // Not a valid endpoint.
+		_, err = NewNotary(cfg, netmode.UnitTestNet, mempool.New(1, 1, true), nil)
+		require.Error(t, err)
+
+		cfg.MainCfg.UnlockWallet.RemoteSigner = srv.URL
+		ntr, err := NewNotary(cfg, netmode.UnitTestNet, mempool.New(1, 1, true), nil)
+		require.NoError(t, err)
+		var remote int
+		for _, acc := range ntr.wallet.Accounts {
+			if acc.CanSign() {
+				require.Nil(t, acc.PrivateKey())
+				remote++
+			}
+		}
+		require.Equal(t, len(privs), remote)
+	})
+}
+
+type failingSigner struct {
+	pub *keys.PublicKey
+}
+
+func (s failingSigner) PublicKey() *keys.PublicKey  { return s.pub }
+func (s failingSigner) Sign([]byte) ([]byte, error) { return nil, errors.New("failure") }
+
+func TestFinalizeSignerFailure(t *testing.T) {
+	bc := fakechain.NewFakeChain()
+	cfg := Config{
+		MainCfg: config.P2PNotary{
+			Enabled:      true,
+			UnlockWallet: config.Wallet{Path: "./testdata/notary1.json", Password: "one"},
+		},
+		Chain: bc,
+		Log:   zaptest.NewLogger(t),
+	}
+	ntr, err := NewNotary(cfg, netmode.UnitTestNet, mempool.New(1, 1, true), nil)
+	require.NoError(t, err)
+	var acc *wallet.Account
+	for _, a := range ntr.wallet.Accounts {
+		if a.CanSign() {
+			acc = a
+			break
+		}
+	}
+	require.NotNil(t, acc)
+	require.NoError(t, acc.SetSigner(failingSigner{pub: acc.PublicKey()}))
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+	tx.Scripts = []transaction.Witness{{}}
+	require.ErrorContains(t, ntr.finalize(acc, tx, tx.Hash()), "failure")
+	require.Empty(t, tx.Scripts[0].InvocationScript)
+	require.Equal(t, 0, len(ntr.newTxs))
 }
 
 func TestVerifyIncompleteRequest(t *testing.T) {
@@ -2,19 +2,141 @@ package oracle
 
 import (
 	"bytes"
+	"encoding/csv"
 	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
 	"unicode/utf8"
 
 	json "github.com/nspcc-dev/go-ordered-json"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/jsonpath"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/xpath"
 )
 
-func filter(value []byte, path string) ([]byte, error) {
+// FilterFunc selects values from the oracle response data using an
+// expression of some filter language. Values returned must be JSON-compatible
+// (strings, numbers, booleans, nil, slices and maps of them). Filters must be
+// deterministic, the same data and expression must produce the same result on
+// every oracle node.
+type FilterFunc func(data []byte, expr string) ([]interface{}, error)
+
+const (
+	// maxScale is the maximum absolute value of scale transformation
+	// parameter.
+	maxScale = 32
+	// maxTransformations is the maximum number of transformations in a
+	// single filter.
+	maxTransformations = 8
+	// maxExponent is the maximum absolute value of number exponent accepted
+	// by scale transformation.
+	maxExponent = 64
+	// maxValues is the maximum number of values selected by CSV filters,
+	// it's the same as for JSONPath ones.
+	maxValues = 1024
+)
+
+// builtinFilters contains filter languages supported by default. Filters
+// without language prefix are JSONPath ones.
+var builtinFilters = map[string]FilterFunc{
+	"jsonpath": filterJSON,
+	"xpath":    filterXPath,
+	"csv":      func(data []byte, expr string) ([]interface{}, error) { return filterCSV(data, expr, ',') },
+	"tsv":      func(data []byte, expr string) ([]interface{}, error) { return filterCSV(data, expr, '\t') },
+}
+
+// filter applies the filter to the value using built-in filter languages.
+func filter(value []byte, expr string) ([]byte, error) {
+	return applyFilter(builtinFilters, value, expr)
+}
+
+// applyFilter applies the filter to the value. Filter is either a JSONPath
+// expression or <language>:<expression> with optional transformations
+// following it, each one prefixed with `|`.
+func applyFilter(langs map[string]FilterFunc, value []byte, expr string) ([]byte, error) {
 	if !utf8.Valid(value) {
 		return nil, errors.New("not an UTF-8")
 	}
+	parts, err := splitFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts) > maxTransformations+1 {
+		return nil, errors.New("too many transformations")
+	}
 
+	f, sel := filterJSON, parts[0]
+	if len(parts) > 1 {
+		sel = strings.TrimRight(sel, " ")
+	}
+	if i := strings.IndexByte(sel, ':'); i > 0 && isFilterLang(sel[:i]) {
+		var ok bool
+		f, ok = langs[sel[:i]]
+		if !ok {
+			return nil, fmt.Errorf("unknown filter language %q", sel[:i])
+		}
+		sel = sel[i+1:]
+	}
+	result, err := f(value, sel)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range parts[1:] {
+		result, err = transform(result, strings.TrimSpace(t))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(result)
+}
+
+// isFilterLang checks whether s can be a filter language name.
+func isFilterLang(s string) bool {
+	for i := range s {
+		if !('a' <= s[i] && s[i] <= 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// splitFilter splits the filter into a selector and transformations. `|`
+// inside quotes or brackets doesn't separate them.
+func splitFilter(expr string) ([]string, error) {
+	var (
+		parts []string
+		quote byte
+		depth int
+		start int
+	)
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+		case c == '|' && depth == 0:
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		}
+	}
+	if quote != 0 || depth != 0 {
+		return nil, errors.New("invalid filter")
+	}
+	return append(parts, expr[start:]), nil
+}
+
+func filterJSON(value []byte, path string) ([]interface{}, error) {
 	buf := bytes.NewBuffer(value)
 	d := json.NewDecoder(buf)
 	d.UseOrderedObject()
@@ -28,12 +150,236 @@ func filter(value []byte, path string) ([]byte, error) {
 	if !ok {
 		return nil, errors.New("invalid filter")
 	}
-	return json.Marshal(result)
+	return result, nil
+}
+
+func filterXPath(value []byte, path string) ([]interface{}, error) {
+	strs, ok := xpath.Get(path, value)
+	if !ok {
+		return nil, errors.New("invalid filter")
+	}
+	return stringsToValues(strs), nil
+}
+
+// filterCSV selects a column from CSV data. The column is either a 0-based
+// index or a header name, optionally quoted (the first record is a header then
+// and it's not included in the result). It can be followed by a 0-based row
+// index in brackets to select a single value.
+func filterCSV(value []byte, expr string, sep rune) ([]interface{}, error) {
+	col, row := expr, -1
+	if strings.HasSuffix(expr, "]") {
+		i := strings.LastIndexByte(expr, '[')
+		if i < 0 {
+			return nil, errors.New("invalid filter")
+		}
+		n, err := strconv.Atoi(expr[i+1 : len(expr)-1])
+		if err != nil || n < 0 {
+			return nil, errors.New("invalid row index")
+		}
+		col, row = expr[:i], n
+	}
+	if col == "" {
+		return nil, errors.New("invalid filter")
+	}
+
+	r := csv.NewReader(bytes.NewReader(value))
+	r.Comma = sep
+	r.FieldsPerRecord = -1
+	r.ReuseRecord = true
+
+	index, err := strconv.Atoi(col)
+	if err != nil || index < 0 {
+		name := col
+		if col[0] == '\'' || col[0] == '"' {
+			if name, err = unquote(col); err != nil {
+				return nil, err
+			}
+		}
+		header, err := r.Read()
+		if err != nil {
+			return nil, fmt.Errorf("no CSV header: %w", err)
+		}
+		index = -1
+		for i := range header {
+			if header[i] == name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			return nil, fmt.Errorf("no %q column", name)
+		}
+	}
+
+	var res = []string{}
+	for i := 0; row < 0 || i <= row; i++ {
+		rec, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(rec) <= index {
+			return nil, fmt.Errorf("no column %d in record %d", index, i)
+		}
+		if row < 0 || i == row {
+			res = append(res, rec[index])
+		}
+		if maxValues < len(res) {
+			return nil, errors.New("too many values")
+		}
+	}
+	return stringsToValues(res), nil
+}
+
+func stringsToValues(strs []string) []interface{} {
+	res := make([]interface{}, len(strs))
+	for i := range strs {
+		res[i] = strs[i]
+	}
+	return res
+}
+
+// transform applies a single transformation in name(args) form to values.
+func transform(values []interface{}, t string) ([]interface{}, error) {
+	i := strings.IndexByte(t, '(')
+	if i < 0 || !strings.HasSuffix(t, ")") {
+		return nil, fmt.Errorf("invalid transformation %q", t)
+	}
+	name, arg := t[:i], strings.TrimSpace(t[i+1:len(t)-1])
+	switch name {
+	case "scale":
+		n, err := strconv.Atoi(arg)
+		if err != nil || n < -maxScale || maxScale < n {
+			return nil, fmt.Errorf("invalid scale %q", arg)
+		}
+		res := make([]interface{}, len(values))
+		for i := range values {
+			res[i], err = scale(values[i], n)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case "join":
+		var sep string
+		if arg != "" {
+			var err error
+			sep, err = unquote(arg)
+			if err != nil {
+				return nil, err
+			}
+		}
+		strs := make([]string, len(values))
+		for i := range values {
+			s, err := toString(values[i])
+			if err != nil {
+				return nil, err
+			}
+			strs[i] = s
+		}
+		return []interface{}{strings.Join(strs, sep)}, nil
+	default:
+		return nil, fmt.Errorf("unknown transformation %q", name)
+	}
+}
+
+// scale multiplies decimal number by 10^n and truncates the result to integer.
+func scale(v interface{}, n int) (json.Number, error) {
+	s, err := toString(v)
+	if err != nil {
+		return "", err
+	}
+	mant, exp, err := parseDecimal(strings.TrimSpace(s))
+	if err != nil {
+		return "", err
+	}
+	exp += n
+	m := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(exp))), nil)
+	if exp < 0 {
+		mant.Quo(mant, m)
+	} else {
+		mant.Mul(mant, m)
+	}
+	return json.Number(mant.String()), nil
+}
+
+// parseDecimal parses decimal number (optionally in exponential notation)
+// into mantissa and exponent, so that the number is mant*10^exp.
+func parseDecimal(s string) (*big.Int, int, error) {
+	var (
+		digits string
+		exp    int
+		num    = s
+	)
+	if i := strings.IndexAny(num, "eE"); i >= 0 {
+		e, err := strconv.Atoi(num[i+1:])
+		if err != nil || e < -maxExponent || maxExponent < e {
+			return nil, 0, fmt.Errorf("not a number: %q", s)
+		}
+		exp, num = e, num[:i]
+	}
+	sign := ""
+	if len(num) > 0 && (num[0] == '-' || num[0] == '+') {
+		sign, num = num[:1], num[1:]
+	}
+	intPart, fracPart, hasFrac := strings.Cut(num, ".")
+	if intPart == "" || (hasFrac && fracPart == "") || !isDigits(intPart) || !isDigits(fracPart) {
+		return nil, 0, fmt.Errorf("not a number: %q", s)
+	}
+	digits = intPart + fracPart
+	exp -= len(fracPart)
+	mant, ok := new(big.Int).SetString(sign+digits, 10)
+	if !ok {
+		return nil, 0, fmt.Errorf("not a number: %q", s)
+	}
+	return mant, exp, nil
+}
+
+func isDigits(s string) bool {
+	for i := range s {
+		if s[i] < '0' || '9' < s[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// toString converts a scalar value to string, numbers and booleans are
+// represented the same way they are in JSON.
+func toString(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return string(v), nil
+	case float64, bool:
+		b, err := json.Marshal(v)
+		return string(b), err
+	default:
+		return "", fmt.Errorf("unsupported value type %T", v)
+	}
+}
+
+// unquote returns the string surrounded by single or double quotes.
+func unquote(s string) (string, error) {
+	if len(s) < 2 || (s[0] != '\'' && s[0] != '"') || s[len(s)-1] != s[0] {
+		return "", fmt.Errorf("invalid string %q", s)
+	}
+	return s[1 : len(s)-1], nil
 }
 
-func filterRequest(result []byte, req *state.OracleRequest) ([]byte, error) {
+func filterRequest(langs map[string]FilterFunc, result []byte, req *state.OracleRequest) ([]byte, error) {
 	if req.Filter != nil {
-		return filter(result, *req.Filter)
+		return applyFilter(langs, result, *req.Filter)
 	}
 	return result, nil
 }
@@ -1,9 +1,12 @@
 package oracle
 
 import (
+	"encoding/json"
+	"os"
 	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/stretchr/testify/require"
 )
 
@@ -81,3 +84,51 @@ func TestFilterOOM(t *testing.T) {
 		require.Error(t, err)
 	})
 }
+
+// TestFilterCompat checks filter results against the set of test vectors,
+// results must never change since all oracle nodes must produce the same
+// response for the same request.
+func TestFilterCompat(t *testing.T) {
+	raw, err := os.ReadFile("./testdata/filters.json")
+	require.NoError(t, err)
+
+	var testCases []struct {
+		Name   string `json:"name"`
+		Data   string `json:"data"`
+		Filter string `json:"filter"`
+		Result string `json:"result"`
+		Error  bool   `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &testCases))
+	for _, tc := range testCases {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual, err := filter([]byte(tc.Data), tc.Filter)
+			if tc.Error {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Result, string(actual))
+		})
+	}
+}
+
+func TestFilterRequest(t *testing.T) {
+	langs := map[string]FilterFunc{
+		"upper": func(data []byte, expr string) ([]interface{}, error) {
+			return []interface{}{strings.ToUpper(string(data))}, nil
+		},
+	}
+	flt := "upper:|join()"
+	actual, err := filterRequest(langs, []byte("abc"), &state.OracleRequest{Filter: &flt})
+	require.NoError(t, err)
+	require.Equal(t, `["ABC"]`, string(actual))
+
+	flt = "xpath:/a"
+	_, err = filterRequest(langs, []byte("<a/>"), &state.OracleRequest{Filter: &flt})
+	require.Error(t, err)
+
+	actual, err = filterRequest(langs, []byte("abc"), &state.OracleRequest{})
+	require.NoError(t, err)
+	require.Equal(t, "abc", string(actual))
+}
@@ -2,6 +2,7 @@ package oracle
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"sync"
 	"time"
@@ -18,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/util/slice"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/remotesigner"
 	"go.uber.org/zap"
 )
 
@@ -66,7 +68,15 @@ type (
 		// removed contains ids of requests which won't be processed further due to expiration.
 		removed map[uint64]bool
 
+		// schemes contains handlers for all supported URL schemes.
+		schemes map[string]SchemeHandler
+		// filters contains all supported filter languages.
+		filters map[string]FilterFunc
+
 		wallet *wallet.Wallet
+		// neofsKey is used for NeoFS requests made by accounts with
+		// external signers.
+		neofsKey *keys.PrivateKey
 	}
 
 	// Config contains oracle module parameters.
@@ -78,6 +88,13 @@ type (
 		Chain           Ledger
 		ResponseHandler Broadcaster
 		OnTransaction   TxCallback
+		// Schemes contains additional URL scheme handlers, they take
+		// precedence over the built-in ones.
+		Schemes map[string]SchemeHandler
+		// Filters contains additional filter languages, they take
+		// precedence over the built-in ones. Language name must consist
+		// of lowercase latin letters.
+		Filters map[string]FilterFunc
 	}
 
 	// HTTPClient is an interface capable of doing oracle requests.
@@ -87,7 +104,7 @@ type (
 
 	// Broadcaster broadcasts oracle responses.
 	Broadcaster interface {
-		SendResponse(priv *keys.PrivateKey, resp *transaction.OracleResponse, txSig []byte)
+		SendResponse(signer wallet.Signer, resp *transaction.OracleResponse, txSig []byte)
 		Run()
 		Shutdown()
 	}
@@ -150,15 +167,24 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	}
 
 	haveAccount := false
-	for _, acc := range o.wallet.Accounts {
-		if err := acc.Decrypt(w.Password, o.wallet.Scrypt); err == nil {
-			haveAccount = true
-			break
+	if len(w.RemoteSigner) > 0 {
+		n, err := remotesigner.Attach(o.wallet, w.RemoteSigner)
+		if err != nil {
+			return nil, fmt.Errorf("can't use remote signer: %w", err)
 		}
+		haveAccount = n > 0
+	}
+	for i := 0; !haveAccount && i < len(o.wallet.Accounts); i++ {
+		haveAccount = o.wallet.Accounts[i].Decrypt(w.Password, o.wallet.Scrypt) == nil
 	}
 	if !haveAccount {
 		return nil, errors.New("no wallet account could be unlocked")
 	}
+	if len(w.RemoteSigner) > 0 {
+		if o.neofsKey, err = keys.NewPrivateKey(); err != nil {
+			return nil, err
+		}
+	}
 
 	if o.ResponseHandler == nil {
 		o.ResponseHandler = broadcaster.New(cfg.MainCfg, cfg.Log)
@@ -169,6 +195,19 @@ func NewOracle(cfg Config) (*Oracle, error) {
 	if o.Client == nil {
 		o.Client = getDefaultClient(o.MainCfg)
 	}
+	if err = o.initSchemes(); err != nil {
+		return nil, err
+	}
+	o.filters = make(map[string]FilterFunc, len(builtinFilters)+len(o.Filters))
+	for name, f := range builtinFilters {
+		o.filters[name] = f
+	}
+	for name, f := range o.Filters {
+		if !isFilterLang(name) {
+			return nil, fmt.Errorf("invalid filter language name %q", name)
+		}
+		o.filters[name] = f
+	}
 	return o, nil
 }
 
@@ -55,6 +55,10 @@ func getOracleConfig(t *testing.T, bc *core.Blockchain, w, pass string, returnOr
 		MainCfg: config.OracleConfiguration{
 			RefreshInterval:     time.Second,
 			AllowedContentTypes: []string{"application/json"},
+			Schemes: map[string]config.OracleSchemeConfiguration{
+				"data": {},
+				"ipfs": {Gateways: []string{"https://gw1.example/ipfs", "https://gw2.example/ipfs/"}},
+			},
 			UnlockWallet: config.Wallet{
 				Path:     w,
 				Password: pass,
@@ -62,6 +66,11 @@ func getOracleConfig(t *testing.T, bc *core.Blockchain, w, pass string, returnOr
 		},
 		Chain:  bc,
 		Client: newDefaultHTTPClient(returnOracleRedirectionErrOn),
+		Schemes: map[string]oracle.SchemeHandler{
+			"custom": oracle.SchemeHandlerFunc(func(req *oracle.SchemeRequest) ([]byte, transaction.OracleResponseCode, error) {
+				return []byte(req.URL.Opaque), transaction.Success, nil
+			}),
+		},
 	}
 }
 
@@ -191,6 +200,16 @@ func TestOracle(t *testing.T) {
 
 	putOracleRequest(t, cInvoker, "https://get.invalidcontent", nil, "handle", []byte{}, 10_000_000)
 
+	putOracleRequest(t, cInvoker, "data:application/json,%7B%22a%22%3A1%7D", nil, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cInvoker, "data:application/json;base64,eyJhIjoxfQ==", nil, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cInvoker, "data:,hello", nil, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cInvoker, "ipfs://bafkreiexample/data.json", nil, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cInvoker, "custom:value", nil, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cInvoker, "unknown://value", nil, "handle", []byte{}, 10_000_000)
+
+	flt = "$.p[*] | scale(2) | join(',')"
+	putOracleRequest(t, cInvoker, "data:application/json,%7B%22p%22%3A%5B1.5%2C2%5D%7D", &flt, "handle", []byte{}, 10_000_000)
+
 	checkResp := func(t *testing.T, id uint64, resp *transaction.OracleResponse) *state.OracleRequest {
 		// Use a hack to get request from Oracle contract, because we can't use GetRequestInternal directly.
 		requestKey := make([]byte, 9)
@@ -337,6 +356,55 @@ func TestOracle(t *testing.T) {
 			Code: transaction.ContentTypeNotSupported,
 		})
 	})
+	t.Run("Schemes", func(t *testing.T) {
+		t.Run("data", func(t *testing.T) {
+			checkResp(t, 12, &transaction.OracleResponse{
+				ID:     12,
+				Code:   transaction.Success,
+				Result: []byte(`{"a":1}`),
+			})
+		})
+		t.Run("data, base64", func(t *testing.T) {
+			checkResp(t, 13, &transaction.OracleResponse{
+				ID:     13,
+				Code:   transaction.Success,
+				Result: []byte(`{"a":1}`),
+			})
+		})
+		t.Run("data, invalid content type", func(t *testing.T) {
+			checkResp(t, 14, &transaction.OracleResponse{
+				ID:   14,
+				Code: transaction.ContentTypeNotSupported,
+			})
+		})
+		t.Run("gateway", func(t *testing.T) {
+			checkResp(t, 15, &transaction.OracleResponse{
+				ID:     15,
+				Code:   transaction.Success,
+				Result: []byte{1, 2, 3},
+			})
+		})
+		t.Run("custom", func(t *testing.T) {
+			checkResp(t, 16, &transaction.OracleResponse{
+				ID:     16,
+				Code:   transaction.Success,
+				Result: []byte("value"),
+			})
+		})
+		t.Run("unknown", func(t *testing.T) {
+			checkResp(t, 17, &transaction.OracleResponse{
+				ID:   17,
+				Code: transaction.ProtocolNotSupported,
+			})
+		})
+	})
+	t.Run("FilterTransformations", func(t *testing.T) {
+		checkResp(t, 18, &transaction.OracleResponse{
+			ID:     18,
+			Code:   transaction.Success,
+			Result: []byte(`["150,200"]`),
+		})
+	})
 }
 
 func TestOracleFull(t *testing.T) {
@@ -438,7 +506,7 @@ type saveToMapBroadcaster struct {
 	m   map[uint64]*responseWithSig
 }
 
-func (b *saveToMapBroadcaster) SendResponse(_ *keys.PrivateKey, resp *transaction.OracleResponse, txSig []byte) {
+func (b *saveToMapBroadcaster) SendResponse(_ wallet.Signer, resp *transaction.OracleResponse, txSig []byte) {
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
 	b.m[resp.ID] = &responseWithSig{
@@ -553,6 +621,11 @@ func newDefaultHTTPClient(returnOracleRedirectionErrOn func(address string) bool
 				ct:   "image/gif",
 				body: []byte{1, 2, 3},
 			},
+			"https://gw2.example/ipfs/bafkreiexample/data.json": {
+				code: http.StatusOK,
+				ct:   "application/json",
+				body: []byte{1, 2, 3},
+			},
 		},
 	}
 }
@@ -560,3 +633,23 @@ func newDefaultHTTPClient(returnOracleRedirectionErrOn func(address string) bool
 func newResponseBody(resp []byte) gio.ReadCloser {
 	return gio.NopCloser(bytes.NewReader(resp))
 }
+
+func TestOracle_InvalidSchemes(t *testing.T) {
+	bc, _, _ := chain.NewMulti(t)
+
+	check := func(t *testing.T, schemes map[string]config.OracleSchemeConfiguration) {
+		cfg := getOracleConfig(t, bc, "./testdata/oracle1.json", "one", nil)
+		cfg.MainCfg.Schemes = schemes
+		_, err := oracle.NewOracle(cfg)
+		require.Error(t, err)
+	}
+	t.Run("built-in", func(t *testing.T) {
+		check(t, map[string]config.OracleSchemeConfiguration{"https": {}})
+	})
+	t.Run("no gateways", func(t *testing.T) {
+		check(t, map[string]config.OracleSchemeConfiguration{"ipfs": {}})
+	})
+	t.Run("invalid gateway", func(t *testing.T) {
+		check(t, map[string]config.OracleSchemeConfiguration{"ipfs": {Gateways: []string{"ftp://gw.example"}}})
+	})
+}
@@ -1,18 +1,17 @@
 package oracle
 
 import (
-	"context"
 	"errors"
+	"fmt"
 	"mime"
-	"net/http"
 	"net/url"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
-	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"go.uber.org/zap"
 )
 
@@ -33,7 +32,7 @@ func (o *Oracle) runRequestWorker() {
 			if acc == nil {
 				continue
 			}
-			err := o.processRequest(acc.PrivateKey(), req)
+			err := o.processRequest(acc, req)
 			if err != nil {
 				o.Log.Debug("can't process request", zap.Uint64("id", req.ID), zap.Error(err))
 			}
@@ -96,15 +95,19 @@ func (o *Oracle) ProcessRequestsInternal(reqs map[uint64]*state.OracleRequest) {
 
 	// Process actual requests.
 	for id, req := range reqs {
-		if err := o.processRequest(acc.PrivateKey(), request{ID: id, Req: req}); err != nil {
+		if err := o.processRequest(acc, request{ID: id, Req: req}); err != nil {
 			o.Log.Debug("can't process request", zap.Error(err))
 		}
 	}
 }
 
-func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
+func (o *Oracle) processRequest(acc *wallet.Account, req request) error {
+	signer := acc.Signer()
+	if signer == nil {
+		return errors.New("account can't sign")
+	}
 	if req.Req == nil {
-		o.processFailedRequest(priv, req)
+		o.processFailedRequest(signer, req)
 		return nil
 	}
 
@@ -118,69 +121,34 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
 		resp.Code = transaction.ProtocolNotSupported
 	} else {
-		switch u.Scheme {
-		case "https":
-			httpReq, err := http.NewRequest("GET", req.Req.URL, nil)
-			if err != nil {
-				o.Log.Warn("failed to create http request", zap.String("url", req.Req.URL), zap.Error(err))
-				resp.Code = transaction.Error
-				break
+		h, ok := o.schemes[u.Scheme]
+		if !ok {
+			resp.Code = transaction.ProtocolNotSupported
+			o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
+		} else {
+			priv := acc.PrivateKey()
+			if priv == nil {
+				// Keys of external signers can't be used for
+				// authenticating requests, so an ephemeral one is
+				// used instead.
+				priv = o.neofsKey
 			}
-			httpReq.Header.Set("User-Agent", "NeoOracleService/3.0")
-			httpReq.Header.Set("Content-Type", "application/json")
-			r, err := o.Client.Do(httpReq)
+			resp.Result, resp.Code, err = h.Fetch(&SchemeRequest{
+				ID:      req.ID,
+				Attempt: incTx.attempts,
+				URL:     u,
+				Key:     priv,
+			})
 			if err != nil {
-				if errors.Is(err, ErrRestrictedRedirect) {
-					resp.Code = transaction.Forbidden
-				} else {
+				if resp.Code == transaction.Success {
 					resp.Code = transaction.Error
 				}
 				o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err), zap.Stringer("code", resp.Code))
-				break
-			}
-			defer r.Body.Close()
-			switch r.StatusCode {
-			case http.StatusOK:
-				if !checkMediaType(r.Header.Get("Content-Type"), o.MainCfg.AllowedContentTypes) {
-					resp.Code = transaction.ContentTypeNotSupported
-					break
-				}
-
-				resp.Result, err = readResponse(r.Body, transaction.MaxOracleResultSize)
-				if err != nil {
-					if errors.Is(err, ErrResponseTooLarge) {
-						resp.Code = transaction.ResponseTooLarge
-					} else {
-						resp.Code = transaction.Error
-					}
-					o.Log.Warn("failed to read data for oracle request", zap.String("url", req.Req.URL), zap.Error(err))
-					break
-				}
-			case http.StatusForbidden:
-				resp.Code = transaction.Forbidden
-			case http.StatusNotFound:
-				resp.Code = transaction.NotFound
-			case http.StatusRequestTimeout:
-				resp.Code = transaction.Timeout
-			default:
-				resp.Code = transaction.Error
-			}
-		case neofs.URIScheme:
-			ctx, cancel := context.WithTimeout(context.Background(), o.MainCfg.NeoFS.Timeout)
-			defer cancel()
-			index := (int(req.ID) + incTx.attempts) % len(o.MainCfg.NeoFS.Nodes)
-			resp.Result, err = neofs.Get(ctx, priv, u, o.MainCfg.NeoFS.Nodes[index])
-			if err != nil {
-				o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err))
-				resp.Code = transaction.Error
 			}
-		default:
-			resp.Code = transaction.ProtocolNotSupported
-			o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
 		}
 	}
 	if resp.Code == transaction.Success {
-		resp.Result, err = filterRequest(resp.Result, req.Req)
+		resp.Result, err = filterRequest(o.filters, resp.Result, req.Req)
 		if err != nil {
 			o.Log.Warn("oracle filter failed", zap.Uint64("request", req.ID), zap.Error(err))
 			resp.Code = transaction.Error
@@ -214,17 +182,23 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 		return err
 	}
 
+	txSig, err := signer.Sign(hash.GetSignedData(uint32(o.Network), tx))
+	if err != nil {
+		return fmt.Errorf("failed to sign response tx: %w", err)
+	}
+	backupSig, err := signer.Sign(hash.GetSignedData(uint32(o.Network), backupTx))
+	if err != nil {
+		return fmt.Errorf("failed to sign backup tx: %w", err)
+	}
+
 	incTx.Lock()
 	incTx.request = req.Req
 	incTx.tx = tx
 	incTx.backupTx = backupTx
 	incTx.reverifyTx(o.Network)
 
-	txSig := priv.SignHashable(uint32(o.Network), tx)
-	incTx.addResponse(priv.PublicKey(), txSig, false)
-
-	backupSig := priv.SignHashable(uint32(o.Network), backupTx)
-	incTx.addResponse(priv.PublicKey(), backupSig, true)
+	incTx.addResponse(signer.PublicKey(), txSig, false)
+	incTx.addResponse(signer.PublicKey(), backupSig, true)
 
 	readyTx, ready := incTx.finalize(o.getOracleNodes(), false)
 	if ready {
@@ -235,14 +209,14 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 	incTx.attempts++
 	incTx.Unlock()
 
-	o.ResponseHandler.SendResponse(priv, resp, txSig)
+	o.ResponseHandler.SendResponse(signer, resp, txSig)
 	if ready {
 		o.sendTx(readyTx)
 	}
 	return nil
 }
 
-func (o *Oracle) processFailedRequest(priv *keys.PrivateKey, req request) {
+func (o *Oracle) processFailedRequest(signer wallet.Signer, req request) {
 	// Request is being processed again.
 	incTx := o.getResponse(req.ID, false)
 	if incTx == nil {
@@ -263,10 +237,10 @@ func (o *Oracle) processFailedRequest(priv *keys.PrivateKey, req request) {
 	}
 	incTx.time = time.Now()
 	incTx.attempts++
-	txSig := incTx.backupSigs[string(priv.PublicKey().Bytes())].sig
+	txSig := incTx.backupSigs[string(signer.PublicKey().Bytes())].sig
 	incTx.Unlock()
 
-	o.ResponseHandler.SendResponse(priv, getFailedResponse(req.ID), txSig)
+	o.ResponseHandler.SendResponse(signer, getFailedResponse(req.ID), txSig)
 	if ready {
 		o.sendTx(readyTx)
 	}
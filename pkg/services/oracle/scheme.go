@@ -0,0 +1,240 @@
+package oracle
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
+)
+
+const (
+	// HTTPSScheme is the URL scheme of HTTPS oracle requests.
+	HTTPSScheme = "https"
+	// DataScheme is the URL scheme of RFC 2397 data URIs.
+	DataScheme = "data"
+
+	// defaultDataMediaType is the media type of data URIs with no explicit
+	// media type.
+	defaultDataMediaType = "text/plain;charset=US-ASCII"
+)
+
+type (
+	// SchemeHandler gets data for oracle requests with a particular URL
+	// scheme. Handlers must be safe for concurrent use and must return the
+	// same result for the same URL on every oracle node, otherwise
+	// responses won't be accepted by the network.
+	SchemeHandler interface {
+		// Fetch gets data for the request. It returns the result (that
+		// must not exceed transaction.MaxOracleResultSize) and the response
+		// code. Non-nil error is only used for logging, the code should
+		// reflect the failure then.
+		Fetch(req *SchemeRequest) ([]byte, transaction.OracleResponseCode, error)
+	}
+
+	// SchemeRequest is an oracle request passed to SchemeHandler.
+	SchemeRequest struct {
+		// ID is the oracle request ID.
+		ID uint64
+		// Attempt is the number of previous attempts to process this
+		// request, it can be used to switch between data sources.
+		Attempt int
+		// URL is the parsed request URL.
+		URL *url.URL
+		// Key is the key that can be used to authenticate requests to
+		// the data source, it's not related to the oracle node key if
+		// an external signer is used.
+		Key *keys.PrivateKey
+	}
+
+	// SchemeHandlerFunc is a function adapter for SchemeHandler.
+	SchemeHandlerFunc func(req *SchemeRequest) ([]byte, transaction.OracleResponseCode, error)
+
+	httpsHandler struct {
+		client  HTTPClient
+		allowed []string
+	}
+
+	neofsHandler struct {
+		cfg config.NeoFSConfiguration
+	}
+
+	dataHandler struct {
+		allowed []string
+	}
+
+	gatewayHandler struct {
+		client   HTTPClient
+		allowed  []string
+		gateways []string
+		timeout  time.Duration
+	}
+)
+
+// Fetch implements the SchemeHandler interface.
+func (f SchemeHandlerFunc) Fetch(req *SchemeRequest) ([]byte, transaction.OracleResponseCode, error) {
+	return f(req)
+}
+
+// initSchemes creates a handler registry from built-in handlers enabled in the
+// configuration and the ones provided by the user.
+func (o *Oracle) initSchemes() error {
+	o.schemes = map[string]SchemeHandler{
+		HTTPSScheme: &httpsHandler{client: o.Client, allowed: o.MainCfg.AllowedContentTypes},
+		neofs.URIScheme: &neofsHandler{
+			cfg: o.MainCfg.NeoFS,
+		},
+	}
+	for scheme, cfg := range o.MainCfg.Schemes {
+		if _, ok := o.schemes[scheme]; ok {
+			return fmt.Errorf("oracle scheme %q can't be configured", scheme)
+		}
+		if scheme == DataScheme {
+			o.schemes[scheme] = &dataHandler{allowed: o.MainCfg.AllowedContentTypes}
+			continue
+		}
+		if len(cfg.Gateways) == 0 {
+			return fmt.Errorf("no gateways specified for oracle scheme %q", scheme)
+		}
+		gws := make([]string, len(cfg.Gateways))
+		for i, gw := range cfg.Gateways {
+			u, err := url.Parse(gw)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				return fmt.Errorf("invalid gateway %q for oracle scheme %q", gw, scheme)
+			}
+			gws[i] = strings.TrimSuffix(gw, "/")
+		}
+		if cfg.Timeout == 0 {
+			cfg.Timeout = o.MainCfg.RequestTimeout
+		}
+		o.schemes[scheme] = &gatewayHandler{
+			client:   o.Client,
+			allowed:  o.MainCfg.AllowedContentTypes,
+			gateways: gws,
+			timeout:  cfg.Timeout,
+		}
+	}
+	for scheme, h := range o.Schemes {
+		o.schemes[scheme] = h
+	}
+	return nil
+}
+
+// Fetch implements the SchemeHandler interface.
+func (h *httpsHandler) Fetch(req *SchemeRequest) ([]byte, transaction.OracleResponseCode, error) {
+	return fetchHTTP(context.Background(), h.client, req.URL.String(), h.allowed)
+}
+
+// Fetch implements the SchemeHandler interface.
+func (h *neofsHandler) Fetch(req *SchemeRequest) ([]byte, transaction.OracleResponseCode, error) {
+	if len(h.cfg.Nodes) == 0 {
+		return nil, transaction.ProtocolNotSupported, errors.New("no NeoFS nodes configured")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), h.cfg.Timeout)
+	defer cancel()
+	index := (int(req.ID) + req.Attempt) % len(h.cfg.Nodes)
+	res, err := neofs.Get(ctx, req.Key, req.URL, h.cfg.Nodes[index])
+	if err != nil {
+		return nil, transaction.Error, err
+	}
+	return res, transaction.Success, nil
+}
+
+// Fetch implements the SchemeHandler interface. Data URI format is
+// data:[<mediatype>][;base64],<data>.
+func (h *dataHandler) Fetch(req *SchemeRequest) ([]byte, transaction.OracleResponseCode, error) {
+	s := strings.TrimPrefix(req.URL.String(), DataScheme+":")
+	i := strings.IndexByte(s, ',')
+	if i < 0 {
+		return nil, transaction.Error, errors.New("malformed data URI")
+	}
+	typ, payload := s[:i], s[i+1:]
+	isBase64 := strings.HasSuffix(typ, ";base64")
+	typ = strings.TrimSuffix(typ, ";base64")
+	if typ == "" || strings.HasPrefix(typ, ";") {
+		typ = defaultDataMediaType
+	}
+	if !checkMediaType(typ, h.allowed) {
+		return nil, transaction.ContentTypeNotSupported, nil
+	}
+	data, err := url.PathUnescape(payload)
+	if err != nil {
+		return nil, transaction.Error, fmt.Errorf("malformed data URI: %w", err)
+	}
+	res := []byte(data)
+	if isBase64 {
+		res, err = base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return nil, transaction.Error, fmt.Errorf("malformed data URI: %w", err)
+		}
+	}
+	if len(res) > transaction.MaxOracleResultSize {
+		return nil, transaction.ResponseTooLarge, ErrResponseTooLarge
+	}
+	return res, transaction.Success, nil
+}
+
+// Fetch implements the SchemeHandler interface. <scheme>://<id>/<path> URL is
+// fetched from <gateway>/<id>/<path>, gateways are used in round-robin fashion.
+func (h *gatewayHandler) Fetch(req *SchemeRequest) ([]byte, transaction.OracleResponseCode, error) {
+	if req.URL.Host == "" {
+		return nil, transaction.Error, errors.New("no content identifier")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), h.timeout)
+	defer cancel()
+	gw := h.gateways[(int(req.ID)+req.Attempt)%len(h.gateways)]
+	u := gw + "/" + req.URL.Host + req.URL.EscapedPath()
+	if req.URL.RawQuery != "" {
+		u += "?" + req.URL.RawQuery
+	}
+	return fetchHTTP(ctx, h.client, u, h.allowed)
+}
+
+// fetchHTTP performs HTTP GET request with the given client and converts its
+// result into oracle response code.
+func fetchHTTP(ctx context.Context, client HTTPClient, u string, allowed []string) ([]byte, transaction.OracleResponseCode, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, transaction.Error, fmt.Errorf("failed to create http request: %w", err)
+	}
+	httpReq.Header.Set("User-Agent", "NeoOracleService/3.0")
+	httpReq.Header.Set("Content-Type", "application/json")
+	r, err := client.Do(httpReq)
+	if err != nil {
+		if errors.Is(err, ErrRestrictedRedirect) {
+			return nil, transaction.Forbidden, err
+		}
+		return nil, transaction.Error, err
+	}
+	defer r.Body.Close()
+	switch r.StatusCode {
+	case http.StatusOK:
+		if !checkMediaType(r.Header.Get("Content-Type"), allowed) {
+			return nil, transaction.ContentTypeNotSupported, nil
+		}
+		res, err := readResponse(r.Body, transaction.MaxOracleResultSize)
+		if err != nil {
+			if errors.Is(err, ErrResponseTooLarge) {
+				return nil, transaction.ResponseTooLarge, err
+			}
+			return nil, transaction.Error, fmt.Errorf("failed to read data: %w", err)
+		}
+		return res, transaction.Success, nil
+	case http.StatusForbidden:
+		return nil, transaction.Forbidden, nil
+	case http.StatusNotFound:
+		return nil, transaction.NotFound, nil
+	case http.StatusRequestTimeout:
+		return nil, transaction.Timeout, nil
+	default:
+		return nil, transaction.Error, nil
+	}
+}
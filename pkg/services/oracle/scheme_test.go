@@ -0,0 +1,32 @@
+package oracle
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDataHandler(t *testing.T) {
+	h := &dataHandler{allowed: []string{"application/json", "text/plain"}}
+	check := func(t *testing.T, uri string, code transaction.OracleResponseCode, expected []byte) {
+		u, err := url.ParseRequestURI(uri)
+		require.NoError(t, err)
+		res, c, _ := h.Fetch(&SchemeRequest{URL: u})
+		require.Equal(t, code, c)
+		require.Equal(t, expected, res)
+	}
+	check(t, "data:,hello%20world", transaction.Success, []byte("hello world"))
+	check(t, "data:;charset=utf-8,text", transaction.Success, []byte("text"))
+	check(t, "data:application/json,[1,2]", transaction.Success, []byte("[1,2]"))
+	check(t, "data:text/plain;base64,aGVsbG8=", transaction.Success, []byte("hello"))
+	check(t, "data:image/png;base64,aGVsbG8=", transaction.ContentTypeNotSupported, nil)
+	check(t, "data:text/plain;base64,!!!", transaction.Error, nil)
+	check(t, "data:text/plain", transaction.Error, nil)
+	check(t, "data:,%zz", transaction.Error, nil)
+
+	big := strings.Repeat("a", transaction.MaxOracleResultSize+1)
+	check(t, "data:,"+big, transaction.ResponseTooLarge, nil)
+}
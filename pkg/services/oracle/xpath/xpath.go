@@ -0,0 +1,415 @@
+/*
+Package xpath implements a subset of XPath 1.0 used by oracle filters to
+select values from XML and HTML documents.
+
+Only location paths are supported. A path is a sequence of steps separated
+by `/` (child) or `//` (descendant) and it always starts from the document
+root. Steps are:
+  - element name or `*` for any element, optionally followed by predicates;
+  - `text()` selecting text nodes;
+  - `@name` or `@*` selecting attribute values (it can only be the last step).
+
+Predicates are:
+  - `[N]` selecting the N-th (1-based) node for every parent;
+  - `[last()]` selecting the last node for every parent;
+  - `[@name]` selecting nodes having the attribute;
+  - `[@name='value']` selecting nodes having the attribute with the value;
+  - `[name='value']` selecting nodes having a child element with the value.
+
+Names are compared by their local part in a case-sensitive way. Documents are
+parsed in a non-strict mode with HTML entities and auto-closing tags
+supported, so most HTML pages can be used too.
+*/
+package xpath
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type (
+	// node is a parsed document node, either an element or a text one.
+	node struct {
+		name     string
+		attrs    []xml.Attr
+		children []*node
+		text     string
+		isText   bool
+		order    int
+	}
+
+	// step is a single parsed location step.
+	step struct {
+		descendant bool
+		name       string
+		text       bool
+		attr       bool
+		preds      []predicate
+	}
+
+	// predicate is a parsed step predicate.
+	predicate struct {
+		index int // 1-based index, -1 for last().
+		attr  bool
+		name  string
+		value *string
+	}
+
+	// pathParser combines an XPath and a position to start parsing from.
+	pathParser struct {
+		s string
+		i int
+	}
+)
+
+const (
+	maxSteps   = 16
+	maxObjects = 1024
+	maxNodes   = 1 << 16
+	maxDepth   = 256
+)
+
+// Get returns string values of nodes selected by path from the XML document.
+// The result is always non-nil unless the path or document is invalid.
+func Get(path string, doc []byte) ([]string, bool) {
+	steps, ok := parsePath(path)
+	if !ok {
+		return nil, false
+	}
+	root, err := parseDocument(doc)
+	if err != nil {
+		return nil, false
+	}
+
+	nodes := []*node{root}
+	for i, st := range steps {
+		if st.attr {
+			if i != len(steps)-1 {
+				return nil, false
+			}
+			return selectAttrs(nodes, st)
+		}
+		nodes = selectStep(nodes, st)
+		if maxObjects < len(nodes) {
+			return nil, false
+		}
+	}
+
+	res := make([]string, len(nodes))
+	for i := range nodes {
+		res[i] = nodes[i].stringValue()
+	}
+	return res, true
+}
+
+func parseDocument(doc []byte) (*node, error) {
+	d := xml.NewDecoder(bytes.NewReader(doc))
+	d.Strict = false
+	d.AutoClose = xml.HTMLAutoClose
+	d.Entity = xml.HTMLEntity
+
+	var (
+		root  = new(node)
+		stack = []*node{root}
+		count int
+	)
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		count++
+		if count > maxNodes {
+			return nil, errors.New("too many nodes")
+		}
+		parent := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if len(stack) > maxDepth {
+				return nil, errors.New("document is too deep")
+			}
+			n := &node{name: t.Name.Local, attrs: t.Attr, order: count}
+			parent.children = append(parent.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) == 1 {
+				return nil, errors.New("unexpected end element")
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			parent.children = append(parent.children, &node{text: string(t), isText: true, order: count})
+		}
+	}
+	return root, nil
+}
+
+// stringValue returns the node's string value which is the concatenation of
+// all descendant text nodes.
+func (n *node) stringValue() string {
+	if n.isText {
+		return n.text
+	}
+	var sb strings.Builder
+	n.writeText(&sb)
+	return sb.String()
+}
+
+func (n *node) writeText(sb *strings.Builder) {
+	for _, c := range n.children {
+		if c.isText {
+			sb.WriteString(c.text)
+		} else {
+			c.writeText(sb)
+		}
+	}
+}
+
+// selfAndDescendants appends the node and all its descendant elements not yet
+// seen to res in document order.
+func (n *node) selfAndDescendants(res []*node, seen map[*node]bool) []*node {
+	if seen[n] {
+		return res
+	}
+	seen[n] = true
+	res = append(res, n)
+	for _, c := range n.children {
+		if !c.isText {
+			res = c.selfAndDescendants(res, seen)
+		}
+	}
+	return res
+}
+
+// contextNodes returns nodes the step is to be applied to, for descendant
+// steps that's all of the nodes with their descendants (each node is returned
+// once).
+func contextNodes(nodes []*node, descendant bool) []*node {
+	if !descendant {
+		return nodes
+	}
+	var (
+		res  []*node
+		seen = make(map[*node]bool)
+	)
+	for _, n := range nodes {
+		res = n.selfAndDescendants(res, seen)
+	}
+	return res
+}
+
+func selectStep(nodes []*node, st step) []*node {
+	var (
+		res  []*node
+		seen = make(map[*node]bool)
+	)
+	for _, p := range contextNodes(nodes, st.descendant) {
+		var cands []*node
+		for _, c := range p.children {
+			if st.matches(c) {
+				cands = append(cands, c)
+			}
+		}
+		for _, pr := range st.preds {
+			cands = pr.filter(cands)
+		}
+		for _, c := range cands {
+			if !seen[c] {
+				seen[c] = true
+				res = append(res, c)
+			}
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].order < res[j].order })
+	return res
+}
+
+func selectAttrs(nodes []*node, st step) ([]string, bool) {
+	var res = []string{}
+	for _, p := range contextNodes(nodes, st.descendant) {
+		for _, a := range p.attrs {
+			if st.name == "*" || a.Name.Local == st.name {
+				res = append(res, a.Value)
+			}
+		}
+		if maxObjects < len(res) {
+			return nil, false
+		}
+	}
+	return res, true
+}
+
+func (st step) matches(n *node) bool {
+	if st.text {
+		return n.isText
+	}
+	return !n.isText && (st.name == "*" || n.name == st.name)
+}
+
+func (pr predicate) filter(nodes []*node) []*node {
+	if pr.index != 0 {
+		i := pr.index - 1
+		if pr.index < 0 {
+			i = len(nodes) - 1
+		}
+		if i < 0 || len(nodes) <= i {
+			return nil
+		}
+		return nodes[i : i+1]
+	}
+	var res []*node
+	for _, n := range nodes {
+		if pr.matches(n) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+func (pr predicate) matches(n *node) bool {
+	if pr.attr {
+		for _, a := range n.attrs {
+			if a.Name.Local == pr.name && (pr.value == nil || a.Value == *pr.value) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, c := range n.children {
+		if !c.isText && c.name == pr.name && c.stringValue() == *pr.value {
+			return true
+		}
+	}
+	return false
+}
+
+func parsePath(path string) ([]step, bool) {
+	p := pathParser{s: path}
+	var steps []step
+	for p.i < len(p.s) {
+		if len(steps) == maxSteps {
+			return nil, false
+		}
+		st, ok := p.parseStep()
+		if !ok {
+			return nil, false
+		}
+		steps = append(steps, st)
+	}
+	return steps, len(steps) != 0
+}
+
+// parseStep parses a single location step including its separator.
+func (p *pathParser) parseStep() (step, bool) {
+	var st step
+	if !p.consume("/") {
+		return st, false
+	}
+	st.descendant = p.consume("/")
+	switch {
+	case p.consume("text()"):
+		st.text = true
+		return st, true
+	case p.consume("@"):
+		st.attr = true
+		if p.consume("*") {
+			st.name = "*"
+			return st, true
+		}
+		name, ok := p.parseName()
+		st.name = name
+		return st, ok
+	case p.consume("*"):
+		st.name = "*"
+	default:
+		name, ok := p.parseName()
+		if !ok {
+			return st, false
+		}
+		st.name = name
+	}
+	for p.consume("[") {
+		pr, ok := p.parsePredicate()
+		if !ok || !p.consume("]") {
+			return st, false
+		}
+		st.preds = append(st.preds, pr)
+	}
+	return st, true
+}
+
+func (p *pathParser) parsePredicate() (predicate, bool) {
+	var pr predicate
+	if p.consume("last()") {
+		pr.index = -1
+		return pr, true
+	}
+	if p.i < len(p.s) && '0' <= p.s[p.i] && p.s[p.i] <= '9' {
+		start := p.i
+		for p.i < len(p.s) && '0' <= p.s[p.i] && p.s[p.i] <= '9' {
+			p.i++
+		}
+		n, err := strconv.Atoi(p.s[start:p.i])
+		if err != nil || n == 0 {
+			return pr, false
+		}
+		pr.index = n
+		return pr, true
+	}
+	pr.attr = p.consume("@")
+	name, ok := p.parseName()
+	if !ok {
+		return pr, false
+	}
+	pr.name = name
+	if !p.consume("=") {
+		// Only attribute presence can be checked this way.
+		return pr, pr.attr
+	}
+	value, ok := p.parseLiteral()
+	pr.value = &value
+	return pr, ok
+}
+
+// parseLiteral parses a string surrounded by single or double quotes.
+func (p *pathParser) parseLiteral() (string, bool) {
+	if p.i >= len(p.s) || (p.s[p.i] != '\'' && p.s[p.i] != '"') {
+		return "", false
+	}
+	end := strings.IndexByte(p.s[p.i+1:], p.s[p.i])
+	if end < 0 {
+		return "", false
+	}
+	res := p.s[p.i+1 : p.i+1+end]
+	p.i += end + 2
+	return res, true
+}
+
+// parseName parses an XML name without namespace prefix.
+func (p *pathParser) parseName() (string, bool) {
+	start := p.i
+	for p.i < len(p.s) {
+		c := p.s[p.i]
+		if c != '_' && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') &&
+			(p.i == start || (c != '-' && c != '.' && !('0' <= c && c <= '9'))) {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i], start != p.i
+}
+
+func (p *pathParser) consume(s string) bool {
+	if strings.HasPrefix(p.s[p.i:], s) {
+		p.i += len(s)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package xpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testDoc = `<?xml version="1.0"?>
+<store>
+	<book id="1" lang="en"><title>Go</title><price>10.5</price></book>
+	<book id="2"><title>Neo</title><price>20</price></book>
+	<shelf>
+		<book id="3" lang="en"><title>XML</title><price>5</price></book>
+	</shelf>
+	<!-- comment -->
+	<note><![CDATA[<raw>]]></note>
+</store>`
+
+func TestGet(t *testing.T) {
+	testCases := []struct {
+		path   string
+		result []string
+	}{
+		{"/store/book/title", []string{"Go", "Neo"}},
+		{"/store/*/title", []string{"Go", "Neo"}},
+		{"//title", []string{"Go", "Neo", "XML"}},
+		{"//book[1]/title", []string{"Go", "XML"}},
+		{"/store/book[2]/price", []string{"20"}},
+		{"/store/book[3]/price", []string{}},
+		{"//book[last()]/@id", []string{"2", "3"}},
+		{"//book[@lang]/title", []string{"Go", "XML"}},
+		{"//book[@id][2]/title", []string{"Neo"}},
+		{"//book[@lang='en'][2]/title", []string{}},
+		{"//book[title='Neo']/price", []string{"20"}},
+		{"/store/book[@id=\"1\"]/@*", []string{"1", "en"}},
+		{"//@lang", []string{"en", "en"}},
+		{"/store/book/title/text()", []string{"Go", "Neo"}},
+		{"/store/note", []string{"<raw>"}},
+		{"/store/shelf", []string{"\n\t\tXML5\n\t"}},
+		{"//store//book/title", []string{"Go", "Neo", "XML"}},
+		{"/nothing", []string{}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.path, func(t *testing.T) {
+			actual, ok := Get(tc.path, []byte(testDoc))
+			require.True(t, ok)
+			require.Equal(t, tc.result, actual)
+		})
+	}
+}
+
+func TestGetHTML(t *testing.T) {
+	doc := `<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Rates</title></head>
+<body>
+<table id="rates">
+<tr><td>USD</td><td>1.25</td></tr>
+<tr><td>EUR&nbsp;&amp;</td><td>1.1</td></tr>
+</table><br>
+<input type=text value=abc>
+</body></html>`
+	actual, ok := Get("//table[@id='rates']/tr[2]/td[2]", []byte(doc))
+	require.True(t, ok)
+	require.Equal(t, []string{"1.1"}, actual)
+
+	actual, ok = Get("//tr/td[1]", []byte(doc))
+	require.True(t, ok)
+	require.Equal(t, []string{"USD", "EUR &"}, actual)
+
+	actual, ok = Get("//input/@value", []byte(doc))
+	require.True(t, ok)
+	require.Equal(t, []string{"abc"}, actual)
+}
+
+func TestInvalid(t *testing.T) {
+	paths := []string{
+		"",
+		"store",
+		"/",
+		"/store/",
+		"///store",
+		"/store/@id/book",
+		"/store[",
+		"/store[0]",
+		"/store[a]",
+		"/store[@id='1]",
+		"/store[@id=1]",
+		"/1store",
+		"/store/text()[1]",
+		strings.Repeat("/a", maxSteps+1),
+	}
+	for _, p := range paths {
+		t.Run(p, func(t *testing.T) {
+			_, ok := Get(p, []byte(testDoc))
+			require.False(t, ok)
+		})
+	}
+
+	t.Run("invalid document", func(t *testing.T) {
+		_, ok := Get("/a", []byte("<a></b></a></a>"))
+		require.False(t, ok)
+	})
+	t.Run("too deep", func(t *testing.T) {
+		doc := strings.Repeat("<a>", maxDepth+1) + strings.Repeat("</a>", maxDepth+1)
+		_, ok := Get("/a", []byte(doc))
+		require.False(t, ok)
+	})
+	t.Run("too many objects", func(t *testing.T) {
+		doc := "<a>" + strings.Repeat("<b/>", maxObjects+1) + "</a>"
+		_, ok := Get("/a/b", []byte(doc))
+		require.False(t, ok)
+		res, ok := Get("/a/b[1]", []byte(doc))
+		require.True(t, ok)
+		require.Equal(t, []string{""}, res)
+	})
+}
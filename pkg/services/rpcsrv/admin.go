@@ -0,0 +1,180 @@
+package rpcsrv
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
+	"go.uber.org/zap"
+)
+
+// defaultBanReason is used for banpeer calls without explicit reason.
+const defaultBanReason = "banned by admin"
+
+// rpcAdminHandlers contains administrative methods, they're only available if
+// enabled in the configuration and only for authenticated clients.
+var rpcAdminHandlers = map[string]func(*Server, params.Params) (interface{}, *neorpc.Error){
+	"addpeer":        (*Server).addPeer,
+	"banpeer":        (*Server).banPeer,
+	"cancelsnapshot": (*Server).cancelSnapshot,
+	"createsnapshot": (*Server).createSnapshot,
+	"disconnectpeer": (*Server).disconnectPeer,
+	"listbans":       (*Server).listBans,
+	"unbanpeer":      (*Server).unbanPeer,
+}
+
+// isAdmin checks whether the given HTTP request contains valid admin
+// credentials.
+func (s *Server) isAdmin(r *http.Request) bool {
+	if !s.config.Admin.Enabled {
+		return false
+	}
+	user, pass, ok := r.BasicAuth()
+	return ok &&
+		subtle.ConstantTimeCompare([]byte(user), []byte(s.config.Admin.User)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(pass), []byte(s.config.Admin.Password)) == 1
+}
+
+// adminHandler returns an administrative method handler if there is one for
+// the given method and if admin methods are enabled.
+func (s *Server) adminHandler(method string) (func(*Server, params.Params) (interface{}, *neorpc.Error), bool) {
+	if !s.config.Admin.Enabled {
+		return nil, false
+	}
+	handler, ok := rpcAdminHandlers[method]
+	return handler, ok
+}
+
+// addPeer adds the peer to the seed list and connects to it.
+func (s *Server) addPeer(reqParams params.Params) (interface{}, *neorpc.Error) {
+	addr, err := reqParams.Value(0).GetString()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	err = s.coreServer.AddPeer(addr)
+	if err != nil {
+		return nil, neorpc.NewRPCError("Can't add peer", err.Error())
+	}
+	return true, nil
+}
+
+// disconnectPeer drops the connection with the peer and forgets its address.
+func (s *Server) disconnectPeer(reqParams params.Params) (interface{}, *neorpc.Error) {
+	addr, err := reqParams.Value(0).GetString()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	err = s.coreServer.DisconnectPeer(addr)
+	if err != nil {
+		return nil, neorpc.NewRPCError("Can't disconnect peer", err.Error())
+	}
+	return true, nil
+}
+
+// banPeer bans the peer's IP address for the specified number of seconds
+// (node's default ban duration is used if it's not specified).
+func (s *Server) banPeer(reqParams params.Params) (interface{}, *neorpc.Error) {
+	addr, err := reqParams.Value(0).GetString()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	var (
+		duration time.Duration
+		reason   = defaultBanReason
+	)
+	if p := reqParams.Value(1); p != nil && !p.IsNull() {
+		secs, err := p.GetInt()
+		if err != nil || secs < 0 {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid ban duration: %s", p))
+		}
+		duration = time.Duration(secs) * time.Second
+	}
+	if p := reqParams.Value(2); p != nil && !p.IsNull() {
+		reason, err = p.GetString()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+		}
+	}
+	err = s.coreServer.BanPeer(addr, duration, reason)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	return true, nil
+}
+
+// unbanPeer removes the peer's IP address from the ban list.
+func (s *Server) unbanPeer(reqParams params.Params) (interface{}, *neorpc.Error) {
+	addr, err := reqParams.Value(0).GetString()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+	}
+	return s.coreServer.UnbanPeer(addr), nil
+}
+
+// listBans returns the list of currently banned peers.
+func (s *Server) listBans(_ params.Params) (interface{}, *neorpc.Error) {
+	return s.bannedPeers(), nil
+}
+
+// bannedPeers converts network-level ban list into the RPC representation.
+func (s *Server) bannedPeers() []result.BannedPeer {
+	bans := s.coreServer.BannedPeers()
+	res := make([]result.BannedPeer, 0, len(bans))
+	for _, b := range bans {
+		res = append(res, result.BannedPeer{
+			Address: b.Address,
+			Until:   uint64(b.Until.UnixMilli()),
+			Reason:  b.Reason,
+		})
+	}
+	return res
+}
+
+// createSnapshot starts DB snapshot creation at the specified (or current)
+// height, the snapshot is made in the background, only its height and path
+// are returned.
+func (s *Server) createSnapshot(reqParams params.Params) (interface{}, *neorpc.Error) {
+	if len(s.config.SnapshotPath) == 0 {
+		return nil, neorpc.NewInvalidRequestError("snapshots are disabled")
+	}
+	height := s.chain.BlockHeight()
+	if p := reqParams.Value(0); p != nil && !p.IsNull() {
+		h, err := p.GetInt()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid height: %s", err))
+		}
+		if err := checkUint32(h); err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+		}
+		height = uint32(h)
+	}
+	path := filepath.Join(s.config.SnapshotPath, "snapshot-"+strconv.FormatUint(uint64(height), 10))
+	done, err := s.chain.Snapshot(path, height)
+	if err != nil {
+		return nil, neorpc.NewRPCError("Can't create snapshot", err.Error())
+	}
+	go func() {
+		if err := <-done; err != nil {
+			s.log.Error("failed to create DB snapshot", zap.String("path", path), zap.Error(err))
+		}
+	}()
+	return result.Snapshot{
+		Height: height,
+		Path:   path,
+	}, nil
+}
+
+// cancelSnapshot cancels DB snapshot creation started with createsnapshot, it
+// returns false if there is no snapshot being made.
+func (s *Server) cancelSnapshot(_ params.Params) (interface{}, *neorpc.Error) {
+	if len(s.config.SnapshotPath) == 0 {
+		return nil, neorpc.NewInvalidRequestError("snapshots are disabled")
+	}
+	return s.chain.CancelSnapshot(), nil
+}
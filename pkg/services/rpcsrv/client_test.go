@@ -29,6 +29,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
@@ -1372,6 +1373,101 @@ func TestClient_GetNativeContracts(t *testing.T) {
 	require.Equal(t, chain.GetNatives(), cs)
 }
 
+func TestClient_GetBlockNotifications(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	h := chain.GetHeaderHash(1)
+	bn, err := c.GetBlockNotifications(h, nil)
+	require.NoError(t, err)
+	require.NotEqual(t, 0, len(bn.OnPersist))
+	require.NotEqual(t, 0, len(bn.Application))
+	require.NotEqual(t, 0, len(bn.PostPersist))
+
+	aers, err := chain.GetAppExecResults(h, trigger.PostPersist)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aers))
+	require.Equal(t, len(aers[0].Events), len(bn.PostPersist))
+	for i := range aers[0].Events {
+		require.Equal(t, h, bn.PostPersist[i].Container)
+		require.Equal(t, aers[0].Events[i], bn.PostPersist[i].NotificationEvent)
+	}
+
+	name := "Unknown"
+	bn, err = c.GetBlockNotifications(h, &neorpc.NotificationFilter{Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(bn.OnPersist))
+	require.Equal(t, 0, len(bn.Application))
+	require.Equal(t, 0, len(bn.PostPersist))
+
+	_, err = c.GetBlockNotifications(util.Uint256{}, nil)
+	require.Error(t, err)
+}
+
+func TestClient_Batch(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	check := func(t *testing.T, c *rpcclient.Client) {
+		b1, err := chain.GetBlock(chain.GetHeaderHash(1))
+		require.NoError(t, err)
+		tx := b1.Transactions[0]
+
+		b := c.NewBatch()
+		b.GetBlockCount()
+		b.GetBlockByIndex(1)
+		b.GetBlockHash(1)
+		b.GetApplicationLog(tx.Hash(), nil)
+		b.GetRawTransaction(tx.Hash())
+		b.GetBlockByHash(util.Uint256{}) // Missing block.
+		b.InvokeScript([]byte{byte(opcode.PUSH1)}, nil)
+		require.Equal(t, 7, b.Len())
+
+		res, err := b.Send()
+		require.NoError(t, err)
+		require.Equal(t, 7, len(res))
+
+		require.NoError(t, res[0].Err)
+		require.Equal(t, chain.BlockHeight()+1, res[0].Value)
+		require.NoError(t, res[1].Err)
+		require.Equal(t, b1.Hash(), res[1].Value.(*block.Block).Hash())
+		require.NoError(t, res[2].Err)
+		require.Equal(t, b1.Hash(), res[2].Value)
+		require.NoError(t, res[3].Err)
+		require.Equal(t, tx.Hash(), res[3].Value.(*result.ApplicationLog).Container)
+		require.NoError(t, res[4].Err)
+		require.Equal(t, tx.Hash(), res[4].Value.(*transaction.Transaction).Hash())
+		require.Error(t, res[5].Err)
+		require.Nil(t, res[5].Value)
+		require.NoError(t, res[6].Err)
+		require.Equal(t, "HALT", res[6].Value.(*result.Invoke).State)
+
+		_, err = b.Send()
+		require.ErrorIs(t, err, rpcclient.ErrBatchSent)
+
+		res, err = c.NewBatch().Send()
+		require.NoError(t, err)
+		require.Equal(t, 0, len(res))
+	}
+	t.Run("http", func(t *testing.T) {
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+		check(t, c)
+	})
+	runWSAndLocal(t, func(t *testing.T, local bool) {
+		c := mkSubsClient(t, rpcSrv, httpSrv, local)
+		check(t, &c.Client)
+		c.Close()
+	})
+}
+
 func TestClient_NEP11_ND(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -1749,6 +1845,109 @@ func TestClient_IteratorSessions(t *testing.T) {
 	})
 }
 
+func TestClient_FindStorage(t *testing.T) {
+	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	storageHash, err := util.Uint160DecodeStringLE(storageContractHash)
+	require.NoError(t, err)
+	cs := chain.GetContractState(storageHash)
+	require.NotNil(t, cs)
+	root, err := chain.GetStateModule().GetStateRoot(chain.BlockHeight())
+	require.NoError(t, err)
+
+	// storageItemsCount is the amount of storage items stored in Storage contract, it's hard-coded in the contract code.
+	const storageItemsCount = 255
+	pageSize := rpcSrv.config.MaxFindStoragePageSize
+	require.Equal(t, config.DefaultMaxFindStoragePageSize, pageSize)
+
+	check := func(t *testing.T, find func(start []byte) (result.FindStorage, error)) {
+		var (
+			start []byte
+			i     int
+		)
+		for ; i < storageItemsCount/pageSize; i++ {
+			res, err := find(start)
+			require.NoError(t, err)
+			require.True(t, res.Truncated)
+			require.Equal(t, pageSize, len(res.Results))
+			for j, kv := range res.Results {
+				require.Equal(t, []byte{0x01, byte(i*pageSize + j)}, kv.Key)
+				require.Equal(t, int64(i*pageSize+j), bigint.FromBytes(kv.Value).Int64())
+			}
+			require.Equal(t, []byte{0x01, byte((i + 1) * pageSize)}, res.Next)
+			start = res.Next
+		}
+		res, err := find(start)
+		require.NoError(t, err)
+		require.False(t, res.Truncated)
+		require.Equal(t, storageItemsCount%pageSize, len(res.Results))
+		require.Equal(t, []byte{0x01, byte(i * pageSize)}, res.Results[0].Key)
+		require.Equal(t, []byte{0x01, storageItemsCount - 1}, res.Results[len(res.Results)-1].Key)
+		require.Nil(t, res.Next)
+
+		// No start specified.
+		first, err := find(nil)
+		require.NoError(t, err)
+		res, err = find([]byte{})
+		require.NoError(t, err)
+		require.Equal(t, res, first)
+
+		// Out of range.
+		res, err = find([]byte{0x01, 0xff})
+		require.NoError(t, err)
+		require.False(t, res.Truncated)
+		require.Equal(t, 0, len(res.Results))
+	}
+	t.Run("by hash", func(t *testing.T) {
+		check(t, func(start []byte) (result.FindStorage, error) {
+			return c.FindStorageByHash(storageHash, []byte{0x01}, start)
+		})
+	})
+	t.Run("by ID", func(t *testing.T) {
+		check(t, func(start []byte) (result.FindStorage, error) {
+			return c.FindStorageByID(cs.ID, []byte{0x01}, start)
+		})
+	})
+	t.Run("historic by hash", func(t *testing.T) {
+		check(t, func(start []byte) (result.FindStorage, error) {
+			return c.FindStorageByHashHistoric(root.Root, storageHash, []byte{0x01}, start)
+		})
+	})
+	t.Run("historic by ID", func(t *testing.T) {
+		check(t, func(start []byte) (result.FindStorage, error) {
+			return c.FindStorageByIDHistoric(root.Root, cs.ID, []byte{0x01}, start)
+		})
+	})
+	t.Run("no prefix", func(t *testing.T) {
+		res, err := c.FindStorageByHash(storageHash, nil, nil)
+		require.NoError(t, err)
+		require.True(t, res.Truncated)
+		require.Equal(t, pageSize, len(res.Results))
+	})
+	t.Run("missing prefix", func(t *testing.T) {
+		res, err := c.FindStorageByHash(storageHash, []byte{0x02}, nil)
+		require.NoError(t, err)
+		require.False(t, res.Truncated)
+		require.Equal(t, 0, len(res.Results))
+	})
+	t.Run("start doesn't match prefix", func(t *testing.T) {
+		_, err := c.FindStorageByHash(storageHash, []byte{0x01}, []byte{0x02, 0x00})
+		require.Error(t, err)
+	})
+	t.Run("unknown contract", func(t *testing.T) {
+		_, err := c.FindStorageByHash(util.Uint160{1, 2, 3}, []byte{0x01}, nil)
+		require.Error(t, err)
+		_, err = c.FindStorageByHashHistoric(root.Root, util.Uint160{1, 2, 3}, []byte{0x01}, nil)
+		require.Error(t, err)
+	})
+}
+
 func TestClient_GetNotaryServiceFeePerKey(t *testing.T) {
 	chain, rpcSrv, httpSrv := initServerWithInMemoryChain(t)
 	defer chain.Close()
@@ -2213,6 +2412,64 @@ func TestWSClientHandshakeError(t *testing.T) {
 	require.ErrorContains(t, err, "websocket users limit reached")
 }
 
+func TestClient_Admin(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ApplicationConfiguration.RPC.Admin = config.RPCAdmin{
+			Enabled:  true,
+			User:     "admin",
+			Password: "secret",
+		}
+	})
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	for name, endpoint := range map[string]string{
+		"http": httpSrv.URL,
+		"ws":   "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws",
+	} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				c   *rpcclient.Client
+				err error
+			)
+			newClient := func(endpoint string) {
+				if name == "ws" {
+					var wsc *rpcclient.WSClient
+					wsc, err = rpcclient.NewWS(context.Background(), endpoint, rpcclient.Options{})
+					require.NoError(t, err)
+					t.Cleanup(wsc.Close)
+					c = &wsc.Client
+				} else {
+					c, err = rpcclient.New(context.Background(), endpoint, rpcclient.Options{})
+					require.NoError(t, err)
+				}
+			}
+
+			newClient(endpoint)
+			_, err = c.ListBans()
+			require.ErrorContains(t, err, "Access denied")
+
+			newClient(strings.Replace(endpoint, "://", "://admin:secret@", 1))
+			require.NoError(t, c.BanPeer("1.2.3.4:10333", time.Hour, "test"))
+			bans, err := c.ListBans()
+			require.NoError(t, err)
+			require.Equal(t, 1, len(bans))
+			require.Equal(t, "1.2.3.4", bans[0].Address)
+			require.Equal(t, "test", bans[0].Reason)
+
+			ok, err := c.UnbanPeer("1.2.3.4")
+			require.NoError(t, err)
+			require.True(t, ok)
+			bans, err = c.ListBans()
+			require.NoError(t, err)
+			require.Equal(t, 0, len(bans))
+
+			require.Error(t, c.AddPeer("invalid"))
+			require.Error(t, c.DisconnectPeer("127.0.0.1:1"))
+		})
+	}
+}
+
 func TestSubClientWaitWithMissedEvent(t *testing.T) {
 	runWSAndLocal(t, testSubClientWaitWithMissedEvent)
 }
@@ -0,0 +1,760 @@
+package rpcsrv
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	gio "io"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/graphql"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"go.uber.org/zap"
+)
+
+const (
+	// defaultGraphQLMaxDepth is the default limit for nested GraphQL
+	// selections.
+	defaultGraphQLMaxDepth = 10
+	// defaultGraphQLMaxNodes is the default limit for the number of fields
+	// resolved for a single GraphQL query.
+	defaultGraphQLMaxNodes = 10000
+	// maxGraphQLBlocks is the maximum number of blocks returned by a
+	// single blocks query.
+	maxGraphQLBlocks = 100
+	// maxGraphQLRequestSize is the maximum size of GraphQL request body.
+	maxGraphQLRequestSize = 1 << 20
+)
+
+// GraphQL object types, each of them resolves fields of the corresponding
+// schema type (see docs/rpc.md) using the same chain accessors and JSON-RPC
+// handlers as JSON-RPC API does.
+type (
+	gqlQuery struct {
+		s *Server
+	}
+	gqlBlock struct {
+		s *Server
+		b *block.Block
+	}
+	gqlTransaction struct {
+		s      *Server
+		tx     *transaction.Transaction
+		height uint32
+	}
+	gqlSigner struct {
+		s      *Server
+		signer transaction.Signer
+	}
+	gqlExecution struct {
+		s    *Server
+		exec state.Execution
+	}
+	gqlNotification struct {
+		s  *Server
+		ev state.NotificationEvent
+	}
+	gqlContract struct {
+		cs *state.Contract
+	}
+	gqlAccount struct {
+		s   *Server
+		acc util.Uint160
+	}
+	gqlNEP17Balance struct {
+		s *Server
+		b result.NEP17Balance
+	}
+	gqlNEP11Balance struct {
+		s *Server
+		b result.NEP11AssetBalance
+	}
+	gqlNEP11Token struct {
+		t result.NEP11TokenBalance
+	}
+	gqlTransfers struct {
+		sent     []*gqlTransfer
+		received []*gqlTransfer
+	}
+	gqlTransfer struct {
+		s     *Server
+		t     result.NEP11Transfer
+		nep11 bool
+	}
+)
+
+// handleGraphQLRequest processes GraphQL request sent either via POST (with
+// JSON body) or via GET (with URL parameters).
+func (s *Server) handleGraphQLRequest(w http.ResponseWriter, httpRequest *http.Request) {
+	var req graphql.Request
+	switch httpRequest.Method {
+	case "GET":
+		q := httpRequest.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if v := q.Get("variables"); v != "" {
+			if err := decodeGraphQLJSON([]byte(v), &req.Variables); err != nil {
+				s.writeGraphQLResponse(w, http.StatusBadRequest, &graphql.Response{Errors: []graphql.Error{{Message: fmt.Sprintf("invalid variables: %s", err)}}})
+				return
+			}
+		}
+	case "POST":
+		data, err := gio.ReadAll(gio.LimitReader(httpRequest.Body, maxGraphQLRequestSize+1))
+		if err == nil && len(data) > maxGraphQLRequestSize {
+			err = errors.New("request is too big")
+		}
+		if err == nil {
+			err = decodeGraphQLJSON(data, &req)
+		}
+		if err != nil {
+			s.writeGraphQLResponse(w, http.StatusBadRequest, &graphql.Response{Errors: []graphql.Error{{Message: fmt.Sprintf("invalid request: %s", err)}}})
+			return
+		}
+	default:
+		s.writeGraphQLResponse(w, http.StatusMethodNotAllowed, &graphql.Response{Errors: []graphql.Error{{Message: fmt.Sprintf("invalid method '%s', please retry with 'GET' or 'POST'", httpRequest.Method)}}})
+		return
+	}
+	s.log.Debug("processing graphql request", zap.String("operation", req.OperationName))
+	resp := graphql.Execute(gqlQuery{s: s}, req, s.config.GraphQL.MaxDepth, s.config.GraphQL.MaxNodes)
+	status := http.StatusOK
+	if resp.Data == nil {
+		status = http.StatusBadRequest
+	}
+	s.writeGraphQLResponse(w, status, resp)
+}
+
+// decodeGraphQLJSON unmarshals JSON keeping numbers as json.Number.
+func decodeGraphQLJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func (s *Server) writeGraphQLResponse(w http.ResponseWriter, status int, resp *graphql.Response) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if s.config.EnableCORSWorkaround {
+		setCORSOriginHeaders(w.Header())
+	}
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.log.Error("Error encountered while encoding graphql response", zap.Error(err))
+	}
+}
+
+// gqlCall calls JSON-RPC handler with the given parameters.
+func (s *Server) gqlCall(handler func(*Server, params.Params) (interface{}, *neorpc.Error), args ...interface{}) (interface{}, error) {
+	ps, err := params.FromAny(args)
+	if err != nil {
+		return nil, err
+	}
+	res, respErr := handler(s, ps)
+	if respErr != nil {
+		return nil, respErr
+	}
+	return res, nil
+}
+
+// gqlParam converts a single value to JSON-RPC parameter.
+func gqlParam(v interface{}) (*params.Param, error) {
+	ps, err := params.FromAny([]interface{}{v})
+	if err != nil {
+		return nil, err
+	}
+	return ps.Value(0), nil
+}
+
+// newGQLBlock returns the block by its hash or index.
+func (s *Server) newGQLBlock(hashOrIndex interface{}) (*gqlBlock, error) {
+	p, err := gqlParam(hashOrIndex)
+	if err != nil {
+		return nil, err
+	}
+	hash, respErr := s.blockHashFromParam(p)
+	if respErr != nil {
+		return nil, respErr
+	}
+	b, err := s.chain.GetBlock(hash)
+	if err != nil {
+		return nil, neorpc.NewRPCError("Failed to get block", err.Error())
+	}
+	return &gqlBlock{s: s, b: b}, nil
+}
+
+// newGQLExecutions returns executions of the given container with the given
+// trigger.
+func (s *Server) newGQLExecutions(h util.Uint256, trig trigger.Type) ([]*gqlExecution, error) {
+	aers, err := s.chain.GetAppExecResults(h, trig)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownScriptContainer, fmt.Sprintf("failed to locate application log: %s", err))
+	}
+	res := make([]*gqlExecution, len(aers))
+	for i := range aers {
+		res[i] = &gqlExecution{s: s, exec: aers[i].Execution}
+	}
+	return res, nil
+}
+
+// newGQLContract returns the contract by its hash, it's nil if the contract
+// doesn't exist.
+func (s *Server) newGQLContract(h util.Uint160) *gqlContract {
+	cs := s.chain.GetContractState(h)
+	if cs == nil {
+		return nil
+	}
+	return &gqlContract{cs: cs}
+}
+
+// gqlHash formats the hash the same way JSON-RPC API does.
+func gqlHash(h interface{ StringLE() string }) string {
+	return "0x" + h.StringLE()
+}
+
+func gqlItemsJSON(items []stackitem.Item) (json.RawMessage, error) {
+	arr := make([]json.RawMessage, len(items))
+	for i := range items {
+		data, err := stackitem.ToJSONWithTypes(items[i])
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = data
+	}
+	return json.Marshal(arr)
+}
+
+// TypeName implements graphql.Object interface.
+func (q gqlQuery) TypeName() string { return "Query" }
+
+// Field implements graphql.Object interface.
+func (q gqlQuery) Field(name string, args graphql.Args) (interface{}, error) {
+	s := q.s
+	switch name {
+	case "height":
+		return s.chain.BlockHeight(), nil
+	case "block":
+		switch {
+		case args.Has("hash"):
+			return s.newGQLBlock(args["hash"])
+		case args.Has("index"):
+			return s.newGQLBlock(args["index"])
+		default:
+			return nil, errors.New("either hash or index must be specified")
+		}
+	case "blocks":
+		from, err := args.Int("from", 0)
+		if err != nil {
+			return nil, err
+		}
+		count, err := args.Int("count", 10)
+		if err != nil {
+			return nil, err
+		}
+		if from < 0 || count <= 0 || count > maxGraphQLBlocks {
+			return nil, fmt.Errorf("invalid range: from should be non-negative and count should be in [1, %d]", maxGraphQLBlocks)
+		}
+		var res = []*gqlBlock{}
+		for i := from; i < from+count && i <= int(s.chain.BlockHeight()); i++ {
+			b, err := s.newGQLBlock(i)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, b)
+		}
+		return res, nil
+	case "transaction":
+		p, err := gqlParam(args["hash"])
+		if err != nil {
+			return nil, err
+		}
+		h, err := p.GetUint256()
+		if err != nil {
+			return nil, neorpc.ErrInvalidParams
+		}
+		tx, height, err := s.chain.GetTransaction(h)
+		if err != nil {
+			return nil, neorpc.ErrUnknownTransaction
+		}
+		return &gqlTransaction{s: s, tx: tx, height: height}, nil
+	case "contract":
+		cs, err := s.gqlCall((*Server).getContractState, args["id"])
+		if err != nil {
+			return nil, err
+		}
+		return &gqlContract{cs: cs.(*state.Contract)}, nil
+	case "account":
+		p, err := gqlParam(args["address"])
+		if err != nil {
+			return nil, err
+		}
+		acc, err := p.GetUint160FromAddressOrHex()
+		if err != nil {
+			return nil, neorpc.ErrInvalidParams
+		}
+		return &gqlAccount{s: s, acc: acc}, nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (b *gqlBlock) TypeName() string { return "Block" }
+
+// Field implements graphql.Object interface.
+func (b *gqlBlock) Field(name string, _ graphql.Args) (interface{}, error) {
+	s, blk := b.s, b.b
+	switch name {
+	case "hash":
+		return gqlHash(blk.Hash()), nil
+	case "size":
+		return io.GetVarSize(blk), nil
+	case "version":
+		return blk.Version, nil
+	case "index":
+		return blk.Index, nil
+	case "time":
+		return blk.Timestamp, nil
+	case "nonce":
+		return fmt.Sprintf("%016X", blk.Nonce), nil
+	case "primary":
+		return blk.PrimaryIndex, nil
+	case "merkleRoot":
+		return gqlHash(blk.MerkleRoot), nil
+	case "nextConsensus":
+		return address.Uint160ToString(blk.NextConsensus), nil
+	case "confirmations":
+		return s.chain.BlockHeight() - blk.Index + 1, nil
+	case "previousBlockHash":
+		return gqlHash(blk.PrevHash), nil
+	case "previous":
+		if blk.Index == 0 {
+			return nil, nil
+		}
+		return s.newGQLBlock(blk.Index - 1)
+	case "next":
+		if blk.Index >= s.chain.BlockHeight() {
+			return nil, nil
+		}
+		return s.newGQLBlock(blk.Index + 1)
+	case "transactionCount":
+		return len(blk.Transactions), nil
+	case "transactions":
+		res := make([]*gqlTransaction, len(blk.Transactions))
+		for i, tx := range blk.Transactions {
+			res[i] = &gqlTransaction{s: s, tx: tx, height: blk.Index}
+		}
+		return res, nil
+	case "executions":
+		return s.newGQLExecutions(blk.Hash(), trigger.All)
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (t *gqlTransaction) TypeName() string { return "Transaction" }
+
+// Field implements graphql.Object interface.
+func (t *gqlTransaction) Field(name string, _ graphql.Args) (interface{}, error) {
+	s, tx := t.s, t.tx
+	switch name {
+	case "hash":
+		return gqlHash(tx.Hash()), nil
+	case "size":
+		return tx.Size(), nil
+	case "version":
+		return tx.Version, nil
+	case "nonce":
+		return tx.Nonce, nil
+	case "sender":
+		return &gqlAccount{s: s, acc: tx.Sender()}, nil
+	case "systemFee":
+		return fmt.Sprint(tx.SystemFee), nil
+	case "networkFee":
+		return fmt.Sprint(tx.NetworkFee), nil
+	case "validUntilBlock":
+		return tx.ValidUntilBlock, nil
+	case "script":
+		return base64.StdEncoding.EncodeToString(tx.Script), nil
+	case "signers":
+		res := make([]*gqlSigner, len(tx.Signers))
+		for i := range tx.Signers {
+			res[i] = &gqlSigner{s: s, signer: tx.Signers[i]}
+		}
+		return res, nil
+	case "attributes":
+		res := make([]json.RawMessage, len(tx.Attributes))
+		for i := range tx.Attributes {
+			data, err := json.Marshal(&tx.Attributes[i])
+			if err != nil {
+				return nil, err
+			}
+			res[i] = data
+		}
+		return res, nil
+	case "block":
+		if t.height == math.MaxUint32 { // Mempooled transaction.
+			return nil, nil
+		}
+		return s.newGQLBlock(t.height)
+	case "execution":
+		if t.height == math.MaxUint32 {
+			return nil, nil
+		}
+		execs, err := s.newGQLExecutions(tx.Hash(), trigger.Application)
+		if err != nil {
+			return nil, err
+		}
+		if len(execs) == 0 {
+			return nil, neorpc.NewRPCError("Inconsistent application log", "application log for the transaction is empty")
+		}
+		return execs[0], nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (sg *gqlSigner) TypeName() string { return "Signer" }
+
+// Field implements graphql.Object interface.
+func (sg *gqlSigner) Field(name string, _ graphql.Args) (interface{}, error) {
+	switch name {
+	case "account":
+		return &gqlAccount{s: sg.s, acc: sg.signer.Account}, nil
+	case "scopes":
+		return sg.signer.Scopes, nil // Marshaled the same way as in JSON-RPC.
+	case "allowedContracts":
+		res := make([]string, len(sg.signer.AllowedContracts))
+		for i := range sg.signer.AllowedContracts {
+			res[i] = gqlHash(sg.signer.AllowedContracts[i])
+		}
+		return res, nil
+	case "allowedGroups":
+		res := make([]string, len(sg.signer.AllowedGroups))
+		for i := range sg.signer.AllowedGroups {
+			res[i] = hex.EncodeToString(sg.signer.AllowedGroups[i].Bytes())
+		}
+		return res, nil
+	case "rules":
+		res := make([]json.RawMessage, len(sg.signer.Rules))
+		for i := range sg.signer.Rules {
+			data, err := json.Marshal(&sg.signer.Rules[i])
+			if err != nil {
+				return nil, err
+			}
+			res[i] = data
+		}
+		return res, nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (e *gqlExecution) TypeName() string { return "Execution" }
+
+// Field implements graphql.Object interface.
+func (e *gqlExecution) Field(name string, _ graphql.Args) (interface{}, error) {
+	switch name {
+	case "trigger":
+		return e.exec.Trigger.String(), nil
+	case "vmState":
+		return e.exec.VMState.String(), nil
+	case "gasConsumed":
+		return fmt.Sprint(e.exec.GasConsumed), nil
+	case "exception":
+		if e.exec.FaultException == "" {
+			return nil, nil
+		}
+		return e.exec.FaultException, nil
+	case "stack":
+		return gqlItemsJSON(e.exec.Stack)
+	case "notifications":
+		res := make([]*gqlNotification, len(e.exec.Events))
+		for i := range e.exec.Events {
+			res[i] = &gqlNotification{s: e.s, ev: e.exec.Events[i]}
+		}
+		return res, nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (n *gqlNotification) TypeName() string { return "Notification" }
+
+// Field implements graphql.Object interface.
+func (n *gqlNotification) Field(name string, _ graphql.Args) (interface{}, error) {
+	switch name {
+	case "contractHash":
+		return gqlHash(n.ev.ScriptHash), nil
+	case "contract":
+		return n.s.newGQLContract(n.ev.ScriptHash), nil
+	case "eventName":
+		return n.ev.Name, nil
+	case "state":
+		return gqlItemsJSON(n.ev.Item.Value().([]stackitem.Item))
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (c *gqlContract) TypeName() string { return "Contract" }
+
+// Field implements graphql.Object interface.
+func (c *gqlContract) Field(name string, _ graphql.Args) (interface{}, error) {
+	switch name {
+	case "id":
+		return c.cs.ID, nil
+	case "hash":
+		return gqlHash(c.cs.Hash), nil
+	case "updateCounter":
+		return c.cs.UpdateCounter, nil
+	case "name":
+		return c.cs.Manifest.Name, nil
+	case "supportedStandards":
+		return c.cs.Manifest.SupportedStandards, nil
+	case "manifest":
+		data, err := json.Marshal(&c.cs.Manifest)
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(data), nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (a *gqlAccount) TypeName() string { return "Account" }
+
+// Field implements graphql.Object interface.
+func (a *gqlAccount) Field(name string, args graphql.Args) (interface{}, error) {
+	s := a.s
+	switch name {
+	case "address":
+		return address.Uint160ToString(a.acc), nil
+	case "scriptHash":
+		return gqlHash(a.acc), nil
+	case "nep17Balances":
+		res, err := s.gqlCall((*Server).getNEP17Balances, a.acc.StringLE())
+		if err != nil {
+			return nil, err
+		}
+		bs := res.(*result.NEP17Balances).Balances
+		bals := make([]*gqlNEP17Balance, len(bs))
+		for i := range bs {
+			bals[i] = &gqlNEP17Balance{s: s, b: bs[i]}
+		}
+		return bals, nil
+	case "nep11Balances":
+		res, err := s.gqlCall((*Server).getNEP11Balances, a.acc.StringLE())
+		if err != nil {
+			return nil, err
+		}
+		bs := res.(*result.NEP11Balances).Balances
+		bals := make([]*gqlNEP11Balance, len(bs))
+		for i := range bs {
+			bals[i] = &gqlNEP11Balance{s: s, b: bs[i]}
+		}
+		return bals, nil
+	case "nep17Transfers", "nep11Transfers":
+		var (
+			isNEP11 = name == "nep11Transfers"
+			now     = time.Now()
+			ps      = []interface{}{a.acc.StringLE()}
+		)
+		// JSON-RPC handler doesn't accept null parameters, so omitted (or
+		// null) arguments are replaced with the same defaults it uses.
+		for _, arg := range []struct {
+			name string
+			def  interface{}
+		}{
+			{"start", now.Add(-time.Hour * 24 * 7).UnixMilli()},
+			{"end", now.UnixMilli()},
+			{"limit", maxTransfersLimit},
+			{"page", 0},
+		} {
+			v := args[arg.name]
+			if v == nil {
+				v = arg.def
+			}
+			ps = append(ps, v)
+		}
+		res, err := s.gqlCall(func(s *Server, ps params.Params) (interface{}, *neorpc.Error) {
+			return s.getTokenTransfers(ps, isNEP11)
+		}, ps...)
+		if err != nil {
+			return nil, err
+		}
+		trs := res.(*tokenTransfers)
+		return &gqlTransfers{
+			sent:     s.newGQLTransfers(trs.Sent),
+			received: s.newGQLTransfers(trs.Received),
+		}, nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// newGQLTransfers converts transfers returned by getTokenTransfers.
+func (s *Server) newGQLTransfers(trs []interface{}) []*gqlTransfer {
+	res := make([]*gqlTransfer, 0, len(trs))
+	for _, tr := range trs {
+		switch t := tr.(type) {
+		case *result.NEP17Transfer:
+			res = append(res, &gqlTransfer{s: s, t: result.NEP11Transfer{
+				Timestamp:   t.Timestamp,
+				Asset:       t.Asset,
+				Address:     t.Address,
+				Amount:      t.Amount,
+				Index:       t.Index,
+				NotifyIndex: t.NotifyIndex,
+				TxHash:      t.TxHash,
+			}})
+		case result.NEP11Transfer:
+			res = append(res, &gqlTransfer{s: s, t: t, nep11: true})
+		}
+	}
+	return res
+}
+
+// TypeName implements graphql.Object interface.
+func (b *gqlNEP17Balance) TypeName() string { return "NEP17Balance" }
+
+// Field implements graphql.Object interface.
+func (b *gqlNEP17Balance) Field(name string, _ graphql.Args) (interface{}, error) {
+	switch name {
+	case "assetHash":
+		return gqlHash(b.b.Asset), nil
+	case "asset":
+		return b.s.newGQLContract(b.b.Asset), nil
+	case "name":
+		return b.b.Name, nil
+	case "symbol":
+		return b.b.Symbol, nil
+	case "decimals":
+		return b.b.Decimals, nil
+	case "amount":
+		return b.b.Amount, nil
+	case "lastUpdatedBlock":
+		return b.b.LastUpdated, nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (b *gqlNEP11Balance) TypeName() string { return "NEP11Balance" }
+
+// Field implements graphql.Object interface.
+func (b *gqlNEP11Balance) Field(name string, _ graphql.Args) (interface{}, error) {
+	switch name {
+	case "assetHash":
+		return gqlHash(b.b.Asset), nil
+	case "asset":
+		return b.s.newGQLContract(b.b.Asset), nil
+	case "name":
+		return b.b.Name, nil
+	case "symbol":
+		return b.b.Symbol, nil
+	case "decimals":
+		return b.b.Decimals, nil
+	case "tokens":
+		res := make([]*gqlNEP11Token, len(b.b.Tokens))
+		for i := range b.b.Tokens {
+			res[i] = &gqlNEP11Token{t: b.b.Tokens[i]}
+		}
+		return res, nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (t *gqlNEP11Token) TypeName() string { return "NEP11Token" }
+
+// Field implements graphql.Object interface.
+func (t *gqlNEP11Token) Field(name string, _ graphql.Args) (interface{}, error) {
+	switch name {
+	case "tokenId":
+		return t.t.ID, nil
+	case "amount":
+		return t.t.Amount, nil
+	case "lastUpdatedBlock":
+		return t.t.LastUpdated, nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (t *gqlTransfers) TypeName() string { return "Transfers" }
+
+// Field implements graphql.Object interface.
+func (t *gqlTransfers) Field(name string, _ graphql.Args) (interface{}, error) {
+	switch name {
+	case "sent":
+		return t.sent, nil
+	case "received":
+		return t.received, nil
+	}
+	return nil, graphql.ErrUnknownField
+}
+
+// TypeName implements graphql.Object interface.
+func (t *gqlTransfer) TypeName() string {
+	if t.nep11 {
+		return "NEP11Transfer"
+	}
+	return "NEP17Transfer"
+}
+
+// Field implements graphql.Object interface.
+func (t *gqlTransfer) Field(name string, _ graphql.Args) (interface{}, error) {
+	switch name {
+	case "timestamp":
+		return t.t.Timestamp, nil
+	case "assetHash":
+		return gqlHash(t.t.Asset), nil
+	case "asset":
+		return t.s.newGQLContract(t.t.Asset), nil
+	case "address":
+		if t.t.Address == "" {
+			return nil, nil
+		}
+		return t.t.Address, nil
+	case "counterparty":
+		if t.t.Address == "" {
+			return nil, nil
+		}
+		acc, err := address.StringToUint160(t.t.Address)
+		if err != nil {
+			return nil, err
+		}
+		return &gqlAccount{s: t.s, acc: acc}, nil
+	case "amount":
+		return t.t.Amount, nil
+	case "blockIndex":
+		return t.t.Index, nil
+	case "transferNotifyIndex":
+		return t.t.NotifyIndex, nil
+	case "txHash":
+		return gqlHash(t.t.TxHash), nil
+	case "transaction":
+		tx, height, err := t.s.chain.GetTransaction(t.t.TxHash)
+		if err != nil { // Block-level transfer, TxHash is the block hash.
+			return nil, nil
+		}
+		return &gqlTransaction{s: t.s, tx: tx, height: height}, nil
+	case "tokenId":
+		if t.nep11 {
+			return t.t.ID, nil
+		}
+	}
+	return nil, graphql.ErrUnknownField
+}
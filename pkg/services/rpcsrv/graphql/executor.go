@@ -0,0 +1,434 @@
+package graphql
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// typenameField is the only introspection field supported.
+const typenameField = "__typename"
+
+// ErrUnknownField should be returned by Object.Field for unknown fields.
+var ErrUnknownField = errors.New("unknown field")
+
+type (
+	// Object is a value of GraphQL object type. Field resolvers can return
+	// other Objects (or slices of them) to allow nested selections, scalars
+	// (strings, booleans, numbers, nil and json.RawMessage for arbitrary JSON
+	// values) or slices of scalars.
+	Object interface {
+		// TypeName returns the GraphQL type name of the object.
+		TypeName() string
+		// Field resolves the field with the given name and arguments.
+		Field(name string, args Args) (interface{}, error)
+	}
+
+	// Args contains field arguments with variables substituted. Values are
+	// the same as for Argument, but variables can also contain json.Number
+	// and float64 values.
+	Args map[string]interface{}
+
+	// Request is a GraphQL request as it's sent over HTTP.
+	Request struct {
+		Query         string                 `json:"query"`
+		OperationName string                 `json:"operationName,omitempty"`
+		Variables     map[string]interface{} `json:"variables,omitempty"`
+	}
+
+	// Response is a GraphQL response, Data is only absent if the request
+	// can't be executed at all.
+	Response struct {
+		Data   interface{} `json:"data,omitempty"`
+		Errors []Error     `json:"errors,omitempty"`
+	}
+
+	// Error is a GraphQL error with an optional path to the field that
+	// caused it.
+	Error struct {
+		Message string        `json:"message"`
+		Path    []interface{} `json:"path,omitempty"`
+	}
+
+	// orderedMap is a JSON object that preserves field order.
+	orderedMap struct {
+		keys   []string
+		values []interface{}
+	}
+
+	// executor holds the state of a single request execution.
+	executor struct {
+		doc       *Document
+		vars      map[string]interface{}
+		maxDepth  int
+		maxNodes  int
+		nodes     int
+		errs      []Error
+		fragments map[string]bool
+	}
+)
+
+// Execute executes the query from the request using the given root query
+// object. Selections deeper than maxDepth are not allowed and the query is
+// aborted if it requires resolving more than maxNodes fields in total (every
+// list element counts separately). Only queries are supported, the only
+// introspection field available is __typename.
+func Execute(root Object, req Request, maxDepth int, maxNodes int) *Response {
+	doc, err := Parse(req.Query)
+	if err != nil {
+		return errResponse(err)
+	}
+	op, err := doc.operation(req.OperationName)
+	if err != nil {
+		return errResponse(err)
+	}
+	if op.Type != "query" {
+		return errResponse(fmt.Errorf("%s operations are not supported", op.Type))
+	}
+	e := &executor{
+		doc:       doc,
+		vars:      make(map[string]interface{}),
+		maxDepth:  maxDepth,
+		maxNodes:  maxNodes,
+		fragments: make(map[string]bool),
+	}
+	for _, v := range op.Variables {
+		val, ok := req.Variables[v.Name]
+		if !ok && v.HasDefault {
+			val = v.Default
+		}
+		if v.NonNull && val == nil {
+			return errResponse(fmt.Errorf("variable $%s of type %s is required", v.Name, v.Type))
+		}
+		// Declared variables that are not provided are null.
+		e.vars[v.Name] = val
+	}
+	data, err := e.executeSelections(root, op.Selections, nil, 1)
+	if err != nil {
+		return errResponse(err)
+	}
+	return &Response{Data: data, Errors: e.errs}
+}
+
+func errResponse(err error) *Response {
+	return &Response{Errors: []Error{{Message: err.Error()}}}
+}
+
+// operation returns the operation to be executed.
+func (d *Document) operation(name string) (*Operation, error) {
+	if name == "" {
+		if len(d.Operations) != 1 {
+			return nil, errors.New("operation name is required for documents with multiple operations")
+		}
+		return d.Operations[0], nil
+	}
+	for _, op := range d.Operations {
+		if op.Name == name {
+			return op, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operation %q", name)
+}
+
+// collectFields groups fields of the selection set by response keys
+// expanding fragments and applying @skip and @include directives.
+func (e *executor) collectFields(obj Object, sels []Selection, keys *[]string, fields map[string][]*Field) error {
+	for _, sel := range sels {
+		switch s := sel.(type) {
+		case *Field:
+			ok, err := e.shouldInclude(s.Directives)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			key := s.Name
+			if s.Alias != "" {
+				key = s.Alias
+			}
+			if _, ok := fields[key]; !ok {
+				*keys = append(*keys, key)
+			}
+			fields[key] = append(fields[key], s)
+		case *FragmentSpread:
+			ok, err := e.shouldInclude(s.Directives)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			f, ok := e.doc.Fragments[s.Name]
+			if !ok {
+				return fmt.Errorf("unknown fragment %q", s.Name)
+			}
+			if e.fragments[s.Name] {
+				return fmt.Errorf("fragment %q is used recursively", s.Name)
+			}
+			if f.TypeCondition != obj.TypeName() {
+				continue
+			}
+			e.fragments[s.Name] = true
+			err = e.collectFields(obj, f.Selections, keys, fields)
+			delete(e.fragments, s.Name)
+			if err != nil {
+				return err
+			}
+		case *InlineFragment:
+			ok, err := e.shouldInclude(s.Directives)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			if s.TypeCondition != "" && s.TypeCondition != obj.TypeName() {
+				continue
+			}
+			if err = e.collectFields(obj, s.Selections, keys, fields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// shouldInclude processes @skip and @include directives.
+func (e *executor) shouldInclude(dirs []Directive) (bool, error) {
+	for _, d := range dirs {
+		if d.Name != "skip" && d.Name != "include" {
+			return false, fmt.Errorf("unknown directive @%s", d.Name)
+		}
+		args, err := e.resolveArgs(d.Arguments)
+		if err != nil {
+			return false, err
+		}
+		cond, ok := args["if"].(bool)
+		if !ok {
+			return false, fmt.Errorf("@%s directive requires boolean \"if\" argument", d.Name)
+		}
+		if cond == (d.Name == "skip") {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// resolveArgs substitutes variables into arguments.
+func (e *executor) resolveArgs(args []Argument) (Args, error) {
+	res := make(Args, len(args))
+	for _, a := range args {
+		v, err := e.resolveValue(a.Value)
+		if err != nil {
+			return nil, err
+		}
+		res[a.Name] = v
+	}
+	return res, nil
+}
+
+func (e *executor) resolveValue(v interface{}) (interface{}, error) {
+	switch t := v.(type) {
+	case Variable:
+		val, ok := e.vars[t.Name]
+		if !ok {
+			return nil, fmt.Errorf("variable $%s is not defined", t.Name)
+		}
+		return val, nil
+	case []interface{}:
+		res := make([]interface{}, len(t))
+		for i := range t {
+			var err error
+			if res[i], err = e.resolveValue(t[i]); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(t))
+		for k := range t {
+			var err error
+			if res[k], err = e.resolveValue(t[k]); err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	default:
+		return v, nil
+	}
+}
+
+// executeSelections resolves the selection set of the object. Errors of
+// individual fields are collected and the field value is set to null, an
+// error is only returned for invalid queries.
+func (e *executor) executeSelections(obj Object, sels []Selection, path []interface{}, depth int) (*orderedMap, error) {
+	if depth > e.maxDepth {
+		return nil, fmt.Errorf("query depth exceeds the limit of %d", e.maxDepth)
+	}
+	var (
+		keys   []string
+		fields = make(map[string][]*Field)
+		res    = new(orderedMap)
+	)
+	if err := e.collectFields(obj, sels, &keys, fields); err != nil {
+		return nil, err
+	}
+	for _, key := range keys {
+		var (
+			f        = fields[key][0]
+			subSels  []Selection
+			fieldVal interface{}
+		)
+		for _, same := range fields[key] {
+			if same.Name != f.Name {
+				return nil, fmt.Errorf("fields %q and %q conflict because they have the same response name %q", f.Name, same.Name, key)
+			}
+			subSels = append(subSels, same.Selections...)
+		}
+		fieldPath := append(path[:len(path):len(path)], key)
+		e.nodes++
+		if e.nodes > e.maxNodes {
+			return nil, fmt.Errorf("query exceeds the limit of %d resolved fields", e.maxNodes)
+		}
+		if f.Name == typenameField {
+			res.add(key, obj.TypeName())
+			continue
+		}
+		args, err := e.resolveArgs(f.Arguments)
+		if err != nil {
+			return nil, err
+		}
+		val, err := obj.Field(f.Name, args)
+		if errors.Is(err, ErrUnknownField) {
+			return nil, fmt.Errorf("cannot query field %q on type %q", f.Name, obj.TypeName())
+		}
+		if err != nil {
+			e.errs = append(e.errs, Error{Message: err.Error(), Path: fieldPath})
+		} else {
+			fieldVal, err = e.completeValue(f, val, subSels, fieldPath, depth)
+			if err != nil {
+				return nil, err
+			}
+		}
+		res.add(key, fieldVal)
+	}
+	return res, nil
+}
+
+// completeValue converts resolved field value into its JSON representation.
+func (e *executor) completeValue(f *Field, val interface{}, sels []Selection, path []interface{}, depth int) (interface{}, error) {
+	if val == nil {
+		return nil, nil
+	}
+	if obj, ok := val.(Object); ok {
+		if rv := reflect.ValueOf(val); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil, nil
+		}
+		if len(sels) == 0 {
+			return nil, fmt.Errorf("field %q of type %q must have a selection of subfields", f.Name, obj.TypeName())
+		}
+		return e.executeSelections(obj, sels, path, depth+1)
+	}
+	if _, ok := val.(json.RawMessage); !ok {
+		rv := reflect.ValueOf(val)
+		switch rv.Kind() {
+		case reflect.Pointer:
+			if rv.IsNil() {
+				return nil, nil
+			}
+		case reflect.Slice, reflect.Array:
+			res := make([]interface{}, rv.Len())
+			for i := range res {
+				var err error
+				res[i], err = e.completeValue(f, rv.Index(i).Interface(), sels, append(path[:len(path):len(path)], i), depth)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return res, nil
+		}
+	}
+	if len(sels) != 0 {
+		return nil, fmt.Errorf("field %q must not have a selection since its type is scalar", f.Name)
+	}
+	return val, nil
+}
+
+func (m *orderedMap) add(key string, val interface{}) {
+	m.keys = append(m.keys, key)
+	m.values = append(m.values, val)
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range m.keys {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString(strconv.Quote(k))
+		buf.WriteByte(':')
+		v, err := json.Marshal(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// Has checks whether the argument is specified and is not null.
+func (a Args) Has(name string) bool {
+	return a[name] != nil
+}
+
+// String returns the value of the string argument or def if it's not
+// specified or null.
+func (a Args) String(name string, def string) (string, error) {
+	v, ok := a[name]
+	if !ok || v == nil {
+		return def, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("argument %q must be a string", name)
+	}
+	return s, nil
+}
+
+// Int returns the value of the integer argument or def if it's not
+// specified or null.
+func (a Args) Int(name string, def int) (int, error) {
+	v, ok := a[name]
+	if !ok || v == nil {
+		return def, nil
+	}
+	var i int64
+	switch t := v.(type) {
+	case int64:
+		i = t
+	case json.Number:
+		n, err := t.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("argument %q must be an integer", name)
+		}
+		i = n
+	case float64:
+		if t != float64(int64(t)) {
+			return 0, fmt.Errorf("argument %q must be an integer", name)
+		}
+		i = int64(t)
+	default:
+		return 0, fmt.Errorf("argument %q must be an integer", name)
+	}
+	if i != int64(int(i)) {
+		return 0, fmt.Errorf("argument %q is out of range", name)
+	}
+	return int(i), nil
+}
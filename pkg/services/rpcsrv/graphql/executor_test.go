@@ -0,0 +1,186 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testNode is a simple linked list node used as a test schema.
+type testNode struct {
+	id   int
+	next *testNode
+}
+
+func (n *testNode) TypeName() string { return "Node" }
+
+func (n *testNode) Field(name string, args Args) (interface{}, error) {
+	switch name {
+	case "id":
+		return n.id, nil
+	case "next":
+		return n.next, nil
+	case "list":
+		count, err := args.Int("count", 2)
+		if err != nil {
+			return nil, err
+		}
+		var res []*testNode
+		for cur := n; cur != nil && len(res) < count; cur = cur.next {
+			res = append(res, cur)
+		}
+		return res, nil
+	case "echo":
+		return args.String("s", "default")
+	case "json":
+		return json.RawMessage(`{"a":[1,2]}`), nil
+	case "tags":
+		return []string{"a", "b"}, nil
+	case "fail":
+		return nil, errors.New("failed")
+	}
+	return nil, ErrUnknownField
+}
+
+func newTestList(n int) *testNode {
+	var head *testNode
+	for i := n; i > 0; i-- {
+		head = &testNode{id: i, next: head}
+	}
+	return head
+}
+
+func execJSON(t *testing.T, req Request, maxDepth int) string {
+	data, err := json.Marshal(Execute(newTestList(5), req, maxDepth, 1000))
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestExecute(t *testing.T) {
+	check := func(t *testing.T, query string, expected string) {
+		require.Equal(t, expected, execJSON(t, Request{Query: query}, 10))
+	}
+	t.Run("scalars", func(t *testing.T) {
+		check(t, `{ id __typename echo json tags }`,
+			`{"data":{"id":1,"__typename":"Node","echo":"default","json":{"a":[1,2]},"tags":["a","b"]}}`)
+	})
+	t.Run("order and aliases", func(t *testing.T) {
+		check(t, `{ b: id, a: echo(s: "x"), id }`, `{"data":{"b":1,"a":"x","id":1}}`)
+	})
+	t.Run("nested", func(t *testing.T) {
+		check(t, `{ next { id next { id } } list(count: 3) { id } }`,
+			`{"data":{"next":{"id":2,"next":{"id":3}},"list":[{"id":1},{"id":2},{"id":3}]}}`)
+	})
+	t.Run("null object", func(t *testing.T) {
+		check(t, `{ next { next { next { next { next { id } } } } } }`,
+			`{"data":{"next":{"next":{"next":{"next":{"next":null}}}}}}`)
+	})
+	t.Run("merged fields", func(t *testing.T) {
+		check(t, `{ next { id } next { echo } }`, `{"data":{"next":{"id":2,"echo":"default"}}}`)
+	})
+	t.Run("fragments", func(t *testing.T) {
+		check(t, `{ ...F next { ... on Node { id } ... on Other { echo } } } fragment F on Node { id } fragment G on Other { tags }`,
+			`{"data":{"id":1,"next":{"id":2}}}`)
+	})
+	t.Run("skip and include", func(t *testing.T) {
+		check(t, `{ id @skip(if: true) echo @include(if: false) tags @include(if: true) ... @skip(if: true) { json } }`,
+			`{"data":{"tags":["a","b"]}}`)
+	})
+	t.Run("field error", func(t *testing.T) {
+		check(t, `{ id next { fail } list { fail } }`,
+			`{"data":{"id":1,"next":{"fail":null},"list":[{"fail":null},{"fail":null}]},"errors":[`+
+				`{"message":"failed","path":["next","fail"]},{"message":"failed","path":["list",0,"fail"]},{"message":"failed","path":["list",1,"fail"]}]}`)
+	})
+	t.Run("argument error", func(t *testing.T) {
+		check(t, `{ echo(s: 1) }`,
+			`{"data":{"echo":null},"errors":[{"message":"argument \"s\" must be a string","path":["echo"]}]}`)
+	})
+}
+
+func TestExecuteVariables(t *testing.T) {
+	query := `query Q($count: Int = 1, $s: String, $skip: Boolean!) { list(count: $count) { id } echo(s: $s) @skip(if: $skip) }`
+	require.Equal(t, `{"data":{"list":[{"id":1}],"echo":"default"}}`,
+		execJSON(t, Request{Query: query, Variables: map[string]interface{}{"skip": false}}, 10))
+	require.Equal(t, `{"data":{"list":[{"id":1},{"id":2},{"id":3}]}}`,
+		execJSON(t, Request{Query: query, Variables: map[string]interface{}{"count": json.Number("3"), "s": "x", "skip": true}}, 10))
+	require.Equal(t, `{"errors":[{"message":"variable $skip of type Boolean! is required"}]}`,
+		execJSON(t, Request{Query: query}, 10))
+}
+
+func TestExecuteOperationName(t *testing.T) {
+	query := `query A { id } query B { echo }`
+	require.Equal(t, `{"data":{"echo":"default"}}`, execJSON(t, Request{Query: query, OperationName: "B"}, 10))
+	require.Equal(t, `{"errors":[{"message":"unknown operation \"C\""}]}`, execJSON(t, Request{Query: query, OperationName: "C"}, 10))
+	require.Equal(t, `{"errors":[{"message":"operation name is required for documents with multiple operations"}]}`, execJSON(t, Request{Query: query}, 10))
+}
+
+func TestExecuteErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		query string
+		err   string
+	}{
+		"syntax":            {"{ id", "syntax error at 1:5: name expected, got <EOF>"},
+		"mutation":          {"mutation { id }", "mutation operations are not supported"},
+		"unknown field":     {"{ next { unknown } }", `cannot query field "unknown" on type "Node"`},
+		"no subselection":   {"{ next }", `field "next" of type "Node" must have a selection of subfields`},
+		"scalar selection":  {"{ id { id } }", `field "id" must not have a selection since its type is scalar`},
+		"unknown fragment":  {"{ ...F }", `unknown fragment "F"`},
+		"recursive":         {"{ ...F } fragment F on Node { next { id } ...F }", `fragment "F" is used recursively`},
+		"unknown directive": {"{ id @foo }", "unknown directive @foo"},
+		"bad directive":     {"{ id @skip(if: 1) }", `@skip directive requires boolean "if" argument`},
+		"undefined var":     {"{ echo(s: $s) }", "variable $s is not defined"},
+		"conflict":          {"{ a: id a: echo }", `fields "id" and "echo" conflict because they have the same response name "a"`},
+		"depth":             {"{ next { next { next { id } } } }", "query depth exceeds the limit of 3"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resp := Execute(newTestList(5), Request{Query: tc.query}, 3, 1000)
+			require.Nil(t, resp.Data)
+			require.Equal(t, []Error{{Message: tc.err}}, resp.Errors)
+		})
+	}
+}
+
+func TestExecuteNodeLimit(t *testing.T) {
+	// 1 (list) + 5 * 2 (id, next) + 4 (next.id, the last next is null),
+	// __typename counts too.
+	query := Request{Query: "{ list(count: 5) { id next { id } } }"}
+	resp := Execute(newTestList(5), query, 10, 15)
+	require.Nil(t, resp.Errors)
+	require.NotNil(t, resp.Data)
+
+	resp = Execute(newTestList(5), query, 10, 14)
+	require.Nil(t, resp.Data)
+	require.Equal(t, []Error{{Message: "query exceeds the limit of 14 resolved fields"}}, resp.Errors)
+
+	resp = Execute(newTestList(5), Request{Query: "{ __typename id }"}, 10, 1)
+	require.Nil(t, resp.Data)
+	require.Equal(t, []Error{{Message: "query exceeds the limit of 1 resolved fields"}}, resp.Errors)
+}
+
+func TestArgs(t *testing.T) {
+	args := Args{"i": int64(1), "n": json.Number("2"), "f": float64(3), "bad": 1.5, "s": "str", "null": nil}
+	require.True(t, args.Has("i"))
+	require.False(t, args.Has("null"))
+	require.False(t, args.Has("missing"))
+
+	for name, expected := range map[string]int{"i": 1, "n": 2, "f": 3, "null": 42, "missing": 42} {
+		i, err := args.Int(name, 42)
+		require.NoError(t, err)
+		require.Equal(t, expected, i)
+	}
+	_, err := args.Int("bad", 0)
+	require.Error(t, err)
+	_, err = args.Int("s", 0)
+	require.Error(t, err)
+
+	s, err := args.String("s", "def")
+	require.NoError(t, err)
+	require.Equal(t, "str", s)
+	s, err = args.String("null", "def")
+	require.NoError(t, err)
+	require.Equal(t, "def", s)
+	_, err = args.String("i", "def")
+	require.Error(t, err)
+}
@@ -0,0 +1,522 @@
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+type (
+	// Document is a parsed GraphQL query document.
+	Document struct {
+		Operations []*Operation
+		Fragments  map[string]*Fragment
+	}
+
+	// Operation is a single operation definition.
+	Operation struct {
+		// Type is "query", "mutation" or "subscription".
+		Type       string
+		Name       string
+		Variables  []VariableDefinition
+		Directives []Directive
+		Selections []Selection
+	}
+
+	// VariableDefinition is an operation variable declaration.
+	VariableDefinition struct {
+		Name string
+		// Type is a textual representation of the variable type.
+		Type    string
+		NonNull bool
+		// Default is the default value of the variable, it's only
+		// meaningful if HasDefault is true.
+		Default    interface{}
+		HasDefault bool
+	}
+
+	// Fragment is a named fragment definition.
+	Fragment struct {
+		Name          string
+		TypeCondition string
+		Directives    []Directive
+		Selections    []Selection
+	}
+
+	// Selection is one of *Field, *FragmentSpread or *InlineFragment.
+	Selection interface {
+		isSelection()
+	}
+
+	// Field is a field selection.
+	Field struct {
+		Alias      string
+		Name       string
+		Arguments  []Argument
+		Directives []Directive
+		Selections []Selection
+	}
+
+	// FragmentSpread is a named fragment usage.
+	FragmentSpread struct {
+		Name       string
+		Directives []Directive
+	}
+
+	// InlineFragment is an anonymous fragment with an optional type condition.
+	InlineFragment struct {
+		TypeCondition string
+		Directives    []Directive
+		Selections    []Selection
+	}
+
+	// Directive is a directive applied to a selection or an operation.
+	Directive struct {
+		Name      string
+		Arguments []Argument
+	}
+
+	// Argument is a named argument of a field or a directive. Value is one of
+	// int64, float64, string (for strings and enum values), bool, nil,
+	// []interface{}, map[string]interface{} or Variable.
+	Argument struct {
+		Name  string
+		Value interface{}
+	}
+
+	// Variable is a reference to an operation variable.
+	Variable struct {
+		Name string
+	}
+)
+
+func (*Field) isSelection()          {}
+func (*FragmentSpread) isSelection() {}
+func (*InlineFragment) isSelection() {}
+
+// parser is a recursive descent GraphQL query parser.
+type parser struct {
+	lex *lexer
+	tok token
+}
+
+// Parse parses the given GraphQL query document (type system definitions
+// are not supported).
+func Parse(query string) (*Document, error) {
+	p := &parser{lex: &lexer{src: query}}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	doc := &Document{Fragments: make(map[string]*Fragment)}
+	for p.tok.kind != tokenEOF {
+		switch {
+		case p.isPunct("{"):
+			sels, err := p.parseSelectionSet()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, &Operation{Type: "query", Selections: sels})
+		case p.isName("query"), p.isName("mutation"), p.isName("subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.Operations = append(doc.Operations, op)
+		case p.isName("fragment"):
+			f, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.Fragments[f.Name]; ok {
+				return nil, fmt.Errorf("duplicate fragment %q", f.Name)
+			}
+			doc.Fragments[f.Name] = f
+		default:
+			return nil, p.unexpected()
+		}
+	}
+	if len(doc.Operations) == 0 {
+		return nil, fmt.Errorf("no operations in the document")
+	}
+	return doc, nil
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lex.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) isPunct(v string) bool {
+	return p.tok.kind == tokenPunct && p.tok.value == v
+}
+
+func (p *parser) isName(v string) bool {
+	return p.tok.kind == tokenName && p.tok.value == v
+}
+
+func (p *parser) unexpected() error {
+	return p.lex.errorf(p.tok.pos, "unexpected %s", p.tok)
+}
+
+// expect consumes the given punctuator.
+func (p *parser) expect(v string) error {
+	if !p.isPunct(v) {
+		return p.lex.errorf(p.tok.pos, "expected %q, got %s", v, p.tok)
+	}
+	return p.advance()
+}
+
+// skip consumes the given punctuator if it's the current token.
+func (p *parser) skip(v string) (bool, error) {
+	if !p.isPunct(v) {
+		return false, nil
+	}
+	return true, p.advance()
+}
+
+// name consumes a name token and returns it.
+func (p *parser) name() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.lex.errorf(p.tok.pos, "name expected, got %s", p.tok)
+	}
+	v := p.tok.value
+	return v, p.advance()
+}
+
+func (p *parser) parseOperation() (*Operation, error) {
+	var (
+		op  = &Operation{Type: p.tok.value}
+		err error
+	)
+	if err = p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		op.Name = p.tok.value
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if ok, err := p.skip("("); err != nil {
+		return nil, err
+	} else if ok {
+		for !p.isPunct(")") {
+			v, err := p.parseVariableDefinition()
+			if err != nil {
+				return nil, err
+			}
+			op.Variables = append(op.Variables, v)
+		}
+		if err = p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	if op.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if op.Selections, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return op, nil
+}
+
+func (p *parser) parseVariableDefinition() (VariableDefinition, error) {
+	var v VariableDefinition
+	if err := p.expect("$"); err != nil {
+		return v, err
+	}
+	name, err := p.name()
+	if err != nil {
+		return v, err
+	}
+	v.Name = name
+	if err = p.expect(":"); err != nil {
+		return v, err
+	}
+	if v.Type, err = p.parseType(); err != nil {
+		return v, err
+	}
+	v.NonNull = v.Type[len(v.Type)-1] == '!'
+	if ok, err := p.skip("="); err != nil {
+		return v, err
+	} else if ok {
+		v.HasDefault = true
+		if v.Default, err = p.parseValue(true); err != nil {
+			return v, err
+		}
+	}
+	// Variable directives are allowed, but ignored.
+	_, err = p.parseDirectives()
+	return v, err
+}
+
+// parseType parses the type reference and returns its textual
+// representation.
+func (p *parser) parseType() (string, error) {
+	var typ string
+	if ok, err := p.skip("["); err != nil {
+		return "", err
+	} else if ok {
+		elem, err := p.parseType()
+		if err != nil {
+			return "", err
+		}
+		if err = p.expect("]"); err != nil {
+			return "", err
+		}
+		typ = "[" + elem + "]"
+	} else {
+		if typ, err = p.name(); err != nil {
+			return "", err
+		}
+	}
+	if ok, err := p.skip("!"); err != nil {
+		return "", err
+	} else if ok {
+		typ += "!"
+	}
+	return typ, nil
+}
+
+func (p *parser) parseFragment() (*Fragment, error) {
+	var (
+		f   = new(Fragment)
+		err error
+	)
+	if err = p.advance(); err != nil {
+		return nil, err
+	}
+	if p.isName("on") {
+		return nil, p.unexpected()
+	}
+	if f.Name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if !p.isName("on") {
+		return nil, p.lex.errorf(p.tok.pos, "expected \"on\", got %s", p.tok)
+	}
+	if err = p.advance(); err != nil {
+		return nil, err
+	}
+	if f.TypeCondition, err = p.name(); err != nil {
+		return nil, err
+	}
+	if f.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if f.Selections, err = p.parseSelectionSet(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (p *parser) parseSelectionSet() ([]Selection, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var sels []Selection
+	for !p.isPunct("}") {
+		sel, err := p.parseSelection()
+		if err != nil {
+			return nil, err
+		}
+		sels = append(sels, sel)
+	}
+	if len(sels) == 0 {
+		return nil, p.lex.errorf(p.tok.pos, "empty selection set")
+	}
+	return sels, p.advance()
+}
+
+func (p *parser) parseSelection() (Selection, error) {
+	var err error
+	if ok, err := p.skip("..."); err != nil {
+		return nil, err
+	} else if ok {
+		if p.tok.kind == tokenName && !p.isName("on") {
+			spread := &FragmentSpread{Name: p.tok.value}
+			if err = p.advance(); err != nil {
+				return nil, err
+			}
+			spread.Directives, err = p.parseDirectives()
+			return spread, err
+		}
+		inline := new(InlineFragment)
+		if p.isName("on") {
+			if err = p.advance(); err != nil {
+				return nil, err
+			}
+			if inline.TypeCondition, err = p.name(); err != nil {
+				return nil, err
+			}
+		}
+		if inline.Directives, err = p.parseDirectives(); err != nil {
+			return nil, err
+		}
+		if inline.Selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+		return inline, nil
+	}
+
+	f := new(Field)
+	if f.Name, err = p.name(); err != nil {
+		return nil, err
+	}
+	if ok, err := p.skip(":"); err != nil {
+		return nil, err
+	} else if ok {
+		f.Alias = f.Name
+		if f.Name, err = p.name(); err != nil {
+			return nil, err
+		}
+	}
+	if f.Arguments, err = p.parseArguments(false); err != nil {
+		return nil, err
+	}
+	if f.Directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if p.isPunct("{") {
+		if f.Selections, err = p.parseSelectionSet(); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func (p *parser) parseArguments(isConst bool) ([]Argument, error) {
+	if ok, err := p.skip("("); err != nil || !ok {
+		return nil, err
+	}
+	var args []Argument
+	for !p.isPunct(")") {
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range args {
+			if a.Name == name {
+				return nil, fmt.Errorf("duplicate argument %q", name)
+			}
+		}
+		if err = p.expect(":"); err != nil {
+			return nil, err
+		}
+		v, err := p.parseValue(isConst)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, Argument{Name: name, Value: v})
+	}
+	if len(args) == 0 {
+		return nil, p.lex.errorf(p.tok.pos, "empty argument list")
+	}
+	return args, p.advance()
+}
+
+func (p *parser) parseDirectives() ([]Directive, error) {
+	var dirs []Directive
+	for p.isPunct("@") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.name()
+		if err != nil {
+			return nil, err
+		}
+		args, err := p.parseArguments(false)
+		if err != nil {
+			return nil, err
+		}
+		dirs = append(dirs, Directive{Name: name, Arguments: args})
+	}
+	return dirs, nil
+}
+
+// parseValue parses an input value, variables are not allowed if isConst is
+// true.
+func (p *parser) parseValue(isConst bool) (interface{}, error) {
+	var (
+		tok = p.tok
+		res interface{}
+	)
+	switch tok.kind {
+	case tokenPunct:
+		switch tok.value {
+		case "$":
+			if isConst {
+				return nil, p.unexpected()
+			}
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			name, err := p.name()
+			return Variable{Name: name}, err
+		case "[":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			list := []interface{}{}
+			for !p.isPunct("]") {
+				v, err := p.parseValue(isConst)
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, v)
+			}
+			return list, p.advance()
+		case "{":
+			if err := p.advance(); err != nil {
+				return nil, err
+			}
+			obj := make(map[string]interface{})
+			for !p.isPunct("}") {
+				name, err := p.name()
+				if err != nil {
+					return nil, err
+				}
+				if err = p.expect(":"); err != nil {
+					return nil, err
+				}
+				if obj[name], err = p.parseValue(isConst); err != nil {
+					return nil, err
+				}
+			}
+			return obj, p.advance()
+		default:
+			return nil, p.unexpected()
+		}
+	case tokenInt:
+		v, err := strconv.ParseInt(tok.value, 10, 64)
+		if err != nil {
+			return nil, p.lex.errorf(tok.pos, "invalid integer %s", tok.value)
+		}
+		res = v
+	case tokenFloat:
+		v, err := strconv.ParseFloat(tok.value, 64)
+		if err != nil {
+			return nil, p.lex.errorf(tok.pos, "invalid float %s", tok.value)
+		}
+		res = v
+	case tokenString:
+		res = tok.value
+	case tokenName:
+		switch tok.value {
+		case "true":
+			res = true
+		case "false":
+			res = false
+		case "null":
+			res = nil
+		default:
+			res = tok.value // Enum value.
+		}
+	default:
+		return nil, p.unexpected()
+	}
+	return res, p.advance()
+}
@@ -0,0 +1,110 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	doc, err := Parse(`
+		# Comment.
+		query Q($h: String!, $n: Int = 10, $list: [Int!]) @dir {
+			alias: block(hash: $h, index: -1, f: 1.5e3, b: true, x: null, e: ENUM, l: [1, "two"], o: {k: $n}) {
+				... on Block @include(if: true) { hash }
+				...F
+				...@skip(if: false) { index }
+			}
+		}
+		fragment F on Block { transactions { hash } }
+		{ height }`)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(doc.Operations))
+	require.Equal(t, &Operation{Type: "query", Selections: []Selection{&Field{Name: "height"}}}, doc.Operations[1])
+
+	op := doc.Operations[0]
+	require.Equal(t, "query", op.Type)
+	require.Equal(t, "Q", op.Name)
+	require.Equal(t, []VariableDefinition{
+		{Name: "h", Type: "String!", NonNull: true},
+		{Name: "n", Type: "Int", Default: int64(10), HasDefault: true},
+		{Name: "list", Type: "[Int!]"},
+	}, op.Variables)
+	require.Equal(t, []Directive{{Name: "dir"}}, op.Directives)
+	require.Equal(t, []Selection{&Field{
+		Alias: "alias",
+		Name:  "block",
+		Arguments: []Argument{
+			{Name: "hash", Value: Variable{Name: "h"}},
+			{Name: "index", Value: int64(-1)},
+			{Name: "f", Value: 1.5e3},
+			{Name: "b", Value: true},
+			{Name: "x", Value: nil},
+			{Name: "e", Value: "ENUM"},
+			{Name: "l", Value: []interface{}{int64(1), "two"}},
+			{Name: "o", Value: map[string]interface{}{"k": Variable{Name: "n"}}},
+		},
+		Selections: []Selection{
+			&InlineFragment{
+				TypeCondition: "Block",
+				Directives:    []Directive{{Name: "include", Arguments: []Argument{{Name: "if", Value: true}}}},
+				Selections:    []Selection{&Field{Name: "hash"}},
+			},
+			&FragmentSpread{Name: "F"},
+			&InlineFragment{
+				Directives: []Directive{{Name: "skip", Arguments: []Argument{{Name: "if", Value: false}}}},
+				Selections: []Selection{&Field{Name: "index"}},
+			},
+		},
+	}}, op.Selections)
+	require.Equal(t, map[string]*Fragment{"F": {
+		Name:          "F",
+		TypeCondition: "Block",
+		Selections: []Selection{
+			&Field{Name: "transactions", Selections: []Selection{&Field{Name: "hash"}}},
+		},
+	}}, doc.Fragments)
+}
+
+func TestParseStrings(t *testing.T) {
+	check := func(t *testing.T, lit string, expected string) {
+		doc, err := Parse(`{ f(s: ` + lit + `) }`)
+		require.NoError(t, err)
+		require.Equal(t, expected, doc.Operations[0].Selections[0].(*Field).Arguments[0].Value)
+	}
+	check(t, `"simple"`, "simple")
+	check(t, `"esc\"\\\/\b\f\n\r\t"`, "esc\"\\/\b\f\n\r\t")
+	check(t, `"Aé"`, "Aé")
+	check(t, `"""block "quoted" \""" """`, `block "quoted" """ `)
+}
+
+func TestParseErrors(t *testing.T) {
+	for name, query := range map[string]string{
+		"empty":                 "",
+		"unterminated set":      "{ a ",
+		"empty set":             "{}",
+		"unexpected character":  "{ a % }",
+		"single dot":            "{ a. }",
+		"unterminated string":   `{ a(s: "abc) }`,
+		"multiline string":      "{ a(s: \"a\nb\") }",
+		"bad escape":            `{ a(s: "\x") }`,
+		"bad unicode":           `{ a(s: "\u00zz") }`,
+		"unterminated block":    `{ a(s: """abc) }`,
+		"leading zero":          "{ a(n: 01) }",
+		"bad number":            "{ a(n: 1x) }",
+		"bad exponent":          "{ a(n: 1e) }",
+		"variable in const":     "query($a: Int = $b) { a }",
+		"duplicate fragment":    "{ a } fragment F on A { a } fragment F on A { b }",
+		"missing type":          "query($a) { a }",
+		"fragment without type": "{ a } fragment F { a }",
+		"fragment named on":     "{ a } fragment on on A { a }",
+		"unknown definition":    "schema { query: Query }",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(query)
+			require.Error(t, err)
+		})
+	}
+	_, err := Parse("{\n  a %\n}")
+	require.EqualError(t, err, "syntax error at 2:5: unexpected character '%'")
+}
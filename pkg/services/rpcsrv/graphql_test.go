@@ -0,0 +1,317 @@
+package rpcsrv
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/testchain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/stretchr/testify/require"
+)
+
+type graphQLResponse struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message string        `json:"message"`
+		Path    []interface{} `json:"path"`
+	} `json:"errors"`
+}
+
+func doGraphQLRequest(t *testing.T, url string, query string, vars map[string]interface{}) (int, graphQLResponse) {
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": vars})
+	require.NoError(t, err)
+	cl := http.Client{Timeout: time.Second}
+	resp, err := cl.Post(url+"/graphql", "application/json", strings.NewReader(string(body)))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var res graphQLResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+	return resp.StatusCode, res
+}
+
+func TestGraphQL(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		resp, err := http.Post(httpSrv.URL+"/graphql", "application/json", strings.NewReader(`{"query": "{ height }"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var res map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		require.Contains(t, res, "jsonrpc") // Processed as JSON-RPC request.
+	})
+	t.Run("node limit", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.RPC.GraphQL.Enabled = true
+			c.ApplicationConfiguration.RPC.GraphQL.MaxNodes = 3
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		status, res := doGraphQLRequest(t, httpSrv.URL, `{ height block(index: 0) { index } }`, nil)
+		require.Equal(t, http.StatusOK, status)
+		require.Empty(t, res.Errors)
+		status, res = doGraphQLRequest(t, httpSrv.URL, `{ a: block(index: 0) { index } b: block(index: 0) { index } }`, nil)
+		require.Equal(t, http.StatusBadRequest, status)
+		require.Nil(t, res.Data)
+		require.Equal(t, 1, len(res.Errors))
+	})
+
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.GraphQL.Enabled = true
+	})
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	require.Equal(t, defaultGraphQLMaxDepth, rpcSrv.config.GraphQL.MaxDepth)
+	require.Equal(t, defaultGraphQLMaxNodes, rpcSrv.config.GraphQL.MaxNodes)
+
+	query := func(t *testing.T, q string, vars map[string]interface{}) map[string]interface{} {
+		status, res := doGraphQLRequest(t, httpSrv.URL, q, vars)
+		require.Equal(t, http.StatusOK, status)
+		require.Empty(t, res.Errors)
+		return res.Data
+	}
+
+	t.Run("height", func(t *testing.T) {
+		data := query(t, `{ height }`, nil)
+		require.Equal(t, float64(chain.BlockHeight()), data["height"])
+	})
+	t.Run("GET", func(t *testing.T) {
+		resp, err := http.Get(httpSrv.URL + "/graphql?query=" + url.QueryEscape(`query($i: Int) { block(index: $i) { index } }`) +
+			"&variables=" + url.QueryEscape(`{"i": 1}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var res graphQLResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		require.Equal(t, map[string]interface{}{"block": map[string]interface{}{"index": float64(1)}}, res.Data)
+	})
+	t.Run("block", func(t *testing.T) {
+		b, err := chain.GetBlock(chain.GetHeaderHash(2))
+		require.NoError(t, err)
+		data := query(t, `query($h: String!) {
+			byHash: block(hash: $h) { index }
+			block(index: 2) {
+				hash index time primary nextConsensus confirmations previousBlockHash transactionCount
+				previous { index previous { index } }
+				next { index }
+				transactions { hash sender { address } block { index } }
+				executions { trigger vmState }
+			}
+		}`, map[string]interface{}{"h": "0x" + b.Hash().StringLE()})
+		require.Equal(t, map[string]interface{}{"index": float64(2)}, data["byHash"])
+
+		blk := data["block"].(map[string]interface{})
+		require.Equal(t, "0x"+b.Hash().StringLE(), blk["hash"])
+		require.Equal(t, float64(2), blk["index"])
+		require.Equal(t, float64(b.Timestamp), blk["time"])
+		require.Equal(t, float64(b.PrimaryIndex), blk["primary"])
+		require.Equal(t, address.Uint160ToString(b.NextConsensus), blk["nextConsensus"])
+		require.Equal(t, float64(chain.BlockHeight()-1), blk["confirmations"])
+		require.Equal(t, "0x"+b.PrevHash.StringLE(), blk["previousBlockHash"])
+		require.Equal(t, float64(len(b.Transactions)), blk["transactionCount"])
+		require.Equal(t, map[string]interface{}{"index": float64(1), "previous": map[string]interface{}{"index": float64(0)}}, blk["previous"])
+		require.Equal(t, map[string]interface{}{"index": float64(3)}, blk["next"])
+
+		txs := blk["transactions"].([]interface{})
+		require.Equal(t, len(b.Transactions), len(txs))
+		for i, tx := range txs {
+			require.Equal(t, map[string]interface{}{
+				"hash":   "0x" + b.Transactions[i].Hash().StringLE(),
+				"sender": map[string]interface{}{"address": address.Uint160ToString(b.Transactions[i].Sender())},
+				"block":  map[string]interface{}{"index": float64(2)},
+			}, tx)
+		}
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"trigger": trigger.OnPersist.String(), "vmState": "HALT"},
+			map[string]interface{}{"trigger": trigger.PostPersist.String(), "vmState": "HALT"},
+		}, blk["executions"])
+	})
+	t.Run("genesis", func(t *testing.T) {
+		data := query(t, `{ block(index: 0) { hash previous { index } } }`, nil)
+		require.Equal(t, map[string]interface{}{"hash": "0x" + genesisBlockHash, "previous": nil}, data["block"])
+	})
+	t.Run("blocks", func(t *testing.T) {
+		data := query(t, `{ blocks(from: 3, count: 2) { index } tail: blocks(from: 100500) { index } }`, nil)
+		require.Equal(t, []interface{}{
+			map[string]interface{}{"index": float64(3)},
+			map[string]interface{}{"index": float64(4)},
+		}, data["blocks"])
+		require.Equal(t, []interface{}{}, data["tail"])
+	})
+	t.Run("transaction", func(t *testing.T) {
+		b, err := chain.GetBlock(chain.GetHeaderHash(1))
+		require.NoError(t, err)
+		tx := b.Transactions[0]
+		data := query(t, `query($h: String!) { transaction(hash: $h) {
+			hash size nonce systemFee networkFee validUntilBlock script
+			signers { account { scriptHash } scopes }
+			execution { trigger vmState gasConsumed exception stack notifications { contractHash eventName contract { name } } }
+		} }`, map[string]interface{}{"h": tx.Hash().StringLE()})
+		res := data["transaction"].(map[string]interface{})
+		require.Equal(t, "0x"+tx.Hash().StringLE(), res["hash"])
+		require.Equal(t, float64(tx.Size()), res["size"])
+		require.Equal(t, float64(tx.Nonce), res["nonce"])
+		require.Equal(t, strconv.FormatInt(tx.SystemFee, 10), res["systemFee"])
+		require.Equal(t, strconv.FormatInt(tx.NetworkFee, 10), res["networkFee"])
+		require.Equal(t, base64.StdEncoding.EncodeToString(tx.Script), res["script"])
+		require.Equal(t, float64(tx.ValidUntilBlock), res["validUntilBlock"])
+		require.Equal(t, []interface{}{map[string]interface{}{
+			"account": map[string]interface{}{"scriptHash": "0x" + tx.Signers[0].Account.StringLE()},
+			"scopes":  tx.Signers[0].Scopes.String(),
+		}}, res["signers"])
+
+		aer, err := chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		require.NoError(t, err)
+		exec := res["execution"].(map[string]interface{})
+		require.Equal(t, "Application", exec["trigger"])
+		require.Equal(t, "HALT", exec["vmState"])
+		require.Nil(t, exec["exception"])
+		require.Equal(t, len(aer[0].Stack), len(exec["stack"].([]interface{})))
+		ntfs := exec["notifications"].([]interface{})
+		require.Equal(t, len(aer[0].Events), len(ntfs))
+		for i, ntf := range ntfs {
+			ev := aer[0].Events[i]
+			cs := chain.GetContractState(ev.ScriptHash)
+			require.NotNil(t, cs)
+			require.Equal(t, map[string]interface{}{
+				"contractHash": "0x" + ev.ScriptHash.StringLE(),
+				"eventName":    ev.Name,
+				"contract":     map[string]interface{}{"name": cs.Manifest.Name},
+			}, ntf)
+		}
+	})
+	t.Run("contract", func(t *testing.T) {
+		data := query(t, `{
+			neo: contract(id: "`+nativenames.Neo+`") { id name supportedStandards }
+			test: contract(id: "`+testContractHash+`") { hash updateCounter manifest }
+		}`, nil)
+		require.Equal(t, map[string]interface{}{
+			"id":                 float64(-5),
+			"name":               nativenames.Neo,
+			"supportedStandards": []interface{}{"NEP-17"},
+		}, data["neo"])
+		test := data["test"].(map[string]interface{})
+		require.Equal(t, "0x"+testContractHash, test["hash"])
+		require.Equal(t, float64(0), test["updateCounter"])
+		require.Equal(t, "Rubl", test["manifest"].(map[string]interface{})["name"])
+	})
+	t.Run("account", func(t *testing.T) {
+		acc := testchain.PrivateKeyByID(0).GetScriptHash()
+		ps, err := params.FromAny([]interface{}{acc.StringLE()})
+		require.NoError(t, err)
+		bals, respErr := rpcSrv.getNEP17Balances(ps)
+		require.Nil(t, respErr)
+		ps, err = params.FromAny([]interface{}{acc.StringLE(), 0, time.Now().UnixMilli(), 3})
+		require.NoError(t, err)
+		trs, respErr := rpcSrv.getTokenTransfers(ps, false)
+		require.Nil(t, respErr)
+
+		data := query(t, `query($a: String!) { account(address: $a) {
+			address
+			nep17Balances { assetHash symbol amount lastUpdatedBlock }
+			nep11Balances { symbol tokens { tokenId amount } }
+			nep17Transfers(start: 0, end: null, limit: 3) {
+				sent { __typename assetHash amount blockIndex txHash counterparty { address } transaction { hash } }
+			}
+		} }`, map[string]interface{}{"a": address.Uint160ToString(acc)})
+		res := data["account"].(map[string]interface{})
+		require.Equal(t, address.Uint160ToString(acc), res["address"])
+
+		expBals := bals.(*result.NEP17Balances).Balances
+		gqlBals := res["nep17Balances"].([]interface{})
+		require.Equal(t, len(expBals), len(gqlBals))
+		expGQLBals := make([]interface{}, len(expBals))
+		for i, b := range expBals {
+			expGQLBals[i] = map[string]interface{}{
+				"assetHash":        "0x" + b.Asset.StringLE(),
+				"symbol":           b.Symbol,
+				"amount":           b.Amount,
+				"lastUpdatedBlock": float64(b.LastUpdated),
+			}
+		}
+		require.ElementsMatch(t, expGQLBals, gqlBals)
+		require.Contains(t, res["nep11Balances"], map[string]interface{}{
+			"symbol": "NNS",
+			"tokens": []interface{}{map[string]interface{}{"tokenId": nnsToken1ID, "amount": "1"}},
+		})
+
+		expSent := trs.(*tokenTransfers).Sent
+		gqlSent := res["nep17Transfers"].(map[string]interface{})["sent"].([]interface{})
+		require.Equal(t, 3, len(gqlSent))
+		require.Equal(t, len(expSent), len(gqlSent))
+		for i, tr := range gqlSent {
+			exp := expSent[i].(*result.NEP17Transfer)
+			var counterparty, tx interface{}
+			if exp.Address != "" {
+				counterparty = map[string]interface{}{"address": exp.Address}
+			}
+			if _, _, err := chain.GetTransaction(exp.TxHash); err == nil {
+				tx = map[string]interface{}{"hash": "0x" + exp.TxHash.StringLE()}
+			}
+			require.Equal(t, map[string]interface{}{
+				"__typename":   "NEP17Transfer",
+				"assetHash":    "0x" + exp.Asset.StringLE(),
+				"amount":       exp.Amount,
+				"blockIndex":   float64(exp.Index),
+				"txHash":       "0x" + exp.TxHash.StringLE(),
+				"counterparty": counterparty,
+				"transaction":  tx,
+			}, tr)
+		}
+	})
+	t.Run("field errors", func(t *testing.T) {
+		status, res := doGraphQLRequest(t, httpSrv.URL, `{ height block(index: 100500) { index } contract(id: "nope") { id } }`, nil)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, float64(chain.BlockHeight()), res.Data["height"])
+		require.Nil(t, res.Data["block"])
+		require.Nil(t, res.Data["contract"])
+		require.Equal(t, 2, len(res.Errors))
+		require.Equal(t, []interface{}{"block"}, res.Errors[0].Path)
+		require.Equal(t, []interface{}{"contract"}, res.Errors[1].Path)
+	})
+	t.Run("invalid queries", func(t *testing.T) {
+		for name, q := range map[string]string{
+			"syntax":        `{ height `,
+			"unknown field": `{ block(index: 1) { unknown } }`,
+			"depth":         `{ block(index: 10) { previous { previous { previous { previous { previous { previous { previous { previous { previous { index } } } } } } } } } } }`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				status, res := doGraphQLRequest(t, httpSrv.URL, q, nil)
+				require.Equal(t, http.StatusBadRequest, status)
+				require.Nil(t, res.Data)
+				require.Equal(t, 1, len(res.Errors))
+			})
+		}
+		t.Run("bad JSON", func(t *testing.T) {
+			resp, err := http.Post(httpSrv.URL+"/graphql", "application/json", strings.NewReader(`{"query": `))
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		})
+		t.Run("bad method", func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPut, httpSrv.URL+"/graphql", nil)
+			require.NoError(t, err)
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+			require.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+		})
+	})
+}
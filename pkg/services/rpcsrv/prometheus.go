@@ -52,4 +52,7 @@ func init() {
 	for call := range rpcWsHandlers {
 		regCounter(call)
 	}
+	for call := range rpcAdminHandlers {
+		regCounter(call)
+	}
 }
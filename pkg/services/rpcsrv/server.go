@@ -51,10 +51,12 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/util/slice"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -65,6 +67,7 @@ type (
 		AddBlock(block *block.Block) error
 		BlockHeight() uint32
 		CalculateClaimable(h util.Uint160, endHeight uint32) (*big.Int, error)
+		CancelSnapshot() bool
 		CurrentBlockHash() util.Uint256
 		FeePerByte() int64
 		ForEachNEP11Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error
@@ -98,6 +101,8 @@ type (
 		GetValidators() ([]*keys.PublicKey, error)
 		HeaderHeight() uint32
 		InitVerificationContext(ic *interop.Context, hash util.Uint160, witness *transaction.Witness) error
+		SeekStorage(id int32, rng storage.SeekRange, f func(k, v []byte) bool)
+		Snapshot(path string, height uint32) (<-chan error, error)
 		SubscribeForBlocks(ch chan *block.Block)
 		SubscribeForExecutions(ch chan *state.AppExecResult)
 		SubscribeForNotifications(ch chan *state.ContainedNotificationEvent)
@@ -198,6 +203,8 @@ const (
 var rpcHandlers = map[string]func(*Server, params.Params) (interface{}, *neorpc.Error){
 	"calculatenetworkfee":          (*Server).calculateNetworkFee,
 	"findstates":                   (*Server).findStates,
+	"findstorage":                  (*Server).findStorage,
+	"findstoragehistoric":          (*Server).findStorageHistoric,
 	"getapplicationlog":            (*Server).getApplicationLog,
 	"getbestblockhash":             (*Server).getBestBlockHash,
 	"getblock":                     (*Server).getBlock,
@@ -205,11 +212,13 @@ var rpcHandlers = map[string]func(*Server, params.Params) (interface{}, *neorpc.
 	"getblockhash":                 (*Server).getBlockHash,
 	"getblockheader":               (*Server).getBlockHeader,
 	"getblockheadercount":          (*Server).getBlockHeaderCount,
+	"getblocknotifications":        (*Server).getBlockNotifications,
 	"getblocksysfee":               (*Server).getBlockSysFee,
 	"getcandidates":                (*Server).getCandidates,
 	"getcommittee":                 (*Server).getCommittee,
 	"getconnectioncount":           (*Server).getConnectionCount,
 	"getcontractstate":             (*Server).getContractState,
+	"getmempooltransactions":       (*Server).getMempoolTransactions,
 	"getnativecontracts":           (*Server).getNativeContracts,
 	"getnep11balances":             (*Server).getNEP11Balances,
 	"getnep11properties":           (*Server).getNEP11Properties,
@@ -286,10 +295,26 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 			log.Info("SessionPoolSize is not set or wrong, setting default value", zap.Int("SessionPoolSize", defaultSessionPoolSize))
 		}
 	}
+	if conf.MaxFindStoragePageSize <= 0 {
+		conf.MaxFindStoragePageSize = config.DefaultMaxFindStoragePageSize
+		log.Info("MaxFindStoragePageSize is not set or wrong, setting default value", zap.Int("MaxFindStoragePageSize", config.DefaultMaxFindStoragePageSize))
+	}
+	if conf.MaxMempoolTransactionsPageSize <= 0 {
+		conf.MaxMempoolTransactionsPageSize = config.DefaultMaxMempoolTransactionsPageSize
+		log.Info("MaxMempoolTransactionsPageSize is not set or wrong, setting default value", zap.Int("MaxMempoolTransactionsPageSize", config.DefaultMaxMempoolTransactionsPageSize))
+	}
 	if conf.MaxWebSocketClients == 0 {
 		conf.MaxWebSocketClients = defaultMaxWebSocketClients
 		log.Info("MaxWebSocketClients is not set or wrong, setting default value", zap.Int("MaxWebSocketClients", defaultMaxWebSocketClients))
 	}
+	if conf.GraphQL.Enabled && conf.GraphQL.MaxDepth <= 0 {
+		conf.GraphQL.MaxDepth = defaultGraphQLMaxDepth
+		log.Info("GraphQL MaxDepth is not set or wrong, setting default value", zap.Int("MaxDepth", defaultGraphQLMaxDepth))
+	}
+	if conf.GraphQL.Enabled && conf.GraphQL.MaxNodes <= 0 {
+		conf.GraphQL.MaxNodes = defaultGraphQLMaxNodes
+		log.Info("GraphQL MaxNodes is not set or wrong, setting default value", zap.Int("MaxNodes", defaultGraphQLMaxNodes))
+	}
 	var oracleWrapped = new(atomic.Value)
 	if orc != nil {
 		oracleWrapped.Store(&orc)
@@ -340,6 +365,10 @@ func (s *Server) Start() {
 		s.log.Info("RPC server is not enabled")
 		return
 	}
+	if s.config.Admin.Enabled && len(s.config.Admin.Password) == 0 {
+		s.errChan <- errors.New("admin RPC methods are enabled, but no password is set")
+		return
+	}
 	if !s.started.CAS(false, true) {
 		s.log.Info("RPC server already started")
 		return
@@ -475,7 +504,7 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		s.subscribers[subscr] = true
 		s.subsLock.Unlock()
 		go s.handleWsWrites(ws, resChan, subChan)
-		s.handleWsReads(ws, resChan, subscr)
+		s.handleWsReads(ws, resChan, subscr, s.isAdmin(httpRequest))
 		return
 	}
 
@@ -486,6 +515,11 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		return
 	}
 
+	if s.config.GraphQL.Enabled && httpRequest.URL.Path == "/graphql" {
+		s.handleGraphQLRequest(w, httpRequest)
+		return
+	}
+
 	if httpRequest.Method != "POST" {
 		s.writeHTTPErrorResponse(
 			params.NewIn(),
@@ -501,7 +535,7 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		return
 	}
 
-	resp := s.handleRequest(req, nil)
+	resp := s.handleRequest(req, nil, s.isAdmin(httpRequest))
 	s.writeHTTPServerResponse(req, w, resp)
 }
 
@@ -518,20 +552,23 @@ func (s *Server) RegisterLocal(ctx context.Context, events chan<- neorpc.Notific
 	}
 }
 
-func (s *Server) handleRequest(req *params.Request, sub *subscriber) abstractResult {
+// handleRequest handles the given request, admin methods are only allowed to
+// be called if admin is true.
+func (s *Server) handleRequest(req *params.Request, sub *subscriber, admin bool) abstractResult {
 	if req.In != nil {
 		req.In.Method = escapeForLog(req.In.Method) // No valid method name will be changed by it.
-		return s.handleIn(req.In, sub)
+		return s.handleIn(req.In, sub, admin)
 	}
 	resp := make(abstractBatch, len(req.Batch))
 	for i, in := range req.Batch {
 		in.Method = escapeForLog(in.Method) // No valid method name will be changed by it.
-		resp[i] = s.handleIn(&in, sub)
+		resp[i] = s.handleIn(&in, sub, admin)
 	}
 	return resp
 }
 
 // handleInternal is an experimental interface to handle client requests directly.
+// Local clients are trusted, so they can use admin methods (if enabled).
 func (s *Server) handleInternal(req *neorpc.Request, sub *subscriber) (*neorpc.Response, error) {
 	var (
 		res    interface{}
@@ -560,6 +597,8 @@ func (s *Server) handleInternal(req *neorpc.Request, sub *subscriber) (*neorpc.R
 	handler, ok := rpcHandlers[req.Method]
 	if ok {
 		res, rpcRes.Error = handler(s, reqParams)
+	} else if handler, ok := s.adminHandler(req.Method); ok {
+		res, rpcRes.Error = handler(s, reqParams)
 	} else if sub != nil {
 		handler, ok := rpcWsHandlers[req.Method]
 		if ok {
@@ -576,7 +615,7 @@ func (s *Server) handleInternal(req *neorpc.Request, sub *subscriber) (*neorpc.R
 	return rpcRes, nil
 }
 
-func (s *Server) handleIn(req *params.In, sub *subscriber) abstract {
+func (s *Server) handleIn(req *params.In, sub *subscriber, admin bool) abstract {
 	var res interface{}
 	var resErr *neorpc.Error
 	if req.JSONRPC != neorpc.JSONRPCVersion {
@@ -596,6 +635,12 @@ func (s *Server) handleIn(req *params.In, sub *subscriber) abstract {
 	handler, ok := rpcHandlers[req.Method]
 	if ok {
 		res, resErr = handler(s, reqParams)
+	} else if handler, ok := s.adminHandler(req.Method); ok {
+		if admin {
+			res, resErr = handler(s, reqParams)
+		} else {
+			resErr = neorpc.ErrAccessDenied
+		}
 	} else if sub != nil {
 		handler, ok := rpcWsHandlers[req.Method]
 		if ok {
@@ -682,7 +727,7 @@ drainloop:
 	}
 }
 
-func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- abstractResult, subscr *subscriber) {
+func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- abstractResult, subscr *subscriber, admin bool) {
 	ws.SetReadLimit(s.wsReadLimit)
 	err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 	ws.SetPongHandler(func(string) error { return ws.SetReadDeadline(time.Now().Add(wsPongLimit)) })
@@ -693,7 +738,7 @@ requestloop:
 		if err != nil {
 			break
 		}
-		res := s.handleRequest(req, subscr)
+		res := s.handleRequest(req, subscr, admin)
 		res.RunForErrors(func(jsonErr *neorpc.Error) {
 			s.logRequestError(req, jsonErr)
 		})
@@ -837,6 +882,9 @@ func (s *Server) getPeers(_ params.Params) (interface{}, *neorpc.Error) {
 	peers.AddUnconnected(s.coreServer.UnconnectedPeers())
 	peers.AddConnected(s.coreServer.ConnectedPeers())
 	peers.AddBad(s.coreServer.BadPeers())
+	if bans := s.bannedPeers(); len(bans) > 0 {
+		peers.Banned = bans
+	}
 	return peers, nil
 }
 
@@ -857,6 +905,93 @@ func (s *Server) getRawMempool(reqParams params.Params) (interface{}, *neorpc.Er
 	}, nil
 }
 
+// getMempoolTransactions returns a page of memory pool transactions (optionally
+// filtered by sender) along with their priority data.
+func (s *Server) getMempoolTransactions(reqParams params.Params) (interface{}, *neorpc.Error) {
+	var (
+		sender   util.Uint160
+		bySender bool
+		offset   int
+		limit    = s.config.MaxMempoolTransactionsPageSize
+		err      error
+	)
+	if p := reqParams.Value(0); p != nil && !p.IsNull() {
+		sender, err = p.GetUint160FromAddressOrHex()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid sender: %s", err))
+		}
+		bySender = true
+	}
+	if p := reqParams.Value(1); p != nil {
+		offset, err = p.GetInt()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid offset: %s", err))
+		}
+		if offset < 0 {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "can't use negative offset")
+		}
+	}
+	if p := reqParams.Value(2); p != nil {
+		limit, err = p.GetInt()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid limit: %s", err))
+		}
+		if limit <= 0 {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "can't use negative or zero limit")
+		}
+		if limit > s.config.MaxMempoolTransactionsPageSize {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("limit exceeds the maximum of %d", s.config.MaxMempoolTransactionsPageSize))
+		}
+	}
+	txs := s.chain.GetMemPool().GetVerifiedTransactions()
+	res := &result.MempoolTransactions{
+		Height:       s.chain.BlockHeight(),
+		Count:        len(txs),
+		Transactions: []result.MempoolTransaction{},
+	}
+	if bySender {
+		res.Sender = &result.MempoolSender{
+			Balance: s.chain.GetUtilityTokenBalance(sender).Int64(),
+		}
+	}
+	var matched int
+	for i, tx := range txs {
+		if bySender {
+			if !tx.Sender().Equals(sender) {
+				continue
+			}
+			// Fees are counted for all sender's transactions, not just
+			// the ones on the page.
+			res.Sender.Fees += tx.SystemFee + tx.NetworkFee
+			res.Sender.Count++
+		}
+		matched++
+		if matched <= offset {
+			continue
+		}
+		if len(res.Transactions) == limit {
+			res.Truncated = true
+			if bySender {
+				continue
+			}
+			break
+		}
+		conflicts := []util.Uint256{}
+		for _, attr := range tx.GetAttributes(transaction.ConflictsT) {
+			conflicts = append(conflicts, attr.Value.(*transaction.Conflicts).Hash)
+		}
+		res.Transactions = append(res.Transactions, result.MempoolTransaction{
+			Transaction: *tx,
+			MempoolTransactionMetadata: result.MempoolTransactionMetadata{
+				Position:          i,
+				NetworkFeePerByte: tx.FeePerByte(),
+				Conflicts:         conflicts,
+			},
+		})
+	}
+	return res, nil
+}
+
 func (s *Server) validateAddress(reqParams params.Params) (interface{}, *neorpc.Error) {
 	param, err := reqParams.Value(0).GetString()
 	if err != nil {
@@ -963,6 +1098,81 @@ func (s *Server) getApplicationLog(reqParams params.Params) (interface{}, *neorp
 	return result.NewApplicationLog(hash, appExecResults, trig), nil
 }
 
+// getBlockNotifications returns all notifications emitted during the specified
+// block processing (both block-level and transaction-level executions),
+// optionally filtered by contract hash and/or event name.
+func (s *Server) getBlockNotifications(reqParams params.Params) (interface{}, *neorpc.Error) {
+	hash, respErr := s.blockHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	block, err := s.chain.GetBlock(hash)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownBlock, err.Error())
+	}
+
+	var filter interface{}
+	if p := reqParams.Value(1); p != nil {
+		jd := json.NewDecoder(bytes.NewReader(p.RawMessage))
+		jd.DisallowUnknownFields()
+		flt := new(neorpc.NotificationFilter)
+		if err := jd.Decode(flt); err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+		}
+		filter = *flt
+	}
+	f := feed{event: neorpc.NotificationEventID, filter: filter}
+
+	res := &result.BlockNotifications{
+		OnPersist:   []state.ContainedNotificationEvent{},
+		Application: []state.ContainedNotificationEvent{},
+		PostPersist: []state.ContainedNotificationEvent{},
+	}
+	aers, err := s.chain.GetAppExecResults(block.Hash(), trigger.OnPersist|trigger.PostPersist)
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get block application log: %s", err))
+	}
+	for i := range aers {
+		switch aers[i].Trigger {
+		case trigger.OnPersist:
+			res.OnPersist = appendMatchingNotifications(res.OnPersist, f, &aers[i])
+		case trigger.PostPersist:
+			res.PostPersist = appendMatchingNotifications(res.PostPersist, f, &aers[i])
+		}
+	}
+	for _, tx := range block.Transactions {
+		aers, err := s.chain.GetAppExecResults(tx.Hash(), trigger.Application)
+		if err != nil {
+			return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get application log for %s: %s", tx.Hash().StringLE(), err))
+		}
+		for i := range aers {
+			res.Application = appendMatchingNotifications(res.Application, f, &aers[i])
+		}
+	}
+	return res, nil
+}
+
+// appendMatchingNotifications appends notifications of the successful execution
+// aer that pass the filter f to dst.
+func appendMatchingNotifications(dst []state.ContainedNotificationEvent, f feed, aer *state.AppExecResult) []state.ContainedNotificationEvent {
+	if aer.VMState != vmstate.Halt {
+		return dst
+	}
+	for _, ev := range aer.Events {
+		ntf := state.ContainedNotificationEvent{
+			Container:         aer.Container,
+			NotificationEvent: ev,
+		}
+		if rpcevent.Matches(f, &neorpc.Notification{
+			Event:   neorpc.NotificationEventID,
+			Payload: []interface{}{&ntf},
+		}) {
+			dst = append(dst, ntf)
+		}
+	}
+	return dst
+}
+
 func (s *Server) getNEP11Tokens(h util.Uint160, acc util.Uint160, bw *io.BufBinWriter) ([]stackitem.Item, string, int, error) {
 	items, finalize, err := s.invokeReadOnlyMulti(bw, h, []string{"tokensOf", "symbol", "decimals"}, [][]interface{}{{acc}, nil, nil})
 	if err != nil {
@@ -1735,6 +1945,108 @@ func (s *Server) getStorage(ps params.Params) (interface{}, *neorpc.Error) {
 	return []byte(item), nil
 }
 
+func (s *Server) findStorage(ps params.Params) (interface{}, *neorpc.Error) {
+	id, respErr := s.contractIDFromParam(ps.Value(0))
+	if respErr == neorpc.ErrUnknown {
+		return nil, neorpc.NewRPCError("Unknown contract", "")
+	}
+	if respErr != nil {
+		return nil, respErr
+	}
+	rng, respErr := findStorageParams(ps, 1)
+	if respErr != nil {
+		return nil, respErr
+	}
+	return s.findStoragePage(rng.Prefix, func(f func(k, v []byte) bool) {
+		s.chain.SeekStorage(id, rng, f)
+	}), nil
+}
+
+func (s *Server) findStorageHistoric(ps params.Params) (interface{}, *neorpc.Error) {
+	nextH, respErr := s.getHistoricParams(ps)
+	if respErr != nil {
+		return nil, respErr
+	}
+	root, err := s.chain.GetStateModule().GetStateRoot(nextH - 1)
+	if err != nil {
+		return nil, neorpc.ErrUnknownStateRoot
+	}
+	var id int32
+	if csHash, err := ps.Value(1).GetUint160FromHex(); err == nil {
+		cs, respErr := s.getHistoricalContractState(root.Root, csHash)
+		if respErr != nil {
+			return nil, respErr
+		}
+		id = cs.ID
+	} else {
+		i, err := ps.Value(1).GetInt()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid contract hash or ID")
+		}
+		if err := checkInt32(i); err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+		}
+		id = int32(i)
+	}
+	rng, respErr := findStorageParams(ps, 2)
+	if respErr != nil {
+		return nil, respErr
+	}
+	prefix := rng.Prefix
+	rng.Prefix = makeStorageKey(id, prefix)
+	return s.findStoragePage(prefix, func(f func(k, v []byte) bool) {
+		s.chain.GetStateModule().SeekStates(root.Root, rng, f)
+	}), nil
+}
+
+// findStorageParams parses prefix and start key parameters of findstorage*
+// calls beginning at the given parameter index. Start key (if any) includes the
+// prefix, the same way findstates does it, it's cut in the resulting range.
+func findStorageParams(ps params.Params, idx int) (storage.SeekRange, *neorpc.Error) {
+	var rng storage.SeekRange
+	prefix, err := ps.Value(idx).GetBytesBase64()
+	if err != nil {
+		return rng, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid prefix: %s", err))
+	}
+	rng.Prefix = prefix
+	if len(ps) > idx+1 {
+		start, err := ps.Value(idx + 1).GetBytesBase64()
+		if err != nil {
+			return rng, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid start: %s", err))
+		}
+		if len(start) > 0 {
+			if !bytes.HasPrefix(start, prefix) {
+				return rng, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "start key doesn't match prefix")
+			}
+			rng.Start = start[len(prefix):]
+		}
+	}
+	return rng, nil
+}
+
+// findStoragePage collects MaxFindStoragePageSize items returned by seek
+// (restoring the prefix in keys), the key of the next item (if there is any)
+// is returned as Next.
+func (s *Server) findStoragePage(prefix []byte, seek func(f func(k, v []byte) bool)) result.FindStorage {
+	var res = result.FindStorage{Results: []result.KeyValue{}}
+	seek(func(k, v []byte) bool {
+		key := make([]byte, len(prefix)+len(k))
+		copy(key, prefix)
+		copy(key[len(prefix):], k)
+		if len(res.Results) == s.config.MaxFindStoragePageSize {
+			res.Truncated = true
+			res.Next = key
+			return false
+		}
+		res.Results = append(res.Results, result.KeyValue{
+			Key:   key,
+			Value: slice.Copy(v),
+		})
+		return true
+	})
+	return res
+}
+
 func (s *Server) getrawtransaction(reqParams params.Params) (interface{}, *neorpc.Error) {
 	txHash, err := reqParams.Value(0).GetUint256()
 	if err != nil {
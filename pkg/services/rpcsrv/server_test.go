@@ -10,6 +10,8 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
@@ -22,11 +24,14 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dboper"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
@@ -51,6 +56,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest"
 )
 
 type executor struct {
@@ -392,6 +398,11 @@ var rpcTestCases = map[string][]rpcTestCase{
 			params: `["` + testchain.PrivateKeyByID(0).Address() + `", "1", "2", "3", "jajaja"]`,
 			fail:   true,
 		},
+		{
+			name:   "null stop timestamp",
+			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0, null]`,
+			fail:   true,
+		},
 		{
 			name:   "positive",
 			params: `["` + testchain.PrivateKeyByID(0).Address() + `", 0]`,
@@ -491,6 +502,85 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"findstorage": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid contract",
+			params: `["notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown contract",
+			params: `["0000000000000000000000000000000000000000", "QQ=="]`,
+			fail:   true,
+		},
+		{
+			name:   "no prefix",
+			params: fmt.Sprintf(`["%s"]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "invalid prefix",
+			params: fmt.Sprintf(`["%s", "notabase64%%"]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "invalid start",
+			params: fmt.Sprintf(`["%s", "QQ==", "notabase64%%"]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "start doesn't match prefix",
+			params: fmt.Sprintf(`["%s", "QQ==", "Qg=="]`, testContractHash),
+			fail:   true,
+		},
+	},
+	"findstoragehistoric": {
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid index",
+			params: `["notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "no contract",
+			params: `[20]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid contract",
+			params: `[20, "notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "unknown contract",
+			params: `[20, "0000000000000000000000000000000000000000", "QQ=="]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid prefix",
+			params: fmt.Sprintf(`[20, "%s", "notabase64%%"]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "invalid start",
+			params: fmt.Sprintf(`[20, "%s", "QQ==", "notabase64%%"]`, testContractHash),
+			fail:   true,
+		},
+		{
+			name:   "start doesn't match prefix",
+			params: fmt.Sprintf(`[20, "%s", "QQ==", "Qg=="]`, testContractHash),
+			fail:   true,
+		},
+	},
 	"getstateheight": {
 		{
 			name:   "positive",
@@ -611,6 +701,91 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:   true,
 		},
 	},
+	"getblocknotifications": {
+		{
+			name:   "positive",
+			params: "[1]",
+			result: func(_ *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+
+				b, err := e.chain.GetBlock(e.chain.GetHeaderHash(1))
+				require.NoError(t, err)
+				require.NotEqual(t, 0, len(b.Transactions))
+				require.NotEqual(t, 0, len(res.OnPersist))
+				require.NotEqual(t, 0, len(res.Application))
+				require.NotEqual(t, 0, len(res.PostPersist))
+				for _, ntf := range res.OnPersist {
+					require.Equal(t, b.Hash(), ntf.Container)
+				}
+				for _, ntf := range res.PostPersist {
+					require.Equal(t, b.Hash(), ntf.Container)
+				}
+				for _, ntf := range res.Application {
+					var found bool
+					for _, tx := range b.Transactions {
+						if tx.Hash() == ntf.Container {
+							found = true
+							break
+						}
+					}
+					require.True(t, found)
+				}
+			},
+		},
+		{
+			name:   "positive, filter by contract and name",
+			params: `[1, {"contract":"0xd2a4cff31913016155e38e474a2c06d08be276cf", "name":"Transfer"}]`,
+			result: func(_ *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+
+				gasHash, err := e.chain.GetNativeContractScriptHash(nativenames.Gas)
+				require.NoError(t, err)
+				require.NotEqual(t, 0, len(res.PostPersist))
+				for _, ntfs := range [][]state.ContainedNotificationEvent{res.OnPersist, res.Application, res.PostPersist} {
+					for _, ntf := range ntfs {
+						require.Equal(t, gasHash, ntf.ScriptHash)
+						require.Equal(t, "Transfer", ntf.Name)
+					}
+				}
+			},
+		},
+		{
+			name:   "positive, filter by missing name",
+			params: `[1, {"name":"Unknown"}]`,
+			result: func(_ *executor) interface{} { return &result.BlockNotifications{} },
+			check: func(t *testing.T, e *executor, acc interface{}) {
+				res, ok := acc.(*result.BlockNotifications)
+				require.True(t, ok)
+				require.Equal(t, 0, len(res.OnPersist))
+				require.Equal(t, 0, len(res.Application))
+				require.Equal(t, 0, len(res.PostPersist))
+			},
+		},
+		{
+			name:   "no params",
+			params: `[]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid hash",
+			params: `["notahex"]`,
+			fail:   true,
+		},
+		{
+			name:   "missing hash",
+			params: `["` + util.Uint256{}.String() + `"]`,
+			fail:   true,
+		},
+		{
+			name:   "invalid filter",
+			params: `[1, {"state":"HALT"}]`,
+			fail:   true,
+		},
+	},
 	"getblockcount": {
 		{
 			params: "[]",
@@ -1737,6 +1912,206 @@ func TestSubmitOracle(t *testing.T) {
 	t.Run("Valid", runCase(t, false, pubStr, `1`, txSigStr, msgSigStr))
 }
 
+func TestCreateSnapshot(t *testing.T) {
+	call := func(params string) string {
+		return fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "createsnapshot", "params": %s}`, params)
+	}
+	var (
+		adminCfg = config.RPCAdmin{Enabled: true, User: "admin", Password: "secret"}
+		adminURL = func(u string) string { return strings.Replace(u, "://", "://admin:secret@", 1) }
+	)
+
+	t.Run("disabled", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.RPC.Admin = adminCfg
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		body := doRPCCallOverHTTP(call("[]"), adminURL(httpSrv.URL), t)
+		checkErrGetResult(t, body, true, "snapshots are disabled")
+	})
+	t.Run("not admin", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.RPC.Admin = adminCfg
+			c.ApplicationConfiguration.RPC.SnapshotPath = t.TempDir()
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		body := doRPCCallOverHTTP(call("[]"), httpSrv.URL, t)
+		checkErrGetResult(t, body, true, "Access denied")
+	})
+	t.Run("unsupported DB", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.RPC.Admin = adminCfg
+			c.ApplicationConfiguration.RPC.SnapshotPath = t.TempDir()
+		})
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		body := doRPCCallOverHTTP(call("[]"), adminURL(httpSrv.URL), t)
+		checkErrGetResult(t, body, true, "DB doesn't support snapshots")
+	})
+
+	cfg, err := config.Load("../../../config", netmode.UnitTestNet)
+	require.NoError(t, err)
+	cfg.ApplicationConfiguration.RPC.Admin = adminCfg
+	cfg.ApplicationConfiguration.RPC.SnapshotPath = t.TempDir()
+	ps, err := storage.NewLevelDBStore(dbconfig.LevelDBOptions{DataDirectoryPath: t.TempDir()})
+	require.NoError(t, err)
+	logger := zaptest.NewLogger(t)
+	chain, err := core.NewBlockchain(ps, cfg.Blockchain(), logger)
+	require.NoError(t, err)
+	go chain.Run()
+	chain, rpcSrv, httpSrv := wrapUnitTestChain(t, chain, nil, cfg, logger)
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+
+	blocks := getTestBlocks(t)
+	for _, b := range blocks[:3] {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	check := func(t *testing.T, params string, fail bool) *result.Snapshot {
+		body := doRPCCallOverHTTP(call(params), adminURL(httpSrv.URL), t)
+		res := checkErrGetResult(t, body, fail)
+		if fail {
+			return nil
+		}
+		snap := new(result.Snapshot)
+		require.NoError(t, json.Unmarshal(res, snap))
+		return snap
+	}
+	waitFor := func(t *testing.T, path string) {
+		require.Eventually(t, func() bool {
+			_, err := os.Stat(path)
+			return err == nil
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	check(t, `["notanumber"]`, true)
+	check(t, `[-1]`, true)
+	check(t, `[1]`, true) // Past block.
+
+	snap := check(t, `[]`, false)
+	require.Equal(t, uint32(3), snap.Height)
+	require.Equal(t, filepath.Join(cfg.ApplicationConfiguration.RPC.SnapshotPath, "snapshot-3"), snap.Path)
+	waitFor(t, snap.Path)
+
+	snap = check(t, `[5]`, false)
+	require.Equal(t, uint32(5), snap.Height)
+	check(t, `[6]`, true) // Another one is in progress.
+	for _, b := range blocks[3:6] {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	waitFor(t, snap.Path)
+
+	cancel := func(t *testing.T) bool {
+		body := doRPCCallOverHTTP(`{"jsonrpc": "2.0", "id": 1, "method": "cancelsnapshot", "params": []}`, adminURL(httpSrv.URL), t)
+		var ok bool
+		require.NoError(t, json.Unmarshal(checkErrGetResult(t, body, false), &ok))
+		return ok
+	}
+	require.False(t, cancel(t))
+	snap = check(t, `[10]`, false)
+	require.True(t, cancel(t))
+	require.Eventually(t, func() bool { return !cancel(t) }, 5*time.Second, 10*time.Millisecond)
+	snap = check(t, `[7]`, false) // A new one can be requested.
+	require.NoError(t, chain.AddBlock(blocks[6]))
+	waitFor(t, snap.Path)
+}
+
+func TestAdminMethods(t *testing.T) {
+	call := func(method string, params string) string {
+		return fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": %s}`, method, params)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		chain, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		body := doRPCCallOverHTTP(call("listbans", "[]"), httpSrv.URL, t)
+		checkErrGetResult(t, body, true, "Method not found")
+	})
+	t.Run("no password", func(t *testing.T) {
+		chain, rpcSrv, _ := initClearServerWithInMemoryChain(t)
+		defer chain.Close()
+		defer rpcSrv.Shutdown()
+
+		cfg := rpcSrv.config
+		cfg.Admin.Enabled = true
+		errCh := make(chan error, 1)
+		srv := New(chain, cfg, rpcSrv.coreServer, nil, rpcSrv.log, errCh)
+		srv.Start()
+		require.Error(t, <-errCh)
+	})
+
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.Admin = config.RPCAdmin{
+			Enabled:  true,
+			User:     "admin",
+			Password: "secret",
+		}
+	})
+	defer chain.Close()
+	defer rpcSrv.Shutdown()
+	adminURL := strings.Replace(httpSrv.URL, "://", "://admin:secret@", 1)
+
+	t.Run("access denied", func(t *testing.T) {
+		body := doRPCCallOverHTTP(call("listbans", "[]"), httpSrv.URL, t)
+		checkErrGetResult(t, body, true, "Access denied")
+		body = doRPCCallOverHTTP(call("listbans", "[]"), strings.Replace(httpSrv.URL, "://", "://admin:wrong@", 1), t)
+		checkErrGetResult(t, body, true, "Access denied")
+		body = doRPCCallOverWS(call("listbans", "[]"), httpSrv.URL, t)
+		checkErrGetResult(t, body, true, "Access denied")
+	})
+	t.Run("bans", func(t *testing.T) {
+		check := func(t *testing.T, method string, params string, fail bool) json.RawMessage {
+			body := doRPCCallOverHTTP(call(method, params), adminURL, t)
+			return checkErrGetResult(t, body, fail)
+		}
+		check(t, "banpeer", `[]`, true)
+		check(t, "banpeer", `["localhost"]`, true)
+		check(t, "banpeer", `["1.2.3.4", -1]`, true)
+		check(t, "banpeer", `["1.2.3.4", "bad"]`, true)
+		check(t, "banpeer", `["1.2.3.4:10333", 60, "spam"]`, false)
+		check(t, "banpeer", `["[::1]:10333"]`, false)
+
+		var bans []result.BannedPeer
+		require.NoError(t, json.Unmarshal(check(t, "listbans", `[]`, false), &bans))
+		require.Equal(t, 2, len(bans))
+		require.Equal(t, "1.2.3.4", bans[0].Address)
+		require.Equal(t, "spam", bans[0].Reason)
+		require.InDelta(t, uint64(time.Now().Add(time.Minute).UnixMilli()), bans[0].Until, float64(10*time.Second/time.Millisecond))
+		require.Equal(t, "::1", bans[1].Address)
+		require.Equal(t, "banned by admin", bans[1].Reason)
+
+		var peers result.GetPeers
+		require.NoError(t, json.Unmarshal(check(t, "getpeers", `[]`, false), &peers))
+		require.Equal(t, bans, peers.Banned)
+
+		// Banned peers can't be added.
+		check(t, "addpeer", `["1.2.3.4:10333"]`, true)
+
+		var ok bool
+		require.NoError(t, json.Unmarshal(check(t, "unbanpeer", `["1.2.3.4"]`, false), &ok))
+		require.True(t, ok)
+		require.NoError(t, json.Unmarshal(check(t, "unbanpeer", `["1.2.3.4"]`, false), &ok))
+		require.False(t, ok)
+		require.NoError(t, json.Unmarshal(check(t, "listbans", `[]`, false), &bans))
+		require.Equal(t, 1, len(bans))
+	})
+	t.Run("peers", func(t *testing.T) {
+		body := doRPCCallOverHTTP(call("addpeer", `["not an address"]`), adminURL, t)
+		checkErrGetResult(t, body, true)
+		body = doRPCCallOverHTTP(call("addpeer", `[]`), adminURL, t)
+		checkErrGetResult(t, body, true)
+		body = doRPCCallOverHTTP(call("disconnectpeer", `["127.0.0.1:1"]`), adminURL, t)
+		checkErrGetResult(t, body, true, "not connected")
+	})
+}
+
 func TestSubmitNotaryRequest(t *testing.T) {
 	rpc := `{"jsonrpc": "2.0", "id": 1, "method": "submitnotaryrequest", "params": %s}`
 
@@ -2104,6 +2479,78 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 			testGetState(t, params, base64.StdEncoding.EncodeToString([]byte("newtestvalue")))
 		})
 	})
+	t.Run("findstorage", func(t *testing.T) {
+		testFindStorage := func(t *testing.T, method string, p string, expected result.FindStorage) {
+			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "%s", "params": [%s]}`, method, p)
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			rawRes := checkErrGetResult(t, body, false)
+
+			var actual result.FindStorage
+			require.NoError(t, json.Unmarshal(rawRes, &actual))
+			require.Equal(t, expected, actual)
+		}
+		prefix := base64.StdEncoding.EncodeToString([]byte("aa"))
+		t.Run("current", func(t *testing.T) {
+			testFindStorage(t, "findstorage", fmt.Sprintf(`"%s", "%s"`, testContractHash, prefix), result.FindStorage{
+				Results: []result.KeyValue{
+					{Key: []byte("aa"), Value: []byte("v1")},
+					{Key: []byte("aa10"), Value: []byte("v2")},
+					{Key: []byte("aa50"), Value: []byte("v3")},
+				},
+			})
+		})
+		t.Run("current, start", func(t *testing.T) {
+			start := base64.StdEncoding.EncodeToString([]byte("aa10"))
+			testFindStorage(t, "findstorage", fmt.Sprintf(`"%s", "%s", "%s"`, testContractHash, prefix, start), result.FindStorage{
+				Results: []result.KeyValue{
+					{Key: []byte("aa10"), Value: []byte("v2")},
+					{Key: []byte("aa50"), Value: []byte("v3")},
+				},
+			})
+		})
+		t.Run("current, missing start", func(t *testing.T) {
+			start := base64.StdEncoding.EncodeToString([]byte("aa2"))
+			testFindStorage(t, "findstorage", fmt.Sprintf(`"%s", "%s", "%s"`, testContractHash, prefix, start), result.FindStorage{
+				Results: []result.KeyValue{
+					{Key: []byte("aa50"), Value: []byte("v3")},
+				},
+			})
+		})
+		t.Run("current, empty start", func(t *testing.T) {
+			testFindStorage(t, "findstorage", fmt.Sprintf(`"%s", "%s", ""`, testContractHash, prefix), result.FindStorage{
+				Results: []result.KeyValue{
+					{Key: []byte("aa"), Value: []byte("v1")},
+					{Key: []byte("aa10"), Value: []byte("v2")},
+					{Key: []byte("aa50"), Value: []byte("v3")},
+				},
+			})
+		})
+		t.Run("historic", func(t *testing.T) {
+			// pairs for this test where put to the contract storage at block #16,
+			// MPT traversal order is the same as for findstates.
+			testFindStorage(t, "findstoragehistoric", fmt.Sprintf(`16, "%s", "%s"`, testContractHash, prefix), result.FindStorage{
+				Results: []result.KeyValue{
+					{Key: []byte("aa10"), Value: []byte("v2")},
+					{Key: []byte("aa50"), Value: []byte("v3")},
+					{Key: []byte("aa"), Value: []byte("v1")},
+				},
+			})
+		})
+		t.Run("historic, start", func(t *testing.T) {
+			// start key is handled the same way as for findstates.
+			start := base64.StdEncoding.EncodeToString([]byte("aa50"))
+			testFindStorage(t, "findstoragehistoric", fmt.Sprintf(`16, "%s", "%s", "%s"`, testContractHash, prefix, start), result.FindStorage{
+				Results: []result.KeyValue{
+					{Key: []byte("aa50"), Value: []byte("v3")},
+				},
+			})
+		})
+		t.Run("historic, no data", func(t *testing.T) {
+			testFindStorage(t, "findstoragehistoric", fmt.Sprintf(`15, "%s", "%s"`, testContractHash, prefix), result.FindStorage{
+				Results: []result.KeyValue{},
+			})
+		})
+	})
 	t.Run("findstates", func(t *testing.T) {
 		testFindStates := func(t *testing.T, p string, root util.Uint256, expected result.FindStates) {
 			rpc := fmt.Sprintf(`{"jsonrpc": "2.0", "id": 1, "method": "findstates", "params": [%s]}`, p)
@@ -2333,6 +2780,111 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 		assert.ElementsMatch(t, expected, actual)
 	})
 
+	t.Run("getmempooltransactions", func(t *testing.T) {
+		mp := chain.GetMemPool()
+		sender := testchain.PrivateKeyByID(0).GetScriptHash()
+		conflict := util.Uint256{1, 2, 3}
+		low := transaction.New([]byte{byte(opcode.PUSH1)}, 1)
+		low.NetworkFee = 100
+		low.Signers = []transaction.Signer{{Account: sender}}
+		low.Attributes = []transaction.Attribute{{Type: transaction.ConflictsT, Value: &transaction.Conflicts{Hash: conflict}}}
+		high := transaction.New([]byte{byte(opcode.PUSH2)}, 2)
+		high.NetworkFee = 100000
+		high.Signers = []transaction.Signer{{Account: sender}}
+		require.NoError(t, mp.Add(low, &FeerStub{}))
+		require.NoError(t, mp.Add(high, &FeerStub{}))
+
+		call := func(t *testing.T, params string) *result.MempoolTransactions {
+			rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getmempooltransactions", "params": [` + params + `]}`
+			body := doRPCCall(rpc, httpSrv.URL, t)
+			res := checkErrGetResult(t, body, false)
+			actual := new(result.MempoolTransactions)
+			require.NoErrorf(t, json.Unmarshal(res, actual), "could not parse response: %s", res)
+			return actual
+		}
+		t.Run("all", func(t *testing.T) {
+			actual := call(t, "")
+			expected := mp.GetVerifiedTransactions()
+			require.Equal(t, chain.BlockHeight(), actual.Height)
+			require.Equal(t, len(expected), actual.Count)
+			require.Equal(t, len(expected), len(actual.Transactions))
+			require.Nil(t, actual.Sender)
+			for i, tx := range actual.Transactions {
+				require.Equal(t, i, tx.Position)
+				require.Equal(t, expected[i].Hash(), tx.Hash())
+				require.Equal(t, expected[i].FeePerByte(), tx.NetworkFeePerByte)
+			}
+		})
+		t.Run("by sender", func(t *testing.T) {
+			actual := call(t, `"`+address.Uint160ToString(sender)+`"`)
+			require.Equal(t, mp.Count(), actual.Count)
+			require.Equal(t, &result.MempoolSender{
+				Balance: chain.GetUtilityTokenBalance(sender).Int64(),
+				Fees:    low.SystemFee + low.NetworkFee + high.SystemFee + high.NetworkFee,
+				Count:   2,
+			}, actual.Sender)
+			require.Equal(t, 2, len(actual.Transactions))
+			require.Equal(t, high.Hash(), actual.Transactions[0].Hash())
+			require.Equal(t, []util.Uint256{}, actual.Transactions[0].Conflicts)
+			require.Equal(t, low.Hash(), actual.Transactions[1].Hash())
+			require.Equal(t, []util.Uint256{conflict}, actual.Transactions[1].Conflicts)
+			require.Equal(t, low.FeePerByte(), actual.Transactions[1].NetworkFeePerByte)
+			require.Less(t, actual.Transactions[0].Position, actual.Transactions[1].Position)
+		})
+		t.Run("unknown sender", func(t *testing.T) {
+			actual := call(t, `"`+util.Uint160{3, 2, 1}.StringLE()+`"`)
+			require.Equal(t, 0, len(actual.Transactions))
+			require.Equal(t, &result.MempoolSender{}, actual.Sender)
+		})
+		t.Run("by sender, limit", func(t *testing.T) {
+			actual := call(t, `"`+address.Uint160ToString(sender)+`", 0, 1`)
+			require.True(t, actual.Truncated)
+			require.Equal(t, 1, len(actual.Transactions))
+			require.Equal(t, high.Hash(), actual.Transactions[0].Hash())
+			// Fees of all sender's transactions are counted.
+			require.Equal(t, low.SystemFee+low.NetworkFee+high.SystemFee+high.NetworkFee, actual.Sender.Fees)
+			require.Equal(t, 2, actual.Sender.Count)
+		})
+		t.Run("by sender, offset", func(t *testing.T) {
+			actual := call(t, `"`+address.Uint160ToString(sender)+`", 1, 1`)
+			require.False(t, actual.Truncated)
+			require.Equal(t, 1, len(actual.Transactions))
+			require.Equal(t, low.Hash(), actual.Transactions[0].Hash())
+			require.Equal(t, low.SystemFee+low.NetworkFee+high.SystemFee+high.NetworkFee, actual.Sender.Fees)
+		})
+		t.Run("null sender, offset and limit", func(t *testing.T) {
+			expected := mp.GetVerifiedTransactions()
+			actual := call(t, `null, 1, 1`)
+			require.Nil(t, actual.Sender)
+			require.Equal(t, len(expected) > 2, actual.Truncated)
+			require.Equal(t, 1, len(actual.Transactions))
+			require.Equal(t, 1, actual.Transactions[0].Position)
+			require.Equal(t, expected[1].Hash(), actual.Transactions[0].Hash())
+		})
+		t.Run("offset past the end", func(t *testing.T) {
+			actual := call(t, `null, 100500`)
+			require.False(t, actual.Truncated)
+			require.Equal(t, 0, len(actual.Transactions))
+		})
+		for name, tc := range map[string]struct {
+			params string
+			errMsg string
+		}{
+			"invalid sender":  {`"notanaddress"`, "invalid sender"},
+			"invalid offset":  {`null, "one"`, "invalid offset"},
+			"negative offset": {`null, -1`, "negative offset"},
+			"invalid limit":   {`null, 0, "one"`, "invalid limit"},
+			"zero limit":      {`null, 0, 0`, "zero limit"},
+			"too big limit":   {`null, 0, 101`, "limit exceeds the maximum of 100"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				rpc := `{"jsonrpc": "2.0", "id": 1, "method": "getmempooltransactions", "params": [` + tc.params + `]}`
+				body := doRPCCall(rpc, httpSrv.URL, t)
+				checkErrGetResult(t, body, true, tc.errMsg)
+			})
+		}
+	})
+
 	t.Run("getnep17transfers", func(t *testing.T) {
 		testNEP17T := func(t *testing.T, start, stop, limit, page int, sent, rcvd []int) {
 			ps := []string{`"` + testchain.PrivateKeyByID(0).Address() + `"`}
@@ -3312,7 +3864,7 @@ func BenchmarkHandleIn(b *testing.B) {
 				b.FailNow()
 			}
 
-			res := rpcServer.handleIn(in, nil)
+			res := rpcServer.handleIn(in, nil, false)
 			if res.Error != nil {
 				b.FailNow()
 			}
@@ -103,7 +103,11 @@ func (s *service) sendValidatedRoot(r *state.MPTRoot, acc *wallet.Account) {
 			VerificationScript: acc.GetVerificationScript(),
 		},
 	}
-	sig := acc.SignHashable(s.Network, ep)
+	sig, err := acc.SignHashableErr(s.Network, ep)
+	if err != nil {
+		s.log.Error("failed to sign state root message", zap.Uint32("height", r.Index), zap.Error(err))
+		return
+	}
 	buf := io.NewBufBinWriter()
 	emit.Bytes(buf.BinWriter, sig)
 	ep.Witness.InvocationScript = buf.Bytes()
@@ -2,6 +2,7 @@ package stateroot
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/remotesigner"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 )
@@ -104,11 +106,15 @@ func New(cfg config.StateRoot, sm *stateroot.Module, log *zap.Logger, bc Ledger,
 		}
 
 		haveAccount := false
-		for _, acc := range s.wallet.Accounts {
-			if err := acc.Decrypt(w.Password, s.wallet.Scrypt); err == nil {
-				haveAccount = true
-				break
+		if len(w.RemoteSigner) > 0 {
+			n, err := remotesigner.Attach(s.wallet, w.RemoteSigner)
+			if err != nil {
+				return nil, fmt.Errorf("can't use remote signer: %w", err)
 			}
+			haveAccount = n > 0
+		}
+		for i := 0; !haveAccount && i < len(s.wallet.Accounts); i++ {
+			haveAccount = s.wallet.Accounts[i].Decrypt(w.Password, s.wallet.Scrypt) == nil
 		}
 		if !haveAccount {
 			return nil, errors.New("no wallet account could be unlocked")
@@ -1,6 +1,7 @@
 package stateroot
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -89,7 +90,10 @@ func (s *service) signAndSend(r *state.MPTRoot) error {
 		return nil
 	}
 
-	sig := acc.SignHashable(s.Network, r)
+	sig, err := acc.SignHashableErr(s.Network, r)
+	if err != nil {
+		return fmt.Errorf("failed to sign state root: %w", err)
+	}
 	incRoot := s.getIncompleteRoot(r.Index, myIndex)
 	incRoot.Lock()
 	defer incRoot.Unlock()
@@ -119,7 +123,10 @@ func (s *service) signAndSend(r *state.MPTRoot) error {
 			VerificationScript: acc.GetVerificationScript(),
 		},
 	}
-	sig = acc.SignHashable(s.Network, e)
+	sig, err = acc.SignHashableErr(s.Network, e)
+	if err != nil {
+		return fmt.Errorf("failed to sign vote: %w", err)
+	}
 	buf := io.NewBufBinWriter()
 	emit.Bytes(buf.BinWriter, sig)
 	e.Witness.InvocationScript = buf.Bytes()
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 	"text/template"
+	"unicode"
 
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
@@ -116,6 +117,14 @@ type {{toTypeName $name}} struct {
 {{- end}}
 }
 {{end -}}
+{{range $e := .CustomEvents}}
+// {{$e.Name}} represents "{{$e.ManifestName}}" event emitted by the contract.
+type {{$e.Name}} struct {
+{{- range $p := $e.Parameters}}
+	{{.Name}} {{.Type}}
+{{- end}}
+}
+{{end -}}
 {{if .HasReader}}// Invoker is used by ContractReader to call various safe methods.
 type Invoker interface {
 {{if or .IsNep11D .IsNep11ND}}	nep11.Invoker
@@ -151,6 +160,15 @@ type Actor interface {
 {{end -}}
 }
 
+{{end -}}
+{{if len .CustomEvents}}// EventReceiver is used by Receive*Events functions to subscribe for contract
+// events, it's implemented by rpcclient.WSClient.
+type EventReceiver interface {
+	Context() context.Context
+	ReceiveExecutionNotifications(flt *neorpc.NotificationFilter, rcvr chan<- *state.ContainedNotificationEvent) (string, error)
+	Unsubscribe(id string) error
+}
+
 {{end -}}
 {{if .HasReader}}// ContractReader implements safe contract methods.
 type ContractReader struct {
@@ -241,14 +259,151 @@ func itemTo{{toTypeName $name}}(item stackitem.Item, err error) (*{{toTypeName $
 {{end}}
 	return res, err
 }
+{{end}}
+{{- range $e := .CustomEvents}}
+// {{$e.Name}}Filter returns a filter for "{{$e.ManifestName}}" events of the
+// contract that can be used to subscribe for them via WSClient's
+// ReceiveExecutionNotifications.
+func {{$e.Name}}Filter() *neorpc.NotificationFilter {
+	var (
+		hash = Hash
+		name = "{{$e.ManifestName}}"
+	)
+	return &neorpc.NotificationFilter{Contract: &hash, Name: &name}
+}
+
+// {{$e.Name}}FromNotification converts the given notification (like the one
+// received via WSClient subscription made with {{$e.Name}}Filter) into
+// {{$e.Name}}. It returns an error if the notification is not a
+// "{{$e.ManifestName}}" event of the contract or if it can't be converted.
+func {{$e.Name}}FromNotification(ne *state.NotificationEvent) (*{{$e.Name}}, error) {
+	if ne.ScriptHash != Hash || ne.Name != "{{$e.ManifestName}}" {
+		return nil, fmt.Errorf("unexpected %s event from %s", ne.Name, ne.ScriptHash.StringLE())
+	}
+	var res = new({{$e.Name}})
+	err := res.FromStackItem(ne.Item)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// Receive{{$e.Name}}s subscribes for "{{$e.ManifestName}}" events of the
+// contract using the given EventReceiver and sends them (converted into
+// {{$e.Name}}) to the given channel until the context is done or the
+// subscription is dropped by the client (like when the connection is lost).
+// Notifications that can't be converted are skipped. The channel is closed
+// when receiving stops, subscription is removed at this point as well.
+func Receive{{$e.Name}}s(ctx context.Context, c EventReceiver, ch chan<- *{{$e.Name}}) error {
+	var rcvr = make(chan *state.ContainedNotificationEvent)
+	id, err := c.ReceiveExecutionNotifications({{$e.Name}}Filter(), rcvr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		defer close(ch)
+		for {
+			select {
+			case ne, ok := <-rcvr:
+				if !ok {
+					return
+				}
+				event, err := {{$e.Name}}FromNotification(&ne.NotificationEvent)
+				if err != nil {
+					continue
+				}
+				select {
+				case ch <- event:
+				case <-ctx.Done():
+				}
+			case <-c.Context().Done():
+				return
+			case <-ctx.Done():
+				var unsubbed = make(chan struct{})
+				go func() {
+					_ = c.Unsubscribe(id)
+					close(unsubbed)
+				}()
+				// The receiver must be drained until it's removed.
+				for {
+					select {
+					case _, ok := <-rcvr:
+						if !ok {
+							rcvr = nil
+						}
+					case <-unsubbed:
+						return
+					}
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Parse{{$e.Name}}sFromApplicationLog retrieves all "{{$e.ManifestName}}"
+// events emitted by the contract from the given application log.
+func Parse{{$e.Name}}sFromApplicationLog(log *result.ApplicationLog) ([]*{{$e.Name}}, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*{{$e.Name}}
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.ScriptHash != Hash || e.Name != "{{$e.ManifestName}}" {
+				continue
+			}
+			event := new({{$e.Name}})
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("execution %d, event %d: %w", i, j, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts the given event state item into {{$e.Name}}, it
+// returns an error if that's not possible. Null parameters are left as zero
+// values of the corresponding fields.
+func (e *{{$e.Name}}) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != {{len $e.Parameters}} {
+		return errors.New("wrong number of event parameters")
+	}
+{{if len $e.Parameters}}
+	var (
+		index = -1
+		err   error
+	)
+{{- range $p := $e.Parameters}}
+	index++
+	if _, ok := arr[index].(stackitem.Null); !ok {
+		e.{{.Name}}, err = {{addIndent (etTypeConverter .ExtType "arr[index]") "\t"}}
+		if err != nil {
+			return fmt.Errorf("field {{.Name}}: %w", err)
+		}
+	}
+{{end}}
+{{end}}
+	return nil
+}
 {{end}}`
 
 type (
 	ContractTmpl struct {
 		binding.ContractTmpl
 
-		SafeMethods []SafeMethodTmpl
-		NamedTypes  map[string]binding.ExtendedType
+		SafeMethods  []SafeMethodTmpl
+		NamedTypes   map[string]binding.ExtendedType
+		CustomEvents []EventTmpl
 
 		IsNep11D  bool
 		IsNep11ND bool
@@ -265,6 +420,17 @@ type (
 		ItemTo         string
 		ExtendedReturn binding.ExtendedType
 	}
+
+	EventTmpl struct {
+		Name         string
+		ManifestName string
+		Parameters   []EventParamTmpl
+	}
+
+	EventParamTmpl struct {
+		binding.ParamTmpl
+		ExtType binding.ExtendedType
+	}
 )
 
 // NewConfig initializes and returns a new config instance.
@@ -388,6 +554,9 @@ func extendedTypeToGo(et binding.ExtendedType, named map[string]binding.Extended
 		return "[]interface{}", ""
 
 	case smartcontract.MapType:
+		if et.Value == nil {
+			return "*stackitem.Map", "github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+		}
 		kt, _ := extendedTypeToGo(binding.ExtendedType{Base: et.Key}, named)
 		vt, _ := extendedTypeToGo(*et.Value, named)
 		return "map[" + kt + "]" + vt, "github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -484,6 +653,15 @@ func etTypeConverter(et binding.ExtendedType, v string) string {
 		}, v)
 
 	case smartcontract.MapType:
+		if et.Value == nil {
+			return `func (item stackitem.Item) (*stackitem.Map, error) {
+		m, ok := item.(*stackitem.Map)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a map", item.Type().String())
+		}
+		return m, nil
+	} (` + v + `)`
+		}
 		at, _ := extendedTypeToGo(et, nil)
 		return `func (item stackitem.Item) (` + at + `, error) {
 		m, ok := item.Value().([]stackitem.MapElement)
@@ -552,6 +730,50 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 		imports["errors"] = struct{}{}
 	}
 
+	var eventTypes = make(map[string]bool)
+	for _, e := range cfg.Manifest.ABI.Events {
+		var (
+			etmpl = EventTmpl{
+				Name:         toIdentifier(e.Name) + "Event",
+				ManifestName: e.Name,
+			}
+			fields = make(map[string]bool)
+		)
+		for eventTypes[etmpl.Name] {
+			etmpl.Name = etmpl.Name + "_"
+		}
+		eventTypes[etmpl.Name] = true
+		for _, p := range e.Parameters {
+			var (
+				et   = binding.ExtendedType{Base: p.Type}
+				name = toIdentifier(p.Name)
+			)
+			for fields[name] {
+				name = name + "_"
+			}
+			fields[name] = true
+			addETImports(et, ctr.NamedTypes, imports)
+			typ, _ := extendedTypeToGo(et, ctr.NamedTypes)
+			etmpl.Parameters = append(etmpl.Parameters, EventParamTmpl{
+				ParamTmpl: binding.ParamTmpl{
+					Name: name,
+					Type: typ,
+				},
+				ExtType: et,
+			})
+		}
+		ctr.CustomEvents = append(ctr.CustomEvents, etmpl)
+	}
+	if len(ctr.CustomEvents) > 0 {
+		imports["context"] = struct{}{}
+		imports["errors"] = struct{}{}
+		imports["fmt"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/core/state"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/neorpc"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/neorpc/result"] = struct{}{}
+		imports["github.com/nspcc-dev/neo-go/pkg/vm/stackitem"] = struct{}{}
+	}
+
 	for i := range ctr.SafeMethods {
 		switch ctr.SafeMethods[i].ReturnType {
 		case "interface{}":
@@ -677,6 +899,31 @@ func toTypeName(s string) string {
 	}, strings.ToUpper(s[0:1])+s[1:])
 }
 
+// toIdentifier converts an arbitrary name from the manifest into an exported
+// Go identifier. Characters that can't be used in identifiers are dropped and
+// the letter following them is capitalized.
+func toIdentifier(s string) string {
+	var (
+		res   = make([]rune, 0, len(s))
+		upper = true
+	)
+	for _, r := range s {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		res = append(res, r)
+	}
+	if len(res) == 0 || !unicode.IsLetter(res[0]) {
+		res = append([]rune{'X'}, res...)
+	}
+	return string(res)
+}
+
 func addIndent(str string, ind string) string {
 	return strings.ReplaceAll(str, "\n", "\n"+ind)
 }
@@ -283,6 +283,24 @@ func (c *Context) DumpArgumentsSlot() string {
 	return dumpSlot(&c.arguments)
 }
 
+// StaticSlot returns a copy of the static slot contents (nil if the slot is
+// not initialized).
+func (c *Context) StaticSlot() []stackitem.Item {
+	return c.sc.static.items()
+}
+
+// LocalSlot returns a copy of the local slot contents (nil if the slot is not
+// initialized).
+func (c *Context) LocalSlot() []stackitem.Item {
+	return c.local.items()
+}
+
+// ArgumentsSlot returns a copy of the arguments slot contents (nil if the slot
+// is not initialized).
+func (c *Context) ArgumentsSlot() []stackitem.Item {
+	return c.arguments.items()
+}
+
 // dumpSlot returns json formatted representation of the given slot.
 func dumpSlot(s *slot) string {
 	if s == nil || *s == nil {
@@ -43,6 +43,19 @@ func (s slot) ClearRefs(refs *refCounter) {
 	}
 }
 
+// items returns a copy of the slot contents, unset items are represented by
+// Null.
+func (s slot) items() []stackitem.Item {
+	if s == nil {
+		return nil
+	}
+	res := make([]stackitem.Item, len(s))
+	for i := range s {
+		res[i] = s.Get(i)
+	}
+	return res
+}
+
 // Size returns the slot size.
 func (s slot) Size() int {
 	if s == nil {
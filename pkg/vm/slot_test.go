@@ -25,3 +25,17 @@ func TestSlot_Get(t *testing.T) {
 	require.Equal(t, stackitem.NewBigInteger(big.NewInt(42)), s.Get(1))
 	require.Equal(t, 3, int(*rc))
 }
+
+func TestSlot_Items(t *testing.T) {
+	rc := newRefCounter()
+	var s slot
+	require.Nil(t, s.items())
+
+	s.init(2, rc)
+	s.Set(1, stackitem.NewBigInteger(big.NewInt(42)), rc)
+	items := s.items()
+	require.Equal(t, []stackitem.Item{stackitem.Null{}, stackitem.NewBigInteger(big.NewInt(42))}, items)
+
+	items[0] = stackitem.NewBool(true)
+	require.Equal(t, stackitem.Null{}, s.Get(0))
+}
@@ -89,8 +89,15 @@ type VM struct {
 
 	// invTree is a top-level invocation tree (if enabled).
 	invTree *invocations.Tree
+
+	// onExecHook is called before every instruction execution (if set).
+	onExecHook OnExecHook
 }
 
+// OnExecHook is a function that is called before every instruction execution
+// with the script hash of the current context, instruction offset and opcode.
+type OnExecHook func(scriptHash util.Uint160, offset int, op opcode.Opcode)
+
 var (
 	bigMinusOne = big.NewInt(-1)
 	bigZero     = big.NewInt(0)
@@ -121,6 +128,13 @@ func (v *VM) SetPriceGetter(f func(opcode.Opcode, []byte) int64) {
 	v.getPrice = f
 }
 
+// SetOnExecHook registers the given OnExecHook in v, nil value removes the
+// hook. It's intended for debugging/testing purposes like code coverage
+// collection.
+func (v *VM) SetOnExecHook(h OnExecHook) {
+	v.onExecHook = h
+}
+
 // Reset allows to reuse existing VM for subsequent executions making them somewhat
 // more efficient. It reuses invocation and evaluation stacks as well as VM structure
 // itself.
@@ -137,6 +151,7 @@ func (v *VM) Reset(t trigger.Type) {
 	v.LoadToken = nil
 	v.trigger = t
 	v.invTree = nil
+	v.onExecHook = nil
 }
 
 // GasConsumed returns the amount of GAS consumed during execution.
@@ -469,6 +484,9 @@ func (v *VM) step(ctx *Context) error {
 		v.state = vmstate.Fault
 		return newError(ctx.ip, op, err)
 	}
+	if v.onExecHook != nil {
+		v.onExecHook(ctx.ScriptHash(), ctx.IP(), op)
+	}
 	return v.execute(ctx, op, param)
 }
 
@@ -14,10 +14,12 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -97,6 +99,29 @@ func TestVM_SetPriceGetter(t *testing.T) {
 	})
 }
 
+func TestVM_SetOnExecHook(t *testing.T) {
+	prog := makeProgram(opcode.PUSH1, opcode.PUSH2, opcode.ADD)
+	v := load(prog)
+
+	var (
+		offsets []int
+		ops     []opcode.Opcode
+	)
+	v.SetOnExecHook(func(h util.Uint160, offset int, op opcode.Opcode) {
+		require.Equal(t, hash.Hash160(prog), h)
+		offsets = append(offsets, offset)
+		ops = append(ops, op)
+	})
+	runVM(t, v)
+	require.Equal(t, []int{0, 1, 2, 3}, offsets)
+	require.Equal(t, []opcode.Opcode{opcode.PUSH1, opcode.PUSH2, opcode.ADD, opcode.RET}, ops)
+
+	v.Reset(trigger.Application)
+	v.LoadScript(prog)
+	runVM(t, v)
+	require.Equal(t, 4, len(offsets))
+}
+
 func TestAddGas(t *testing.T) {
 	v := newTestVM()
 	v.GasLimit = 10
@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 )
 
@@ -20,6 +22,9 @@ type Account struct {
 	// NEO private key.
 	privateKey *keys.PrivateKey
 
+	// External signer used instead of the private key (if set).
+	signer Signer
+
 	// Script hash corresponding to the Address.
 	scriptHash util.Uint160
 
@@ -111,10 +116,10 @@ func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
 	if len(a.Contract.Parameters) == 0 {
 		return nil
 	}
-	if a.privateKey == nil {
-		return errors.New("account key is not available (need to decrypt?)")
+	sign, err := a.sign(net, t)
+	if err != nil {
+		return err
 	}
-	sign := a.privateKey.SignHashable(uint32(net), t)
 
 	invoc := append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, sign...)
 	if len(a.Contract.Parameters) == 1 {
@@ -127,18 +132,85 @@ func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
 }
 
 // SignHashable signs the given Hashable item and returns the signature. If this
-// account can't sign (CanSign() returns false) nil is returned.
+// account can't sign (CanSign() returns false) or its external signer fails, nil
+// is returned, use SignHashableErr if you need to know the reason.
 func (a *Account) SignHashable(net netmode.Magic, item hash.Hashable) []byte {
+	sig, _ := a.SignHashableErr(net, item)
+	return sig
+}
+
+// SignHashableErr is similar to SignHashable, but it returns an error if this
+// account can't sign or if its external signer fails.
+func (a *Account) SignHashableErr(net netmode.Magic, item hash.Hashable) ([]byte, error) {
 	if !a.CanSign() {
-		return nil
+		return nil, errors.New("account is locked or has no key")
 	}
-	return a.privateKey.SignHashable(uint32(net), item)
+	return a.sign(net, item)
+}
+
+func (a *Account) sign(net netmode.Magic, item hash.Hashable) ([]byte, error) {
+	if a.signer != nil {
+		return a.signer.Sign(hash.GetSignedData(uint32(net), item))
+	}
+	if a.privateKey == nil {
+		return nil, errors.New("account key is not available (need to decrypt?)")
+	}
+	return a.privateKey.SignHashable(uint32(net), item), nil
 }
 
 // CanSign returns true when account is not locked and has a decrypted private
-// key inside, so it's ready to create real signatures.
+// key inside or an external signer attached, so it's ready to create real
+// signatures.
 func (a *Account) CanSign() bool {
-	return !a.Locked && a.privateKey != nil
+	return !a.Locked && (a.privateKey != nil || a.signer != nil)
+}
+
+// SetSigner attaches an external Signer to the account, it's then used for
+// all signatures made by the account instead of the private key (which doesn't
+// need to be decrypted then). Signer's key must be the one used by the
+// account's contract (either a simple signature or a multisignature one).
+func (a *Account) SetSigner(s Signer) error {
+	pub := s.PublicKey()
+	if pub == nil {
+		return errors.New("signer has no public key")
+	}
+	if a.Contract == nil {
+		if pub.GetScriptHash() != a.ScriptHash() {
+			return errors.New("signer key doesn't match the account")
+		}
+	} else if !contractHasKey(a.Contract.Script, pub) {
+		return errors.New("signer key is not used by the account contract")
+	}
+	a.signer = s
+	return nil
+}
+
+// Signer returns a Signer that can be used to sign things for this account.
+// It's the external signer if it's attached (see SetSigner) or an in-process
+// signer with the decrypted private key. nil is returned if account can't
+// sign (see CanSign).
+func (a *Account) Signer() Signer {
+	if !a.CanSign() {
+		return nil
+	}
+	if a.signer != nil {
+		return a.signer
+	}
+	return NewPrivateKeySigner(a.privateKey)
+}
+
+func contractHasKey(script []byte, pub *keys.PublicKey) bool {
+	if key, ok := vm.ParseSignatureContract(script); ok {
+		return bytes.Equal(key, pub.Bytes())
+	}
+	if _, pubs, ok := vm.ParseMultiSigContract(script); ok {
+		for i := range pubs {
+			if bytes.Equal(pubs[i], pub.Bytes()) {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // GetVerificationScript returns account's verification script.
@@ -187,12 +259,16 @@ func (a *Account) PrivateKey() *keys.PrivateKey {
 	return a.privateKey
 }
 
-// PublicKey returns the public key associated with the private key corresponding to
-// the account. It can return nil if account is locked (use CanSign to check).
+// PublicKey returns the public key associated with the private key (or external
+// signer) corresponding to the account. It can return nil if account is locked
+// (use CanSign to check).
 func (a *Account) PublicKey() *keys.PublicKey {
 	if !a.CanSign() {
 		return nil
 	}
+	if a.signer != nil {
+		return a.signer.PublicKey()
+	}
 	return a.privateKey.PublicKey()
 }
 
@@ -206,10 +282,12 @@ func (a *Account) ScriptHash() util.Uint160 {
 	return a.scriptHash
 }
 
-// Close cleans up the private key used by Account and disassociates it from
-// Account. The Account can no longer sign anything after this call, but Decrypt
-// can make it usable again.
+// Close cleans up the private key used by Account and disassociates it (as
+// well as external signer) from Account. The Account can no longer sign
+// anything after this call, but Decrypt (or SetSigner) can make it usable
+// again.
 func (a *Account) Close() {
+	a.signer = nil
 	if a.privateKey == nil {
 		return
 	}
@@ -244,11 +322,11 @@ func (a *Account) ConvertMultisig(m int, pubs []*keys.PublicKey) error {
 	if a.Locked {
 		return errors.New("account is locked")
 	}
-	if a.privateKey == nil {
+	if a.privateKey == nil && a.signer == nil {
 		return errors.New("account key is not available (need to decrypt?)")
 	}
 	var found bool
-	accKey := a.privateKey.PublicKey()
+	accKey := a.PublicKey()
 	for i := range pubs {
 		if accKey.Equal(pubs[i]) {
 			found = true
@@ -3,6 +3,7 @@ package wallet
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/keytestcases"
@@ -242,3 +243,98 @@ func compareFields(t *testing.T, tk keytestcases.Ktype, acc *Account) {
 	want, have = tk.PrivateKey, acc.privateKey.String()
 	require.Equalf(t, want, have, "expected priv key %s got %s", want, have)
 }
+
+func TestAccount_SetSigner(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	other, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	// Watch-only account with no key.
+	acc := &Account{
+		Address: priv.Address(),
+		Contract: &Contract{
+			Script:     priv.PublicKey().GetVerificationScript(),
+			Parameters: getContractParams(1),
+		},
+	}
+	require.False(t, acc.CanSign())
+	require.Nil(t, acc.Signer())
+	require.Error(t, acc.SetSigner(NewPrivateKeySigner(other)))
+	require.False(t, acc.CanSign())
+
+	require.NoError(t, acc.SetSigner(NewPrivateKeySigner(priv)))
+	require.True(t, acc.CanSign())
+	require.True(t, priv.PublicKey().Equal(acc.PublicKey()))
+	require.NotNil(t, acc.Signer())
+
+	tx := transaction.New([]byte{1, 2, 3}, 0)
+	tx.Signers = []transaction.Signer{{Account: acc.ScriptHash()}}
+	require.NoError(t, acc.SignTx(0, tx))
+	require.True(t, priv.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], 0, tx))
+	require.Equal(t, priv.SignHashable(0, tx), acc.SignHashable(0, tx))
+
+	acc.Locked = true
+	require.False(t, acc.CanSign())
+	require.Nil(t, acc.Signer())
+	acc.Locked = false
+
+	acc.Close()
+	require.False(t, acc.CanSign())
+
+	t.Run("no contract", func(t *testing.T) {
+		acc := &Account{Address: priv.Address()}
+		require.Error(t, acc.SetSigner(NewPrivateKeySigner(other)))
+		require.NoError(t, acc.SetSigner(NewPrivateKeySigner(priv)))
+	})
+	t.Run("multisig", func(t *testing.T) {
+		acc := NewAccountFromPrivateKey(priv)
+		pubs := keys.PublicKeys{priv.PublicKey(), other.PublicKey()}
+		require.NoError(t, acc.ConvertMultisig(1, pubs))
+		acc.privateKey = nil
+		require.NoError(t, acc.SetSigner(NewPrivateKeySigner(other)))
+		require.True(t, other.PublicKey().Equal(acc.PublicKey()))
+
+		third, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		require.Error(t, acc.SetSigner(NewPrivateKeySigner(third)))
+	})
+}
+
+func TestAccount_Signer(t *testing.T) {
+	acc, err := NewAccount()
+	require.NoError(t, err)
+	s := acc.Signer()
+	require.NotNil(t, s)
+	require.True(t, acc.PublicKey().Equal(s.PublicKey()))
+	sig, err := s.Sign([]byte{1, 2, 3})
+	require.NoError(t, err)
+	require.True(t, acc.PublicKey().Verify(sig, hash.Sha256([]byte{1, 2, 3}).BytesBE()))
+}
+
+type failingSigner struct {
+	pub *keys.PublicKey
+}
+
+func (s failingSigner) PublicKey() *keys.PublicKey  { return s.pub }
+func (s failingSigner) Sign([]byte) ([]byte, error) { return nil, errors.New("failure") }
+
+func TestAccount_SignHashableErr(t *testing.T) {
+	acc, err := NewAccount()
+	require.NoError(t, err)
+	tx := transaction.New([]byte{1, 2, 3}, 0)
+
+	sig, err := acc.SignHashableErr(0, tx)
+	require.NoError(t, err)
+	require.Equal(t, acc.SignHashable(0, tx), sig)
+
+	require.NoError(t, acc.SetSigner(failingSigner{pub: acc.PublicKey()}))
+	_, err = acc.SignHashableErr(0, tx)
+	require.ErrorContains(t, err, "failure")
+	require.Nil(t, acc.SignHashable(0, tx))
+
+	acc.Locked = true
+	_, err = acc.SignHashableErr(0, tx)
+	require.Error(t, err)
+	require.Nil(t, acc.SignHashable(0, tx))
+}
@@ -0,0 +1,210 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hd"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util/slice"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+// DefaultHDPath is the default BIP-44 derivation path prefix used for HD
+// wallet accounts (purpose 44', Neo coin type 888', account 0', external
+// chain 0), account index is appended to it.
+const DefaultHDPath = "m/44'/888'/0'/0"
+
+// MnemonicEntropyBits is the amount of entropy used for new mnemonics, it
+// gives 24 words.
+const MnemonicEntropyBits = 256
+
+const (
+	hdSaltLen = 16
+	hdKeyLen  = 32
+)
+
+// HDInfo contains data needed to deterministically derive wallet accounts
+// from a BIP-39 seed. It's stored in the wallet's Extra field, so wallets
+// remain NEP-6 compatible.
+type HDInfo struct {
+	// Seed is a BIP-39 seed encrypted with the wallet passphrase (scrypt
+	// parameters of the wallet are used for key derivation): salt, nonce
+	// and AES-GCM ciphertext concatenated.
+	Seed []byte `json:"seed"`
+	// Path is the derivation path prefix, account index is appended to it.
+	Path string `json:"path"`
+	// Next is the index of the next account to derive.
+	Next uint32 `json:"next"`
+}
+
+// ErrNotHD is returned when HD-specific operation is performed on a wallet
+// without HD data.
+var ErrNotHD = errors.New("not an HD wallet")
+
+// NewMnemonic generates a new random 24-word BIP-39 mnemonic (English word
+// list).
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(MnemonicEntropyBits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// IsHD returns true if the wallet has HD data, so its accounts are derived
+// from the seed.
+func (w *Wallet) IsHD() bool {
+	return w.Extra.HD != nil
+}
+
+// InitHD turns the wallet into an HD one using the given BIP-39 mnemonic and
+// optional BIP-39 password (seedPassword, it's not the same as the wallet
+// passphrase that is used to encrypt the seed and accounts). The wallet must
+// not have HD data already, existing accounts are kept intact. It doesn't
+// derive any accounts and doesn't save the wallet.
+func (w *Wallet) InitHD(mnemonic, seedPassword, passphrase string) error {
+	if w.IsHD() {
+		return errors.New("wallet already has HD data")
+	}
+	mnemonic = strings.Join(strings.Fields(norm.NFKD.String(mnemonic)), " ")
+	// EntropyFromMnemonic checks the checksum (unlike IsMnemonicValid).
+	if _, err := bip39.EntropyFromMnemonic(mnemonic); err != nil {
+		return fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	seed := bip39.NewSeed(mnemonic, norm.NFKD.String(seedPassword))
+	defer slice.Clean(seed)
+
+	enc, err := encryptSeed(seed, passphrase, w.Scrypt)
+	if err != nil {
+		return err
+	}
+	w.Extra.HD = &HDInfo{
+		Seed: enc,
+		Path: DefaultHDPath,
+	}
+	return nil
+}
+
+// DeriveAccount derives the next HD account, encrypts it with the given
+// passphrase (which must be the same one used for the seed) and adds it to
+// the wallet. It doesn't save the wallet.
+func (w *Wallet) DeriveAccount(name, passphrase string) (*Account, error) {
+	if !w.IsHD() {
+		return nil, ErrNotHD
+	}
+	seed, err := decryptSeed(w.Extra.HD.Seed, passphrase, w.Scrypt)
+	if err != nil {
+		return nil, err
+	}
+	defer slice.Clean(seed)
+
+	acc, err := w.deriveAccount(seed, w.Extra.HD.Next, name, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	w.Extra.HD.Next++
+	w.AddAccount(acc)
+	return acc, nil
+}
+
+// RecoverAccounts derives HD accounts with indices from 0 to n-1 adding those
+// that are not yet present in the wallet. It's intended to be used after
+// InitHD on a new wallet to restore all accounts from the mnemonic. It
+// doesn't save the wallet.
+func (w *Wallet) RecoverAccounts(n uint32, passphrase string) error {
+	if !w.IsHD() {
+		return ErrNotHD
+	}
+	seed, err := decryptSeed(w.Extra.HD.Seed, passphrase, w.Scrypt)
+	if err != nil {
+		return err
+	}
+	defer slice.Clean(seed)
+
+	for i := uint32(0); i < n; i++ {
+		acc, err := w.deriveAccount(seed, i, "", passphrase)
+		if err != nil {
+			return err
+		}
+		if w.GetAccount(acc.ScriptHash()) == nil {
+			w.AddAccount(acc)
+		}
+	}
+	if w.Extra.HD.Next < n {
+		w.Extra.HD.Next = n
+	}
+	return nil
+}
+
+func (w *Wallet) deriveAccount(seed []byte, index uint32, name, passphrase string) (*Account, error) {
+	master, err := hd.NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	k, err := master.Derive(fmt.Sprintf("%s/%d", w.Extra.HD.Path, index))
+	if err != nil {
+		return nil, err
+	}
+	acc := NewAccountFromPrivateKey(k.PrivateKey)
+	acc.Label = name
+	if err := acc.Encrypt(passphrase, w.Scrypt); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+func seedKey(passphrase string, salt []byte, params keys.ScryptParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key(norm.NFC.Bytes([]byte(passphrase)), salt, params.N, params.R, params.P, hdKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	defer slice.Clean(key)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func encryptSeed(seed []byte, passphrase string, params keys.ScryptParams) ([]byte, error) {
+	salt := make([]byte, hdSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := seedKey(passphrase, salt, params)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]byte, hdSaltLen+aead.NonceSize(), hdSaltLen+aead.NonceSize()+len(seed)+aead.Overhead())
+	copy(res, salt)
+	if _, err := rand.Read(res[hdSaltLen:]); err != nil {
+		return nil, err
+	}
+	return aead.Seal(res, res[hdSaltLen:], seed, nil), nil
+}
+
+func decryptSeed(data []byte, passphrase string, params keys.ScryptParams) ([]byte, error) {
+	if len(data) < hdSaltLen {
+		return nil, errors.New("invalid encrypted seed")
+	}
+	aead, err := seedKey(passphrase, data[:hdSaltLen], params)
+	if err != nil {
+		return nil, err
+	}
+	data = data[hdSaltLen:]
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("invalid encrypted seed")
+	}
+	seed, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, errors.New("can't decrypt seed (wrong passphrase?)")
+	}
+	return seed, nil
+}
@@ -0,0 +1,123 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+func newHDTestWallet(t *testing.T, mnemonic string) *Wallet {
+	w := checkWalletConstructor(t)
+	w.Scrypt = keys.ScryptParams{N: 2, R: 1, P: 1}
+	require.NoError(t, w.InitHD(mnemonic, "", "pass"))
+	return w
+}
+
+func TestNewMnemonic(t *testing.T) {
+	m, err := NewMnemonic()
+	require.NoError(t, err)
+	require.Equal(t, 24, len(strings.Fields(m)))
+
+	w := checkWalletConstructor(t)
+	require.NoError(t, w.InitHD(m, "", "pass"))
+}
+
+func TestWallet_InitHD(t *testing.T) {
+	w := checkWalletConstructor(t)
+	require.False(t, w.IsHD())
+	_, err := w.DeriveAccount("", "pass")
+	require.ErrorIs(t, err, ErrNotHD)
+	require.ErrorIs(t, w.RecoverAccounts(1, "pass"), ErrNotHD)
+
+	t.Run("bad checksum", func(t *testing.T) {
+		require.Error(t, w.InitHD(strings.Repeat("abandon ", 12), "", "pass"))
+	})
+	t.Run("unknown word", func(t *testing.T) {
+		require.Error(t, w.InitHD(strings.Replace(testMnemonic, "about", "neo", 1), "", "pass"))
+	})
+	require.False(t, w.IsHD())
+
+	require.NoError(t, w.InitHD(testMnemonic, "", "pass"))
+	require.True(t, w.IsHD())
+	require.Equal(t, DefaultHDPath, w.Extra.HD.Path)
+	require.Equal(t, uint32(0), w.Extra.HD.Next)
+	require.Error(t, w.InitHD(testMnemonic, "", "pass"))
+}
+
+func TestWallet_DeriveAccount(t *testing.T) {
+	w1 := newHDTestWallet(t, testMnemonic)
+	// Extra spaces are ignored.
+	w2 := newHDTestWallet(t, "  "+strings.ReplaceAll(testMnemonic, " ", "\t ")+"\n")
+	// BIP-39 password changes the seed.
+	w3 := checkWalletConstructor(t)
+	w3.Scrypt = w1.Scrypt
+	require.NoError(t, w3.InitHD(testMnemonic, "secret", "pass"))
+
+	for i := 0; i < 3; i++ {
+		a1, err := w1.DeriveAccount("acc", "pass")
+		require.NoError(t, err)
+		a2, err := w2.DeriveAccount("acc", "pass")
+		require.NoError(t, err)
+		a3, err := w3.DeriveAccount("acc", "pass")
+		require.NoError(t, err)
+		require.Equal(t, a1.Address, a2.Address)
+		require.NotEqual(t, a1.Address, a3.Address)
+		require.Equal(t, "acc", a1.Label)
+		require.True(t, a1.CanSign())
+		require.Equal(t, uint32(i+1), w1.Extra.HD.Next)
+	}
+	require.Equal(t, 3, len(w1.Accounts))
+	require.NotEqual(t, w1.Accounts[0].Address, w1.Accounts[1].Address)
+
+	_, err := w1.DeriveAccount("", "wrong")
+	require.Error(t, err)
+	require.Equal(t, 3, len(w1.Accounts))
+
+	t.Run("CreateAccount", func(t *testing.T) {
+		require.NoError(t, w2.CreateAccount("next", "pass"))
+		require.Equal(t, 4, len(w2.Accounts))
+		require.Equal(t, uint32(4), w2.Extra.HD.Next)
+
+		w, err := NewWalletFromFile(w2.Path())
+		require.NoError(t, err)
+		require.True(t, w.IsHD())
+		require.Equal(t, w2.Extra.HD, w.Extra.HD)
+		require.Equal(t, 4, len(w.Accounts))
+
+		acc, err := w.DeriveAccount("", "pass")
+		require.NoError(t, err)
+		acc1, err := w1.DeriveAccount("", "pass")
+		require.NoError(t, err)
+		acc1, err = w1.DeriveAccount("", "pass")
+		require.NoError(t, err)
+		require.Equal(t, acc1.Address, acc.Address)
+	})
+}
+
+func TestWallet_RecoverAccounts(t *testing.T) {
+	w1 := newHDTestWallet(t, testMnemonic)
+	for i := 0; i < 5; i++ {
+		_, err := w1.DeriveAccount("", "pass")
+		require.NoError(t, err)
+	}
+
+	w2 := newHDTestWallet(t, testMnemonic)
+	_, err := w2.DeriveAccount("", "pass")
+	require.NoError(t, err)
+	require.Error(t, w2.RecoverAccounts(5, "wrong"))
+	require.NoError(t, w2.RecoverAccounts(5, "pass"))
+	require.Equal(t, uint32(5), w2.Extra.HD.Next)
+	require.Equal(t, len(w1.Accounts), len(w2.Accounts))
+	for i := range w1.Accounts {
+		require.Equal(t, w1.Accounts[i].Address, w2.Accounts[i].Address)
+	}
+
+	// Next index is not decreased.
+	require.NoError(t, w2.RecoverAccounts(2, "pass"))
+	require.Equal(t, uint32(5), w2.Extra.HD.Next)
+	require.Equal(t, 5, len(w2.Accounts))
+}
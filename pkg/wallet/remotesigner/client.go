@@ -0,0 +1,207 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// DefaultTimeout is the default timeout for remote signer requests.
+const DefaultTimeout = 10 * time.Second
+
+const (
+	keysPath = "/keys"
+	signPath = "/sign"
+)
+
+type (
+	// KeysResponse is the response for the keys request.
+	KeysResponse struct {
+		Keys keys.PublicKeys `json:"keys"`
+	}
+
+	// SignRequest is the sign request body.
+	SignRequest struct {
+		Key  *keys.PublicKey `json:"key"`
+		Data []byte          `json:"data"`
+	}
+
+	// SignResponse is the response for the sign request.
+	SignResponse struct {
+		Signature []byte `json:"signature"`
+	}
+
+	// ErrorResponse is the response returned in case of error.
+	ErrorResponse struct {
+		Error string `json:"error"`
+	}
+)
+
+// Options contains remote signer client parameters.
+type Options struct {
+	// Timeout is the timeout for each request, DefaultTimeout is used if
+	// it's not set.
+	Timeout time.Duration
+}
+
+// Client is a remote signer client.
+type Client struct {
+	base string
+	cli  http.Client
+}
+
+// Signer is a wallet.Signer implementation for a particular key of the
+// remote signer.
+type Signer struct {
+	client *Client
+	key    *keys.PublicKey
+}
+
+var _ wallet.Signer = (*Signer)(nil)
+
+// New creates a new Client for the given endpoint which is either an HTTP(S)
+// URL or a Unix socket path in unix:///path/to/socket form.
+func New(endpoint string, opts Options) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote signer endpoint: %w", err)
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	c := &Client{
+		cli: http.Client{Timeout: opts.Timeout},
+	}
+	switch u.Scheme {
+	case "http", "https":
+		c.base = strings.TrimSuffix(endpoint, "/")
+	case "unix":
+		var (
+			path   = u.Path
+			dialer net.Dialer
+		)
+		if path == "" {
+			path = u.Opaque
+		}
+		if path == "" {
+			return nil, errors.New("invalid remote signer endpoint: empty socket path")
+		}
+		c.base = "http://unix"
+		c.cli.Transport = &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "unix", path)
+			},
+		}
+	default:
+		return nil, fmt.Errorf("unsupported remote signer endpoint scheme %q", u.Scheme)
+	}
+	return c, nil
+}
+
+// Keys returns the list of public keys available in the remote signer.
+func (c *Client) Keys() (keys.PublicKeys, error) {
+	var resp KeysResponse
+	if err := c.do(http.MethodGet, keysPath, nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Keys, nil
+}
+
+// Sign requests a signature of the given message (see wallet.Signer) for the
+// given key from the remote signer.
+func (c *Client) Sign(pub *keys.PublicKey, msg []byte) ([]byte, error) {
+	var resp SignResponse
+	if err := c.do(http.MethodPost, signPath, &SignRequest{Key: pub, Data: msg}, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Signature) != keys.SignatureLen {
+		return nil, fmt.Errorf("invalid signature length %d", len(resp.Signature))
+	}
+	return resp.Signature, nil
+}
+
+// Signer returns wallet.Signer for the given key of the remote signer. It
+// doesn't check for the key to be available.
+func (c *Client) Signer(pub *keys.PublicKey) *Signer {
+	return &Signer{client: c, key: pub}
+}
+
+func (c *Client) do(method string, path string, body interface{}, result interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, c.base+path, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.cli.Do(req)
+	if err != nil {
+		return fmt.Errorf("remote signer request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var e ErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&e) != nil || e.Error == "" {
+			e.Error = resp.Status
+		}
+		return fmt.Errorf("remote signer error: %s", e.Error)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("invalid remote signer response: %w", err)
+	}
+	return nil
+}
+
+// PublicKey implements the wallet.Signer interface.
+func (s *Signer) PublicKey() *keys.PublicKey {
+	return s.key
+}
+
+// Sign implements the wallet.Signer interface.
+func (s *Signer) Sign(msg []byte) ([]byte, error) {
+	return s.client.Sign(s.key, msg)
+}
+
+// Attach connects to the remote signer at the given endpoint and attaches
+// signers to all wallet accounts that use the keys it has (see
+// wallet.Account.SetSigner). It returns the number of accounts signers were
+// attached to.
+func Attach(w *wallet.Wallet, endpoint string) (int, error) {
+	c, err := New(endpoint, Options{})
+	if err != nil {
+		return 0, err
+	}
+	pubs, err := c.Keys()
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	for _, acc := range w.Accounts {
+		for _, pub := range pubs {
+			if acc.SetSigner(c.Signer(pub)) == nil {
+				n++
+				break
+			}
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,145 @@
+package remotesigner
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeys(t *testing.T, n int) []*keys.PrivateKey {
+	privs := make([]*keys.PrivateKey, n)
+	for i := range privs {
+		var err error
+		privs[i], err = keys.NewPrivateKey()
+		require.NoError(t, err)
+	}
+	return privs
+}
+
+// newWatchOnlyAccount creates an account that can't sign anything by itself
+// (like an encrypted one) without destroying the original key.
+func newWatchOnlyAccount(t *testing.T, priv *keys.PrivateKey) *wallet.Account {
+	k, err := keys.NewPrivateKeyFromBytes(priv.Bytes())
+	require.NoError(t, err)
+	acc := wallet.NewAccountFromPrivateKey(k)
+	acc.Close()
+	require.False(t, acc.CanSign())
+	return acc
+}
+
+func checkClient(t *testing.T, c *Client, privs []*keys.PrivateKey) {
+	pubs, err := c.Keys()
+	require.NoError(t, err)
+	require.Equal(t, len(privs), len(pubs))
+	for i := range privs {
+		require.True(t, privs[i].PublicKey().Equal(pubs[i]))
+	}
+
+	msg := []byte("some message")
+	s := c.Signer(pubs[0])
+	require.True(t, pubs[0].Equal(s.PublicKey()))
+	sig, err := s.Sign(msg)
+	require.NoError(t, err)
+	require.Equal(t, privs[0].Sign(msg), sig)
+
+	other, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	_, err = c.Sign(other.PublicKey(), msg)
+	require.ErrorContains(t, err, "unknown key")
+}
+
+func TestClientHTTP(t *testing.T) {
+	privs := newTestKeys(t, 2)
+	srv := httptest.NewServer(NewServer(privs...))
+	t.Cleanup(srv.Close)
+
+	c, err := New(srv.URL, Options{})
+	require.NoError(t, err)
+	checkClient(t, c, privs)
+
+	t.Run("bad path", func(t *testing.T) {
+		c, err := New(srv.URL+"/some", Options{})
+		require.NoError(t, err)
+		_, err = c.Keys()
+		require.Error(t, err)
+	})
+}
+
+func TestClientUnix(t *testing.T) {
+	privs := newTestKeys(t, 1)
+	sock := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", sock)
+	require.NoError(t, err)
+	srv := &http.Server{Handler: NewServer(privs...)}
+	go func() { _ = srv.Serve(l) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	c, err := New("unix://"+sock, Options{})
+	require.NoError(t, err)
+	checkClient(t, c, privs)
+}
+
+func TestNew(t *testing.T) {
+	for _, e := range []string{"tcp://127.0.0.1:1234", "unix://", "%zz", "127.0.0.1:1234"} {
+		_, err := New(e, Options{})
+		require.Error(t, err, e)
+	}
+	c, err := New("unix://"+filepath.Join(t.TempDir(), "nonexistent"), Options{})
+	require.NoError(t, err)
+	_, err = c.Keys()
+	require.Error(t, err)
+}
+
+func TestAttach(t *testing.T) {
+	privs := newTestKeys(t, 3)
+	srv := httptest.NewServer(NewServer(privs[0], privs[1]))
+	t.Cleanup(srv.Close)
+
+	w, err := wallet.NewWallet(filepath.Join(t.TempDir(), "wallet.json"))
+	require.NoError(t, err)
+	accs := make([]*wallet.Account, 3)
+	for i := range privs {
+		accs[i] = newWatchOnlyAccount(t, privs[i])
+		w.AddAccount(accs[i])
+	}
+	pubs := keys.PublicKeys{privs[1].PublicKey(), privs[2].PublicKey()}
+	k, err := keys.NewPrivateKeyFromBytes(privs[2].Bytes())
+	require.NoError(t, err)
+	multiAcc := wallet.NewAccountFromPrivateKey(k)
+	require.NoError(t, multiAcc.ConvertMultisig(1, pubs))
+	multiAcc.Close()
+	w.AddAccount(multiAcc)
+
+	n, err := Attach(w, srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+	require.True(t, accs[0].CanSign())
+	require.True(t, accs[1].CanSign())
+	require.False(t, accs[2].CanSign())
+	require.True(t, multiAcc.CanSign())
+	require.True(t, privs[1].PublicKey().Equal(multiAcc.PublicKey()))
+
+	tx := transaction.New([]byte{1, 2, 3}, 0)
+	tx.Signers = []transaction.Signer{{Account: accs[0].ScriptHash()}, {Account: multiAcc.ScriptHash()}}
+	require.NoError(t, accs[0].SignTx(netmode.UnitTestNet, tx))
+	require.NoError(t, multiAcc.SignTx(netmode.UnitTestNet, tx))
+	require.Equal(t, 2, len(tx.Scripts))
+	require.True(t, privs[0].PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], uint32(netmode.UnitTestNet), tx))
+	require.True(t, privs[1].PublicKey().VerifyHashable(tx.Scripts[1].InvocationScript[2:], uint32(netmode.UnitTestNet), tx))
+	require.Equal(t, hash.NetSha256(uint32(netmode.UnitTestNet), tx), hash.Sha256(hash.GetSignedData(uint32(netmode.UnitTestNet), tx)))
+
+	srv.Close()
+	require.Nil(t, accs[0].SignHashable(netmode.UnitTestNet, tx))
+	require.Error(t, accs[0].SignTx(netmode.UnitTestNet, tx))
+	_, err = Attach(w, srv.URL)
+	require.Error(t, err)
+}
@@ -0,0 +1,26 @@
+/*
+Package remotesigner implements a client for remote (external) signers, that
+is separate processes holding private keys and signing data on request, so
+that keys don't have to be decrypted by the node or CLI.
+
+Remote signer is reached via HTTP either over TCP (http:// and https://
+endpoints) or over a Unix socket (unix:///path/to/socket endpoints). The
+protocol is simple and JSON-based:
+
+  - GET /keys returns the list of public keys available:
+    {"keys":["02a7...", "03b2..."]}
+  - POST /sign with {"key":"02a7...","data":"base64-encoded message"} body
+    returns ECDSA (secp256r1) signature of SHA256 hash of the message:
+    {"signature":"base64-encoded 64-byte signature"}
+
+Errors are returned with non-200 HTTP status code and {"error":"description"}
+body. Messages signed are the same ones that are signed by
+keys.PrivateKey.Sign, for transactions and other hashable items it's the
+network magic (4 bytes, little-endian) followed by the item's hash.
+
+Client implements wallet.Signer for every key provided by the remote signer
+and Attach can be used to connect them with wallet accounts. Server is a
+simple in-process implementation of the protocol that can be used as a
+stand-in for real remote signers in tests.
+*/
+package remotesigner
@@ -0,0 +1,70 @@
+package remotesigner
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// Server is a simple in-process remote signer implementation. It's
+// an http.Handler that can be served over TCP or Unix socket and is mostly
+// useful as a stand-in for real remote signers in tests.
+type Server struct {
+	keys map[string]*keys.PrivateKey
+	pubs keys.PublicKeys
+}
+
+// NewServer creates a Server for the given set of keys.
+func NewServer(privs ...*keys.PrivateKey) *Server {
+	s := &Server{
+		keys: make(map[string]*keys.PrivateKey, len(privs)),
+		pubs: make(keys.PublicKeys, 0, len(privs)),
+	}
+	for _, p := range privs {
+		pub := p.PublicKey()
+		s.keys[string(pub.Bytes())] = p
+		s.pubs = append(s.pubs, pub)
+	}
+	return s
+}
+
+// ServeHTTP implements the http.Handler interface.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case keysPath:
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		writeJSON(w, http.StatusOK, KeysResponse{Keys: s.pubs})
+	case signPath:
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		var req SignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Key == nil {
+			writeError(w, http.StatusBadRequest, "invalid request")
+			return
+		}
+		priv, ok := s.keys[string(req.Key.Bytes())]
+		if !ok {
+			writeError(w, http.StatusNotFound, "unknown key")
+			return
+		}
+		writeJSON(w, http.StatusOK, SignResponse{Signature: priv.Sign(req.Data)})
+	default:
+		writeError(w, http.StatusNotFound, "not found")
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, msg string) {
+	writeJSON(w, code, ErrorResponse{Error: msg})
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
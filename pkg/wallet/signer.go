@@ -0,0 +1,41 @@
+package wallet
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// Signer is a key holder that can create signatures for some key without
+// exposing it. It can be attached to an Account (see SetSigner) to make it
+// usable for signing without decrypting the key in this process.
+type Signer interface {
+	// PublicKey returns the public key corresponding to the key used for
+	// signing.
+	PublicKey() *keys.PublicKey
+	// Sign creates a signature for the given message (it's hashed with
+	// SHA256 and signed with ECDSA, the same way keys.PrivateKey.Sign does
+	// that). The signature is 64 bytes long.
+	Sign(msg []byte) ([]byte, error)
+}
+
+// PrivateKeySigner is an in-process Signer implementation that uses a
+// private key directly.
+type PrivateKeySigner struct {
+	key *keys.PrivateKey
+}
+
+var _ Signer = (*PrivateKeySigner)(nil)
+
+// NewPrivateKeySigner returns a Signer for the given private key.
+func NewPrivateKeySigner(key *keys.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{key: key}
+}
+
+// PublicKey implements the Signer interface.
+func (s *PrivateKeySigner) PublicKey() *keys.PublicKey {
+	return s.key.PublicKey()
+}
+
+// Sign implements the Signer interface.
+func (s *PrivateKeySigner) Sign(msg []byte) ([]byte, error) {
+	return s.key.Sign(msg), nil
+}
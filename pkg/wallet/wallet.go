@@ -36,10 +36,12 @@ type Wallet struct {
 	path string
 }
 
-// Extra stores imported token contracts.
+// Extra stores imported token contracts and HD wallet data.
 type Extra struct {
 	// Tokens is a list of imported token contracts.
 	Tokens []*Token
+	// HD contains HD wallet data, it's nil for regular wallets.
+	HD *HDInfo `json:",omitempty"`
 }
 
 // NewWallet creates a new NEO wallet at the given location.
@@ -83,8 +85,15 @@ func newWallet(rw io.ReadWriter) *Wallet {
 }
 
 // CreateAccount generates a new account for the end user and encrypts
-// the private key with the given passphrase.
+// the private key with the given passphrase. For HD wallets the next account
+// is derived from the seed (see DeriveAccount).
 func (w *Wallet) CreateAccount(name, passphrase string) error {
+	if w.IsHD() {
+		if _, err := w.DeriveAccount(name, passphrase); err != nil {
+			return err
+		}
+		return w.Save()
+	}
 	acc, err := NewAccount()
 	if err != nil {
 		return err